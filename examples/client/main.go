@@ -1172,10 +1172,9 @@ func createAnimatedSprite(ctx context.Context, session *mcp.ClientSession, logge
 		return fmt.Errorf("draw_rectangle failed: %w", err)
 	}
 
-	// Note: Selection and clipboard operations persist across MCP tool calls using sprite custom properties (sprite.data)
-	// and a hidden clipboard layer. This allows you to create a selection in one tool call, then copy/cut/paste in
-	// subsequent calls. Selection bounds are automatically saved and restored, and clipboard content is stored in a
-	// hidden __mcp_clipboard__ layer.
+	// Note: Selection and clipboard operations persist across MCP tool calls. This allows you to create a selection
+	// in one tool call, then copy/cut/paste in subsequent calls. The selection mask is automatically saved to and
+	// restored from a hidden __mcp_selection__ layer, and clipboard content is kept in named slots in server memory.
 
 	// For demonstration, let's show how to use drawing tools to achieve copy/paste effect
 	logger.Information("  Copying red square to position (60, 60) using draw_rectangle...")
@@ -1225,7 +1224,7 @@ func createAnimatedSprite(ctx context.Context, session *mcp.ClientSession, logge
 
 	logger.Information("  ✓ Drawing operations completed successfully")
 	logger.Information("  ✓ Result saved to: {OutputPath}", selectionOutputPath)
-	logger.Information("  Note: Selection and clipboard operations now persist across MCP tool calls using sprite.data and hidden clipboard layer")
+	logger.Information("  Note: Selection and clipboard operations now persist across MCP tool calls (hidden selection layer, in-memory clipboard slots)")
 
 	// Step 22: Demonstrate advanced export tools
 	logger.Information("")
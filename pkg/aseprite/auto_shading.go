@@ -27,6 +27,11 @@ func (v Vector3D) Normalize() Vector3D {
 	}
 }
 
+// Length returns the Euclidean length of the vector.
+func (v Vector3D) Length() float64 {
+	return math.Sqrt(v.X*v.X + v.Y*v.Y + v.Z*v.Z)
+}
+
 // Dot calculates the dot product with another vector.
 func (v Vector3D) Dot(other Vector3D) float64 {
 	return v.X*other.X + v.Y*other.Y + v.Z*other.Z
@@ -268,6 +273,10 @@ func colorsMatch(c1, c2 color.Color, tolerance uint32) bool {
 }
 
 // generateColorRamp generates shadow and highlight colors for a base color.
+//
+// Shadows and highlights are darkened or lightened by up to 30% in HSL with
+// rampEndWithHue, the builder behind GenerateRamp's anchors; with hueShift the
+// shadow hue is rotated +10 degrees and the highlight hue -10 degrees.
 // Returns: array of hex colors, base color (for reference)
 func generateColorRamp(baseColor color.Color, intensity float64, hueShift bool) ([]string, color.Color) {
 	r, g, b, _ := baseColor.RGBA()
@@ -277,41 +286,18 @@ func generateColorRamp(baseColor color.Color, intensity float64, hueShift bool)
 		B: float64(b) / 65535.0,
 	}
 
-	// Generate shadow (darker)
-	h, s, l := base.Hsl()
+	h, _, l := base.Hsl()
+	shadowL := math.Max(0, l-l*0.3*intensity)          // Darken by up to 30%
+	highlightL := math.Min(1, l+(1.0-l)*0.3*intensity) // Lighten by up to 30%
 
-	shadowL := l - (l * 0.3 * intensity) // Darken by up to 30%
-	if shadowL < 0 {
-		shadowL = 0
-	}
-
-	shadowH := h
+	shadowH, highlightH := h, h
 	if hueShift {
-		// Shift hue toward cool (blue) for shadows
-		shadowH = h + 10 // Shift 10 degrees toward blue
-		if shadowH > 360 {
-			shadowH -= 360
-		}
-	}
-
-	shadow := colorful.Hsl(shadowH, s, shadowL)
-
-	// Generate highlight (lighter)
-	highlightL := l + ((1.0 - l) * 0.3 * intensity) // Lighten by up to 30%
-	if highlightL > 1.0 {
-		highlightL = 1.0
-	}
-
-	highlightH := h
-	if hueShift {
-		// Shift hue toward warm (yellow) for highlights
-		highlightH = h - 10 // Shift 10 degrees toward yellow
-		if highlightH < 0 {
-			highlightH += 360
-		}
+		shadowH = math.Mod(h+10, 360)     // Shift 10 degrees toward blue
+		highlightH = math.Mod(h+350, 360) // Shift 10 degrees toward yellow
 	}
 
-	highlight := colorful.Hsl(highlightH, s, highlightL)
+	shadow := rampEndWithHue(base, "hsl", shadowL, 1, shadowH)
+	highlight := rampEndWithHue(base, "hsl", highlightL, 1, highlightH)
 
 	// Convert to hex
 	sr, sg, sb := shadow.RGB255()
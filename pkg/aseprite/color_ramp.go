@@ -0,0 +1,190 @@
+package aseprite
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// RampOptions configures GenerateRamp.
+type RampOptions struct {
+	Steps            int     // Number of colors in the ramp (2-32)
+	HueShift         float64 // Degrees of hue rotation per step away from the base (0-60)
+	SaturationCurve  string  // "flat", "arc", "shadows", or "highlights"
+	SaturationAmount float64 // Strength of the saturation curve at the ramp ends (0.0-1.0)
+	LightnessMin     float64 // Lightness of the darkest step (0.0-1.0)
+	LightnessMax     float64 // Lightness of the lightest step (0.0-1.0)
+	ColorSpace       string  // "oklab", "oklch", or "hsl"
+}
+
+// Hue targets used for hue shifting: highlights rotate toward warm yellow and
+// shadows toward cool blue. The angles differ between OKLCH and HSL.
+const (
+	okLchWarmHue = 90.0
+	okLchCoolHue = 265.0
+	hslWarmHue   = 55.0
+	hslCoolHue   = 240.0
+)
+
+// GenerateRamp builds a dark-to-light color ramp around a base color.
+//
+// The base color is kept unchanged at the step whose lightness is closest to
+// its own. The darkest and lightest steps are anchors at LightnessMin and
+// LightnessMax whose hue is rotated by HueShift degrees per step, toward blue
+// for shadows and toward yellow for highlights, as is common in pixel art.
+// Their saturation is scaled by the saturation curve:
+//   - "flat": anchors keep the base saturation
+//   - "arc": both ends lose SaturationAmount, so the base is the most saturated
+//   - "shadows": shadows gain and highlights lose saturation
+//   - "highlights": highlights gain and shadows lose saturation
+//
+// The steps in between are interpolated from the base to each anchor in the
+// given color space: OKLab (straight perceptual blend), OKLCH (perceptual with
+// hue following the shortest arc), or HSL. Lightness values are in the
+// lightness scale of that space.
+//
+// Returns the ramp from darkest to lightest and the index of the base color.
+func GenerateRamp(base colorful.Color, opts RampOptions) ([]colorful.Color, int, error) {
+	if opts.Steps < 2 || opts.Steps > 32 {
+		return nil, 0, fmt.Errorf("steps must be between 2 and 32, got %d", opts.Steps)
+	}
+	if opts.HueShift < 0 || opts.HueShift > 60 {
+		return nil, 0, fmt.Errorf("hue shift must be between 0 and 60 degrees, got %f", opts.HueShift)
+	}
+	if opts.SaturationAmount < 0 || opts.SaturationAmount > 1 {
+		return nil, 0, fmt.Errorf("saturation amount must be between 0.0 and 1.0, got %f", opts.SaturationAmount)
+	}
+	if opts.LightnessMin < 0 || opts.LightnessMax > 1 || opts.LightnessMin >= opts.LightnessMax {
+		return nil, 0, fmt.Errorf("lightness range must satisfy 0 <= min < max <= 1, got %f-%f", opts.LightnessMin, opts.LightnessMax)
+	}
+
+	var darkSat, lightSat float64
+	switch opts.SaturationCurve {
+	case "flat":
+		darkSat, lightSat = 1, 1
+	case "arc":
+		darkSat, lightSat = 1-opts.SaturationAmount, 1-opts.SaturationAmount
+	case "shadows":
+		darkSat, lightSat = 1+opts.SaturationAmount, 1-opts.SaturationAmount
+	case "highlights":
+		darkSat, lightSat = 1-opts.SaturationAmount, 1+opts.SaturationAmount
+	default:
+		return nil, 0, fmt.Errorf("invalid saturation curve: %s (must be flat, arc, shadows, or highlights)", opts.SaturationCurve)
+	}
+
+	if opts.ColorSpace != "oklab" && opts.ColorSpace != "oklch" && opts.ColorSpace != "hsl" {
+		return nil, 0, fmt.Errorf("invalid color space: %s (must be oklab, oklch, or hsl)", opts.ColorSpace)
+	}
+
+	// Place the base at the step matching its lightness
+	baseL, _, _ := rampPolar(base, opts.ColorSpace)
+	last := opts.Steps - 1
+	t := (baseL - opts.LightnessMin) / (opts.LightnessMax - opts.LightnessMin)
+	baseIndex := int(math.Round(math.Max(0, math.Min(1, t)) * float64(last)))
+
+	dark := rampEnd(base, opts.ColorSpace, opts.LightnessMin, darkSat, opts.HueShift*float64(baseIndex), false)
+	light := rampEnd(base, opts.ColorSpace, opts.LightnessMax, lightSat, opts.HueShift*float64(last-baseIndex), true)
+
+	blend := func(from, to colorful.Color, t float64) colorful.Color {
+		switch opts.ColorSpace {
+		case "oklab":
+			return from.BlendOkLab(to, t)
+		case "oklch":
+			return from.BlendOkLch(to, t)
+		default:
+			return blendHsl(from, to, t)
+		}
+	}
+
+	ramp := make([]colorful.Color, opts.Steps)
+	for i := range ramp {
+		switch {
+		case i == baseIndex:
+			ramp[i] = base
+		case i < baseIndex:
+			ramp[i] = blend(base, dark, float64(baseIndex-i)/float64(baseIndex)).Clamped()
+		default:
+			ramp[i] = blend(base, light, float64(i-baseIndex)/float64(last-baseIndex)).Clamped()
+		}
+	}
+
+	return ramp, baseIndex, nil
+}
+
+// rampPolar returns the lightness, saturation (HSL) or chroma (OKLab and
+// OKLCH), and hue of a color in the lightness scale of the color space.
+func rampPolar(c colorful.Color, space string) (l, s, h float64) {
+	if space == "hsl" {
+		h, s, l = c.Hsl()
+		return l, s, h
+	}
+	return c.OkLch()
+}
+
+// rampEnd builds a shadow or highlight color from a base color: the given
+// lightness, the base saturation or chroma scaled by satScale, and the base hue
+// rotated by hueShift degrees toward cool blue for shadows or warm yellow for
+// highlights. Used for the anchors of GenerateRamp.
+func rampEnd(base colorful.Color, space string, lightness, satScale, hueShift float64, highlight bool) colorful.Color {
+	_, _, h := rampPolar(base, space)
+
+	cool, warm := okLchCoolHue, okLchWarmHue
+	if space == "hsl" {
+		cool, warm = hslCoolHue, hslWarmHue
+	}
+	target := cool
+	if highlight {
+		target = warm
+	}
+	return rampEndWithHue(base, space, lightness, satScale, shiftHueToward(h, target, hueShift))
+}
+
+// rampEndWithHue builds a shadow or highlight color from a base color with the
+// given lightness and hue and the base saturation or chroma scaled by satScale.
+// Shared by rampEnd and the auto-shading ramps, which rotate hues their own way.
+func rampEndWithHue(base colorful.Color, space string, lightness, satScale, hue float64) colorful.Color {
+	_, s, _ := rampPolar(base, space)
+	if space == "hsl" {
+		return colorful.Hsl(hue, math.Min(1, s*satScale), lightness)
+	}
+	return okLchInGamut(lightness, s*satScale, hue)
+}
+
+// shiftHueToward rotates hue h by up to amount degrees toward target along the
+// shortest arc, stopping at the target.
+func shiftHueToward(h, target, amount float64) float64 {
+	diff := math.Mod(target-h+540, 360) - 180
+	if math.Abs(diff) <= amount {
+		return target
+	}
+	return math.Mod(h+math.Copysign(amount, diff)+360, 360)
+}
+
+// blendHsl interpolates two colors in HSL, taking the shorter way around the hue
+// circle. The hue of an achromatic endpoint is ignored so grays do not pull
+// the blend through unrelated hues.
+func blendHsl(c1, c2 colorful.Color, t float64) colorful.Color {
+	h1, s1, l1 := c1.Hsl()
+	h2, s2, l2 := c2.Hsl()
+	if s1 == 0 {
+		h1 = h2
+	} else if s2 == 0 {
+		h2 = h1
+	}
+
+	diff := math.Mod(h2-h1+540, 360) - 180
+	h := math.Mod(h1+diff*t+360, 360)
+	return colorful.Hsl(h, s1+(s2-s1)*t, l1+(l2-l1)*t)
+}
+
+// okLchInGamut converts OKLCH to RGB, reducing chroma until the color fits in
+// the sRGB gamut so the hue and lightness are preserved.
+func okLchInGamut(l, c, h float64) colorful.Color {
+	col := colorful.OkLch(l, c, h)
+	for i := 0; i < 50 && !col.IsValid(); i++ {
+		c *= 0.9
+		col = colorful.OkLch(l, c, h)
+	}
+	return col.Clamped()
+}
@@ -0,0 +1,217 @@
+package aseprite
+
+import (
+	"image/color"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+func defaultRampOptions() RampOptions {
+	return RampOptions{
+		Steps:            5,
+		HueShift:         8,
+		SaturationCurve:  "arc",
+		SaturationAmount: 0.3,
+		LightnessMin:     0.15,
+		LightnessMax:     0.95,
+		ColorSpace:       "oklch",
+	}
+}
+
+func TestGenerateRamp_KeepsBaseAndOrdersLightness(t *testing.T) {
+	base, _ := colorful.Hex("#3B7DD8")
+
+	for _, space := range []string{"oklab", "oklch", "hsl"} {
+		t.Run(space, func(t *testing.T) {
+			opts := defaultRampOptions()
+			opts.ColorSpace = space
+			opts.Steps = 7
+
+			ramp, baseIndex, err := GenerateRamp(base, opts)
+			if err != nil {
+				t.Fatalf("GenerateRamp() error = %v", err)
+			}
+			if len(ramp) != 7 {
+				t.Fatalf("got %d steps, want 7", len(ramp))
+			}
+			if ramp[baseIndex].Hex() != base.Hex() {
+				t.Errorf("ramp[%d] = %s, want base %s", baseIndex, ramp[baseIndex].Hex(), base.Hex())
+			}
+
+			for i := 1; i < len(ramp); i++ {
+				prev, _, _ := ramp[i-1].OkLch()
+				cur, _, _ := ramp[i].OkLch()
+				if cur <= prev {
+					t.Errorf("step %d lightness %.3f not above step %d lightness %.3f", i, cur, i-1, prev)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateRamp_BaseIndexFollowsLightness(t *testing.T) {
+	opts := defaultRampOptions()
+
+	dark, _ := colorful.Hex("#201030")
+	_, idx, err := GenerateRamp(dark, opts)
+	if err != nil {
+		t.Fatalf("GenerateRamp() error = %v", err)
+	}
+	if idx > 1 {
+		t.Errorf("dark base index = %d, want 0 or 1", idx)
+	}
+
+	light, _ := colorful.Hex("#F0E8D0")
+	_, idx, err = GenerateRamp(light, opts)
+	if err != nil {
+		t.Fatalf("GenerateRamp() error = %v", err)
+	}
+	if idx < 3 {
+		t.Errorf("light base index = %d, want 3 or 4", idx)
+	}
+}
+
+func TestGenerateRamp_HueShift(t *testing.T) {
+	// A green base: shadows should rotate toward blue, highlights toward yellow
+	base, _ := colorful.Hex("#40A040")
+	_, _, baseHue := base.OkLch()
+
+	opts := defaultRampOptions()
+	opts.HueShift = 15
+	ramp, baseIndex, err := GenerateRamp(base, opts)
+	if err != nil {
+		t.Fatalf("GenerateRamp() error = %v", err)
+	}
+	if baseIndex == 0 || baseIndex == len(ramp)-1 {
+		t.Fatalf("base index %d leaves no room for shadows and highlights", baseIndex)
+	}
+
+	_, _, darkHue := ramp[0].OkLch()
+	_, _, lightHue := ramp[len(ramp)-1].OkLch()
+	if darkHue <= baseHue {
+		t.Errorf("shadow hue %.1f should move from %.1f toward blue", darkHue, baseHue)
+	}
+	if lightHue >= baseHue {
+		t.Errorf("highlight hue %.1f should move from %.1f toward yellow", lightHue, baseHue)
+	}
+
+	// Without hue shift the ends keep the base hue (within gamut rounding)
+	opts.HueShift = 0
+	ramp, _, _ = GenerateRamp(base, opts)
+	_, _, darkHue = ramp[0].OkLch()
+	if math.Abs(darkHue-baseHue) > 3 {
+		t.Errorf("unshifted shadow hue %.1f differs from base %.1f", darkHue, baseHue)
+	}
+}
+
+func TestGenerateRamp_SaturationCurve(t *testing.T) {
+	base, _ := colorful.Hex("#C04040")
+
+	opts := defaultRampOptions()
+	opts.HueShift = 0
+	opts.SaturationCurve = "flat"
+	flat, _, _ := GenerateRamp(base, opts)
+
+	opts.SaturationCurve = "arc"
+	opts.SaturationAmount = 0.8
+	arc, _, _ := GenerateRamp(base, opts)
+
+	_, flatChroma, _ := flat[len(flat)-1].OkLch()
+	_, arcChroma, _ := arc[len(arc)-1].OkLch()
+	if arcChroma >= flatChroma {
+		t.Errorf("arc highlight chroma %.3f should be below flat chroma %.3f", arcChroma, flatChroma)
+	}
+}
+
+func TestGenerateRamp_Validation(t *testing.T) {
+	base, _ := colorful.Hex("#808080")
+
+	tests := []struct {
+		name   string
+		modify func(*RampOptions)
+		errMsg string
+	}{
+		{"too few steps", func(o *RampOptions) { o.Steps = 1 }, "steps"},
+		{"too many steps", func(o *RampOptions) { o.Steps = 33 }, "steps"},
+		{"negative hue shift", func(o *RampOptions) { o.HueShift = -1 }, "hue shift"},
+		{"bad amount", func(o *RampOptions) { o.SaturationAmount = 2 }, "saturation amount"},
+		{"inverted range", func(o *RampOptions) { o.LightnessMin, o.LightnessMax = 0.8, 0.2 }, "lightness range"},
+		{"bad curve", func(o *RampOptions) { o.SaturationCurve = "wavy" }, "saturation curve"},
+		{"bad space", func(o *RampOptions) { o.ColorSpace = "rgb" }, "color space"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := defaultRampOptions()
+			tt.modify(&opts)
+			_, _, err := GenerateRamp(base, opts)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("GenerateRamp() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestShiftHueToward(t *testing.T) {
+	tests := []struct {
+		h, target, amount, want float64
+	}{
+		{100, 265, 20, 120},
+		{100, 265, 500, 265},
+		{10, 265, 20, 350},
+		{300, 90, 30, 330},
+	}
+	for _, tt := range tests {
+		if got := shiftHueToward(tt.h, tt.target, tt.amount); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("shiftHueToward(%v, %v, %v) = %v, want %v", tt.h, tt.target, tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestRampEnd_ShiftsHueTowardCoolAndWarm(t *testing.T) {
+	// Red sits between the cool (240) and warm (55) HSL targets on either side
+	base := colorful.Hsl(0, 0.8, 0.5)
+
+	shadow := rampEnd(base, "hsl", 0.3, 1, 10, false)
+	if h, _, l := shadow.Hsl(); math.Abs(h-350) > 1 || math.Abs(l-0.3) > 0.01 {
+		t.Errorf("shadow hue/lightness = %.1f/%.2f, want 350/0.30", h, l)
+	}
+
+	highlight := rampEnd(base, "hsl", 0.7, 1, 10, true)
+	if h, _, l := highlight.Hsl(); math.Abs(h-10) > 1 || math.Abs(l-0.7) > 0.01 {
+		t.Errorf("highlight hue/lightness = %.1f/%.2f, want 10/0.70", h, l)
+	}
+
+}
+
+func TestGenerateColorRamp_KeepsAutoShadingColors(t *testing.T) {
+	// apply_auto_shading shifts shadows +10 and highlights -10 degrees in HSL
+	tests := []struct {
+		base      color.RGBA
+		intensity float64
+		hueShift  bool
+		want      []string
+	}{
+		{color.RGBA{R: 204, G: 25, B: 25, A: 255}, 0.5, false, []string{"#AD1515", "#CC1919", "#E52A2A"}},
+		{color.RGBA{R: 204, G: 25, B: 25, A: 255}, 1, true, []string{"#8F2611", "#CC1919", "#EA5069"}},
+		{color.RGBA{R: 40, G: 120, B: 200, A: 255}, 0.5, true, []string{"#224FAA", "#2878C8", "#40A6D9"}},
+		{color.RGBA{R: 40, G: 120, B: 200, A: 255}, 1, false, []string{"#1C548C", "#2878C8", "#62A0DF"}},
+		{color.RGBA{R: 90, G: 160, B: 60, A: 255}, 1, true, []string{"#33702A", "#5AA03C", "#96C86B"}},
+	}
+
+	for _, tt := range tests {
+		ramp, _ := generateColorRamp(tt.base, tt.intensity, tt.hueShift)
+		if len(ramp) != len(tt.want) {
+			t.Fatalf("generateColorRamp(%v) returned %d colors, want %d", tt.base, len(ramp), len(tt.want))
+		}
+		for i := range ramp {
+			if ramp[i] != tt.want[i] {
+				t.Errorf("generateColorRamp(%v, %v, %v) = %v, want %v", tt.base, tt.intensity, tt.hueShift, ramp, tt.want)
+				break
+			}
+		}
+	}
+}
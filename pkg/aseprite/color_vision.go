@@ -0,0 +1,346 @@
+package aseprite
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// Color vision deficiencies accepted by SimulateColorVision.
+const (
+	VisionProtanopia    = "protanopia"    // No red cones
+	VisionDeuteranopia  = "deuteranopia"  // No green cones
+	VisionTritanopia    = "tritanopia"    // No blue cones
+	VisionAchromatopsia = "achromatopsia" // No color vision (luminance only)
+)
+
+// AllColorDeficiencies lists every deficiency, in report order.
+var AllColorDeficiencies = []string{
+	VisionProtanopia,
+	VisionDeuteranopia,
+	VisionTritanopia,
+	VisionAchromatopsia,
+}
+
+// WCAG 2.x contrast levels reported by WCAGLevel.
+const (
+	WCAGLevelAAA     = "AAA"      // At least 7:1
+	WCAGLevelAA      = "AA"       // At least 4.5:1
+	WCAGLevelAALarge = "AA_large" // At least 3:1 (large text and UI components)
+	WCAGLevelFail    = "fail"     // Below 3:1
+)
+
+const (
+	// Minimum contrast ratios of the WCAG levels
+	wcagAAARatio     = 7.0
+	wcagAARatio      = 4.5
+	wcagAALargeRatio = 3.0
+
+	// Defaults for AccessibilityOptions
+	defaultAccessibilityDE       = 10.0
+	defaultAccessibilityMaxPairs = 50
+)
+
+// Linear RGB luminance weights (ITU-R BT.709), used by WCAG relative luminance
+// and the achromatopsia simulation.
+const (
+	luminanceWeightR = 0.2126
+	luminanceWeightG = 0.7152
+	luminanceWeightB = 0.0722
+)
+
+// dichromacyMatrices are the Machado, Oliveira and Fernandes (2009)
+// simulation matrices for full-severity dichromacy, applied in linear RGB.
+var dichromacyMatrices = map[string][3][3]float64{
+	VisionProtanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	VisionDeuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	VisionTritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// SimulateColorVision returns how c appears to a viewer with the given color
+// vision deficiency.
+//
+// Dichromacies use the Machado et al. (2009) model; achromatopsia reduces the
+// color to its relative luminance. Severity (0.0-1.0) blends linearly between
+// normal vision and the full deficiency.
+func SimulateColorVision(c colorful.Color, deficiency string, severity float64) (colorful.Color, error) {
+	if severity < 0 || severity > 1 {
+		return c, fmt.Errorf("severity must be between 0.0 and 1.0, got %f", severity)
+	}
+
+	r, g, b := c.LinearRgb()
+
+	var sr, sg, sb float64
+	if deficiency == VisionAchromatopsia {
+		y := luminanceWeightR*r + luminanceWeightG*g + luminanceWeightB*b
+		sr, sg, sb = y, y, y
+	} else {
+		m, ok := dichromacyMatrices[deficiency]
+		if !ok {
+			return c, fmt.Errorf("invalid deficiency: %s (must be protanopia, deuteranopia, tritanopia, or achromatopsia)", deficiency)
+		}
+		sr = m[0][0]*r + m[0][1]*g + m[0][2]*b
+		sg = m[1][0]*r + m[1][1]*g + m[1][2]*b
+		sb = m[2][0]*r + m[2][1]*g + m[2][2]*b
+	}
+
+	sr = r + (sr-r)*severity
+	sg = g + (sg-g)*severity
+	sb = b + (sb-b)*severity
+
+	return colorful.LinearRgb(clamp01(sr), clamp01(sg), clamp01(sb)), nil
+}
+
+// SimulateColorVisionImage applies SimulateColorVision to every pixel of img,
+// keeping alpha. Fully transparent pixels are left untouched.
+func SimulateColorVisionImage(img image.Image, deficiency string, severity float64) (*image.NRGBA, error) {
+	// Validate once so the per-pixel loop cannot fail
+	if _, err := SimulateColorVision(colorful.Color{}, deficiency, severity); err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	out := image.NewNRGBA(bounds)
+	cache := make(map[color.NRGBA]color.NRGBA)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			px := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if px.A == 0 {
+				out.SetNRGBA(x, y, px)
+				continue
+			}
+
+			key := color.NRGBA{R: px.R, G: px.G, B: px.B, A: 255}
+			sim, ok := cache[key]
+			if !ok {
+				c := colorful.Color{R: float64(px.R) / 255.0, G: float64(px.G) / 255.0, B: float64(px.B) / 255.0}
+				s, _ := SimulateColorVision(c, deficiency, severity)
+				sr, sg, sb := s.RGB255()
+				sim = color.NRGBA{R: sr, G: sg, B: sb, A: 255}
+				cache[key] = sim
+			}
+			sim.A = px.A
+			out.SetNRGBA(x, y, sim)
+		}
+	}
+
+	return out, nil
+}
+
+// RelativeLuminance returns the WCAG 2.x relative luminance of c (0.0-1.0).
+func RelativeLuminance(c colorful.Color) float64 {
+	r, g, b := c.LinearRgb()
+	return luminanceWeightR*r + luminanceWeightG*g + luminanceWeightB*b
+}
+
+// ContrastRatio returns the WCAG 2.x contrast ratio between two colors,
+// from 1 (identical luminance) to 21 (black on white).
+func ContrastRatio(c1, c2 colorful.Color) float64 {
+	l1, l2 := RelativeLuminance(c1), RelativeLuminance(c2)
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+	return (l1 + 0.05) / (l2 + 0.05)
+}
+
+// WCAGLevel returns the highest WCAG 2.x contrast level a ratio satisfies.
+func WCAGLevel(ratio float64) string {
+	switch {
+	case ratio >= wcagAAARatio:
+		return WCAGLevelAAA
+	case ratio >= wcagAARatio:
+		return WCAGLevelAA
+	case ratio >= wcagAALargeRatio:
+		return WCAGLevelAALarge
+	default:
+		return WCAGLevelFail
+	}
+}
+
+// AccessibilityOptions configures AnalyzePaletteAccessibility.
+type AccessibilityOptions struct {
+	Deficiencies []string // Deficiencies to check (empty = AllColorDeficiencies)
+	Threshold    float64  // CIEDE2000 ΔE below which two colors are indistinguishable (default 10)
+	MaxPairs     int      // Maximum pairs listed per section (default 50; counts are always complete)
+}
+
+// ConfusablePair is a pair of palette colors that are hard to tell apart
+// under a color vision deficiency.
+type ConfusablePair struct {
+	Color1       string  `json:"color1"`         // First color (#RRGGBB)
+	Color2       string  `json:"color2"`         // Second color (#RRGGBB)
+	Index1       int     `json:"index1"`         // Palette index of the first color
+	Index2       int     `json:"index2"`         // Palette index of the second color
+	DeltaE       float64 `json:"delta_e"`        // CIEDE2000 ΔE as seen with the deficiency
+	NormalDeltaE float64 `json:"normal_delta_e"` // CIEDE2000 ΔE with normal vision
+}
+
+// DeficiencyReport lists the confusable pairs for one deficiency.
+type DeficiencyReport struct {
+	Deficiency      string           `json:"deficiency"`       // Simulated deficiency
+	ConfusableCount int              `json:"confusable_count"` // Total pairs below the threshold
+	Pairs           []ConfusablePair `json:"pairs"`            // Closest pairs first, limited to MaxPairs
+	Simulated       []string         `json:"simulated"`        // Palette as seen with the deficiency (#RRGGBB)
+}
+
+// ContrastPair is the WCAG contrast between two palette colors.
+type ContrastPair struct {
+	Color1 string  `json:"color1"` // First color (#RRGGBB)
+	Color2 string  `json:"color2"` // Second color (#RRGGBB)
+	Index1 int     `json:"index1"` // Palette index of the first color
+	Index2 int     `json:"index2"` // Palette index of the second color
+	Ratio  float64 `json:"ratio"`  // WCAG contrast ratio (1-21)
+	Level  string  `json:"level"`  // "AAA", "AA", "AA_large", or "fail"
+}
+
+// AccessibilityReport is the result of AnalyzePaletteAccessibility.
+type AccessibilityReport struct {
+	Threshold     float64            `json:"threshold"`      // ΔE threshold used
+	Deficiencies  []DeficiencyReport `json:"deficiencies"`   // One report per deficiency
+	Contrast      []ContrastPair     `json:"contrast"`       // Lowest-contrast (failing) pairs first, limited to MaxPairs
+	ContrastLevel map[string]int     `json:"contrast_level"` // Number of pairs at each WCAG level
+}
+
+// AnalyzePaletteAccessibility checks how distinguishable palette colors are.
+//
+// For each deficiency, every color pair is simulated and pairs whose CIEDE2000
+// ΔE falls below the threshold are reported as confusable, closest first.
+// WCAG contrast ratios are computed for every pair under normal vision and
+// listed lowest first, so failing pairs survive the MaxPairs limit.
+// Duplicate colors are compared once, using their first palette index.
+func AnalyzePaletteAccessibility(colors []string, opts AccessibilityOptions) (*AccessibilityReport, error) {
+	if len(opts.Deficiencies) == 0 {
+		opts.Deficiencies = AllColorDeficiencies
+	}
+	if opts.Threshold == 0 {
+		opts.Threshold = defaultAccessibilityDE
+	}
+	if opts.MaxPairs == 0 {
+		opts.MaxPairs = defaultAccessibilityMaxPairs
+	}
+	if opts.Threshold < 0 {
+		return nil, fmt.Errorf("threshold must be positive, got %f", opts.Threshold)
+	}
+	if opts.MaxPairs < 0 {
+		return nil, fmt.Errorf("max pairs must be positive, got %d", opts.MaxPairs)
+	}
+
+	// Unique colors with their first palette index
+	var parsed []colorful.Color
+	var hexes []string
+	var indices []int
+	seen := make(map[string]bool)
+	for i, s := range colors {
+		var c Color
+		if err := c.FromHex(s); err != nil {
+			return nil, fmt.Errorf("invalid color at index %d: %w", i, err)
+		}
+		hex := fmt.Sprintf("#%02X%02X%02X", c.R, c.G, c.B)
+		if seen[hex] {
+			continue
+		}
+		seen[hex] = true
+		parsed = append(parsed, colorful.Color{R: float64(c.R) / 255.0, G: float64(c.G) / 255.0, B: float64(c.B) / 255.0})
+		hexes = append(hexes, hex)
+		indices = append(indices, i)
+	}
+
+	report := &AccessibilityReport{
+		Threshold:     opts.Threshold,
+		Deficiencies:  make([]DeficiencyReport, 0, len(opts.Deficiencies)),
+		Contrast:      []ContrastPair{},
+		ContrastLevel: map[string]int{WCAGLevelAAA: 0, WCAGLevelAA: 0, WCAGLevelAALarge: 0, WCAGLevelFail: 0},
+	}
+
+	for _, deficiency := range opts.Deficiencies {
+		simulated := make([]colorful.Color, len(parsed))
+		dr := DeficiencyReport{
+			Deficiency: deficiency,
+			Pairs:      []ConfusablePair{},
+			Simulated:  make([]string, len(parsed)),
+		}
+		for i, c := range parsed {
+			s, err := SimulateColorVision(c, deficiency, 1)
+			if err != nil {
+				return nil, err
+			}
+			simulated[i] = s
+			r, g, b := s.RGB255()
+			dr.Simulated[i] = fmt.Sprintf("#%02X%02X%02X", r, g, b)
+		}
+
+		for i := 0; i < len(parsed); i++ {
+			for j := i + 1; j < len(parsed); j++ {
+				de := simulated[i].DistanceCIEDE2000(simulated[j]) * 100
+				if de >= opts.Threshold {
+					continue
+				}
+				dr.Pairs = append(dr.Pairs, ConfusablePair{
+					Color1:       hexes[i],
+					Color2:       hexes[j],
+					Index1:       indices[i],
+					Index2:       indices[j],
+					DeltaE:       roundTo(de, 2),
+					NormalDeltaE: roundTo(parsed[i].DistanceCIEDE2000(parsed[j])*100, 2),
+				})
+			}
+		}
+
+		sort.SliceStable(dr.Pairs, func(a, b int) bool { return dr.Pairs[a].DeltaE < dr.Pairs[b].DeltaE })
+		dr.ConfusableCount = len(dr.Pairs)
+		if len(dr.Pairs) > opts.MaxPairs {
+			dr.Pairs = dr.Pairs[:opts.MaxPairs]
+		}
+		report.Deficiencies = append(report.Deficiencies, dr)
+	}
+
+	for i := 0; i < len(parsed); i++ {
+		for j := i + 1; j < len(parsed); j++ {
+			ratio := ContrastRatio(parsed[i], parsed[j])
+			level := WCAGLevel(ratio)
+			report.ContrastLevel[level]++
+			report.Contrast = append(report.Contrast, ContrastPair{
+				Color1: hexes[i],
+				Color2: hexes[j],
+				Index1: indices[i],
+				Index2: indices[j],
+				Ratio:  roundTo(ratio, 2),
+				Level:  level,
+			})
+		}
+	}
+	sort.SliceStable(report.Contrast, func(a, b int) bool { return report.Contrast[a].Ratio < report.Contrast[b].Ratio })
+	if len(report.Contrast) > opts.MaxPairs {
+		report.Contrast = report.Contrast[:opts.MaxPairs]
+	}
+
+	return report, nil
+}
+
+// roundTo rounds v to the given number of decimal places.
+func roundTo(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
+
+// clamp01 limits v to the range 0.0-1.0.
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
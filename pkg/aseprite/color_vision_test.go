@@ -0,0 +1,230 @@
+package aseprite
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+func TestSimulateColorVision_RedGreenConfusion(t *testing.T) {
+	red, _ := colorful.Hex("#D03030")
+	green, _ := colorful.Hex("#609020")
+
+	normal := red.DistanceCIEDE2000(green) * 100
+
+	for _, deficiency := range []string{VisionProtanopia, VisionDeuteranopia} {
+		sr, err := SimulateColorVision(red, deficiency, 1)
+		if err != nil {
+			t.Fatalf("SimulateColorVision() error = %v", err)
+		}
+		sg, _ := SimulateColorVision(green, deficiency, 1)
+
+		if simulated := sr.DistanceCIEDE2000(sg) * 100; simulated >= normal/2 {
+			t.Errorf("%s: red/green ΔE %.1f should drop well below normal %.1f", deficiency, simulated, normal)
+		}
+	}
+}
+
+func TestSimulateColorVision_Achromatopsia(t *testing.T) {
+	c, _ := colorful.Hex("#3080C0")
+
+	s, err := SimulateColorVision(c, VisionAchromatopsia, 1)
+	if err != nil {
+		t.Fatalf("SimulateColorVision() error = %v", err)
+	}
+	r, g, b := s.RGB255()
+	if r != g || g != b {
+		t.Errorf("achromatopsia result %s is not gray", s.Hex())
+	}
+	if math.Abs(RelativeLuminance(s)-RelativeLuminance(c)) > 0.01 {
+		t.Errorf("achromatopsia changed luminance from %.3f to %.3f", RelativeLuminance(c), RelativeLuminance(s))
+	}
+}
+
+func TestSimulateColorVision_SeverityAndNeutrals(t *testing.T) {
+	c, _ := colorful.Hex("#C04080")
+
+	s, _ := SimulateColorVision(c, VisionTritanopia, 0)
+	if s.Hex() != c.Hex() {
+		t.Errorf("severity 0 changed color from %s to %s", c.Hex(), s.Hex())
+	}
+
+	// Grays look the same to everyone
+	gray, _ := colorful.Hex("#808080")
+	for _, deficiency := range AllColorDeficiencies {
+		s, _ := SimulateColorVision(gray, deficiency, 1)
+		if gray.DistanceCIEDE2000(s)*100 > 1 {
+			t.Errorf("%s changed gray to %s", deficiency, s.Hex())
+		}
+	}
+
+	if _, err := SimulateColorVision(c, "tetrachromacy", 1); err == nil {
+		t.Error("expected error for unknown deficiency")
+	}
+	if _, err := SimulateColorVision(c, VisionProtanopia, 1.5); err == nil {
+		t.Error("expected error for severity out of range")
+	}
+}
+
+func TestSimulateColorVisionImage_KeepsAlpha(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.SetNRGBA(0, 0, color.NRGBA{R: 200, G: 40, B: 40, A: 128})
+
+	out, err := SimulateColorVisionImage(img, VisionAchromatopsia, 1)
+	if err != nil {
+		t.Fatalf("SimulateColorVisionImage() error = %v", err)
+	}
+
+	px := out.NRGBAAt(0, 0)
+	if px.A != 128 {
+		t.Errorf("alpha = %d, want 128", px.A)
+	}
+	if px.R != px.G || px.G != px.B {
+		t.Errorf("pixel %v is not gray", px)
+	}
+	if out.NRGBAAt(1, 0).A != 0 {
+		t.Error("transparent pixel should stay transparent")
+	}
+
+	if _, err := SimulateColorVisionImage(img, "bad", 1); err == nil {
+		t.Error("expected error for unknown deficiency")
+	}
+}
+
+func TestContrastRatio(t *testing.T) {
+	black, _ := colorful.Hex("#000000")
+	white, _ := colorful.Hex("#FFFFFF")
+
+	if got := ContrastRatio(black, white); math.Abs(got-21) > 0.01 {
+		t.Errorf("black/white contrast = %.2f, want 21", got)
+	}
+	if got := ContrastRatio(white, white); got != 1 {
+		t.Errorf("white/white contrast = %.2f, want 1", got)
+	}
+
+	tests := []struct {
+		ratio float64
+		want  string
+	}{
+		{21, WCAGLevelAAA},
+		{5, WCAGLevelAA},
+		{3.2, WCAGLevelAALarge},
+		{1.5, WCAGLevelFail},
+	}
+	for _, tt := range tests {
+		if got := WCAGLevel(tt.ratio); got != tt.want {
+			t.Errorf("WCAGLevel(%v) = %s, want %s", tt.ratio, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzePaletteAccessibility(t *testing.T) {
+	colors := []string{"#000000", "#FFFFFF", "#D03030", "#609020", "#000000"}
+
+	report, err := AnalyzePaletteAccessibility(colors, AccessibilityOptions{Threshold: 15})
+	if err != nil {
+		t.Fatalf("AnalyzePaletteAccessibility() error = %v", err)
+	}
+
+	if len(report.Deficiencies) != len(AllColorDeficiencies) {
+		t.Fatalf("got %d deficiency reports, want %d", len(report.Deficiencies), len(AllColorDeficiencies))
+	}
+
+	var deut *DeficiencyReport
+	for i := range report.Deficiencies {
+		if report.Deficiencies[i].Deficiency == VisionDeuteranopia {
+			deut = &report.Deficiencies[i]
+		}
+	}
+	if deut == nil {
+		t.Fatal("missing deuteranopia report")
+	}
+	found := false
+	for _, p := range deut.Pairs {
+		if p.Color1 == "#D03030" && p.Color2 == "#609020" {
+			found = true
+			if p.Index1 != 2 || p.Index2 != 3 {
+				t.Errorf("pair indices = %d,%d, want 2,3", p.Index1, p.Index2)
+			}
+			if p.NormalDeltaE <= p.DeltaE {
+				t.Errorf("normal ΔE %.1f should exceed simulated ΔE %.1f", p.NormalDeltaE, p.DeltaE)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("red/green pair not flagged under deuteranopia: %+v", deut.Pairs)
+	}
+	if len(deut.Simulated) != 4 {
+		t.Errorf("simulated palette has %d colors, want 4 (duplicates merged)", len(deut.Simulated))
+	}
+
+	// 4 unique colors -> 6 pairs, lowest contrast first, black/white last
+	if len(report.Contrast) != 6 {
+		t.Fatalf("got %d contrast pairs, want 6", len(report.Contrast))
+	}
+	for i := 1; i < len(report.Contrast); i++ {
+		if report.Contrast[i].Ratio < report.Contrast[i-1].Ratio {
+			t.Errorf("contrast pairs not sorted lowest first: %+v", report.Contrast)
+			break
+		}
+	}
+	if last := report.Contrast[5]; last.Level != WCAGLevelAAA || last.Ratio != 21 {
+		t.Errorf("highest contrast pair = %+v, want black/white AAA 21", last)
+	}
+	total := 0
+	for _, n := range report.ContrastLevel {
+		total += n
+	}
+	if total != 6 {
+		t.Errorf("contrast level counts sum to %d, want 6", total)
+	}
+}
+
+func TestAnalyzePaletteAccessibility_Options(t *testing.T) {
+	colors := []string{"#101010", "#121212", "#141414", "#161616"}
+
+	report, err := AnalyzePaletteAccessibility(colors, AccessibilityOptions{
+		Deficiencies: []string{VisionTritanopia},
+		MaxPairs:     2,
+	})
+	if err != nil {
+		t.Fatalf("AnalyzePaletteAccessibility() error = %v", err)
+	}
+	if len(report.Deficiencies) != 1 {
+		t.Fatalf("got %d deficiency reports, want 1", len(report.Deficiencies))
+	}
+	dr := report.Deficiencies[0]
+	if dr.ConfusableCount != 6 || len(dr.Pairs) != 2 {
+		t.Errorf("confusable count = %d with %d listed, want 6 with 2 listed", dr.ConfusableCount, len(dr.Pairs))
+	}
+	if len(report.Contrast) != 2 {
+		t.Errorf("got %d contrast pairs, want 2", len(report.Contrast))
+	}
+
+	if _, err := AnalyzePaletteAccessibility([]string{"nope"}, AccessibilityOptions{}); err == nil {
+		t.Error("expected error for invalid color")
+	}
+	if _, err := AnalyzePaletteAccessibility(colors, AccessibilityOptions{Deficiencies: []string{"bad"}}); err == nil {
+		t.Error("expected error for unknown deficiency")
+	}
+}
+
+func TestAnalyzePaletteAccessibility_KeepsFailingPairs(t *testing.T) {
+	// Most pairs pass, but with one listed pair a failing one must be shown
+	// rather than black/white at 21:1
+	colors := []string{"#000000", "#FFFFFF", "#F0F0F0", "#0000AA", "#AA0000", "#00007F", "#7F0000", "#202020"}
+
+	report, err := AnalyzePaletteAccessibility(colors, AccessibilityOptions{MaxPairs: 1})
+	if err != nil {
+		t.Fatalf("AnalyzePaletteAccessibility() error = %v", err)
+	}
+	if len(report.Contrast) != 1 {
+		t.Fatalf("got %d contrast pairs, want 1", len(report.Contrast))
+	}
+	if got := report.Contrast[0]; got.Level != WCAGLevelFail {
+		t.Errorf("listed contrast pair = %+v, want the lowest (failing) pair", got)
+	}
+}
@@ -0,0 +1,129 @@
+package aseprite
+
+import (
+	"fmt"
+	"math"
+)
+
+// Easing is a timing curve defined, like CSS cubic-bezier(), by the two
+// inner control points of a cubic Bezier curve running from (0, 0) to (1, 1).
+// X is time and Y is progress; Y values outside 0-1 overshoot.
+type Easing struct {
+	X1, Y1, X2, Y2 float64
+}
+
+// EasingPresets are the named easing curves, matching their CSS equivalents.
+var EasingPresets = map[string]Easing{
+	"linear":      {X1: 0, Y1: 0, X2: 1, Y2: 1},
+	"ease_in":     {X1: 0.42, Y1: 0, X2: 1, Y2: 1},
+	"ease_out":    {X1: 0, Y1: 0, X2: 0.58, Y2: 1},
+	"ease_in_out": {X1: 0.42, Y1: 0, X2: 0.58, Y2: 1},
+}
+
+// NewCubicBezierEasing creates an easing curve from its control points.
+// Returns an error if x1 or x2 is outside 0-1, which would make time run
+// backwards.
+func NewCubicBezierEasing(x1, y1, x2, y2 float64) (Easing, error) {
+	if x1 < 0 || x1 > 1 || x2 < 0 || x2 > 1 {
+		return Easing{}, fmt.Errorf("bezier x control points must be between 0 and 1, got x1=%g x2=%g", x1, x2)
+	}
+	return Easing{X1: x1, Y1: y1, X2: x2, Y2: y2}, nil
+}
+
+// At returns the progress at time t (clamped to 0-1).
+func (e Easing) At(t float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	if t >= 1 {
+		return 1
+	}
+
+	// Control points on the diagonal make the curve the identity
+	if e.X1 == e.Y1 && e.X2 == e.Y2 {
+		return t
+	}
+
+	// Find the curve parameter u whose x is t. x(u) is monotonic because
+	// both x control points lie in 0-1, so bisection always converges.
+	lo, hi := 0.0, 1.0
+	u := t
+	for i := 0; i < 64; i++ {
+		x := cubicBezier(u, e.X1, e.X2)
+		if math.Abs(x-t) < 1e-9 {
+			break
+		}
+		if x < t {
+			lo = u
+		} else {
+			hi = u
+		}
+		u = (lo + hi) / 2
+	}
+
+	return cubicBezier(u, e.Y1, e.Y2)
+}
+
+// cubicBezier evaluates one coordinate of a cubic Bezier curve from 0 to 1
+// with inner control values p1 and p2.
+func cubicBezier(u, p1, p2 float64) float64 {
+	v := 1 - u
+	return 3*v*v*u*p1 + 3*v*u*u*p2 + u*u*u
+}
+
+// TimeAt returns the time (0-1) at which the curve first reaches progress p,
+// the inverse of At for curves that do not overshoot.
+func (e Easing) TimeAt(p float64) float64 {
+	if p <= 0 {
+		return 0
+	}
+	if p >= 1 {
+		return 1
+	}
+	if e.X1 == e.Y1 && e.X2 == e.Y2 {
+		return p
+	}
+
+	lo, hi := 0.0, 1.0
+	for i := 0; i < 64; i++ {
+		mid := (lo + hi) / 2
+		if e.At(mid) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// DistributeDuration splits totalMs across count frames following an easing
+// curve. The frames are treated as evenly spaced steps of progress, so each
+// frame lasts as long as the curve takes to advance one step: ease_in makes
+// the first frames longest, ease_out the last ones, and linear splits the
+// time evenly. Durations are whole milliseconds and every frame lasts at
+// least 1 ms; the time given to frames raised to 1 ms is taken back from the
+// longest frames, so the durations sum to totalMs whenever totalMs >= count.
+func DistributeDuration(totalMs, count int, easing Easing) []int {
+	durations := make([]int, count)
+	prev, sum := 0, 0
+	for i := 1; i <= count; i++ {
+		next := int(math.Round(float64(totalMs) * easing.TimeAt(float64(i)/float64(count))))
+		durations[i-1] = max(next-prev, 1)
+		prev = max(next, prev+1)
+		sum += durations[i-1]
+	}
+
+	for ; sum > totalMs; sum-- {
+		longest := 0
+		for i, d := range durations {
+			if d > durations[longest] {
+				longest = i
+			}
+		}
+		if durations[longest] == 1 {
+			break
+		}
+		durations[longest]--
+	}
+	return durations
+}
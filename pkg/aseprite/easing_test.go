@@ -0,0 +1,135 @@
+package aseprite
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEasingPresets(t *testing.T) {
+	for name, easing := range EasingPresets {
+		if got := easing.At(0); got != 0 {
+			t.Errorf("%s.At(0) = %f, want 0", name, got)
+		}
+		if got := easing.At(1); got != 1 {
+			t.Errorf("%s.At(1) = %f, want 1", name, got)
+		}
+
+		// Progress never decreases
+		prev := 0.0
+		for i := 1; i <= 20; i++ {
+			got := easing.At(float64(i) / 20)
+			if got < prev-1e-9 {
+				t.Errorf("%s decreases at t=%f", name, float64(i)/20)
+			}
+			prev = got
+		}
+	}
+
+	tests := []struct {
+		name  string
+		check func(float64) bool
+	}{
+		{"linear", func(v float64) bool { return math.Abs(v-0.5) < 1e-6 }},
+		{"ease_in", func(v float64) bool { return v < 0.4 }},
+		{"ease_out", func(v float64) bool { return v > 0.6 }},
+		{"ease_in_out", func(v float64) bool { return math.Abs(v-0.5) < 1e-6 }},
+	}
+	for _, tt := range tests {
+		if got := EasingPresets[tt.name].At(0.5); !tt.check(got) {
+			t.Errorf("%s.At(0.5) = %f", tt.name, got)
+		}
+	}
+}
+
+func TestNewCubicBezierEasing(t *testing.T) {
+	if _, err := NewCubicBezierEasing(-0.1, 0, 1, 1); err == nil {
+		t.Error("expected error for x1 < 0")
+	}
+	if _, err := NewCubicBezierEasing(0, 0, 1.5, 1); err == nil {
+		t.Error("expected error for x2 > 1")
+	}
+
+	// Back-out style curve overshoots past 1 before settling
+	easing, err := NewCubicBezierEasing(0.34, 1.56, 0.64, 1)
+	if err != nil {
+		t.Fatalf("NewCubicBezierEasing() error = %v", err)
+	}
+	overshoot := false
+	for i := 1; i < 20; i++ {
+		if easing.At(float64(i)/20) > 1 {
+			overshoot = true
+		}
+	}
+	if !overshoot {
+		t.Error("expected overshoot above 1")
+	}
+}
+
+func TestEasing_TimeAt(t *testing.T) {
+	for name, easing := range EasingPresets {
+		for _, p := range []float64{0, 0.1, 0.5, 0.9, 1} {
+			if got := easing.At(easing.TimeAt(p)); math.Abs(got-p) > 1e-6 {
+				t.Errorf("%s.At(TimeAt(%f)) = %f", name, p, got)
+			}
+		}
+	}
+}
+
+func TestDistributeDuration(t *testing.T) {
+	sum := func(values []int) int {
+		total := 0
+		for _, v := range values {
+			total += v
+		}
+		return total
+	}
+
+	linear := DistributeDuration(1000, 4, EasingPresets["linear"])
+	for i, d := range linear {
+		if d != 250 {
+			t.Errorf("linear[%d] = %d, want 250", i, d)
+		}
+	}
+
+	uneven := DistributeDuration(100, 3, EasingPresets["linear"])
+	if sum(uneven) != 100 {
+		t.Errorf("durations %v sum to %d, want 100", uneven, sum(uneven))
+	}
+
+	easeIn := DistributeDuration(1000, 5, EasingPresets["ease_in"])
+	if sum(easeIn) != 1000 {
+		t.Errorf("ease_in durations %v sum to %d, want 1000", easeIn, sum(easeIn))
+	}
+	if easeIn[0] <= easeIn[4] {
+		t.Errorf("ease_in should hold the first frame longest, got %v", easeIn)
+	}
+
+	easeOut := DistributeDuration(1000, 5, EasingPresets["ease_out"])
+	if easeOut[0] >= easeOut[4] {
+		t.Errorf("ease_out should hold the last frame longest, got %v", easeOut)
+	}
+
+	// Small totals: frames raised to 1 ms must not push the sum over total_ms
+	for _, name := range []string{"ease_in", "ease_out", "ease_in_out"} {
+		for count := 1; count <= 12; count++ {
+			for total := count; total <= 4*count; total++ {
+				durations := DistributeDuration(total, count, EasingPresets[name])
+				if sum(durations) != total {
+					t.Errorf("%s over %d frames with %d ms = %v, sums to %d", name, count, total, durations, sum(durations))
+				}
+				for i, d := range durations {
+					if d < 1 {
+						t.Errorf("%s over %d frames with %d ms: frame %d lasts %d ms", name, count, total, i, d)
+					}
+				}
+			}
+		}
+	}
+
+	tiny := DistributeDuration(3, 5, EasingPresets["linear"])
+	for i, d := range tiny {
+		if d < 1 {
+			t.Errorf("tiny[%d] = %d, want at least 1", i, d)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+package aseprite
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// DropShadow renders the shadow cast by an image's silhouette.
+//
+// The returned image has the same bounds as img and contains only the shadow:
+// every pixel whose source pixel at (x-offsetX, y-offsetY) is visible gets the
+// shadow color. With dithered false the shadow is a flat color whose alpha is
+// opacity times the source alpha. With dithered true the shadow pixels are fully
+// opaque and opacity controls their density through a 4x4 Bayer pattern, which
+// keeps the result palette-friendly. Combine with CompositeUnder to place the
+// shadow behind the original pixels.
+//
+// Parameters:
+//   - img: source image (typically sprite-sized so the shadow has room to fall)
+//   - offsetX, offsetY: shadow offset in pixels (positive = right/down)
+//   - shadowColor: shadow color (alpha ignored)
+//   - opacity: shadow opacity or dither density (0.0-1.0)
+//   - dithered: use ordered dithering instead of partial alpha
+func DropShadow(img image.Image, offsetX, offsetY int, shadowColor color.RGBA, opacity float64, dithered bool) (*image.NRGBA, error) {
+	if opacity < 0.0 || opacity > 1.0 {
+		return nil, fmt.Errorf("opacity must be between 0.0 and 1.0, got %f", opacity)
+	}
+
+	bounds := img.Bounds()
+	result := image.NewNRGBA(bounds)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			src := image.Pt(x-offsetX, y-offsetY)
+			if !src.In(bounds) {
+				continue
+			}
+			_, _, _, a := img.At(src.X, src.Y).RGBA()
+			if a == 0 {
+				continue
+			}
+
+			var alpha uint8
+			if dithered {
+				threshold := (float64(bayerMatrices["bayer_4x4"][y&3][x&3]) + 0.5) / 16.0
+				if threshold >= opacity {
+					continue
+				}
+				alpha = 255
+			} else {
+				alpha = uint8(math.Round(opacity * float64(a>>8)))
+				if alpha == 0 {
+					continue
+				}
+			}
+
+			result.SetNRGBA(x, y, color.NRGBA{R: shadowColor.R, G: shadowColor.G, B: shadowColor.B, A: alpha})
+		}
+	}
+
+	return result, nil
+}
+
+// RimLight renders a rim of light along the edges of a silhouette that face a light.
+//
+// An opaque pixel is lit when one of the next width pixels towards the light is
+// transparent (or outside the image). The rim is strongest on the outermost pixel
+// and fades linearly inwards. The returned image contains only the rim, with alpha
+// already scaled by the source alpha; combine with BlendEffect to apply it.
+//
+// Parameters:
+//   - img: source image
+//   - lightDir: light direction (top_left, top, top_right, left, right, bottom_left, bottom, bottom_right)
+//   - width: rim width in pixels (1-16)
+//   - rimColor: rim color (alpha ignored)
+//   - intensity: rim strength (0.0-1.0)
+func RimLight(img image.Image, lightDir string, width int, rimColor color.RGBA, intensity float64) (*image.NRGBA, error) {
+	if width < 1 || width > 16 {
+		return nil, fmt.Errorf("width must be between 1 and 16, got %d", width)
+	}
+	if intensity < 0.0 || intensity > 1.0 {
+		return nil, fmt.Errorf("intensity must be between 0.0 and 1.0, got %f", intensity)
+	}
+
+	lightVec, err := lightDirectionToVector(lightDir)
+	if err != nil {
+		return nil, err
+	}
+	stepX := sign(lightVec.X)
+	stepY := sign(lightVec.Y)
+
+	bounds := img.Bounds()
+	mask := alphaMask(img)
+	result := image.NewNRGBA(bounds)
+
+	// visible reports whether the pixel at mask coordinates (x, y) is opaque.
+	visible := func(x, y int) bool {
+		if x < 0 || y < 0 || y >= len(mask) || x >= len(mask[y]) {
+			return false
+		}
+		return mask[y][x]
+	}
+
+	for y := range mask {
+		for x := range mask[y] {
+			if !mask[y][x] {
+				continue
+			}
+
+			for k := 1; k <= width; k++ {
+				if visible(x+k*stepX, y+k*stepY) {
+					continue
+				}
+
+				strength := intensity * (1.0 - float64(k-1)/float64(width))
+				_, _, _, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+				alpha := uint8(math.Round(strength * float64(a>>8)))
+				if alpha > 0 {
+					result.SetNRGBA(bounds.Min.X+x, bounds.Min.Y+y, color.NRGBA{R: rimColor.R, G: rimColor.G, B: rimColor.B, A: alpha})
+				}
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// InnerGlow renders a glow that fades inwards from the edges of a silhouette.
+//
+// Pixels on the edge get the full intensity, which falls off linearly to zero at
+// width pixels from the edge. With perRegion true, boundaries between color
+// regions (found with the same region detection as ApplyAutoShading) count as
+// edges too, so each region glows on its own. The returned image contains only
+// the glow; combine with BlendEffect to apply it.
+//
+// Parameters:
+//   - img: source image
+//   - width: glow width in pixels (1-16)
+//   - glowColor: glow color (alpha ignored)
+//   - intensity: glow strength at the edge (0.0-1.0)
+//   - perRegion: treat color region boundaries as edges
+func InnerGlow(img image.Image, width int, glowColor color.RGBA, intensity float64, perRegion bool) (*image.NRGBA, error) {
+	if width < 1 || width > 16 {
+		return nil, fmt.Errorf("width must be between 1 and 16, got %d", width)
+	}
+	if intensity < 0.0 || intensity > 1.0 {
+		return nil, fmt.Errorf("intensity must be between 0.0 and 1.0, got %f", intensity)
+	}
+
+	dist := effectEdgeDistance(img, perRegion)
+	bounds := img.Bounds()
+	result := image.NewNRGBA(bounds)
+
+	for y := range dist {
+		for x := range dist[y] {
+			d := dist[y][x]
+			if d == 0 || d > float64(width) {
+				continue
+			}
+
+			strength := intensity * (1.0 - (d-1.0)/float64(width))
+			_, _, _, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			alpha := uint8(math.Round(strength * float64(a>>8)))
+			if alpha > 0 {
+				result.SetNRGBA(bounds.Min.X+x, bounds.Min.Y+y, color.NRGBA{R: glowColor.R, G: glowColor.G, B: glowColor.B, A: alpha})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// InnerBevel renders a bevel along the inside edges of a silhouette.
+//
+// The rim (width pixels deep) is treated as a slope rising from the edge, like the
+// "bevel" normal map method. Slopes facing the light get the highlight color and
+// slopes facing away get the shadow color, with alpha proportional to how directly
+// they face or avoid the light. The flat interior is left untouched. The returned
+// image contains only the bevel; combine with BlendEffect to apply it.
+//
+// Parameters:
+//   - img: source image
+//   - width: bevel width in pixels (1-16)
+//   - lightDir: light direction (top_left, top, top_right, left, right, bottom_left, bottom, bottom_right)
+//   - highlight: color for slopes facing the light (alpha ignored)
+//   - shadow: color for slopes facing away from the light (alpha ignored)
+//   - intensity: bevel strength (0.0-1.0)
+//   - perRegion: treat color region boundaries as edges
+func InnerBevel(img image.Image, width int, lightDir string, highlight, shadow color.RGBA, intensity float64, perRegion bool) (*image.NRGBA, error) {
+	if width < 1 || width > 16 {
+		return nil, fmt.Errorf("width must be between 1 and 16, got %d", width)
+	}
+	if intensity < 0.0 || intensity > 1.0 {
+		return nil, fmt.Errorf("intensity must be between 0.0 and 1.0, got %f", intensity)
+	}
+
+	lightVec, err := lightDirectionToVector(lightDir)
+	if err != nil {
+		return nil, err
+	}
+
+	dist := effectEdgeDistance(img, perRegion)
+	heights := make([][]float64, len(dist))
+	for y := range dist {
+		heights[y] = make([]float64, len(dist[y]))
+		for x := range dist[y] {
+			heights[y][x] = math.Min(dist[y][x], float64(width)) / float64(width)
+		}
+	}
+
+	// Scale slopes so a one-pixel bevel is as pronounced as a wide one
+	normals := normalsFromHeightField(heights, float64(width))
+	flat := lightVec.Z
+
+	bounds := img.Bounds()
+	result := image.NewNRGBA(bounds)
+
+	for y := range dist {
+		for x := range dist[y] {
+			d := dist[y][x]
+			if d == 0 || d > float64(width) {
+				continue
+			}
+
+			shade := normals[y][x].Dot(lightVec) - flat
+			c := highlight
+			if shade < 0 {
+				c = shadow
+			}
+
+			strength := intensity * math.Min(1.0, math.Abs(shade)/(1.0-flat))
+			_, _, _, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			alpha := uint8(math.Round(strength * float64(a>>8)))
+			if alpha > 0 {
+				result.SetNRGBA(bounds.Min.X+x, bounds.Min.Y+y, color.NRGBA{R: c.R, G: c.G, B: c.B, A: alpha})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// BlendEffect blends an effect image onto a base image without changing the
+// base alpha, so effects never spill outside the original silhouette.
+//
+// Each base pixel's color is interpolated towards the effect color by the effect
+// alpha. Both images must have the same bounds.
+func BlendEffect(base, effect image.Image) (*image.NRGBA, error) {
+	if base.Bounds() != effect.Bounds() {
+		return nil, fmt.Errorf("base and effect images must have the same bounds, got %v and %v", base.Bounds(), effect.Bounds())
+	}
+
+	bounds := base.Bounds()
+	result := image.NewNRGBA(bounds)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			b := color.NRGBAModel.Convert(base.At(x, y)).(color.NRGBA)
+			if b.A == 0 {
+				continue
+			}
+
+			e := color.NRGBAModel.Convert(effect.At(x, y)).(color.NRGBA)
+			t := float64(e.A) / 255.0
+			result.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(math.Round(float64(b.R) + (float64(e.R)-float64(b.R))*t)),
+				G: uint8(math.Round(float64(b.G) + (float64(e.G)-float64(b.G))*t)),
+				B: uint8(math.Round(float64(b.B) + (float64(e.B)-float64(b.B))*t)),
+				A: b.A,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// CompositeUnder draws base over an underlay (such as a drop shadow), so the
+// underlay only shows where base is transparent or translucent.
+func CompositeUnder(base, underlay image.Image) *image.NRGBA {
+	result := image.NewNRGBA(base.Bounds())
+	draw.Draw(result, result.Bounds(), underlay, underlay.Bounds().Min, draw.Src)
+	draw.Draw(result, result.Bounds(), base, base.Bounds().Min, draw.Over)
+	return result
+}
+
+// effectEdgeDistance returns the distance of each opaque pixel to the nearest
+// edge, optionally treating color region boundaries as edges.
+//
+// Region boundaries come from detectRegions. Opaque pixels that belong to no
+// region (regions smaller than 4 pixels are skipped) are treated as edge pixels.
+func effectEdgeDistance(img image.Image, perRegion bool) [][]float64 {
+	if !perRegion {
+		return edgeDistance(alphaMask(img))
+	}
+
+	bounds := img.Bounds()
+	mask := alphaMask(img)
+	dist := make([][]float64, len(mask))
+	for y := range mask {
+		dist[y] = make([]float64, len(mask[y]))
+		for x := range mask[y] {
+			if mask[y][x] {
+				dist[y][x] = 1
+			}
+		}
+	}
+
+	for _, region := range detectRegions(img) {
+		rb := region.Bounds
+		sub := make([][]bool, rb.Dy())
+		for y := range sub {
+			sub[y] = make([]bool, rb.Dx())
+		}
+		for _, p := range region.Pixels {
+			sub[p.Y-rb.Min.Y][p.X-rb.Min.X] = true
+		}
+
+		subDist := edgeDistance(sub)
+		for _, p := range region.Pixels {
+			dist[p.Y-bounds.Min.Y][p.X-bounds.Min.X] = subDist[p.Y-rb.Min.Y][p.X-rb.Min.X]
+		}
+	}
+
+	return dist
+}
+
+// sign returns -1, 0, or 1 according to the sign of v.
+func sign(v float64) int {
+	switch {
+	case v > 1e-9:
+		return 1
+	case v < -1e-9:
+		return -1
+	default:
+		return 0
+	}
+}
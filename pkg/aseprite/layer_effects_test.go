@@ -0,0 +1,170 @@
+package aseprite
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestDropShadow_Hard(t *testing.T) {
+	img := newSolidSquare(10, 3, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+	black := color.RGBA{A: 255}
+
+	shadow, err := DropShadow(img, 2, 1, black, 0.5, false)
+	if err != nil {
+		t.Fatalf("DropShadow() error = %v", err)
+	}
+
+	// Square spans 3..6; shadow spans 5..8 horizontally and 4..7 vertically
+	if got := shadow.NRGBAAt(8, 7); got.A != 128 {
+		t.Errorf("shadow pixel alpha = %d, want 128", got.A)
+	}
+	if got := shadow.NRGBAAt(4, 3); got.A != 0 {
+		t.Errorf("pixel outside shadow alpha = %d, want 0", got.A)
+	}
+
+	composite := CompositeUnder(img, shadow)
+	if got := composite.NRGBAAt(4, 4); got != (color.NRGBA{R: 255, G: 255, B: 255, A: 255}) {
+		t.Errorf("sprite pixel covered by shadow: %+v", got)
+	}
+	if got := composite.NRGBAAt(8, 7); got.A != 128 || got.R != 0 {
+		t.Errorf("shadow pixel after composite = %+v, want black at alpha 128", got)
+	}
+}
+
+func TestDropShadow_Dithered(t *testing.T) {
+	img := newSolidSquare(8, 0, color.RGBA{R: 255, A: 255})
+
+	shadow, err := DropShadow(img, 0, 0, color.RGBA{B: 255, A: 255}, 0.5, true)
+	if err != nil {
+		t.Fatalf("DropShadow() error = %v", err)
+	}
+
+	opaque := 0
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			switch shadow.NRGBAAt(x, y).A {
+			case 255:
+				opaque++
+			case 0:
+			default:
+				t.Fatalf("dithered shadow pixel (%d,%d) has partial alpha", x, y)
+			}
+		}
+	}
+	if opaque != 32 {
+		t.Errorf("dithered shadow at 50%% has %d opaque pixels, want 32", opaque)
+	}
+
+	if _, err := DropShadow(img, 1, 1, color.RGBA{}, 1.5, false); err == nil {
+		t.Error("DropShadow() expected error for opacity > 1")
+	}
+}
+
+func TestRimLight(t *testing.T) {
+	img := newSolidSquare(10, 2, color.RGBA{R: 100, G: 100, B: 100, A: 255})
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+
+	rim, err := RimLight(img, "left", 2, white, 1.0)
+	if err != nil {
+		t.Fatalf("RimLight() error = %v", err)
+	}
+
+	if got := rim.NRGBAAt(2, 5).A; got != 255 {
+		t.Errorf("outer rim alpha = %d, want 255", got)
+	}
+	if got := rim.NRGBAAt(3, 5).A; got != 128 {
+		t.Errorf("inner rim alpha = %d, want 128", got)
+	}
+	if got := rim.NRGBAAt(7, 5).A; got != 0 {
+		t.Errorf("edge facing away from light alpha = %d, want 0", got)
+	}
+	if got := rim.NRGBAAt(0, 5).A; got != 0 {
+		t.Errorf("transparent pixel alpha = %d, want 0", got)
+	}
+
+	if _, err := RimLight(img, "up", 1, white, 1.0); err == nil {
+		t.Error("RimLight() expected error for invalid direction")
+	}
+	if _, err := RimLight(img, "left", 0, white, 1.0); err == nil {
+		t.Error("RimLight() expected error for zero width")
+	}
+}
+
+func TestInnerGlow(t *testing.T) {
+	img := newSolidSquare(12, 1, color.RGBA{R: 50, G: 50, B: 50, A: 255})
+	yellow := color.RGBA{R: 255, G: 255, A: 255}
+
+	glow, err := InnerGlow(img, 2, yellow, 1.0, false)
+	if err != nil {
+		t.Fatalf("InnerGlow() error = %v", err)
+	}
+
+	if got := glow.NRGBAAt(1, 6).A; got != 255 {
+		t.Errorf("edge glow alpha = %d, want 255", got)
+	}
+	if got := glow.NRGBAAt(2, 6).A; got != 128 {
+		t.Errorf("second ring glow alpha = %d, want 128", got)
+	}
+	if got := glow.NRGBAAt(6, 6).A; got != 0 {
+		t.Errorf("interior glow alpha = %d, want 0", got)
+	}
+
+	blended, err := BlendEffect(img, glow)
+	if err != nil {
+		t.Fatalf("BlendEffect() error = %v", err)
+	}
+	if got := blended.NRGBAAt(1, 6); got.R != 255 || got.B != 0 || got.A != 255 {
+		t.Errorf("blended edge pixel = %+v, want yellow", got)
+	}
+	if got := blended.NRGBAAt(0, 0).A; got != 0 {
+		t.Errorf("blending changed transparent pixel alpha to %d", got)
+	}
+}
+
+func TestInnerGlow_PerRegion(t *testing.T) {
+	img := newSolidSquare(12, 0, color.RGBA{R: 200, A: 255})
+	// Right half is a second color region
+	for y := 0; y < 12; y++ {
+		for x := 6; x < 12; x++ {
+			img.SetRGBA(x, y, color.RGBA{B: 200, A: 255})
+		}
+	}
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+
+	whole, err := InnerGlow(img, 1, white, 1.0, false)
+	if err != nil {
+		t.Fatalf("InnerGlow() error = %v", err)
+	}
+	regions, err := InnerGlow(img, 1, white, 1.0, true)
+	if err != nil {
+		t.Fatalf("InnerGlow() error = %v", err)
+	}
+
+	if got := whole.NRGBAAt(5, 6).A; got != 0 {
+		t.Errorf("region boundary glows without per_region: alpha = %d", got)
+	}
+	if got := regions.NRGBAAt(5, 6).A; got != 255 {
+		t.Errorf("region boundary alpha with per_region = %d, want 255", got)
+	}
+}
+
+func TestInnerBevel(t *testing.T) {
+	img := newSolidSquare(16, 2, color.RGBA{R: 128, G: 128, B: 128, A: 255})
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	black := color.RGBA{A: 255}
+
+	bevel, err := InnerBevel(img, 2, "left", white, black, 1.0, false)
+	if err != nil {
+		t.Fatalf("InnerBevel() error = %v", err)
+	}
+
+	if got := bevel.NRGBAAt(2, 8); got.R != 255 || got.A == 0 {
+		t.Errorf("left rim facing the light = %+v, want highlight", got)
+	}
+	if got := bevel.NRGBAAt(13, 8); got.R != 0 || got.A == 0 {
+		t.Errorf("right rim facing away = %+v, want shadow", got)
+	}
+	if got := bevel.NRGBAAt(8, 8).A; got != 0 {
+		t.Errorf("flat interior alpha = %d, want 0", got)
+	}
+}
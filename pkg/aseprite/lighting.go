@@ -0,0 +1,134 @@
+package aseprite
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+)
+
+// Light describes a light source used by RenderLit.
+//
+// Point lights sit at Position in sprite pixel coordinates, where Position.Z is
+// the height above the sprite plane. Directional lights ignore Position and shine
+// from Direction, one of the names accepted by apply_auto_shading (top_left, top,
+// top_right, left, right, bottom_left, bottom, bottom_right).
+type Light struct {
+	Type      string     // "point" or "directional"
+	Position  Vector3D   // Point light position (X, Y in pixels, Z = height)
+	Direction string     // Directional light direction name
+	Color     color.RGBA // Light color
+	Intensity float64    // Brightness multiplier (0.0-10.0)
+	Falloff   float64    // Point light radius in pixels where light reaches zero (0 = no attenuation)
+}
+
+// RenderLit shades a color image with a normal map under a set of lights.
+//
+// Each output pixel is the albedo multiplied by the ambient term plus the sum of
+// Lambertian (N·L) contributions of every light. Point lights are attenuated with
+// a smooth quadratic falloff when Falloff is set. Pixels where the normal map is
+// transparent are treated as facing the viewer.
+//
+// Both images must have the same bounds. Alpha is taken from the color image.
+//
+// Parameters:
+//   - albedo: unlit color image
+//   - normals: normal map encoded as by GenerateNormalMap
+//   - lights: light sources (at least one)
+//   - ambient: ambient light color
+//   - ambientIntensity: ambient multiplier (0.0-1.0)
+//   - invertY: decode normals with the DirectX (+Y down) convention
+//
+// Returns the lit image, or an error if the inputs are inconsistent.
+func RenderLit(albedo, normals image.Image, lights []Light, ambient color.RGBA, ambientIntensity float64, invertY bool) (*image.NRGBA, error) {
+	if albedo.Bounds() != normals.Bounds() {
+		return nil, fmt.Errorf("color and normal images must have the same bounds, got %v and %v", albedo.Bounds(), normals.Bounds())
+	}
+	if len(lights) == 0 {
+		return nil, fmt.Errorf("at least one light is required")
+	}
+	if ambientIntensity < 0.0 || ambientIntensity > 1.0 {
+		return nil, fmt.Errorf("ambient intensity must be between 0.0 and 1.0, got %f", ambientIntensity)
+	}
+
+	// Resolve directional light vectors once
+	directions := make([]Vector3D, len(lights))
+	for i, light := range lights {
+		switch light.Type {
+		case "point":
+		case "directional":
+			vec, err := lightDirectionToVector(light.Direction)
+			if err != nil {
+				return nil, err
+			}
+			directions[i] = vec
+		default:
+			return nil, fmt.Errorf("invalid light type: %s (must be point or directional)", light.Type)
+		}
+		if light.Intensity < 0.0 || light.Intensity > 10.0 {
+			return nil, fmt.Errorf("light intensity must be between 0.0 and 10.0, got %f", light.Intensity)
+		}
+		if light.Falloff < 0 {
+			return nil, fmt.Errorf("light falloff must be non-negative, got %f", light.Falloff)
+		}
+	}
+
+	ambR := float64(ambient.R) / 255.0 * ambientIntensity
+	ambG := float64(ambient.G) / 255.0 * ambientIntensity
+	ambB := float64(ambient.B) / 255.0 * ambientIntensity
+
+	bounds := albedo.Bounds()
+	result := image.NewNRGBA(bounds)
+	flat := Vector3D{X: 0, Y: 0, Z: 1}
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(albedo.At(x, y)).(color.NRGBA)
+			if c.A == 0 {
+				continue
+			}
+
+			n := flat
+			if _, _, _, na := normals.At(x, y).RGBA(); na > 0 {
+				n = DecodeNormal(normals.At(x, y), invertY)
+			}
+
+			lightR, lightG, lightB := ambR, ambG, ambB
+			for i, light := range lights {
+				var l Vector3D
+				attenuation := 1.0
+
+				if light.Type == "point" {
+					// Light vector from the pixel center to the light
+					toLight := Vector3D{
+						X: light.Position.X - (float64(x) + 0.5),
+						Y: light.Position.Y - (float64(y) + 0.5),
+						Z: light.Position.Z,
+					}
+					if light.Falloff > 0 {
+						dist := toLight.Length()
+						falloff := math.Max(0, 1-dist/light.Falloff)
+						attenuation = falloff * falloff
+					}
+					l = toLight.Normalize()
+				} else {
+					l = directions[i]
+				}
+
+				diffuse := math.Max(0, n.Dot(l)) * light.Intensity * attenuation
+				lightR += float64(light.Color.R) / 255.0 * diffuse
+				lightG += float64(light.Color.G) / 255.0 * diffuse
+				lightB += float64(light.Color.B) / 255.0 * diffuse
+			}
+
+			result.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(math.Min(255, float64(c.R)*lightR)),
+				G: uint8(math.Min(255, float64(c.G)*lightG)),
+				B: uint8(math.Min(255, float64(c.B)*lightB)),
+				A: c.A,
+			})
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,107 @@
+package aseprite
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// newFlatNormals creates a normal map where every pixel faces the viewer.
+func newFlatNormals(w, h int) *image.NRGBA {
+	nm := image.NewNRGBA(image.Rect(0, 0, w, h))
+	flat := EncodeNormal(Vector3D{X: 0, Y: 0, Z: 1}, false, 255)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			nm.SetNRGBA(x, y, flat)
+		}
+	}
+	return nm
+}
+
+func TestRenderLit_AmbientOnly(t *testing.T) {
+	albedo := newSolidSquare(8, 0, color.RGBA{R: 200, G: 100, B: 40, A: 255})
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+
+	// A zero-intensity light contributes nothing, leaving only the ambient term
+	lights := []Light{{Type: "directional", Direction: "top", Color: white, Intensity: 0}}
+	lit, err := RenderLit(albedo, newFlatNormals(8, 8), lights, white, 0.5, false)
+	if err != nil {
+		t.Fatalf("RenderLit() error = %v", err)
+	}
+
+	got := lit.NRGBAAt(4, 4)
+	if got.R != 100 || got.G != 50 || got.B != 20 || got.A != 255 {
+		t.Errorf("ambient lit pixel = %+v, want (100,50,20,255)", got)
+	}
+}
+
+func TestRenderLit_PointLightFacing(t *testing.T) {
+	albedo := newSolidSquare(16, 0, color.RGBA{R: 128, G: 128, B: 128, A: 255})
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+
+	lights := []Light{{Type: "point", Position: Vector3D{X: 2, Y: 8, Z: 4}, Color: white, Intensity: 1, Falloff: 12}}
+	lit, err := RenderLit(albedo, newFlatNormals(16, 16), lights, white, 0, false)
+	if err != nil {
+		t.Fatalf("RenderLit() error = %v", err)
+	}
+
+	near := lit.NRGBAAt(2, 8).R
+	far := lit.NRGBAAt(14, 8).R
+	if near <= far {
+		t.Errorf("pixel near light (%d) should be brighter than far pixel (%d)", near, far)
+	}
+	if far != 0 {
+		t.Errorf("pixel beyond falloff = %d, want 0", far)
+	}
+}
+
+func TestRenderLit_NormalsFacingLight(t *testing.T) {
+	albedo := newSolidSquare(2, 0, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+
+	// Left pixel faces left, right pixel faces right
+	normals := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	for y := 0; y < 2; y++ {
+		normals.SetNRGBA(0, y, EncodeNormal(Vector3D{X: -0.8, Y: 0, Z: 0.6}, false, 255))
+		normals.SetNRGBA(1, y, EncodeNormal(Vector3D{X: 0.8, Y: 0, Z: 0.6}, false, 255))
+	}
+
+	lights := []Light{{Type: "directional", Direction: "left", Color: white, Intensity: 1}}
+	lit, err := RenderLit(albedo, normals, lights, white, 0, false)
+	if err != nil {
+		t.Fatalf("RenderLit() error = %v", err)
+	}
+
+	if left, right := lit.NRGBAAt(0, 0).R, lit.NRGBAAt(1, 0).R; left <= right {
+		t.Errorf("surface facing the light (%d) should be brighter than surface facing away (%d)", left, right)
+	}
+}
+
+func TestRenderLit_InvalidParams(t *testing.T) {
+	img := newSolidSquare(4, 0, color.RGBA{R: 255, A: 255})
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	valid := Light{Type: "point", Color: white, Intensity: 1}
+
+	tests := []struct {
+		name    string
+		normals image.Image
+		lights  []Light
+		ambient float64
+	}{
+		{name: "mismatched bounds", normals: newFlatNormals(5, 5), lights: []Light{valid}},
+		{name: "no lights", normals: newFlatNormals(4, 4)},
+		{name: "ambient too high", normals: newFlatNormals(4, 4), lights: []Light{valid}, ambient: 1.5},
+		{name: "unknown type", normals: newFlatNormals(4, 4), lights: []Light{{Type: "spot", Intensity: 1}}},
+		{name: "bad direction", normals: newFlatNormals(4, 4), lights: []Light{{Type: "directional", Direction: "up", Intensity: 1}}},
+		{name: "intensity too high", normals: newFlatNormals(4, 4), lights: []Light{{Type: "point", Intensity: 11}}},
+		{name: "negative falloff", normals: newFlatNormals(4, 4), lights: []Light{{Type: "point", Intensity: 1, Falloff: -1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RenderLit(img, tt.normals, tt.lights, white, tt.ambient, false); err == nil {
+				t.Error("RenderLit() expected error, got nil")
+			}
+		})
+	}
+}
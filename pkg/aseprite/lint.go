@@ -0,0 +1,776 @@
+package aseprite
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// Lint check names accepted by LintOptions.Checks.
+const (
+	LintOrphanPixels   = "orphan_pixels"
+	LintJaggies        = "jaggies"
+	LintDoubles        = "doubles"
+	LintBanding        = "banding"
+	LintPillowShading  = "pillow_shading"
+	LintOffPalette     = "off_palette"
+	LintNearDuplicates = "near_duplicate_colors"
+	LintLowContrast    = "low_contrast"
+)
+
+// Lint issue severities.
+const (
+	LintSeverityError   = "error"
+	LintSeverityWarning = "warning"
+	LintSeverityInfo    = "info"
+)
+
+// AllLintChecks lists every check run by LintPixelArt, in report order.
+var AllLintChecks = []string{
+	LintOrphanPixels,
+	LintJaggies,
+	LintDoubles,
+	LintBanding,
+	LintPillowShading,
+	LintOffPalette,
+	LintNearDuplicates,
+	LintLowContrast,
+}
+
+// LintIssue is a single problem found by LintPixelArt.
+type LintIssue struct {
+	Check    string   `json:"check"`            // Check that produced the issue
+	Severity string   `json:"severity"`         // "error", "warning", or "info"
+	X        int      `json:"x"`                // Pixel the issue is anchored at
+	Y        int      `json:"y"`                // Pixel the issue is anchored at
+	Message  string   `json:"message"`          // Human-readable description
+	Colors   []string `json:"colors,omitempty"` // Colors involved (#RRGGBB)
+}
+
+// LintOptions configures LintPixelArt.
+type LintOptions struct {
+	Checks           []string       // Checks to run (empty = AllLintChecks)
+	Palette          []PaletteColor // Sprite palette for the off_palette check (empty = skip)
+	NearDuplicateDE  float64        // CIE76 ΔE below which two used colors are near-duplicates (default 3.0)
+	MinContrast      int            // Minimum luminance difference (0-255) between adjacent regions (default 12)
+	MinBandingLength int            // Minimum pixels in a 1px band before it is reported (default 4)
+}
+
+// LintPixelArt analyzes an image for common pixel art problems.
+//
+// Checks:
+//   - orphan_pixels: opaque pixels with no same-colored neighbor (8-connected)
+//   - jaggies: uneven segment lengths along thin lines (e.g. 3-1-3 steps)
+//   - doubles: redundant L-shaped corner pixels that thicken 1px lines
+//   - banding: 1px strips of a ramp color sandwiched between two other colors
+//   - pillow_shading: shading that darkens evenly towards every edge instead of
+//     following a light direction
+//   - off_palette: colors that are not in the given palette
+//   - near_duplicate_colors: used colors that are almost indistinguishable
+//   - low_contrast: adjacent color regions whose boundary is not picked up by
+//     Sobel edge detection (DetectEdges) at the minimum contrast
+//
+// Issues are sorted by severity (errors first), then by position.
+func LintPixelArt(img image.Image, opts LintOptions) ([]LintIssue, error) {
+	if opts.NearDuplicateDE == 0 {
+		opts.NearDuplicateDE = 3.0
+	}
+	if opts.MinContrast == 0 {
+		opts.MinContrast = 12
+	}
+	if opts.MinBandingLength == 0 {
+		opts.MinBandingLength = 4
+	}
+	if opts.NearDuplicateDE < 0 || opts.NearDuplicateDE > 100 {
+		return nil, fmt.Errorf("near-duplicate ΔE must be between 0 and 100, got %f", opts.NearDuplicateDE)
+	}
+	if opts.MinContrast < 0 || opts.MinContrast > 255 {
+		return nil, fmt.Errorf("minimum contrast must be between 0 and 255, got %d", opts.MinContrast)
+	}
+
+	checks := opts.Checks
+	if len(checks) == 0 {
+		checks = AllLintChecks
+	}
+
+	g := newLintGrid(img)
+	var issues []LintIssue
+
+	for _, check := range checks {
+		switch check {
+		case LintOrphanPixels:
+			issues = append(issues, g.orphanPixels()...)
+		case LintJaggies:
+			issues = append(issues, g.segmentJaggies()...)
+		case LintDoubles:
+			issues = append(issues, g.doubles()...)
+		case LintBanding:
+			issues = append(issues, g.banding(opts.MinBandingLength)...)
+		case LintPillowShading:
+			issues = append(issues, g.pillowShading()...)
+		case LintOffPalette:
+			issues = append(issues, g.offPalette(opts.Palette)...)
+		case LintNearDuplicates:
+			issues = append(issues, g.nearDuplicates(opts.NearDuplicateDE)...)
+		case LintLowContrast:
+			found, err := g.lowContrast(opts.MinContrast)
+			if err != nil {
+				return nil, err
+			}
+			issues = append(issues, found...)
+		default:
+			return nil, fmt.Errorf("unknown lint check: %s", check)
+		}
+	}
+
+	SortLintIssues(issues)
+	return issues, nil
+}
+
+// SortLintIssues orders issues by severity (errors first), then by row and column.
+func SortLintIssues(issues []LintIssue) {
+	rank := map[string]int{LintSeverityError: 0, LintSeverityWarning: 1, LintSeverityInfo: 2}
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if rank[a.Severity] != rank[b.Severity] {
+			return rank[a.Severity] < rank[b.Severity]
+		}
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		return a.X < b.X
+	})
+}
+
+// lintGrid holds an image as non-premultiplied pixels with 0-based coordinates.
+type lintGrid struct {
+	width, height int
+	origin        image.Point
+	px            [][]color.NRGBA
+}
+
+// newLintGrid copies an image into a lintGrid, clearing fully transparent pixels.
+func newLintGrid(img image.Image) *lintGrid {
+	bounds := img.Bounds()
+	g := &lintGrid{width: bounds.Dx(), height: bounds.Dy(), origin: bounds.Min}
+	g.px = make([][]color.NRGBA, g.height)
+	for y := range g.px {
+		g.px[y] = make([]color.NRGBA, g.width)
+		for x := range g.px[y] {
+			c := color.NRGBAModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.NRGBA)
+			if c.A == 0 {
+				c = color.NRGBA{}
+			}
+			g.px[y][x] = c
+		}
+	}
+	return g
+}
+
+// at returns the pixel at (x, y), or transparent outside the grid.
+func (g *lintGrid) at(x, y int) color.NRGBA {
+	if x < 0 || y < 0 || x >= g.width || y >= g.height {
+		return color.NRGBA{}
+	}
+	return g.px[y][x]
+}
+
+// opaque reports whether (x, y) is inside the grid and not fully transparent.
+func (g *lintGrid) opaque(x, y int) bool {
+	return g.at(x, y).A > 0
+}
+
+// same reports whether (x, y) is opaque and has exactly color c.
+func (g *lintGrid) same(x, y int, c color.NRGBA) bool {
+	p := g.at(x, y)
+	return p.A > 0 && p == c
+}
+
+// issue builds a LintIssue anchored at grid coordinates (x, y).
+func (g *lintGrid) issue(check, severity string, x, y int, message string, colors ...color.NRGBA) LintIssue {
+	hexes := make([]string, len(colors))
+	for i, c := range colors {
+		hexes[i] = fmt.Sprintf("#%02X%02X%02X", c.R, c.G, c.B)
+	}
+	if len(hexes) == 0 {
+		hexes = nil
+	}
+	return LintIssue{
+		Check:    check,
+		Severity: severity,
+		X:        g.origin.X + x,
+		Y:        g.origin.Y + y,
+		Message:  message,
+		Colors:   hexes,
+	}
+}
+
+// inBlock reports whether (x, y) is part of a 2x2 block of its own color,
+// i.e. whether it belongs to a filled area rather than a thin line.
+func (g *lintGrid) inBlock(x, y int) bool {
+	c := g.at(x, y)
+	for _, d := range [][2]int{{-1, -1}, {0, -1}, {-1, 0}, {0, 0}} {
+		bx, by := x+d[0], y+d[1]
+		if g.same(bx, by, c) && g.same(bx+1, by, c) && g.same(bx, by+1, c) && g.same(bx+1, by+1, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// orphanPixels flags opaque pixels without any same-colored 8-neighbor.
+//
+// Checkerboard dithering is not flagged because its pixels have diagonal
+// neighbors of the same color.
+func (g *lintGrid) orphanPixels() []LintIssue {
+	var issues []LintIssue
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			c := g.px[y][x]
+			if c.A == 0 {
+				continue
+			}
+
+			hasSame, hasOpaque := false, false
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					if dx == 0 && dy == 0 {
+						continue
+					}
+					if g.opaque(x+dx, y+dy) {
+						hasOpaque = true
+					}
+					if g.same(x+dx, y+dy, c) {
+						hasSame = true
+					}
+				}
+			}
+
+			switch {
+			case !hasOpaque:
+				issues = append(issues, g.issue(LintOrphanPixels, LintSeverityWarning, x, y, "stray pixel with no opaque neighbors", c))
+			case !hasSame:
+				issues = append(issues, g.issue(LintOrphanPixels, LintSeverityInfo, x, y, "isolated pixel with no same-colored neighbor (noise unless intentional)", c))
+			}
+		}
+	}
+	return issues
+}
+
+// lintRun is a horizontal (or, transposed, vertical) run of thin same-colored pixels.
+type lintRun struct {
+	line, start, end int // line = row (or column); start/end inclusive
+	c                color.NRGBA
+}
+
+// runs collects runs of thin pixels along rows (vertical=false) or columns.
+func (g *lintGrid) runs(vertical bool) [][]lintRun {
+	lines, length := g.height, g.width
+	if vertical {
+		lines, length = g.width, g.height
+	}
+	pixel := func(line, pos int) (int, int) {
+		if vertical {
+			return line, pos
+		}
+		return pos, line
+	}
+
+	result := make([][]lintRun, lines)
+	for line := 0; line < lines; line++ {
+		pos := 0
+		for pos < length {
+			x, y := pixel(line, pos)
+			c := g.at(x, y)
+			if c.A == 0 || g.inBlock(x, y) {
+				pos++
+				continue
+			}
+			end := pos
+			for end+1 < length {
+				nx, ny := pixel(line, end+1)
+				if !g.same(nx, ny, c) || g.inBlock(nx, ny) {
+					break
+				}
+				end++
+			}
+			result[line] = append(result[line], lintRun{line: line, start: pos, end: end, c: c})
+			pos = end + 1
+		}
+	}
+	return result
+}
+
+// segmentJaggies flags uneven segment lengths along thin diagonal lines.
+//
+// Runs on consecutive rows (or columns) that touch diagonally are chained into
+// lines. A run that is a local extreme in length (e.g. the 1 in 3-1-3) is a
+// jaggy unless the line simply alternates between two lengths.
+func (g *lintGrid) segmentJaggies() []LintIssue {
+	var issues []LintIssue
+	seen := make(map[image.Point]bool)
+
+	for _, vertical := range []bool{false, true} {
+		runs := g.runs(vertical)
+		used := make([][]bool, len(runs))
+		for i := range runs {
+			used[i] = make([]bool, len(runs[i]))
+		}
+
+		for line := range runs {
+			for i := range runs[line] {
+				if used[line][i] {
+					continue
+				}
+
+				// Follow the chain down the lines in a consistent step direction
+				chain := []lintRun{runs[line][i]}
+				used[line][i] = true
+				step := 0
+				for next := line + 1; next < len(runs); next++ {
+					prev := chain[len(chain)-1]
+					found := false
+					for j, r := range runs[next] {
+						if used[next][j] || r.c != prev.c {
+							continue
+						}
+						dir := 0
+						if r.start == prev.end+1 {
+							dir = 1
+						} else if r.end == prev.start-1 {
+							dir = -1
+						}
+						if dir == 0 || (step != 0 && dir != step) {
+							continue
+						}
+						step = dir
+						chain = append(chain, r)
+						used[next][j] = true
+						found = true
+						break
+					}
+					if !found {
+						break
+					}
+				}
+
+				for k := 1; k+1 < len(chain); k++ {
+					prevLen := chain[k-1].end - chain[k-1].start + 1
+					curLen := chain[k].end - chain[k].start + 1
+					nextLen := chain[k+1].end - chain[k+1].start + 1
+
+					extreme := (curLen < prevLen && curLen < nextLen) || (curLen > prevLen && curLen > nextLen)
+					if !extreme {
+						continue
+					}
+					alternating := prevLen == nextLen && absInt(curLen-prevLen) == 1
+					if alternating {
+						continue
+					}
+
+					x, y := chain[k].start, chain[k].line
+					if vertical {
+						x, y = chain[k].line, chain[k].start
+					}
+					if seen[image.Pt(x, y)] {
+						continue
+					}
+					seen[image.Pt(x, y)] = true
+					issues = append(issues, g.issue(LintJaggies, LintSeverityWarning, x, y,
+						fmt.Sprintf("uneven line segments (%d-%d-%d); keep segment lengths consistent", prevLen, curLen, nextLen), chain[k].c))
+				}
+			}
+		}
+	}
+
+	return issues
+}
+
+// doubles flags L-shaped corner pixels that make 1px lines look thick.
+//
+// A pixel with same-colored neighbors on two perpendicular sides, whose
+// opposite diagonal is a different color, can be removed without breaking the
+// line. Square corners where both arms continue straight are not flagged.
+func (g *lintGrid) doubles() []LintIssue {
+	var issues []LintIssue
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			c := g.px[y][x]
+			if c.A == 0 || g.inBlock(x, y) {
+				continue
+			}
+
+			for _, d := range [][2]int{{-1, -1}, {1, -1}, {-1, 1}, {1, 1}} {
+				dx, dy := d[0], d[1]
+				if !g.same(x+dx, y, c) || !g.same(x, y+dy, c) || g.same(x+dx, y+dy, c) {
+					continue
+				}
+				// Both arms continuing straight means a square corner, not a double
+				if g.same(x+2*dx, y, c) && g.same(x, y+2*dy, c) {
+					continue
+				}
+				issues = append(issues, g.issue(LintDoubles, LintSeverityWarning, x, y, "double pixel in 1px line corner; removing it keeps the line connected and thinner", c))
+				break
+			}
+		}
+	}
+	return issues
+}
+
+// banding flags 1px strips of a color sandwiched between two other colors that
+// form a light-to-dark ramp, which makes shading read as parallel bands.
+func (g *lintGrid) banding(minLength int) []LintIssue {
+	strip := make([][]bool, g.height)
+	for y := range strip {
+		strip[y] = make([]bool, g.width)
+		for x := range strip[y] {
+			c := g.px[y][x]
+			if c.A == 0 {
+				continue
+			}
+			for _, d := range [][2]int{{1, 0}, {0, 1}} {
+				a := g.at(x-d[0], y-d[1])
+				b := g.at(x+d[0], y+d[1])
+				if a.A == 0 || b.A == 0 || a == c || b == c || a == b {
+					continue
+				}
+				la, lc, lb := lintLuminance(a), lintLuminance(c), lintLuminance(b)
+				if (la < lc && lc < lb) || (la > lc && lc > lb) {
+					strip[y][x] = true
+					break
+				}
+			}
+		}
+	}
+
+	var issues []LintIssue
+	visited := make([][]bool, g.height)
+	for y := range visited {
+		visited[y] = make([]bool, g.width)
+	}
+
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			if !strip[y][x] || visited[y][x] {
+				continue
+			}
+
+			// Group 8-connected strip pixels of the same color
+			c := g.px[y][x]
+			count := 0
+			queue := []image.Point{{X: x, Y: y}}
+			visited[y][x] = true
+			for len(queue) > 0 {
+				p := queue[0]
+				queue = queue[1:]
+				count++
+				for dy := -1; dy <= 1; dy++ {
+					for dx := -1; dx <= 1; dx++ {
+						nx, ny := p.X+dx, p.Y+dy
+						if nx < 0 || ny < 0 || nx >= g.width || ny >= g.height {
+							continue
+						}
+						if strip[ny][nx] && !visited[ny][nx] && g.px[ny][nx] == c {
+							visited[ny][nx] = true
+							queue = append(queue, image.Point{X: nx, Y: ny})
+						}
+					}
+				}
+			}
+
+			if count >= minLength {
+				issues = append(issues, g.issue(LintBanding, LintSeverityWarning, x, y,
+					fmt.Sprintf("%d-pixel 1px band between darker and lighter colors; vary band widths or break up the strip", count), c))
+			}
+		}
+	}
+	return issues
+}
+
+// pillowShading flags silhouettes that darken evenly towards every edge.
+//
+// The first pixel of every edge is skipped so a dark outline is not mistaken
+// for shading. The near-edge ring (depth 2-3) is compared with the interior
+// (depth 5+): pillow shading has a much brighter interior while the ring is
+// equally dark on opposite sides, i.e. the shading ignores the light direction.
+func (g *lintGrid) pillowShading() []LintIssue {
+	depth := g.edgeDepth()
+
+	var interiorSum float64
+	var interiorCount int
+	sideSum := map[string]float64{}
+	sideCount := map[string]int{}
+	minX, minY, maxX, maxY := g.width, g.height, -1, -1
+
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			d := depth[y][x]
+			if d == 0 {
+				continue
+			}
+			minX, minY = min(minX, x), min(minY, y)
+			maxX, maxY = max(maxX, x), max(maxY, y)
+
+			l := lintLuminance(g.px[y][x])
+			switch {
+			case d >= 5:
+				interiorSum += l
+				interiorCount++
+			case d == 2 || d == 3:
+				// Attribute the pixel to the side its nearest edge lies on
+				for _, side := range []struct {
+					name   string
+					dx, dy int
+				}{{"top", 0, -1}, {"bottom", 0, 1}, {"left", -1, 0}, {"right", 1, 0}} {
+					if !g.opaque(x+side.dx*d, y+side.dy*d) {
+						sideSum[side.name] += l
+						sideCount[side.name]++
+						break
+					}
+				}
+			}
+		}
+	}
+
+	if interiorCount < 9 {
+		return nil
+	}
+	for _, side := range []string{"top", "bottom", "left", "right"} {
+		if sideCount[side] < 3 {
+			return nil
+		}
+	}
+
+	mean := func(side string) float64 { return sideSum[side] / float64(sideCount[side]) }
+	ring := (mean("top") + mean("bottom") + mean("left") + mean("right")) / 4
+	interior := interiorSum / float64(interiorCount)
+
+	if interior-ring < 0.12 {
+		return nil
+	}
+	if math.Abs(mean("top")-mean("bottom")) > 0.05 || math.Abs(mean("left")-mean("right")) > 0.05 {
+		return nil
+	}
+
+	return []LintIssue{g.issue(LintPillowShading, LintSeverityWarning, (minX+maxX)/2, (minY+maxY)/2,
+		"pillow shading: edges are equally dark on all sides; shade according to a light direction instead")}
+}
+
+// edgeDepth returns each opaque pixel's 4-connected distance to the nearest
+// transparent pixel (or the image border), starting at 1 on the edge.
+func (g *lintGrid) edgeDepth() [][]int {
+	depth := make([][]int, g.height)
+	var queue []image.Point
+	for y := range depth {
+		depth[y] = make([]int, g.width)
+		for x := range depth[y] {
+			if !g.opaque(x, y) {
+				continue
+			}
+			if !g.opaque(x-1, y) || !g.opaque(x+1, y) || !g.opaque(x, y-1) || !g.opaque(x, y+1) {
+				depth[y][x] = 1
+				queue = append(queue, image.Point{X: x, Y: y})
+			}
+		}
+	}
+
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, d := range []image.Point{{X: 1}, {X: -1}, {Y: 1}, {Y: -1}} {
+			n := p.Add(d)
+			if g.opaque(n.X, n.Y) && depth[n.Y][n.X] == 0 {
+				depth[n.Y][n.X] = depth[p.Y][p.X] + 1
+				queue = append(queue, n)
+			}
+		}
+	}
+
+	return depth
+}
+
+// usedColors returns each distinct opaque color with its first position and count.
+func (g *lintGrid) usedColors() ([]color.NRGBA, map[color.NRGBA]image.Point, map[color.NRGBA]int) {
+	var order []color.NRGBA
+	first := make(map[color.NRGBA]image.Point)
+	counts := make(map[color.NRGBA]int)
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			c := g.px[y][x]
+			if c.A == 0 {
+				continue
+			}
+			c.A = 255
+			if _, ok := first[c]; !ok {
+				first[c] = image.Point{X: x, Y: y}
+				order = append(order, c)
+			}
+			counts[c]++
+		}
+	}
+	return order, first, counts
+}
+
+// offPalette flags colors that do not appear in the palette.
+func (g *lintGrid) offPalette(palette []PaletteColor) []LintIssue {
+	if len(palette) == 0 {
+		return nil
+	}
+
+	inPalette := make(map[color.NRGBA]bool)
+	for _, pc := range palette {
+		if c, err := colorful.Hex(pc.Color); err == nil {
+			r, gr, b := c.RGB255()
+			inPalette[color.NRGBA{R: r, G: gr, B: b, A: 255}] = true
+		}
+	}
+
+	var issues []LintIssue
+	order, first, counts := g.usedColors()
+	for _, c := range order {
+		if inPalette[c] {
+			continue
+		}
+		target := colorful.Color{R: float64(c.R) / 255.0, G: float64(c.G) / 255.0, B: float64(c.B) / 255.0}
+		closest, _, _ := FindClosestPaletteColor(target, palette)
+		p := first[c]
+		issues = append(issues, g.issue(LintOffPalette, LintSeverityError, p.X, p.Y,
+			fmt.Sprintf("color not in palette (%d pixels); closest palette color is %s", counts[c], closest), c))
+	}
+	return issues
+}
+
+// nearDuplicates flags pairs of used colors closer than the ΔE threshold.
+func (g *lintGrid) nearDuplicates(threshold float64) []LintIssue {
+	order, first, _ := g.usedColors()
+
+	var issues []LintIssue
+	for i := 0; i < len(order); i++ {
+		ci := colorful.Color{R: float64(order[i].R) / 255.0, G: float64(order[i].G) / 255.0, B: float64(order[i].B) / 255.0}
+		for j := i + 1; j < len(order); j++ {
+			cj := colorful.Color{R: float64(order[j].R) / 255.0, G: float64(order[j].G) / 255.0, B: float64(order[j].B) / 255.0}
+			// colorful's Lab distance is on a 0-1 lightness scale; ΔE uses 0-100
+			de := colorDistanceLab(ci, cj) * 100
+			if de >= threshold {
+				continue
+			}
+			p := first[order[j]]
+			issues = append(issues, g.issue(LintNearDuplicates, LintSeverityInfo, p.X, p.Y,
+				fmt.Sprintf("near-duplicate colors (ΔE %.1f); merge them to simplify the palette", de), order[i], order[j]))
+		}
+	}
+	return issues
+}
+
+// lowContrast flags adjacent color regions with too little value contrast.
+//
+// Regions come from detectRegions (as in ApplyAutoShading). Boundaries are
+// checked against DetectEdges run with a threshold matching minContrast for a
+// straight edge, so a boundary counts as visible when Sobel picks it up.
+func (g *lintGrid) lowContrast(minContrast int) ([]LintIssue, error) {
+	canvas := image.NewNRGBA(image.Rect(0, 0, g.width, g.height))
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			canvas.SetNRGBA(x, y, g.px[y][x])
+		}
+	}
+
+	// A straight edge between luminances a and b has a Sobel magnitude of 4|a-b|
+	threshold := min(255, 4*minContrast)
+	edges, err := DetectEdges(canvas, threshold, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	regions := detectRegions(canvas)
+	label := make([][]int, g.height)
+	for y := range label {
+		label[y] = make([]int, g.width)
+		for x := range label[y] {
+			label[y][x] = -1
+		}
+	}
+	for i, r := range regions {
+		for _, p := range r.Pixels {
+			label[p.Y][p.X] = i
+		}
+	}
+
+	type pair struct{ a, b int }
+	type boundary struct {
+		total, visible int
+		at             image.Point
+	}
+	boundaries := make(map[pair]*boundary)
+
+	for y := 1; y < g.height-1; y++ {
+		for x := 1; x < g.width-1; x++ {
+			a := label[y][x]
+			if a < 0 {
+				continue
+			}
+			for _, d := range []image.Point{{X: 1}, {Y: 1}} {
+				nx, ny := x+d.X, y+d.Y
+				if nx >= g.width-1 || ny >= g.height-1 {
+					continue
+				}
+				b := label[ny][nx]
+				if b < 0 || b == a {
+					continue
+				}
+				key := pair{min(a, b), max(a, b)}
+				bd := boundaries[key]
+				if bd == nil {
+					bd = &boundary{at: image.Point{X: x, Y: y}}
+					boundaries[key] = bd
+				}
+				bd.total++
+				if edges.Grid[y][x] == 1 || edges.Grid[ny][nx] == 1 {
+					bd.visible++
+				}
+			}
+		}
+	}
+
+	keys := make([]pair, 0, len(boundaries))
+	for k := range boundaries {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].a != keys[j].a {
+			return keys[i].a < keys[j].a
+		}
+		return keys[i].b < keys[j].b
+	})
+
+	var issues []LintIssue
+	for _, k := range keys {
+		bd := boundaries[k]
+		if bd.total < 3 || float64(bd.visible) >= 0.5*float64(bd.total) {
+			continue
+		}
+		ca := color.NRGBAModel.Convert(regions[k.a].BaseColor).(color.NRGBA)
+		cb := color.NRGBAModel.Convert(regions[k.b].BaseColor).(color.NRGBA)
+		issues = append(issues, g.issue(LintLowContrast, LintSeverityInfo, bd.at.X, bd.at.Y,
+			fmt.Sprintf("adjacent regions have low value contrast along %d boundary pixels; they may blend together", bd.total), ca, cb))
+	}
+	return issues, nil
+}
+
+// lintLuminance returns the perceptual lightness (CIELAB L, 0.0-1.0) of a color.
+func lintLuminance(c color.NRGBA) float64 {
+	l, _, _ := colorful.Color{R: float64(c.R) / 255.0, G: float64(c.G) / 255.0, B: float64(c.B) / 255.0}.Lab()
+	return l
+}
+
+// absInt returns the absolute value of an int.
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
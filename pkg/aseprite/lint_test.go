@@ -0,0 +1,203 @@
+package aseprite
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// lintIssuesFor runs a single lint check and returns its issues.
+func lintIssuesFor(t *testing.T, img image.Image, check string, palette []PaletteColor) []LintIssue {
+	t.Helper()
+	issues, err := LintPixelArt(img, LintOptions{Checks: []string{check}, Palette: palette})
+	if err != nil {
+		t.Fatalf("LintPixelArt(%s) error = %v", check, err)
+	}
+	return issues
+}
+
+func TestLintPixelArt_OrphanPixels(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 12, 12))
+	img.SetNRGBA(1, 1, color.NRGBA{R: 255, A: 255})
+	for y := 5; y < 10; y++ {
+		for x := 5; x < 10; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{B: 255, A: 255})
+		}
+	}
+	img.SetNRGBA(7, 7, color.NRGBA{G: 255, A: 255})
+
+	issues := lintIssuesFor(t, img, LintOrphanPixels, nil)
+	if len(issues) != 2 {
+		t.Fatalf("got %d issues, want 2: %+v", len(issues), issues)
+	}
+	if issues[0].X != 1 || issues[0].Y != 1 || issues[0].Severity != LintSeverityWarning {
+		t.Errorf("stray pixel issue = %+v, want warning at (1,1)", issues[0])
+	}
+	if issues[1].X != 7 || issues[1].Y != 7 || issues[1].Severity != LintSeverityInfo {
+		t.Errorf("isolated pixel issue = %+v, want info at (7,7)", issues[1])
+	}
+}
+
+func TestLintPixelArt_DitheringIsNotOrphaned(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			c := color.NRGBA{R: 255, A: 255}
+			if (x+y)%2 == 1 {
+				c = color.NRGBA{B: 255, A: 255}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+
+	if issues := lintIssuesFor(t, img, LintOrphanPixels, nil); len(issues) != 0 {
+		t.Errorf("checkerboard flagged as orphans: %+v", issues)
+	}
+}
+
+func TestLintPixelArt_Jaggies(t *testing.T) {
+	black := color.NRGBA{A: 255}
+
+	jaggy := image.NewNRGBA(image.Rect(0, 0, 10, 4))
+	for _, p := range []image.Point{{0, 0}, {1, 0}, {2, 0}, {3, 1}, {4, 2}, {5, 2}, {6, 2}} {
+		jaggy.SetNRGBA(p.X, p.Y, black)
+	}
+	issues := lintIssuesFor(t, jaggy, LintJaggies, nil)
+	if len(issues) != 1 || issues[0].X != 3 || issues[0].Y != 1 {
+		t.Errorf("3-1-3 line issues = %+v, want one at (3,1)", issues)
+	}
+
+	clean := image.NewNRGBA(image.Rect(0, 0, 10, 4))
+	for _, p := range []image.Point{{0, 0}, {1, 0}, {2, 1}, {3, 1}, {4, 2}, {5, 2}} {
+		clean.SetNRGBA(p.X, p.Y, black)
+	}
+	if issues := lintIssuesFor(t, clean, LintJaggies, nil); len(issues) != 0 {
+		t.Errorf("2-2-2 line flagged as jaggy: %+v", issues)
+	}
+}
+
+func TestLintPixelArt_Doubles(t *testing.T) {
+	black := color.NRGBA{A: 255}
+
+	staircase := image.NewNRGBA(image.Rect(0, 0, 6, 6))
+	for _, p := range []image.Point{{0, 0}, {1, 0}, {1, 1}, {2, 1}, {2, 2}} {
+		staircase.SetNRGBA(p.X, p.Y, black)
+	}
+	if issues := lintIssuesFor(t, staircase, LintDoubles, nil); len(issues) == 0 {
+		t.Error("thick diagonal staircase not flagged for doubles")
+	}
+
+	// A hollow rectangle's square corners are intentional
+	box := image.NewNRGBA(image.Rect(0, 0, 6, 6))
+	for i := 0; i < 6; i++ {
+		box.SetNRGBA(i, 0, black)
+		box.SetNRGBA(i, 5, black)
+		box.SetNRGBA(0, i, black)
+		box.SetNRGBA(5, i, black)
+	}
+	if issues := lintIssuesFor(t, box, LintDoubles, nil); len(issues) != 0 {
+		t.Errorf("rectangle corners flagged as doubles: %+v", issues)
+	}
+}
+
+func TestLintPixelArt_Banding(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 6, 6))
+	for y := 0; y < 6; y++ {
+		for x := 0; x < 6; x++ {
+			c := color.NRGBA{R: 40, G: 40, B: 40, A: 255}
+			switch {
+			case x == 2:
+				c = color.NRGBA{R: 120, G: 120, B: 120, A: 255}
+			case x > 2:
+				c = color.NRGBA{R: 220, G: 220, B: 220, A: 255}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+
+	issues := lintIssuesFor(t, img, LintBanding, nil)
+	if len(issues) != 1 || issues[0].X != 2 {
+		t.Errorf("banding issues = %+v, want one strip at x=2", issues)
+	}
+}
+
+// newDepthShadedSquare creates an opaque 16x16 square shaded by distance to the
+// border. When directional is true, only the left and top rims are dark.
+func newDepthShadedSquare(directional bool) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, 16, 16))
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 16; x++ {
+			d := min(x, y, 15-x, 15-y) + 1
+			v := uint8(200)
+			switch {
+			case d == 1:
+				v = 0
+			case d <= 4 && (!directional || x < 5 || y < 5):
+				v = 80
+			}
+			img.SetNRGBA(x, y, color.NRGBA{R: v, G: v, B: v, A: 255})
+		}
+	}
+	return img
+}
+
+func TestLintPixelArt_PillowShading(t *testing.T) {
+	if issues := lintIssuesFor(t, newDepthShadedSquare(false), LintPillowShading, nil); len(issues) != 1 {
+		t.Errorf("pillow shaded square issues = %+v, want 1", issues)
+	}
+	if issues := lintIssuesFor(t, newDepthShadedSquare(true), LintPillowShading, nil); len(issues) != 0 {
+		t.Errorf("directionally shaded square flagged as pillow shading: %+v", issues)
+	}
+}
+
+func TestLintPixelArt_PaletteChecks(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 1))
+	img.SetNRGBA(0, 0, color.NRGBA{R: 255, A: 255})
+	img.SetNRGBA(1, 0, color.NRGBA{G: 255, A: 255})
+	img.SetNRGBA(2, 0, color.NRGBA{R: 128, G: 128, B: 128, A: 255})
+	img.SetNRGBA(3, 0, color.NRGBA{R: 129, G: 129, B: 129, A: 255})
+
+	palette := []PaletteColor{{Color: "#FF0000"}, {Color: "#808080"}, {Color: "#818181"}}
+	off := lintIssuesFor(t, img, LintOffPalette, palette)
+	if len(off) != 1 || off[0].X != 1 || off[0].Severity != LintSeverityError || off[0].Colors[0] != "#00FF00" {
+		t.Errorf("off-palette issues = %+v, want one error for #00FF00", off)
+	}
+	if issues := lintIssuesFor(t, img, LintOffPalette, nil); len(issues) != 0 {
+		t.Errorf("off-palette check without palette returned %+v", issues)
+	}
+
+	dups := lintIssuesFor(t, img, LintNearDuplicates, nil)
+	if len(dups) != 1 || len(dups[0].Colors) != 2 {
+		t.Errorf("near-duplicate issues = %+v, want one pair", dups)
+	}
+}
+
+func TestLintPixelArt_LowContrast(t *testing.T) {
+	newHalves := func(right uint8) *image.NRGBA {
+		img := image.NewNRGBA(image.Rect(0, 0, 12, 12))
+		for y := 0; y < 12; y++ {
+			for x := 0; x < 12; x++ {
+				v := uint8(128)
+				if x >= 6 {
+					v = right
+				}
+				img.SetNRGBA(x, y, color.NRGBA{R: v, G: v, B: v, A: 255})
+			}
+		}
+		return img
+	}
+
+	if issues := lintIssuesFor(t, newHalves(134), LintLowContrast, nil); len(issues) != 1 {
+		t.Errorf("low contrast halves issues = %+v, want 1", issues)
+	}
+	if issues := lintIssuesFor(t, newHalves(255), LintLowContrast, nil); len(issues) != 0 {
+		t.Errorf("high contrast halves flagged: %+v", issues)
+	}
+}
+
+func TestLintPixelArt_UnknownCheck(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	if _, err := LintPixelArt(img, LintOptions{Checks: []string{"style"}}); err == nil {
+		t.Error("LintPixelArt() expected error for unknown check")
+	}
+}
@@ -2,6 +2,7 @@ package aseprite
 
 import (
 	"fmt"
+	"strings"
 )
 
 // SetFrameDuration generates a Lua script to set the duration of a frame.
@@ -40,6 +41,97 @@ spr:saveAs(spr.filename)
 print("Frame duration set successfully")`, frameNumber, frameNumber, durationSec)
 }
 
+// SetFrameDurations generates a Lua script to set the durations of a range of frames.
+//
+// Frame fromFrame gets the first duration, the next frame the second, and so
+// on, covering len(durationsMs) consecutive frames.
+//
+// Parameters:
+//   - fromFrame: 1-based index of the first frame to modify
+//   - durationsMs: frame durations in milliseconds (converted to seconds for Aseprite)
+//
+// The operation is wrapped in a transaction for atomicity and the sprite
+// is saved after the durations are set.
+//
+// Prints "Frame durations set successfully" followed by JSON:
+// {"from_frame": N, "to_frame": N, "total_ms": N} with the modified range and
+// its total duration.
+// Returns an error if:
+//   - No sprite is active
+//   - The frame range exceeds the sprite's frame count
+func (g *LuaGenerator) SetFrameDurations(fromFrame int, durationsMs []int) string {
+	durations := make([]string, len(durationsMs))
+	for i, ms := range durationsMs {
+		durations[i] = fmt.Sprintf("%.3f", float64(ms)/1000.0)
+	}
+
+	return fmt.Sprintf(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+local first = %d
+local durations = { %s }
+local last = first + #durations - 1
+
+if first < 1 or last > #spr.frames then
+	error("Frame range exceeds sprite frames")
+end
+
+app.transaction(function()
+	for k, duration in ipairs(durations) do
+		spr.frames[first + k - 1].duration = duration
+	end
+end)
+
+local total = 0
+for f = first, last do
+	total = total + math.floor(spr.frames[f].duration * 1000 + 0.5)
+end
+
+spr:saveAs(spr.filename)
+print("Frame durations set successfully")
+print(string.format('{"from_frame":%%d,"to_frame":%%d,"total_ms":%%d}', first, last, total))`,
+		fromFrame, strings.Join(durations, ", "))
+}
+
+// GetAnimationTiming generates a Lua script to read the animation timing of a sprite.
+//
+// Reports the duration of every frame and every tag as described by ListTags
+// (frame range, direction, repeat count, and total duration).
+//
+// Prints JSON: {"durations_ms": [N, ...], "tags": [...]}
+// Returns an error if no sprite is active.
+func (g *LuaGenerator) GetAnimationTiming() string {
+	return `local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+` + tagInfoHelper + `
+local durations = {}
+for i, frame in ipairs(spr.frames) do
+	table.insert(durations, tostring(math.floor(frame.duration * 1000 + 0.5)))
+end
+
+print('{"durations_ms":[' .. table.concat(durations, ",") .. '],"tags":' .. tagsInfoJSON(spr) .. '}')`
+}
+
+// aniDirLua maps a playback direction name to the Aseprite AniDir enum.
+// Unknown directions map to AniDir.FORWARD.
+func aniDirLua(direction string) string {
+	switch direction {
+	case "reverse":
+		return "AniDir.REVERSE"
+	case "pingpong":
+		return "AniDir.PING_PONG"
+	case "pingpong_reverse":
+		return "AniDir.PING_PONG_REVERSE"
+	default:
+		return "AniDir.FORWARD"
+	}
+}
+
 // CreateTag generates a Lua script to create an animation tag.
 //
 // Creates a named tag spanning a range of frames. Tags are used to define
@@ -50,12 +142,13 @@ print("Frame duration set successfully")`, frameNumber, frameNumber, durationSec
 //   - tagName: name for the animation tag (automatically escaped for Lua safety)
 //   - fromFrame: 1-based starting frame index (inclusive)
 //   - toFrame: 1-based ending frame index (inclusive)
-//   - direction: playback direction - "forward" (default), "reverse", or "pingpong"
+//   - direction: playback direction - "forward" (default), "reverse", "pingpong", or "pingpong_reverse"
 //
 // Direction modes:
 //   - "forward": plays frames from start to end
 //   - "reverse": plays frames from end to start
 //   - "pingpong": plays forward then backward in a loop
+//   - "pingpong_reverse": plays backward then forward in a loop
 //
 // The operation is wrapped in a transaction for atomicity and the sprite
 // is saved after the tag is created.
@@ -67,18 +160,7 @@ print("Frame duration set successfully")`, frameNumber, frameNumber, durationSec
 func (g *LuaGenerator) CreateTag(tagName string, fromFrame, toFrame int, direction string) string {
 	escapedName := EscapeString(tagName)
 
-	// Map direction to Aseprite AniDir enum
-	var aniDir string
-	switch direction {
-	case "forward":
-		aniDir = "AniDir.FORWARD"
-	case "reverse":
-		aniDir = "AniDir.REVERSE"
-	case "pingpong":
-		aniDir = "AniDir.PING_PONG"
-	default:
-		aniDir = "AniDir.FORWARD"
-	}
+	aniDir := aniDirLua(direction)
 
 	return fmt.Sprintf(`local spr = app.activeSprite
 if not spr then
@@ -62,6 +62,7 @@ print("Layers flattened successfully")`
 //   - Color mode (RGB, Grayscale, or Indexed)
 //   - Frame count
 //   - Layer count and names
+//   - Tags with their frame ranges, directions, and durations (see ListTags)
 //
 // The script outputs JSON-formatted sprite information to stdout.
 // Returns an error if no sprite is active.
@@ -71,6 +72,8 @@ if not spr then
 	error("No active sprite")
 end
 
+` + tagInfoHelper + `
+
 -- Map color mode enum to string
 local colorModeStr = "rgb"
 if spr.colorMode == ColorMode.GRAYSCALE then
@@ -92,14 +95,16 @@ local output = string.format([[{
 	"color_mode": "%s",
 	"frame_count": %d,
 	"layer_count": %d,
-	"layers": ["%s"]
+	"layers": ["%s"],
+	"tags": %s
 }]],
 	spr.width,
 	spr.height,
 	colorModeStr,
 	#spr.frames,
 	#spr.layers,
-	table.concat(layers, '","')
+	table.concat(layers, '","'),
+	tagsInfoJSON(spr)
 )
 
 print(output)`
@@ -212,6 +217,60 @@ spr:saveAs(spr.filename)
 print("Layer deleted successfully")`, escapedName, escapedName)
 }
 
+// MoveLayerBelow generates a Lua script to move a layer directly below another layer.
+//
+// Used to place effect layers such as drop shadows behind the layer they were
+// generated from. Both layers must be top-level layers. If the layer is already
+// below the reference layer, it is left where it is.
+//
+// The operation is wrapped in a transaction for atomicity and the sprite
+// is saved after the layer is moved.
+//
+// Parameters:
+//   - layerName: name of the layer to move (automatically escaped for Lua safety)
+//   - referenceLayer: name of the layer to place it under (automatically escaped)
+//
+// Prints "Layer moved successfully" on success.
+// Returns an error if:
+//   - No sprite is active
+//   - Either layer is not found
+func (g *LuaGenerator) MoveLayerBelow(layerName, referenceLayer string) string {
+	escapedName := EscapeString(layerName)
+	escapedRef := EscapeString(referenceLayer)
+	return fmt.Sprintf(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+-- Find both layers by name
+local layer = nil
+local ref = nil
+for i, lyr in ipairs(spr.layers) do
+	if lyr.name == "%s" then
+		layer = lyr
+	end
+	if lyr.name == "%s" then
+		ref = lyr
+	end
+end
+
+if not layer then
+	error("Layer not found: %s")
+end
+if not ref then
+	error("Layer not found: %s")
+end
+
+if layer.stackIndex > ref.stackIndex then
+	app.transaction(function()
+		layer.stackIndex = ref.stackIndex
+	end)
+	spr:saveAs(spr.filename)
+end
+
+print("Layer moved successfully")`, escapedName, escapedRef, escapedName, escapedRef)
+}
+
 // DeleteFrame generates a Lua script to delete a frame.
 //
 // Removes the specified frame from the active sprite's animation sequence.
@@ -523,6 +523,51 @@ print("Area filled successfully")`,
 	return sb.String()
 }
 
+// bayerMatrices holds the ordered dithering matrices (threshold values
+// 0..n*n-1) shared by the Lua dithering scripts and the Go layer effects.
+var bayerMatrices = map[string][][]int{
+	"bayer_2x2": {
+		{0, 2},
+		{3, 1},
+	},
+	"bayer_4x4": {
+		{0, 8, 2, 10},
+		{12, 4, 14, 6},
+		{3, 11, 1, 9},
+		{15, 7, 13, 5},
+	},
+	"bayer_8x8": {
+		{0, 32, 8, 40, 2, 34, 10, 42},
+		{48, 16, 56, 24, 50, 18, 58, 26},
+		{12, 44, 4, 36, 14, 46, 6, 38},
+		{60, 28, 52, 20, 62, 30, 54, 22},
+		{3, 35, 11, 43, 1, 33, 9, 41},
+		{51, 19, 59, 27, 49, 17, 57, 25},
+		{15, 47, 7, 39, 13, 45, 5, 37},
+		{63, 31, 55, 23, 61, 29, 53, 21},
+	},
+}
+
+// bayerMatrixLua returns Lua code defining matrix (1-based rows) and
+// matrixSize for a Bayer pattern, or false if the pattern is not a Bayer
+// matrix.
+func bayerMatrixLua(pattern string) (string, bool) {
+	m, ok := bayerMatrices[pattern]
+	if !ok {
+		return "", false
+	}
+
+	rows := make([]string, len(m))
+	for i, row := range m {
+		values := make([]string, len(row))
+		for j, v := range row {
+			values[j] = fmt.Sprintf("%d", v)
+		}
+		rows[i] = "{" + strings.Join(values, ", ") + "}"
+	}
+	return fmt.Sprintf("local matrix = {%s}\nlocal matrixSize = %d", strings.Join(rows, ", "), len(m)), true
+}
+
 // DrawWithDither generates a Lua script to fill a region with a dithering pattern.
 //
 // Applies a dithering pattern to create texture, gradients, or retro aesthetic effects.
@@ -567,29 +612,8 @@ func (g *LuaGenerator) DrawWithDither(layerName string, frameNumber int, x, y, w
 	// Get dithering matrix based on pattern
 	var matrixCode string
 	switch pattern {
-	case "bayer_2x2":
-		matrixCode = `local matrix = {{0, 2}, {3, 1}}
-local matrixSize = 2`
-	case "bayer_4x4":
-		matrixCode = `local matrix = {
-	{ 0,  8,  2, 10},
-	{12,  4, 14,  6},
-	{ 3, 11,  1,  9},
-	{15,  7, 13,  5}
-}
-local matrixSize = 4`
-	case "bayer_8x8":
-		matrixCode = `local matrix = {
-	{ 0, 32,  8, 40,  2, 34, 10, 42},
-	{48, 16, 56, 24, 50, 18, 58, 26},
-	{12, 44,  4, 36, 14, 46,  6, 38},
-	{60, 28, 52, 20, 62, 30, 54, 22},
-	{ 3, 35, 11, 43,  1, 33,  9, 41},
-	{51, 19, 59, 27, 49, 17, 57, 25},
-	{15, 47,  7, 39, 13, 45,  5, 37},
-	{63, 31, 55, 23, 61, 29, 53, 21}
-}
-local matrixSize = 8`
+	case "bayer_2x2", "bayer_4x4", "bayer_8x8":
+		matrixCode, _ = bayerMatrixLua(pattern)
 	case "checkerboard":
 		matrixCode = `local matrix = {{0, 1}, {1, 0}}
 local matrixSize = 2`
@@ -2,6 +2,7 @@ package aseprite
 
 import (
 	"fmt"
+	"strings"
 )
 
 // ExportSprite generates a Lua script to export a sprite.
@@ -260,6 +261,205 @@ print("Image imported successfully")`,
 		posX, posY)
 }
 
+// StampImage generates a Lua script to draw an external image onto an existing layer.
+//
+// Unlike ImportImage, which replaces the cel, the image is blended over the
+// pixels already in the cel (normal blend mode), so transparent areas of the
+// image leave the layer untouched. The cel is expanded to the full canvas so
+// the image can be placed anywhere; a cel is created if the layer has none at
+// the frame.
+//
+// Parameters:
+//   - imagePath: absolute path to source image file (automatically escaped for Lua safety)
+//   - layerName: name of the target layer (automatically escaped for Lua safety)
+//   - frameNumber: 1-based frame index to draw on
+//   - x, y: canvas position of the image's top-left corner (may be negative)
+//
+// The operation is wrapped in a transaction for atomicity and the sprite
+// is saved after the image is drawn.
+//
+// Prints "Image stamped successfully" on success.
+// Returns an error if:
+//   - No sprite is active
+//   - The image file cannot be loaded
+//   - The layer is not found
+//   - The frame number is invalid
+func (g *LuaGenerator) StampImage(imagePath, layerName string, frameNumber int, x, y int) string {
+	escapedImage := EscapeString(imagePath)
+	escapedLayer := EscapeString(layerName)
+
+	return fmt.Sprintf(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+-- Load external image
+local img = Image{ fromFile = "%s" }
+if not img then
+	error("Failed to load image: %s")
+end
+
+-- Find layer by name
+local layer = nil
+for i, lyr in ipairs(spr.layers) do
+	if lyr.name == "%s" then
+		layer = lyr
+		break
+	end
+end
+
+if not layer then
+	error("Layer not found: %s")
+end
+
+local frame = spr.frames[%d]
+if not frame then
+	error("Frame not found: %d")
+end
+
+app.transaction(function()
+	local src = img
+
+	-- Convert color mode if needed
+	if img.colorMode ~= spr.colorMode then
+		src = Image(img.width, img.height, spr.colorMode)
+		src:drawImage(img, Point(0, 0), 255, BlendMode.SRC)
+	end
+
+	-- Blend onto a canvas-sized copy of the cel
+	local cel = layer:cel(frame)
+	local canvas = Image(spr.width, spr.height, spr.colorMode)
+	if spr.colorMode == ColorMode.INDEXED then
+		canvas:clear(spr.transparentColor)
+	end
+	if cel then
+		canvas:drawImage(cel.image, cel.position)
+	end
+	canvas:drawImage(src, Point(%d, %d))
+
+	if cel then
+		cel.image = canvas
+		cel.position = Point(0, 0)
+	else
+		spr:newCel(layer, frame, canvas, Point(0, 0))
+	end
+end)
+
+spr:saveAs(spr.filename)
+print("Image stamped successfully")`,
+		escapedImage, escapedImage,
+		escapedLayer, escapedLayer,
+		frameNumber, frameNumber,
+		x, y)
+}
+
+// ExportCelImage generates a Lua script to export a single cel image to a PNG file.
+//
+// Saves the image of the cel at the given layer and frame without flattening or
+// compositing. The exported image has the cel's own dimensions, so the cel position
+// is reported alongside it to allow the result to be placed back at the same offset.
+//
+// Parameters:
+//   - layerName: name of the layer to export from (automatically escaped for Lua safety)
+//   - frameNumber: 1-based frame index to export from
+//   - outputPath: absolute path for the PNG file (automatically escaped for Lua safety)
+//
+// The sprite palette is passed to the encoder so indexed cels export with their
+// real colors.
+//
+// Prints JSON with the cel bounds: {"x":N,"y":N,"width":N,"height":N}
+// Returns an error if:
+//   - No sprite is active
+//   - The layer is not found
+//   - The frame number is invalid
+//   - No cel exists at the specified layer/frame
+func (g *LuaGenerator) ExportCelImage(layerName string, frameNumber int, outputPath string) string {
+	escapedName := EscapeString(layerName)
+	escapedPath := EscapeString(outputPath)
+
+	return fmt.Sprintf(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+-- Find layer by name
+local layer = nil
+for i, lyr in ipairs(spr.layers) do
+	if lyr.name == "%s" then
+		layer = lyr
+		break
+	end
+end
+
+if not layer then
+	error("Layer not found: %s")
+end
+
+local frame = spr.frames[%d]
+if not frame then
+	error("Frame not found: %d")
+end
+
+local cel = layer:cel(frame)
+if not cel then
+	error("No cel found at layer '%s' frame %d")
+end
+
+cel.image:saveAs{ filename = "%s", palette = spr.palettes[1] }
+
+print(string.format('{"x":%%d,"y":%%d,"width":%%d,"height":%%d}',
+	cel.position.x, cel.position.y, cel.image.width, cel.image.height))`,
+		escapedName, escapedName,
+		frameNumber, frameNumber,
+		escapedName, frameNumber,
+		escapedPath)
+}
+
+// ExportFrameImages generates a Lua script to export flattened frames as PNGs.
+//
+// Renders every visible layer of each frame into a sprite-sized RGB image,
+// honoring layer opacity and blend modes, and writes it to
+// "frame-<N>.png" in outputDir. Indexed and grayscale sprites are rendered
+// with their real colors.
+//
+// Parameters:
+//   - frames: 1-based frame numbers to export
+//   - outputDir: absolute path of an existing directory (automatically escaped for Lua safety)
+//
+// Prints "Frames exported successfully".
+// Returns an error if:
+//   - No sprite is active
+//   - A frame does not exist
+func (g *LuaGenerator) ExportFrameImages(frames []int, outputDir string) string {
+	escapedDir := EscapeString(outputDir)
+
+	frameList := make([]string, len(frames))
+	for i, f := range frames {
+		frameList[i] = fmt.Sprintf("%d", f)
+	}
+
+	return fmt.Sprintf(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+local frames = { %s }
+for _, f in ipairs(frames) do
+	if f < 1 or f > #spr.frames then
+		error("Frame not found: " .. f)
+	end
+end
+
+local outputDir = "%s"
+for _, f in ipairs(frames) do
+	local img = Image(spr.width, spr.height, ColorMode.RGB)
+	img:drawSprite(spr, f)
+	img:saveAs(app.fs.joinPath(outputDir, "frame-" .. f .. ".png"))
+end
+
+print("Frames exported successfully")`, strings.Join(frameList, ", "), escapedDir)
+}
+
 // SaveAs generates a Lua script to save the sprite to a new file path.
 //
 // Saves the active sprite to a new location, effectively creating a copy or
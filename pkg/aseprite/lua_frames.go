@@ -0,0 +1,399 @@
+package aseprite
+
+import (
+	"fmt"
+)
+
+// frameRangeHelper defines the Lua helpers shared by the frame range
+// operations:
+//   - collectCelLayers(layers, list) lists every non-group layer, including
+//     layers inside groups
+//   - permuteFrames(spr, first, last, source) rewrites frames first..last so
+//     that frame k gets the cels and duration of old frame source[k]
+//   - captureTags(spr) records every tag with its current frame range
+//   - remapTags(tags, mapFrame, inBlock) moves captured tags to the new
+//     positions of their frames (see remapTags below)
+//   - tagsJSON(spr) returns the tags as a JSON array of
+//     {"name", "from_frame", "to_frame"} objects
+const frameRangeHelper = `-- List every layer that can hold cels, including layers inside groups
+local function collectCelLayers(layers, list)
+	for _, lyr in ipairs(layers) do
+		if lyr.isGroup then
+			collectCelLayers(lyr.layers, list)
+		else
+			table.insert(list, lyr)
+		end
+	end
+	return list
+end
+
+-- Rewrite frames first..last so frame k takes the cels and duration of old
+-- frame source[k]. Cels are snapshotted first, so overlapping moves are safe.
+local function permuteFrames(spr, first, last, source)
+	local durations = {}
+	for k = first, last do
+		durations[k] = spr.frames[source[k]].duration
+	end
+
+	for _, layer in ipairs(collectCelLayers(spr.layers, {})) do
+		local cels = {}
+		for f = first, last do
+			local cel = layer:cel(f)
+			if cel then
+				cels[f] = {
+					image = Image(cel.image),
+					position = cel.position,
+					opacity = cel.opacity,
+					color = cel.color,
+					data = cel.data,
+				}
+				spr:deleteCel(cel)
+			end
+		end
+
+		for k = first, last do
+			local c = cels[source[k]]
+			if c then
+				local cel = spr:newCel(layer, k, c.image, c.position)
+				cel.opacity = c.opacity
+				cel.color = c.color
+				cel.data = c.data
+			end
+		end
+	end
+
+	for k = first, last do
+		spr.frames[k].duration = durations[k]
+	end
+end
+
+-- Record every tag with its frame range before frames change
+local function captureTags(spr)
+	local tags = {}
+	for _, tag in ipairs(spr.tags) do
+		table.insert(tags, { tag = tag, from = tag.fromFrame.frameNumber, to = tag.toFrame.frameNumber })
+	end
+	return tags
+end
+
+-- Set a tag range without passing through an inverted range
+local function setTagRange(tag, first, last)
+	if first > tag.toFrame.frameNumber then
+		tag.toFrame = last
+		tag.fromFrame = first
+	else
+		tag.fromFrame = first
+		tag.toFrame = last
+	end
+end
+
+-- Move captured tags to the new positions of their frames. mapFrame returns
+-- the new number of an old frame (nil if it was deleted). A tag whose frames
+-- stay contiguous (including a tag lying entirely inside the block, inBlock)
+-- keeps all of them. Otherwise the tag spans its remaining frames outside the
+-- block, so it shrinks when frames leave it and grows when frames are
+-- inserted inside it.
+local function remapTags(tags, mapFrame, inBlock)
+	for _, t in ipairs(tags) do
+		local first, last, count = nil, nil, 0
+		for f = t.from, t.to do
+			local n = mapFrame(f)
+			if n then
+				first = first and math.min(first, n) or n
+				last = last and math.max(last, n) or n
+				count = count + 1
+			end
+		end
+
+		if first and last - first + 1 ~= count then
+			first, last = nil, nil
+			for f = t.from, t.to do
+				if not inBlock(f) then
+					local n = mapFrame(f)
+					if n then
+						first = first and math.min(first, n) or n
+						last = last and math.max(last, n) or n
+					end
+				end
+			end
+		end
+
+		if first then
+			setTagRange(t.tag, first, last)
+		end
+	end
+end
+
+-- Describe the tags as a JSON array
+local function tagsJSON(spr)
+	local items = {}
+	for _, tag in ipairs(spr.tags) do
+		local name = tag.name:gsub('\\', '\\\\'):gsub('"', '\\"')
+		table.insert(items, string.format('{"name":"%s","from_frame":%d,"to_frame":%d}',
+			name, tag.fromFrame.frameNumber, tag.toFrame.frameNumber))
+	end
+	return "[" .. table.concat(items, ",") .. "]"
+end
+`
+
+// MoveFrames generates a Lua script to move a range of frames to a new position.
+//
+// The frames fromFrame..toFrame are taken out of the timeline and reinserted
+// so that the first of them becomes frame targetFrame. Cels (image, position,
+// opacity, color, and user data) and frame durations move with their frames.
+// Linked cels in the affected span are unlinked.
+//
+// Tags whose frames stay contiguous, such as tags lying entirely inside the
+// moved range or spanning both the range and its destination, keep all their
+// frames. Other tags keep their frames outside the moved range: they shrink
+// when moved frames leave them and grow when moved frames land inside them.
+//
+// Parameters:
+//   - fromFrame, toFrame: 1-based inclusive range of frames to move
+//   - targetFrame: 1-based position of the first moved frame after the move
+//
+// The operation is wrapped in a transaction for atomicity and the sprite
+// is saved after the frames are moved.
+//
+// Prints "Frames moved successfully" followed by JSON:
+// {"frame_count": N, "from_frame": N, "to_frame": N, "tags": [...]} with the
+// new position of the moved range and the resulting tag ranges.
+// Returns an error if:
+//   - No sprite is active
+//   - The frame range exceeds the sprite's frame count
+//   - The moved range would extend past the last frame
+func (g *LuaGenerator) MoveFrames(fromFrame, toFrame, targetFrame int) string {
+	return fmt.Sprintf(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+%s
+local first, last, target = %d, %d, %d
+local count = last - first + 1
+
+if last > #spr.frames then
+	error("Frame range exceeds sprite frames")
+end
+
+if target + count - 1 > #spr.frames then
+	error("Target position exceeds sprite frames")
+end
+
+-- New timeline order as old frame numbers
+local order = {}
+for f = 1, #spr.frames do
+	if f < first or f > last then
+		table.insert(order, f)
+	end
+end
+for k = 0, count - 1 do
+	table.insert(order, target + k, first + k)
+end
+
+local newIndex = {}
+for k, f in ipairs(order) do
+	newIndex[f] = k
+end
+
+local tags = captureTags(spr)
+
+app.transaction(function()
+	permuteFrames(spr, math.min(first, target), math.max(last, target + count - 1), order)
+	remapTags(tags, function(f) return newIndex[f] end, function(f) return f >= first and f <= last end)
+end)
+
+spr:saveAs(spr.filename)
+print("Frames moved successfully")
+print(string.format('{"frame_count":%%d,"from_frame":%%d,"to_frame":%%d,"tags":%%s}',
+	#spr.frames, target, target + count - 1, tagsJSON(spr)))`, frameRangeHelper, fromFrame, toFrame, targetFrame)
+}
+
+// ReverseFrames generates a Lua script to reverse the order of a range of frames.
+//
+// Frame fromFrame swaps with toFrame, fromFrame+1 with toFrame-1, and so on.
+// Cels (image, position, opacity, color, and user data) and frame durations
+// are reversed with their frames. Linked cels in the range are unlinked.
+//
+// Tags lying entirely inside the range are mirrored so they keep covering the
+// same frames; tags that only overlap or contain the range are unchanged.
+//
+// Parameters:
+//   - fromFrame, toFrame: 1-based inclusive range of frames to reverse
+//
+// The operation is wrapped in a transaction for atomicity and the sprite
+// is saved after the frames are reversed.
+//
+// Prints "Frames reversed successfully" followed by JSON:
+// {"frame_count": N, "tags": [...]} with the resulting tag ranges.
+// Returns an error if:
+//   - No sprite is active
+//   - The frame range exceeds the sprite's frame count
+func (g *LuaGenerator) ReverseFrames(fromFrame, toFrame int) string {
+	return fmt.Sprintf(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+%s
+local first, last = %d, %d
+
+if last > #spr.frames then
+	error("Frame range exceeds sprite frames")
+end
+
+local source = {}
+for k = first, last do
+	source[k] = first + last - k
+end
+
+local tags = captureTags(spr)
+
+app.transaction(function()
+	permuteFrames(spr, first, last, source)
+
+	-- Mirror tags that lie inside the reversed range
+	for _, t in ipairs(tags) do
+		if t.from >= first and t.to <= last then
+			setTagRange(t.tag, first + last - t.to, first + last - t.from)
+		end
+	end
+end)
+
+spr:saveAs(spr.filename)
+print("Frames reversed successfully")
+print(string.format('{"frame_count":%%d,"tags":%%s}', #spr.frames, tagsJSON(spr)))`, frameRangeHelper, fromFrame, toFrame)
+}
+
+// DeleteFrames generates a Lua script to delete a range of frames.
+//
+// Removes frames fromFrame..toFrame with all their cels. Tags lying entirely
+// inside the range are deleted; other tags shrink to their remaining frames
+// and tags after the range shift back. As a safety measure, the script
+// prevents deleting every frame of the sprite.
+//
+// Parameters:
+//   - fromFrame, toFrame: 1-based inclusive range of frames to delete
+//
+// The operation is wrapped in a transaction for atomicity and the sprite
+// is saved after the frames are deleted.
+//
+// Prints "Frames deleted successfully" followed by JSON:
+// {"frame_count": N, "frames_deleted": N, "tags_deleted": N, "tags": [...]}
+// with the remaining frame count and tag ranges.
+// Returns an error if:
+//   - No sprite is active
+//   - The frame range exceeds the sprite's frame count
+//   - The range covers every frame of the sprite
+func (g *LuaGenerator) DeleteFrames(fromFrame, toFrame int) string {
+	return fmt.Sprintf(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+%s
+local first, last = %d, %d
+local count = last - first + 1
+
+if last > #spr.frames then
+	error("Frame range exceeds sprite frames")
+end
+
+if count >= #spr.frames then
+	error("Cannot delete every frame of the sprite")
+end
+
+local tags = captureTags(spr)
+local kept = {}
+local tagsDeleted = 0
+
+app.transaction(function()
+	-- Tags with no frames left are removed before their frames
+	for _, t in ipairs(tags) do
+		if t.from >= first and t.to <= last then
+			spr:deleteTag(t.tag)
+			tagsDeleted = tagsDeleted + 1
+		else
+			table.insert(kept, t)
+		end
+	end
+
+	for f = last, first, -1 do
+		spr:deleteFrame(f)
+	end
+
+	remapTags(kept, function(f)
+		if f < first then
+			return f
+		elseif f > last then
+			return f - count
+		end
+		return nil
+	end, function(f) return f >= first and f <= last end)
+end)
+
+spr:saveAs(spr.filename)
+print("Frames deleted successfully")
+print(string.format('{"frame_count":%%d,"frames_deleted":%%d,"tags_deleted":%%d,"tags":%%s}',
+	#spr.frames, count, tagsDeleted, tagsJSON(spr)))`, frameRangeHelper, fromFrame, toFrame)
+}
+
+// InsertEmptyFrames generates a Lua script to insert empty frames.
+//
+// Inserts count frames without cels so that the first of them becomes frame
+// atFrame; existing frames from atFrame on shift back. Use atFrame equal to
+// the frame count + 1 to append.
+//
+// Tags starting at or after atFrame shift with their frames. Tags that span
+// the insertion point (start before atFrame and end at or after it) grow to
+// include the new frames.
+//
+// Parameters:
+//   - atFrame: 1-based position of the first inserted frame
+//   - count: number of frames to insert
+//   - durationMs: duration of each new frame in milliseconds (converted to seconds for Aseprite)
+//
+// The operation is wrapped in a transaction for atomicity and the sprite
+// is saved after the frames are inserted.
+//
+// Prints "Empty frames inserted successfully" followed by JSON:
+// {"frame_count": N, "from_frame": N, "to_frame": N, "tags": [...]} with the
+// range of inserted frames and the resulting tag ranges.
+// Returns an error if:
+//   - No sprite is active
+//   - The insert position is past the frame after the last frame
+func (g *LuaGenerator) InsertEmptyFrames(atFrame, count, durationMs int) string {
+	durationSec := float64(durationMs) / 1000.0
+	return fmt.Sprintf(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+%s
+local at, count = %d, %d
+
+if at > #spr.frames + 1 then
+	error("Insert position exceeds sprite frames")
+end
+
+local tags = captureTags(spr)
+
+app.transaction(function()
+	for k = 1, count do
+		local frame = spr:newEmptyFrame(at)
+		frame.duration = %.3f
+	end
+
+	remapTags(tags, function(f)
+		if f >= at then
+			return f + count
+		end
+		return f
+	end, function(f) return false end)
+end)
+
+spr:saveAs(spr.filename)
+print("Empty frames inserted successfully")
+print(string.format('{"frame_count":%%d,"from_frame":%%d,"to_frame":%%d,"tags":%%s}',
+	#spr.frames, at, at + count - 1, tagsJSON(spr)))`, frameRangeHelper, atFrame, count, durationSec)
+}
@@ -0,0 +1,103 @@
+package aseprite
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLuaGenerator_MoveFrames(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.MoveFrames(2, 3, 5)
+
+	for _, want := range []string{
+		"local first, last, target = 2, 3, 5",
+		`error("Target position exceeds sprite frames")`,
+		"table.insert(order, target + k, first + k)",
+		"permuteFrames(spr, math.min(first, target), math.max(last, target + count - 1), order)",
+		"remapTags(tags, function(f) return newIndex[f] end",
+		"Frames moved successfully",
+		`"tags":%s}`,
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+}
+
+func TestLuaGenerator_ReverseFrames(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.ReverseFrames(1, 4)
+
+	for _, want := range []string{
+		"local first, last = 1, 4",
+		"source[k] = first + last - k",
+		"permuteFrames(spr, first, last, source)",
+		"setTagRange(t.tag, first + last - t.to, first + last - t.from)",
+		"Frames reversed successfully",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+}
+
+func TestLuaGenerator_DeleteFrames(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.DeleteFrames(2, 4)
+
+	for _, want := range []string{
+		"local first, last = 2, 4",
+		`error("Cannot delete every frame of the sprite")`,
+		"spr:deleteTag(t.tag)",
+		"for f = last, first, -1 do\n\t\tspr:deleteFrame(f)",
+		"return f - count",
+		`"tags_deleted":%d`,
+		"Frames deleted successfully",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+
+	// Tags are removed before the frames they cover
+	if strings.Index(script, "spr:deleteTag(t.tag)") > strings.Index(script, "spr:deleteFrame(f)") {
+		t.Error("script should delete emptied tags before deleting frames")
+	}
+}
+
+func TestLuaGenerator_InsertEmptyFrames(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.InsertEmptyFrames(3, 2, 150)
+
+	for _, want := range []string{
+		"local at, count = 3, 2",
+		"spr:newEmptyFrame(at)",
+		"frame.duration = 0.150",
+		"return f + count",
+		"Empty frames inserted successfully",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+}
+
+func TestFrameRangeHelper(t *testing.T) {
+	for _, want := range []string{
+		"local function collectCelLayers(layers, list)",
+		"collectCelLayers(lyr.layers, list)",
+		"local function permuteFrames(spr, first, last, source)",
+		"image = Image(cel.image)",
+		"spr.frames[k].duration = durations[k]",
+		"local function remapTags(tags, mapFrame, inBlock)",
+		"local function tagsJSON(spr)",
+	} {
+		if !strings.Contains(frameRangeHelper, want) {
+			t.Errorf("helper missing %q", want)
+		}
+	}
+}
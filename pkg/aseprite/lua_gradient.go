@@ -0,0 +1,329 @@
+package aseprite
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Gradient shapes supported by DrawGradient.
+const (
+	GradientLinear  = "linear"  // Bands perpendicular to the start -> end line
+	GradientRadial  = "radial"  // Circles around the start point
+	GradientAngular = "angular" // Sweeps around the start point (conic)
+	GradientDiamond = "diamond" // Diamonds (Manhattan distance) around the start point
+)
+
+// Gradient clipping modes supported by DrawGradient.
+const (
+	GradientClipRegion    = "region"    // Every pixel in the region
+	GradientClipSelection = "selection" // Selected pixels only
+	GradientClipOpaque    = "opaque"    // Non-transparent pixels only, keeping their alpha
+)
+
+// GradientStop is a color at a position along a gradient.
+type GradientStop struct {
+	Position float64 // 0.0 (start) to 1.0 (end)
+	Color    Color
+}
+
+// GradientOptions configures DrawGradient.
+type GradientOptions struct {
+	Type       string         // "linear", "radial", "angular", or "diamond"
+	Start      Point          // Linear start point, or center of the other shapes
+	End        Point          // Linear end point; for other shapes its distance from Start is the radius and its direction where the angular sweep begins
+	Stops      []GradientStop // At least two stops, sorted by position
+	Dither     string         // "none" (smooth blend) or a Bayer pattern between adjacent stops: bayer_2x2, bayer_4x4, bayer_8x8
+	Region     Rectangle      // Area to fill, clipped to the canvas (zero size = whole canvas)
+	Clip       string         // "region", "selection", or "opaque"
+	UsePalette bool           // Quantize colors to the sprite palette
+	Palette    []Color        // Quantize colors to this palette instead of the sprite palette
+}
+
+// DrawGradient generates a Lua script to fill an area with a multi-stop gradient.
+//
+// Each pixel's position along the gradient (0.0 at Start, 1.0 at End or the
+// radius) selects the two surrounding stops. With Dither "none" the stop colors
+// are blended; with a Bayer pattern each pixel takes one of the two stop colors
+// by comparing its blend fraction against the ordered dithering matrix, so
+// only stop colors are used. Colors are quantized to the palette when
+// UsePalette is set or Palette is given, and always map to the nearest palette
+// index in indexed sprites.
+//
+// The gradient replaces the pixels of the target cel inside Region, limited
+// to the active selection (restored from its persisted mask if needed) or to
+// non-transparent pixels depending on Clip.
+//
+// The operation is wrapped in a transaction for atomicity and the sprite
+// is saved after the gradient is drawn.
+//
+// Prints JSON: {"pixels_painted": N}
+// Returns an error if:
+//   - No sprite is active
+//   - The layer is not found
+//   - The frame number is invalid
+//   - Clip is "selection" and there is no selection
+func (g *LuaGenerator) DrawGradient(layerName string, frameNumber int, opts GradientOptions) string {
+	escapedName := EscapeString(layerName)
+
+	var stops strings.Builder
+	for _, s := range opts.Stops {
+		stops.WriteString(fmt.Sprintf("\t{pos = %f, r = %d, g = %d, b = %d, a = %d},\n",
+			s.Position, s.Color.R, s.Color.G, s.Color.B, s.Color.A))
+	}
+
+	paletteCode := "local palette = nil"
+	if len(opts.Palette) > 0 {
+		var pal strings.Builder
+		for _, c := range opts.Palette {
+			pal.WriteString(fmt.Sprintf("{%d, %d, %d}, ", c.R, c.G, c.B))
+		}
+		paletteCode = fmt.Sprintf("local palette = {%s}", strings.TrimSuffix(pal.String(), ", "))
+	}
+
+	matrixCode := `local matrix = nil
+local matrixSize = 0`
+	if m, ok := bayerMatrixLua(opts.Dither); ok {
+		matrixCode = m
+	}
+
+	var sb strings.Builder
+	sb.WriteString(GenerateSelectionHelper())
+	sb.WriteString(fmt.Sprintf(`
+local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+-- Find layer by name
+local layer = nil
+for i, lyr in ipairs(spr.layers) do
+	if lyr.name == "%s" then
+		layer = lyr
+		break
+	end
+end
+
+if not layer then
+	error("Layer not found: %s")
+end
+
+local frame = spr.frames[%d]
+if not frame then
+	error("Frame not found: %d")
+end
+
+local gradientType = "%s"
+local x1, y1, x2, y2 = %d, %d, %d, %d
+local stops = {
+%s}
+local clip = "%s"
+local regionX, regionY, regionW, regionH = %d, %d, %d, %d
+local quantize = %t
+%s
+
+-- Dithering matrix
+%s
+`,
+		escapedName, escapedName,
+		frameNumber, frameNumber,
+		opts.Type,
+		opts.Start.X, opts.Start.Y, opts.End.X, opts.End.Y,
+		stops.String(),
+		opts.Clip,
+		opts.Region.X, opts.Region.Y, opts.Region.Width, opts.Region.Height,
+		opts.UsePalette || len(opts.Palette) > 0,
+		paletteCode,
+		matrixCode))
+
+	sb.WriteString(`
+-- Restore selection from persisted state if needed
+if clip == "selection" then
+	restoreSelection(spr)
+	if spr.selection.isEmpty then
+		error("No active selection to clip the gradient to")
+	end
+end
+
+-- Quantize to the given palette, or to the sprite palette
+if quantize and not palette then
+	palette = {}
+	local pal = spr.palettes[1]
+	for i = 0, #pal - 1 do
+		local c = pal:getColor(i)
+		palette[#palette + 1] = {c.red, c.green, c.blue}
+	end
+end
+
+local function quantizeColor(r, g, b)
+	local best, bestDist = nil, math.huge
+	for _, c in ipairs(palette) do
+		local dr, dg, db = r - c[1], g - c[2], b - c[3]
+		local dist = dr*dr + dg*dg + db*db
+		if dist < bestDist then
+			best, bestDist = c, dist
+		end
+	end
+	if not best then
+		return r, g, b
+	end
+	return best[1], best[2], best[3]
+end
+
+-- Helper: Find nearest sprite palette index for given RGBA color
+local function findNearestPaletteIndex(r, g, b, a)
+	if a == 0 then
+		return spr.transparentColor
+	end
+	local pal = spr.palettes[1]
+	local minDist = math.huge
+	local nearestIndex = 0
+	for i = 0, #pal - 1 do
+		local c = pal:getColor(i)
+		local dr, dg, db, da = r - c.red, g - c.green, b - c.blue, a - c.alpha
+		local dist = dr*dr + dg*dg + db*db + da*da
+		if dist < minDist then
+			minDist = dist
+			nearestIndex = i
+		end
+	end
+	return nearestIndex
+end
+
+-- Pixel value for a color in the sprite color mode (cached per color)
+local pixelCache = {}
+local function pixelValue(r, g, b, a)
+	local key = ((r * 256 + g) * 256 + b) * 256 + a
+	local value = pixelCache[key]
+	if value then
+		return value
+	end
+	if quantize then
+		r, g, b = quantizeColor(r, g, b)
+	end
+	if spr.colorMode == ColorMode.INDEXED then
+		value = findNearestPaletteIndex(r, g, b, a)
+	elseif spr.colorMode == ColorMode.GRAY then
+		value = app.pixelColor.graya(math.floor(0.299*r + 0.587*g + 0.114*b + 0.5), a)
+	else
+		value = app.pixelColor.rgba(r, g, b, a)
+	end
+	pixelCache[key] = value
+	return value
+end
+
+-- Alpha of an existing pixel
+local function pixelAlpha(value)
+	if spr.colorMode == ColorMode.INDEXED then
+		return value == spr.transparentColor and 0 or 255
+	elseif spr.colorMode == ColorMode.GRAY then
+		return app.pixelColor.grayaA(value)
+	end
+	return app.pixelColor.rgbaA(value)
+end
+
+-- Position along the gradient (0..1) for a pixel
+local dx, dy = x2 - x1, y2 - y1
+local length = math.sqrt(dx*dx + dy*dy)
+local startAngle = math.atan(dy, dx)
+local function gradientAt(px, py)
+	local vx, vy = px - x1, py - y1
+	local t
+	if gradientType == "linear" then
+		t = (vx*dx + vy*dy) / (length*length)
+	elseif gradientType == "radial" then
+		t = math.sqrt(vx*vx + vy*vy) / length
+	elseif gradientType == "diamond" then
+		t = (math.abs(vx) + math.abs(vy)) / length
+	elseif vx == 0 and vy == 0 then
+		t = 0
+	else
+		t = ((math.atan(vy, vx) - startAngle) / (2 * math.pi)) % 1
+	end
+	return math.max(0, math.min(1, t))
+end
+
+-- Adjacent stops around t and the blend fraction between them
+local function segmentAt(t)
+	if t <= stops[1].pos then
+		return stops[1], stops[1], 0
+	end
+	for i = 1, #stops - 1 do
+		local a, b = stops[i], stops[i + 1]
+		if t <= b.pos then
+			if b.pos <= a.pos then
+				return b, b, 0
+			end
+			return a, b, (t - a.pos) / (b.pos - a.pos)
+		end
+	end
+	return stops[#stops], stops[#stops], 0
+end
+
+-- Work on a canvas-sized copy of the cel so the whole region can be painted
+local cel = layer:cel(frame)
+local img = Image(spr.width, spr.height, spr.colorMode)
+if spr.colorMode == ColorMode.INDEXED then
+	img:clear(spr.transparentColor)
+end
+if cel then
+	img:drawImage(cel.image, cel.position)
+end
+
+if regionW <= 0 or regionH <= 0 then
+	regionX, regionY, regionW, regionH = 0, 0, spr.width, spr.height
+end
+
+local left = math.max(regionX, 0)
+local top = math.max(regionY, 0)
+local right = math.min(regionX + regionW, spr.width) - 1
+local bottom = math.min(regionY + regionH, spr.height) - 1
+
+local painted = 0
+app.transaction(function()
+	for py = top, bottom do
+		for px = left, right do
+			local alpha = 255
+			local inside = true
+			if clip == "selection" then
+				inside = spr.selection:contains(px, py)
+			elseif clip == "opaque" then
+				alpha = pixelAlpha(img:getPixel(px, py))
+				inside = alpha > 0
+			end
+
+			if inside then
+				local a, b, f = segmentAt(gradientAt(px, py))
+				local r, g, bl, al
+				if matrix then
+					local threshold = (matrix[(py % matrixSize) + 1][(px % matrixSize) + 1] + 0.5) / (matrixSize * matrixSize)
+					local c = f > threshold and b or a
+					r, g, bl, al = c.r, c.g, c.b, c.a
+				else
+					r = math.floor(a.r + (b.r - a.r) * f + 0.5)
+					g = math.floor(a.g + (b.g - a.g) * f + 0.5)
+					bl = math.floor(a.b + (b.b - a.b) * f + 0.5)
+					al = math.floor(a.a + (b.a - a.a) * f + 0.5)
+				end
+				if alpha < 255 then
+					al = math.floor(al * alpha / 255 + 0.5)
+				end
+
+				img:drawPixel(px, py, pixelValue(r, g, bl, al))
+				painted = painted + 1
+			end
+		end
+	end
+
+	if cel then
+		cel.image = img
+		cel.position = Point(0, 0)
+	else
+		spr:newCel(layer, frame, img, Point(0, 0))
+	end
+end)
+
+spr:saveAs(spr.filename)
+print(string.format('{"pixels_painted":%d}', painted))`)
+
+	return sb.String()
+}
@@ -32,33 +32,7 @@ func (g *LuaGenerator) SetPalette(colors []string) string {
 		return `error("No colors provided for palette")`
 	}
 
-	// Build palette color list
-	colorList := "{\n"
-	for i, hexColor := range colors {
-		// Parse hex color #RRGGBB
-		hexColor = strings.TrimPrefix(hexColor, "#")
-		if len(hexColor) != 6 && len(hexColor) != 8 {
-			continue
-		}
-
-		var r, g, b, a int
-		// Parse hex color components (errors ignored as format is validated above)
-		_, _ = fmt.Sscanf(hexColor[:2], "%x", &r)
-		_, _ = fmt.Sscanf(hexColor[2:4], "%x", &g)
-		_, _ = fmt.Sscanf(hexColor[4:6], "%x", &b)
-		if len(hexColor) == 8 {
-			_, _ = fmt.Sscanf(hexColor[6:8], "%x", &a)
-		} else {
-			a = 255
-		}
-
-		colorList += fmt.Sprintf("\t\tColor{r=%d, g=%d, b=%d, a=%d}", r, g, b, a)
-		if i < len(colors)-1 {
-			colorList += ","
-		}
-		colorList += "\n"
-	}
-	colorList += "\t}"
+	colorList := luaPaletteColorList(colors)
 
 	return fmt.Sprintf(`local spr = app.activeSprite
 if not spr then
@@ -85,6 +59,59 @@ spr:saveAs(spr.filename)
 print("Palette set successfully")`, len(colors), colorList)
 }
 
+// SetFramePalette generates a Lua script to set the palette used from a given frame on.
+//
+// Aseprite stores palette changes per frame: a palette set on a frame applies to
+// that frame and all following frames until the next palette change. This is
+// how palette cycling animations store one rotated palette per frame.
+//
+// Parameters:
+//   - frameNumber: frame the palette starts at (1-based)
+//   - colors: slice of hex color strings in #RRGGBB or #RRGGBBAA format
+//
+// The colors are parsed like SetPalette. The operation is wrapped in a
+// transaction and the sprite is saved after the palette is set.
+//
+// Prints "Frame palette set successfully" on success.
+// Returns an error if:
+//   - No sprite is active
+//   - The frame does not exist
+//   - No colors are provided
+func (g *LuaGenerator) SetFramePalette(frameNumber int, colors []string) string {
+	if len(colors) == 0 {
+		return `error("No colors provided for palette")`
+	}
+
+	colorList := luaPaletteColorList(colors)
+
+	return fmt.Sprintf(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+local frame = spr.frames[%d]
+if not frame then
+	error("Frame not found: %d")
+end
+
+-- Build the frame palette
+local palette = Palette(%d)
+local colors = %s
+
+for i, color in ipairs(colors) do
+	palette:setColor(i - 1, color)  -- Palette is 0-indexed
+end
+
+-- setPalette applies to the active frame
+app.transaction(function()
+	app.activeFrame = frame
+	spr:setPalette(palette)
+end)
+
+spr:saveAs(spr.filename)
+print("Frame palette set successfully")`, frameNumber, frameNumber, len(colors), colorList)
+}
+
 // GetPalette generates a Lua script to retrieve the sprite's current palette.
 //
 // Extracts all colors from the sprite's palette and returns them as a JSON object.
@@ -96,7 +123,7 @@ print("Palette set successfully")`, len(colors), colorList)
 //
 // Returns an error if:
 //   - No sprite is active
-//   - No palette exists in the sprite
+//   - No palette is found
 func (g *LuaGenerator) GetPalette() string {
 	return `local spr = app.activeSprite
 if not spr then
@@ -108,7 +135,54 @@ local palette = spr.palettes[1]
 if not palette then
 	error("No palette found")
 end
+` + paletteJSONCode
+}
+
+// GetFramePalette generates a Lua script to retrieve the palette in effect at a frame.
+//
+// Sprites with per-frame palettes (see SetFramePalette) use the last palette
+// starting at or before the frame; other sprites use their only palette, like
+// GetPalette.
+//
+// Parameters:
+//   - frameNumber: frame to read the palette of (1-based)
+//
+// Returns JSON in the same format as GetPalette.
+//
+// Returns an error if:
+//   - No sprite is active
+//   - The frame does not exist
+//   - No palette is found
+func (g *LuaGenerator) GetFramePalette(frameNumber int) string {
+	return fmt.Sprintf(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+local frame = spr.frames[%d]
+if not frame then
+	error("Frame not found: %d")
+end
+
+-- Get the last palette starting at or before the frame
+local palette = nil
+local paletteStart = 0
+for i, pal in ipairs(spr.palettes) do
+	local start = pal.frame and pal.frame.frameNumber or 1
+	if start <= frame.frameNumber and start >= paletteStart then
+		palette = pal
+		paletteStart = start
+	end
+end
+if not palette then
+	error("No palette found")
+end
+`, frameNumber, frameNumber) + paletteJSONCode
+}
 
+// paletteJSONCode prints the colors of the Lua variable palette as the JSON
+// returned by GetPalette and GetFramePalette.
+const paletteJSONCode = `
 -- Extract colors as hex strings
 local colors = {}
 for i = 0, #palette - 1 do
@@ -122,7 +196,6 @@ local colorList = '["' .. table.concat(colors, '","') .. '"]'
 local output = string.format('{"colors":%s,"size":%d}', colorList, #palette)
 
 print(output)`
-}
 
 // SetPaletteColor generates a Lua script to set a specific palette color at an index.
 //
@@ -242,6 +315,75 @@ local output = string.format('{"color_index":%%d}', newIndex)
 print(output)`, red, green, blue, alpha)
 }
 
+// AppendPaletteColors generates a Lua script to append several colors to the palette.
+//
+// Like AddPaletteColor, but adds all colors in a single script, for example a
+// generated color ramp. Colors are added in order after the last palette entry.
+//
+// Parameters:
+//   - colors: colors in #RRGGBB or #RRGGBBAA format (invalid entries are skipped)
+//
+// The sprite is saved after the colors are added.
+//
+// Prints JSON with the index of the first added color and the new palette size:
+// {"start_index":N,"size":M}
+// Returns an error if:
+//   - No sprite is active
+//   - No palette exists in the sprite
+//   - The palette would exceed 256 colors
+func (g *LuaGenerator) AppendPaletteColors(colors []string) string {
+	var colorList strings.Builder
+	for _, hexColor := range colors {
+		hexColor = strings.TrimPrefix(hexColor, "#")
+		if len(hexColor) != 6 && len(hexColor) != 8 {
+			continue
+		}
+
+		var r, gr, b int
+		a := 255
+		// Parse hex color components (errors ignored as format is validated by caller)
+		_, _ = fmt.Sscanf(hexColor[:2], "%x", &r)
+		_, _ = fmt.Sscanf(hexColor[2:4], "%x", &gr)
+		_, _ = fmt.Sscanf(hexColor[4:6], "%x", &b)
+		if len(hexColor) == 8 {
+			_, _ = fmt.Sscanf(hexColor[6:8], "%x", &a)
+		}
+
+		colorList.WriteString(fmt.Sprintf("\tColor{r=%d, g=%d, b=%d, a=%d},\n", r, gr, b, a))
+	}
+
+	return fmt.Sprintf(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+-- Get palette
+local palette = spr.palettes[1]
+if not palette then
+	error("No palette found")
+end
+
+local colors = {
+%s}
+
+if #palette + #colors > 256 then
+	error(string.format("Palette would exceed maximum size (256 colors): %%d + %%d", #palette, #colors))
+end
+
+-- Append colors after the last entry
+local startIndex = #palette
+palette:resize(#palette + #colors)
+for i, color in ipairs(colors) do
+	palette:setColor(startIndex + i - 1, color)
+end
+
+spr:saveAs(spr.filename)
+
+-- Output JSON with start_index and size
+local output = string.format('{"start_index":%%d,"size":%%d}', startIndex, #palette)
+print(output)`, colorList.String())
+}
+
 // SortPalette generates a Lua script to sort the palette by a specified method.
 //
 // Reorders palette colors based on HSL color space properties. This is useful
@@ -631,3 +773,182 @@ func parseHexColor(hexColor string) Color {
 
 	return Color{R: uint8(r), G: uint8(g), B: uint8(b), A: uint8(a)}
 }
+
+// luaPaletteColorList formats hex colors as a Lua table of Color values for
+// SetPalette and SetFramePalette. Invalid colors are skipped.
+func luaPaletteColorList(colors []string) string {
+	colorList := "{\n"
+	for i, hexColor := range colors {
+		// Parse hex color #RRGGBB
+		hexColor = strings.TrimPrefix(hexColor, "#")
+		if len(hexColor) != 6 && len(hexColor) != 8 {
+			continue
+		}
+
+		var r, g, b, a int
+		// Parse hex color components (errors ignored as format is validated above)
+		_, _ = fmt.Sscanf(hexColor[:2], "%x", &r)
+		_, _ = fmt.Sscanf(hexColor[2:4], "%x", &g)
+		_, _ = fmt.Sscanf(hexColor[4:6], "%x", &b)
+		if len(hexColor) == 8 {
+			_, _ = fmt.Sscanf(hexColor[6:8], "%x", &a)
+		} else {
+			a = 255
+		}
+
+		colorList += fmt.Sprintf("\t\tColor{r=%d, g=%d, b=%d, a=%d}", r, g, b, a)
+		if i < len(colors)-1 {
+			colorList += ","
+		}
+		colorList += "\n"
+	}
+	colorList += "\t}"
+
+	return colorList
+}
+
+// GetPaletteUsage generates a Lua script that counts how many pixels use each
+// palette index of an indexed sprite.
+//
+// All cels of all layers and frames are scanned; linked cels are counted once.
+// Pixels outside cel bounds are not counted.
+//
+// Prints JSON: {"colors":["#RRGGBBAA",...],"counts":[N,...],"transparent_index":N}
+// Returns an error if no sprite is active, the sprite is not indexed, or the
+// sprite has per-frame palettes (such as a palette cycle), whose frames would
+// not follow a remap of the first palette.
+func (g *LuaGenerator) GetPaletteUsage() string {
+	return `local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+if spr.colorMode ~= ColorMode.INDEXED then
+	error("Sprite is not in indexed color mode")
+end
+
+if #spr.palettes > 1 then
+	error("Sprite has per-frame palettes (" .. #spr.palettes .. "); only single-palette sprites are supported")
+end
+
+local palette = spr.palettes[1]
+local counts = {}
+for i = 0, #palette - 1 do
+	counts[i] = 0
+end
+
+local seen = {}
+for _, cel in ipairs(spr.cels) do
+	if cel.layer.isImage and not cel.layer.isTilemap and not seen[cel.image.id] then
+		seen[cel.image.id] = true
+		for it in cel.image:pixels() do
+			local v = it()
+			if counts[v] then
+				counts[v] = counts[v] + 1
+			end
+		end
+	end
+end
+
+local colorList = {}
+local countList = {}
+for i = 0, #palette - 1 do
+	local c = palette:getColor(i)
+	table.insert(colorList, string.format('"#%02X%02X%02X%02X"', c.red, c.green, c.blue, c.alpha))
+	table.insert(countList, tostring(counts[i]))
+end
+
+print('{"colors":[' .. table.concat(colorList, ",") .. '],"counts":[' .. table.concat(countList, ",") ..
+	'],"transparent_index":' .. spr.transparentColor .. '}')`
+}
+
+// RemapPaletteIndices generates a Lua script that rewrites an indexed sprite's
+// pixels and palette in one step.
+//
+// Every pixel index i in all cels becomes mapping[i] (entries of -1, and
+// indices outside the mapping, are left unchanged), then the palette is
+// replaced with colors and the sprite's transparent index is set to
+// transparentIndex (ignored when negative). Linked cels are remapped once.
+//
+// The script refuses to run if the palette size no longer matches the
+// mapping, which means the palette changed since the mapping was computed.
+// The operation is wrapped in a transaction and the sprite is saved.
+//
+// Prints JSON: {"palette_size":N,"pixels_changed":N}
+// Returns an error if no sprite is active, the sprite is not indexed, the
+// sprite has per-frame palettes, or the palette size does not match.
+func (g *LuaGenerator) RemapPaletteIndices(mapping []int, colors []string, transparentIndex int) string {
+	if len(colors) == 0 {
+		return `error("No colors provided for palette")`
+	}
+
+	var entries []string
+	for i, to := range mapping {
+		if to >= 0 {
+			entries = append(entries, fmt.Sprintf("[%d]=%d", i, to))
+		}
+	}
+
+	transparent := ""
+	if transparentIndex >= 0 {
+		transparent = fmt.Sprintf("\n\tspr.transparentColor = %d", transparentIndex)
+	}
+
+	return fmt.Sprintf(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+if spr.colorMode ~= ColorMode.INDEXED then
+	error("Sprite is not in indexed color mode")
+end
+
+if #spr.palettes > 1 then
+	error("Sprite has per-frame palettes (" .. #spr.palettes .. "); only single-palette sprites are supported")
+end
+
+local palette = spr.palettes[1]
+if #palette ~= %d then
+	error(string.format("Palette has %%d colors, expected %d; it changed since it was analyzed", #palette))
+end
+
+local map = {%s}
+local colors = %s
+
+local pixelsChanged = 0
+app.transaction(function()
+	local seen = {}
+	for _, cel in ipairs(spr.cels) do
+		if cel.layer.isImage and not cel.layer.isTilemap and not seen[cel.image.id] then
+			seen[cel.image.id] = true
+
+			local img = cel.image:clone()
+			local changed = 0
+			for it in img:pixels() do
+				local v = it()
+				local nv = map[v]
+				if nv and nv ~= v then
+					it(nv)
+					changed = changed + 1
+				end
+			end
+
+			if changed > 0 then
+				cel.image = img
+				-- Linked cels now share the new image
+				seen[cel.image.id] = true
+				pixelsChanged = pixelsChanged + changed
+			end
+		end
+	end
+
+	palette:resize(#colors)
+	for i, color in ipairs(colors) do
+		palette:setColor(i - 1, color)  -- Palette is 0-indexed
+	end%s
+end)
+
+spr:saveAs(spr.filename)
+print(string.format('{"palette_size":%%d,"pixels_changed":%%d}', #palette, pixelsChanged))`,
+		len(mapping), len(mapping), strings.Join(entries, ", "), luaPaletteColorList(colors), transparent)
+}
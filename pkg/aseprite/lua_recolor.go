@@ -0,0 +1,374 @@
+package aseprite
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ColorRemap maps one color or palette index to another for RemapColors.
+//
+// From and To are hex colors. When From is empty, FromIndex selects the palette
+// entry to replace; when To is empty, ToIndex selects the replacement entry.
+// A 6-digit To color keeps each pixel's alpha, an 8-digit one replaces it.
+type ColorRemap struct {
+	From      string
+	FromIndex int
+	To        string
+	ToIndex   int
+}
+
+// RemapColors generates a Lua script that replaces colors in cels.
+//
+// All mappings are applied at once, so swapping two colors works. Cels are
+// filtered by layer name and frame number; nil filters select everything.
+// Linked cels are only remapped once.
+//
+// In RGB mode pixels are matched by RGB value (fully transparent pixels are
+// skipped) and palette indices refer to the sprite palette colors. In indexed
+// mode pixels are remapped by index; a From color matches every palette entry
+// with that RGB value, and a To color missing from the palette is appended.
+// Pixels with the transparent index are left alone except on background
+// layers, where that index is an opaque color.
+//
+// The sprite is saved after remapping.
+//
+// Prints JSON: {"pixels_changed":N,"cels_processed":N,"color_mode":"rgb","palette_size":N}
+// Returns an error if:
+//   - No sprite is active or the sprite is in grayscale mode
+//   - A named layer does not exist
+//   - A palette index is out of range or the palette is full
+func (g *LuaGenerator) RemapColors(mappings []ColorRemap, layers []string, frames []int) string {
+	var sb strings.Builder
+
+	sb.WriteString(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+if spr.colorMode == ColorMode.GRAYSCALE then
+	error("Grayscale sprites are not supported")
+end
+
+local palette = spr.palettes[1]
+local indexed = spr.colorMode == ColorMode.INDEXED
+local pc = app.pixelColor
+
+`)
+	sb.WriteString(luaLayerFilter(layers))
+	sb.WriteString(luaFrameFilter(frames))
+
+	sb.WriteString("\nlocal mappings = {\n")
+	for _, m := range mappings {
+		sb.WriteString(fmt.Sprintf("\t{from=%s, fromIndex=%d, to=%s, toIndex=%d},\n",
+			luaColorTable(m.From), m.FromIndex, luaColorTable(m.To), m.ToIndex))
+	}
+	sb.WriteString("}\n")
+
+	sb.WriteString(`
+local function checkIndex(i)
+	if i < 0 or i >= #palette then
+		error(string.format("Palette index out of range: %d (palette has %d colors)", i, #palette))
+	end
+end
+
+local function rgbKey(r, g, b)
+	return r + g * 256 + b * 65536
+end
+
+-- Build lookup table from original pixel value (RGB key or index) to new value
+local lut = {}
+for _, m in ipairs(mappings) do
+	if indexed then
+		local toIndex = m.toIndex
+		if m.to then
+			toIndex = nil
+			for i = 0, #palette - 1 do
+				local c = palette:getColor(i)
+				if c.red == m.to[1] and c.green == m.to[2] and c.blue == m.to[3] and c.alpha == (m.to[4] or 255) then
+					toIndex = i
+					break
+				end
+			end
+			if not toIndex then
+				if #palette >= 256 then
+					error("Palette is full; cannot add replacement color")
+				end
+				toIndex = #palette
+				palette:resize(#palette + 1)
+				palette:setColor(toIndex, Color{r=m.to[1], g=m.to[2], b=m.to[3], a=m.to[4] or 255})
+			end
+		else
+			checkIndex(toIndex)
+		end
+
+		if m.from then
+			for i = 0, #palette - 1 do
+				local c = palette:getColor(i)
+				if c.red == m.from[1] and c.green == m.from[2] and c.blue == m.from[3] then
+					lut[i] = toIndex
+				end
+			end
+		else
+			checkIndex(m.fromIndex)
+			lut[m.fromIndex] = toIndex
+		end
+	else
+		local from = m.from
+		if not from then
+			checkIndex(m.fromIndex)
+			local c = palette:getColor(m.fromIndex)
+			from = {c.red, c.green, c.blue}
+		end
+		local to = m.to
+		if not to then
+			checkIndex(m.toIndex)
+			local c = palette:getColor(m.toIndex)
+			to = {c.red, c.green, c.blue}
+		end
+		lut[rgbKey(from[1], from[2], from[3])] = to
+	end
+end
+
+local pixelsChanged = 0
+local celsProcessed = 0
+local seen = {}
+
+app.transaction(function()
+	for _, cel in ipairs(spr.cels) do
+		local layer = cel.layer
+		if layer.isImage and not layer.isTilemap
+			and (not layerFilter or layerFilter[layer.name])
+			and (not frameFilter or frameFilter[cel.frameNumber])
+			and not seen[cel.image.id] then
+			seen[cel.image.id] = true
+
+			-- Background layers have no transparency, so every index is a color
+			local transparentIndex = spr.transparentColor
+			if layer.isBackground then
+				transparentIndex = -1
+			end
+
+			local img = cel.image:clone()
+			local changed = 0
+			for it in img:pixels() do
+				local v = it()
+				if indexed then
+					local to = lut[v]
+					if to and to ~= v and v ~= transparentIndex then
+						it(to)
+						changed = changed + 1
+					end
+				elseif pc.rgbaA(v) > 0 then
+					local to = lut[rgbKey(pc.rgbaR(v), pc.rgbaG(v), pc.rgbaB(v))]
+					if to then
+						local nv = pc.rgba(to[1], to[2], to[3], to[4] or pc.rgbaA(v))
+						if nv ~= v then
+							it(nv)
+							changed = changed + 1
+						end
+					end
+				end
+			end
+
+			if changed > 0 then
+				cel.image = img
+				-- Linked cels now share the new image
+				seen[cel.image.id] = true
+			end
+			pixelsChanged = pixelsChanged + changed
+			celsProcessed = celsProcessed + 1
+		end
+	end
+end)
+
+spr:saveAs(spr.filename)
+
+local output = string.format('{"pixels_changed":%d,"cels_processed":%d,"color_mode":"%s","palette_size":%d}',
+	pixelsChanged, celsProcessed, indexed and "indexed" or "rgb", #palette)
+print(output)`)
+
+	return sb.String()
+}
+
+// GetUsedColors generates a Lua script that lists the distinct colors used in cels.
+//
+// Cels are filtered by layer name and frame number; nil filters select
+// everything. Fully transparent pixels and the transparent index of indexed
+// sprites are ignored. Colors are reported as #RRGGBB in order of first use.
+//
+// Prints JSON: {"colors":["#RRGGBB",...],"counts":[N,...]}
+// Returns an error if no sprite is active or a named layer does not exist.
+func (g *LuaGenerator) GetUsedColors(layers []string, frames []int) string {
+	var sb strings.Builder
+
+	sb.WriteString(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+local palette = spr.palettes[1]
+local pc = app.pixelColor
+local mode = spr.colorMode
+
+`)
+	sb.WriteString(luaLayerFilter(layers))
+	sb.WriteString(luaFrameFilter(frames))
+
+	sb.WriteString(`
+local order = {}
+local counts = {}
+local seen = {}
+
+local function add(hex)
+	if not counts[hex] then
+		counts[hex] = 0
+		table.insert(order, hex)
+	end
+	counts[hex] = counts[hex] + 1
+end
+
+for _, cel in ipairs(spr.cels) do
+	local layer = cel.layer
+	if layer.isImage and not layer.isTilemap
+		and (not layerFilter or layerFilter[layer.name])
+		and (not frameFilter or frameFilter[cel.frameNumber])
+		and not seen[cel.image.id] then
+		seen[cel.image.id] = true
+
+		for it in cel.image:pixels() do
+			local v = it()
+			if mode == ColorMode.INDEXED then
+				if v ~= spr.transparentColor and v < #palette then
+					local c = palette:getColor(v)
+					if c.alpha > 0 then
+						add(string.format("#%02X%02X%02X", c.red, c.green, c.blue))
+					end
+				end
+			elseif mode == ColorMode.GRAYSCALE then
+				if pc.grayaA(v) > 0 then
+					local gv = pc.grayaV(v)
+					add(string.format("#%02X%02X%02X", gv, gv, gv))
+				end
+			elseif pc.rgbaA(v) > 0 then
+				add(string.format("#%02X%02X%02X", pc.rgbaR(v), pc.rgbaG(v), pc.rgbaB(v)))
+			end
+		end
+	end
+end
+
+local colorList = {}
+local countList = {}
+for _, hex in ipairs(order) do
+	table.insert(colorList, '"' .. hex .. '"')
+	table.insert(countList, tostring(counts[hex]))
+end
+
+print('{"colors":[' .. table.concat(colorList, ",") .. '],"counts":[' .. table.concat(countList, ",") .. ']}')`)
+
+	return sb.String()
+}
+
+// DuplicateLayer generates a Lua script that duplicates a layer with all its cels.
+//
+// The copy is placed directly above the source layer and given newName.
+// The sprite is saved after the layer is duplicated.
+//
+// Prints "Layer duplicated successfully" on success.
+// Returns an error if no sprite is active or the source layer does not exist.
+func (g *LuaGenerator) DuplicateLayer(layerName, newName string) string {
+	escapedName := EscapeString(layerName)
+	escapedNew := EscapeString(newName)
+
+	return fmt.Sprintf(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+-- Find layer by name
+local layer = nil
+for i, lyr in ipairs(spr.layers) do
+	if lyr.name == "%s" then
+		layer = lyr
+		break
+	end
+end
+
+if not layer then
+	error("Layer not found: %s")
+end
+
+app.transaction(function()
+	app.activeLayer = layer
+	app.command.DuplicateLayer()
+	app.activeLayer.name = "%s"
+end)
+
+spr:saveAs(spr.filename)
+print("Layer duplicated successfully")`, escapedName, escapedName, escapedNew)
+}
+
+// luaLayerFilter returns Lua code defining layerFilter, a set of layer names,
+// or nil when layers is empty. The generated code errors on unknown layers.
+func luaLayerFilter(layers []string) string {
+	if len(layers) == 0 {
+		return "local layerFilter = nil\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("local layerFilter = {\n")
+	for _, name := range layers {
+		sb.WriteString(fmt.Sprintf("\t[\"%s\"] = true,\n", EscapeString(name)))
+	}
+	sb.WriteString(`}
+
+-- Verify that every requested layer exists (including layers inside groups)
+local function collectLayerNames(layers, names)
+	for _, lyr in ipairs(layers) do
+		names[lyr.name] = true
+		if lyr.isGroup then
+			collectLayerNames(lyr.layers, names)
+		end
+	end
+	return names
+end
+local layerNames = collectLayerNames(spr.layers, {})
+for name in pairs(layerFilter) do
+	if not layerNames[name] then
+		error("Layer not found: " .. name)
+	end
+end
+`)
+	return sb.String()
+}
+
+// luaFrameFilter returns Lua code defining frameFilter, a set of 1-based frame
+// numbers, or nil when frames is empty.
+func luaFrameFilter(frames []int) string {
+	if len(frames) == 0 {
+		return "local frameFilter = nil\n"
+	}
+
+	parts := make([]string, len(frames))
+	for i, f := range frames {
+		parts[i] = fmt.Sprintf("[%d] = true", f)
+	}
+	return fmt.Sprintf("local frameFilter = {%s}\n", strings.Join(parts, ", "))
+}
+
+// luaColorTable formats a hex color as a Lua {r, g, b[, a]} table, or "nil"
+// for an empty or invalid color. The alpha entry is only present for 8-digit
+// colors.
+func luaColorTable(hex string) string {
+	if hex == "" {
+		return "nil"
+	}
+
+	var c Color
+	if err := c.FromHex(hex); err != nil {
+		return "nil"
+	}
+	if len(strings.TrimPrefix(hex, "#")) == 8 {
+		return fmt.Sprintf("{%d, %d, %d, %d}", c.R, c.G, c.B, c.A)
+	}
+	return fmt.Sprintf("{%d, %d, %d}", c.R, c.G, c.B)
+}
@@ -2,8 +2,180 @@ package aseprite
 
 import (
 	"fmt"
+	"strings"
 )
 
+// GenerateSelectionHelper returns Lua code defining selection persistence helpers.
+//
+// Aseprite does not save the selection in the sprite file, so each tool call
+// starts without one. The helpers store the exact selection mask in a
+// "<sprite file>.selection" file next to the sprite (one "x y width" line per
+// horizontal run of selected pixels) and restore it in later calls, so
+// non-rectangular selections survive between calls without adding layers or
+// touching the sprite's user data:
+//
+//  1. restoreSelection(spr) - loads the persisted mask when spr.selection is empty
+//  2. persistSelection(spr) - saves spr.selection, removing the file when it is empty
+//  3. selectionFromPixels(width, height, originX, originY, isSelected) - builds a
+//     Selection from a per-pixel predicate
+//  4. selectionMask(spr) - rasterizes spr.selection to a canvas-sized table of
+//     booleans indexed by y * spr.width + x
+//  5. morphMask(mask, w, h, grow, diamond) - one dilation (grow) or erosion step
+//     with 8 neighbors, or 4 when diamond is true; off-canvas pixels are unselected
+//
+// Selections persisted by older versions as bounds in spr.data are still
+// restored, and that entry is cleared the next time the selection is saved.
+//
+// Include this helper at the start of scripts that read or change the selection.
+func GenerateSelectionHelper() string {
+	return `
+-- Helper: Path of the file holding the persisted selection mask
+local function selectionMaskPath(spr)
+	return spr.filename .. ".selection"
+end
+
+-- Helper: Legacy selection bounds written to spr.data by older versions
+local legacySelectionPattern = '^{"selection":{"x":(%-?%d+),"y":(%-?%d+),"w":(%d+),"h":(%d+)}}$'
+
+-- Helper: Build a selection from the pixels of a width x height area at
+-- (originX, originY) for which isSelected(x, y) returns true
+local function selectionFromPixels(width, height, originX, originY, isSelected)
+	local sel = Selection()
+	for y = 0, height - 1 do
+		local runStart = nil
+		for x = 0, width do
+			local selected = x < width and isSelected(x, y)
+			if selected and not runStart then
+				runStart = x
+			elseif not selected and runStart then
+				sel:add(Rectangle(originX + runStart, originY + y, x - runStart, 1))
+				runStart = nil
+			end
+		end
+	end
+	return sel
+end
+
+-- Helper: Rasterize spr.selection to a canvas-sized mask indexed by y * w + x
+local function selectionMask(spr)
+	local w, h = spr.width, spr.height
+	local mask = {}
+	for y = 0, h - 1 do
+		for x = 0, w - 1 do
+			mask[y * w + x] = spr.selection:contains(x, y)
+		end
+	end
+	return mask
+end
+
+-- Helper: One dilation (grow = true) or erosion (grow = false) step of a mask
+local function morphMask(mask, w, h, grow, diamond)
+	local offsets = {{1, 0}, {-1, 0}, {0, 1}, {0, -1}, {1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+	if diamond then
+		offsets = {{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+	end
+	local dst = {}
+	for y = 0, h - 1 do
+		for x = 0, w - 1 do
+			local v = mask[y * w + x]
+			if v ~= grow then
+				for _, o in ipairs(offsets) do
+					local nx, ny = x + o[1], y + o[2]
+					local n = false
+					if nx >= 0 and ny >= 0 and nx < w and ny < h then
+						n = mask[ny * w + nx]
+					end
+					if n == grow then
+						v = grow
+						break
+					end
+				end
+			end
+			dst[y * w + x] = v
+		end
+	end
+	return dst
+end
+
+-- Helper: Restore spr.selection from the persisted mask if it is empty
+local function restoreSelection(spr)
+	if not spr.selection.isEmpty then
+		return
+	end
+
+	local file = io.open(selectionMaskPath(spr), "r")
+	if not file then
+		local x, y, w, h = spr.data:match(legacySelectionPattern)
+		if x then
+			spr.selection = Selection(Rectangle(tonumber(x), tonumber(y), tonumber(w), tonumber(h)))
+		end
+		return
+	end
+
+	-- Rebuild the mask from its runs
+	local sel = Selection()
+	for line in file:lines() do
+		local x, y, w = line:match("^(%-?%d+) (%-?%d+) (%d+)$")
+		if x then
+			sel:add(Rectangle(tonumber(x), tonumber(y), tonumber(w), 1))
+		end
+	end
+	file:close()
+	spr.selection = sel
+end
+
+-- Helper: Persist spr.selection as runs in the selection mask file
+local function persistSelection(spr)
+	if spr.data:match(legacySelectionPattern) then
+		spr.data = ""
+	end
+
+	local path = selectionMaskPath(spr)
+	if spr.selection.isEmpty then
+		os.remove(path)
+		return
+	end
+
+	local file = io.open(path, "w")
+	if not file then
+		error("Failed to write selection mask: " .. path)
+	end
+	local bounds = spr.selection.bounds
+	for y = bounds.y, bounds.y + bounds.height - 1 do
+		local runStart = nil
+		for x = bounds.x, bounds.x + bounds.width do
+			local selected = x < bounds.x + bounds.width and spr.selection:contains(x, y)
+			if selected and not runStart then
+				runStart = x
+			elseif not selected and runStart then
+				file:write(string.format("%d %d %d\n", runStart, y, x - runStart))
+				runStart = nil
+			end
+		end
+	end
+	file:close()
+end
+`
+}
+
+// selectionModeCode returns Lua code that restores the persisted selection
+// and combines it with the Selection in the local variable sel according to
+// mode ("replace", "add", "subtract", or "intersect").
+func selectionModeCode(mode string) string {
+	return fmt.Sprintf(`-- Combine with the persisted selection
+restoreSelection(spr)
+if "%s" == "replace" then
+	spr.selection = sel
+elseif "%s" == "add" then
+	spr.selection:add(sel)
+elseif "%s" == "subtract" then
+	spr.selection:subtract(sel)
+elseif "%s" == "intersect" then
+	spr.selection:intersect(sel)
+end
+`, mode, mode, mode, mode)
+}
+
 // SelectRectangle generates a Lua script to create a rectangular selection.
 //
 // Creates or modifies the current selection using a rectangular region.
@@ -22,13 +194,14 @@ import (
 //   - "subtract": removes rectangle from current selection
 //   - "intersect": keeps only the intersection of current and new selection
 //
-// The selection is persisted to sprite.data as JSON and restored across operations.
-// The sprite is saved after the selection is created.
+// The selection mask is persisted next to the sprite (see GenerateSelectionHelper)
+// and restored across operations. The sprite is saved after the selection is created.
 //
 // Prints "Rectangle selection created successfully" on success.
 // Returns an error if no sprite is active.
 func (g *LuaGenerator) SelectRectangle(x, y, width, height int, mode string) string {
-	return fmt.Sprintf(`local spr = app.activeSprite
+	return GenerateSelectionHelper() + fmt.Sprintf(`
+local spr = app.activeSprite
 if not spr then
 	error("No active sprite")
 end
@@ -36,33 +209,18 @@ end
 local rect = Rectangle(%d, %d, %d, %d)
 local sel = Selection(rect)
 
-if "%s" == "replace" then
-	spr.selection = sel
-else
-	spr.selection:add(sel)
-	if "%s" == "subtract" then
-		spr.selection:subtract(sel)
-	elseif "%s" == "intersect" then
-		spr.selection:intersect(sel)
-	end
-end
-
--- Persist selection state to sprite.data for cross-process persistence
-if not spr.selection.isEmpty then
-	local bounds = spr.selection.bounds
-	spr.data = string.format('{"selection":{"x":%%d,"y":%%d,"w":%%d,"h":%%d}}',
-		bounds.x, bounds.y, bounds.width, bounds.height)
-	spr:saveAs(spr.filename)
-end
+%s
+persistSelection(spr)
+spr:saveAs(spr.filename)
 
-print("Rectangle selection created successfully")`, x, y, width, height, mode, mode, mode)
+print("Rectangle selection created successfully")`, x, y, width, height, selectionModeCode(mode))
 }
 
 // SelectEllipse generates a Lua script to create an elliptical selection.
 //
 // Creates or modifies the current selection using an elliptical region.
-// The ellipse is defined by its bounding rectangle and filled using the
-// midpoint ellipse algorithm.
+// The ellipse is defined by its bounding rectangle; every pixel whose center
+// lies inside the ellipse is selected.
 //
 // Parameters:
 //   - x, y: top-left corner of the ellipse bounding box
@@ -76,61 +234,320 @@ print("Rectangle selection created successfully")`, x, y, width, height, mode, m
 //   - "subtract": removes ellipse from current selection
 //   - "intersect": keeps only the intersection of current and new selection
 //
-// The selection is persisted to sprite.data as JSON and restored across operations.
-// The sprite is saved after the selection is created.
+// The selection mask is persisted next to the sprite (see GenerateSelectionHelper)
+// and restored across operations. The sprite is saved after the selection is created.
 //
 // Prints "Ellipse selection created successfully" on success.
 // Returns an error if no sprite is active.
 func (g *LuaGenerator) SelectEllipse(x, y, width, height int, mode string) string {
-	return fmt.Sprintf(`local spr = app.activeSprite
+	return GenerateSelectionHelper() + fmt.Sprintf(`
+local spr = app.activeSprite
 if not spr then
 	error("No active sprite")
 end
 
--- Create ellipse selection by using drawPixel for each point on ellipse
+-- Build the ellipse one row at a time from pixel centers inside it
 local sel = Selection()
 local rx = %d / 2
 local ry = %d / 2
 local cx = %d + rx
 local cy = %d + ry
 
--- Midpoint ellipse algorithm to create selection
-for angle = 0, 360 do
-	local rad = math.rad(angle)
-	local ex = math.floor(cx + rx * math.cos(rad))
-	local ey = math.floor(cy + ry * math.sin(rad))
-	-- Fill from center to edge
-	for fillx = math.floor(cx - rx), math.floor(cx + rx) do
-		for filly = math.floor(cy - ry), math.floor(cy + ry) do
-			local dx = (fillx - cx) / rx
-			local dy = (filly - cy) / ry
-			if dx * dx + dy * dy <= 1 then
-				sel:add(Rectangle(fillx, filly, 1, 1))
+for filly = math.floor(cy - ry), math.ceil(cy + ry) - 1 do
+	local runStart = nil
+	for fillx = math.floor(cx - rx), math.ceil(cx + rx) do
+		local dx = (fillx + 0.5 - cx) / rx
+		local dy = (filly + 0.5 - cy) / ry
+		local inside = dx * dx + dy * dy <= 1
+		if inside and not runStart then
+			runStart = fillx
+		elseif not inside and runStart then
+			sel:add(Rectangle(runStart, filly, fillx - runStart, 1))
+			runStart = nil
+		end
+	end
+end
+
+%s
+persistSelection(spr)
+spr:saveAs(spr.filename)
+
+print("Ellipse selection created successfully")`, width, height, x, y, selectionModeCode(mode))
+}
+
+// SelectByColor generates a Lua script to select pixels by color (magic wand).
+//
+// The reference color is the pixel at (x, y) on the given layer and frame. A
+// pixel matches when every RGBA channel differs from the reference by at most
+// tolerance; fully transparent pixels always match each other. Indexed pixels
+// are compared by their palette colors.
+//
+// Parameters:
+//   - layerName: name of the layer to sample (automatically escaped for Lua safety)
+//   - frameNumber: 1-based frame index to sample
+//   - x, y: seed pixel coordinates on the canvas
+//   - tolerance: color similarity threshold (0-255, where 0 = exact match only)
+//   - contiguous: if true, selects only matching pixels connected to the seed;
+//     if false, selects every matching pixel on the canvas
+//   - connectivity: 4 or 8 neighbors for contiguous selection
+//   - mode: selection mode - "replace" (default), "add", "subtract", or "intersect"
+//
+// The selection mask is persisted next to the sprite (see GenerateSelectionHelper)
+// and restored across operations. The sprite is saved after the selection is created.
+//
+// Prints "Color selection created successfully" on success.
+// Returns an error if:
+//   - No sprite is active
+//   - The layer is not found
+//   - The frame number is invalid
+//   - The seed pixel is outside the canvas
+func (g *LuaGenerator) SelectByColor(layerName string, frameNumber int, x, y int, tolerance int, contiguous bool, connectivity int, mode string) string {
+	escapedName := EscapeString(layerName)
+	return GenerateSelectionHelper() + fmt.Sprintf(`
+local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+-- Find layer by name
+local layer = nil
+for i, lyr in ipairs(spr.layers) do
+	if lyr.name == "%s" then
+		layer = lyr
+		break
+	end
+end
+
+if not layer then
+	error("Layer not found: %s")
+end
+
+local frame = spr.frames[%d]
+if not frame then
+	error("Frame not found: %d")
+end
+
+local seedX, seedY = %d, %d
+local tolerance = %d
+local contiguous = %t
+local connectivity = %d
+
+if seedX < 0 or seedY < 0 or seedX >= spr.width or seedY >= spr.height then
+	error("Seed pixel outside canvas: " .. seedX .. "," .. seedY)
+end
+
+-- Flatten the cel onto a canvas-sized image
+local canvas = Image(spr.width, spr.height, spr.colorMode)
+if spr.colorMode == ColorMode.INDEXED then
+	canvas:clear(spr.transparentColor)
+end
+local cel = layer:cel(frame)
+if cel then
+	canvas:drawImage(cel.image, cel.position)
+end
+
+-- Convert a pixel value to r, g, b, a
+local pc = app.pixelColor
+local pal = spr.palettes[1]
+local function toRGBA(px)
+	if spr.colorMode == ColorMode.INDEXED then
+		if px == spr.transparentColor then
+			return 0, 0, 0, 0
+		end
+		local c = pal:getColor(px)
+		return c.red, c.green, c.blue, c.alpha
+	elseif spr.colorMode == ColorMode.GRAY then
+		local v = pc.grayaV(px)
+		return v, v, v, pc.grayaA(px)
+	end
+	return pc.rgbaR(px), pc.rgbaG(px), pc.rgbaB(px), pc.rgbaA(px)
+end
+
+local refR, refG, refB, refA = toRGBA(canvas:getPixel(seedX, seedY))
+local function matches(x, y)
+	local r, g, b, a = toRGBA(canvas:getPixel(x, y))
+	if a == 0 and refA == 0 then
+		return true
+	end
+	return math.abs(r - refR) <= tolerance and math.abs(g - refG) <= tolerance and
+		math.abs(b - refB) <= tolerance and math.abs(a - refA) <= tolerance
+end
+
+local selected = {}
+if contiguous then
+	-- Flood fill from the seed
+	local offsets = {{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+	if connectivity == 8 then
+		offsets = {{1, 0}, {-1, 0}, {0, 1}, {0, -1}, {1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+	end
+	local stack = {seedX, seedY}
+	selected[seedY * spr.width + seedX] = true
+	while #stack > 0 do
+		local py = table.remove(stack)
+		local px = table.remove(stack)
+		for _, o in ipairs(offsets) do
+			local nx, ny = px + o[1], py + o[2]
+			if nx >= 0 and ny >= 0 and nx < spr.width and ny < spr.height then
+				local key = ny * spr.width + nx
+				if not selected[key] and matches(nx, ny) then
+					selected[key] = true
+					stack[#stack + 1] = nx
+					stack[#stack + 1] = ny
+				end
 			end
 		end
 	end
-	break  -- Only need one pass to fill
 end
 
-if "%s" == "replace" then
-	spr.selection = sel
-elseif "%s" == "add" then
-	spr.selection:add(sel)
-elseif "%s" == "subtract" then
-	spr.selection:subtract(sel)
-elseif "%s" == "intersect" then
-	spr.selection:intersect(sel)
+local sel = selectionFromPixels(spr.width, spr.height, 0, 0, function(x, y)
+	if contiguous then
+		return selected[y * spr.width + x] == true
+	end
+	return matches(x, y)
+end)
+
+%s
+persistSelection(spr)
+spr:saveAs(spr.filename)
+
+print("Color selection created successfully")`,
+		escapedName, escapedName,
+		frameNumber, frameNumber,
+		x, y, tolerance, contiguous, connectivity,
+		selectionModeCode(mode))
+}
+
+// SelectLayerAlpha generates a Lua script to select a layer's non-transparent pixels.
+//
+// Every pixel of the layer's cel in the given frame with a non-zero alpha (or,
+// in indexed sprites, any index other than the transparent color) is selected,
+// like Ctrl+clicking a layer in Aseprite.
+//
+// Parameters:
+//   - layerName: name of the layer to read (automatically escaped for Lua safety)
+//   - frameNumber: 1-based frame index to read
+//   - mode: selection mode - "replace" (default), "add", "subtract", or "intersect"
+//
+// The selection mask is persisted next to the sprite (see GenerateSelectionHelper)
+// and restored across operations. The sprite is saved after the selection is created.
+//
+// Prints "Layer alpha selection created successfully" on success.
+// Returns an error if:
+//   - No sprite is active
+//   - The layer is not found
+//   - The frame number is invalid
+func (g *LuaGenerator) SelectLayerAlpha(layerName string, frameNumber int, mode string) string {
+	escapedName := EscapeString(layerName)
+	return GenerateSelectionHelper() + fmt.Sprintf(`
+local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
 end
 
--- Persist selection state to sprite.data for cross-process persistence
-if not spr.selection.isEmpty then
-	local bounds = spr.selection.bounds
-	spr.data = string.format('{"selection":{"x":%%d,"y":%%d,"w":%%d,"h":%%d}}',
-		bounds.x, bounds.y, bounds.width, bounds.height)
-	spr:saveAs(spr.filename)
+-- Find layer by name
+local layer = nil
+for i, lyr in ipairs(spr.layers) do
+	if lyr.name == "%s" then
+		layer = lyr
+		break
+	end
+end
+
+if not layer then
+	error("Layer not found: %s")
+end
+
+local frame = spr.frames[%d]
+if not frame then
+	error("Frame not found: %d")
+end
+
+-- Select the cel's opaque pixels (an empty cel gives an empty selection)
+local sel = Selection()
+local cel = layer:cel(frame)
+if cel then
+	local img = cel.image
+	sel = selectionFromPixels(img.width, img.height, cel.position.x, cel.position.y, function(x, y)
+		local px = img:getPixel(x, y)
+		if img.colorMode == ColorMode.INDEXED then
+			return px ~= spr.transparentColor
+		elseif img.colorMode == ColorMode.GRAY then
+			return app.pixelColor.grayaA(px) > 0
+		end
+		return app.pixelColor.rgbaA(px) > 0
+	end)
+end
+
+%s
+persistSelection(spr)
+spr:saveAs(spr.filename)
+
+print("Layer alpha selection created successfully")`,
+		escapedName, escapedName,
+		frameNumber, frameNumber,
+		selectionModeCode(mode))
+}
+
+// SelectPolygon generates a Lua script to create a polygonal (lasso) selection.
+//
+// The polygon is implicitly closed and rasterized in Go with the same rules as
+// DrawPolygon (see RasterizePolygon), so the selection covers exactly the
+// pixels a filled polygon through the same points would paint. Pixels outside
+// the canvas are dropped.
+//
+// Parameters:
+//   - points: polygon vertices in order (at least 3)
+//   - fillRule: FillRuleEvenOdd or FillRuleNonZero
+//   - mode: selection mode - "replace" (default), "add", "subtract", or "intersect"
+//   - clip: sprite bounds; only pixels inside are rasterized, so oversized
+//     shapes cost no more than the canvas
+//
+// The selection mask is persisted next to the sprite (see GenerateSelectionHelper)
+// and restored across operations. The sprite is saved after the selection is created.
+//
+// Prints "Polygon selection created successfully" on success.
+// Returns an error if no sprite is active.
+func (g *LuaGenerator) SelectPolygon(points []Point, fillRule string, mode string, clip Rectangle) string {
+	var sb strings.Builder
+	sb.WriteString(GenerateSelectionHelper())
+	sb.WriteString(`
+local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+-- Pixel runs as {y, x1, x2, ...}
+local runs = {`)
+
+	for i, run := range pixelRuns(RasterizePolygon(points, fillRule, clip)) {
+		if i%16 == 0 {
+			sb.WriteString("\n\t")
+		} else {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(fmt.Sprintf("%d, %d, %d,", run[0], run[1], run[2]))
+	}
+
+	sb.WriteString(fmt.Sprintf(`
+}
+
+local sel = Selection()
+for i = 1, #runs, 3 do
+	local y = runs[i]
+	local x1 = math.max(runs[i + 1], 0)
+	local x2 = math.min(runs[i + 2], spr.width - 1)
+	if y >= 0 and y < spr.height and x1 <= x2 then
+		sel:add(Rectangle(x1, y, x2 - x1 + 1, 1))
+	end
 end
 
-print("Ellipse selection created successfully")`, width, height, x, y, mode, mode, mode, mode)
+%s
+persistSelection(spr)
+spr:saveAs(spr.filename)
+
+print("Polygon selection created successfully")`, selectionModeCode(mode)))
+
+	return sb.String()
 }
 
 // SelectAll generates a Lua script to select the entire canvas.
@@ -139,13 +556,14 @@ print("Ellipse selection created successfully")`, width, height, x, y, mode, mod
 // (sprite.width, sprite.height). This is useful before copy/cut operations
 // or to quickly select all content for transformations.
 //
-// The selection is persisted to sprite.data as JSON and restored across operations.
-// The sprite is saved after the selection is created.
+// The selection mask is persisted next to the sprite (see GenerateSelectionHelper)
+// and restored across operations. The sprite is saved after the selection is created.
 //
 // Prints "Select all completed successfully" on success.
 // Returns an error if no sprite is active.
 func (g *LuaGenerator) SelectAll() string {
-	return `local spr = app.activeSprite
+	return GenerateSelectionHelper() + `
+local spr = app.activeSprite
 if not spr then
 	error("No active sprite")
 end
@@ -155,10 +573,7 @@ local rect = Rectangle(0, 0, spr.width, spr.height)
 local sel = Selection(rect)
 spr.selection = sel
 
--- Persist selection state to sprite.data for cross-process persistence
-local bounds = spr.selection.bounds
-spr.data = string.format('{"selection":{"x":%%d,"y":%%d,"w":%%d,"h":%%d}}',
-	bounds.x, bounds.y, bounds.width, bounds.height)
+persistSelection(spr)
 spr:saveAs(spr.filename)
 
 print("Select all completed successfully")`
@@ -169,12 +584,13 @@ print("Select all completed successfully")`
 // Removes the current selection mask, allowing operations to affect the entire
 // canvas again. This is the opposite of SelectAll.
 //
-// The persisted selection state in sprite.data is cleared and the sprite is saved.
+// The persisted selection mask file is removed and the sprite is saved.
 //
 // Prints "Deselect completed successfully" on success.
 // Returns an error if no sprite is active.
 func (g *LuaGenerator) Deselect() string {
-	return `local spr = app.activeSprite
+	return GenerateSelectionHelper() + `
+local spr = app.activeSprite
 if not spr then
 	error("No active sprite")
 end
@@ -182,23 +598,24 @@ end
 app.command.DeselectMask()
 
 -- Clear persisted selection state
-spr.data = ""
+persistSelection(spr)
 spr:saveAs(spr.filename)
 
 print("Deselect completed successfully")`
 }
 
-// MoveSelection generates a Lua script to translate the selection bounds.
+// MoveSelection generates a Lua script to translate the selection mask.
 //
 // Shifts the selection mask by the specified offset without moving the pixel
 // content. This is useful for repositioning the selection after creating it,
-// or for aligning selections with specific features.
+// or for aligning selections with specific features. The shape of the
+// selection is preserved.
 //
 // Parameters:
 //   - dx: horizontal offset in pixels (positive = right, negative = left)
 //   - dy: vertical offset in pixels (positive = down, negative = up)
 //
-// The selection is restored from sprite.data if needed, then moved and persisted back.
+// The selection is restored from its persisted mask, then moved and persisted back.
 // The sprite is saved after the selection is moved.
 //
 // Prints "Selection moved successfully" on success.
@@ -206,68 +623,199 @@ print("Deselect completed successfully")`
 //   - No sprite is active
 //   - No selection exists to move
 func (g *LuaGenerator) MoveSelection(dx, dy int) string {
-	return fmt.Sprintf(`local spr = app.activeSprite
+	return GenerateSelectionHelper() + fmt.Sprintf(`
+local spr = app.activeSprite
 if not spr then
 	error("No active sprite")
 end
 
 -- Restore selection from persisted state if needed
-if spr.selection.isEmpty and spr.data ~= "" then
-	local x, y, w, h = spr.data:match('x":(%%d+),"y":(%%d+),"w":(%%d+),"h":(%%d+)')
-	if x and y and w and h then
-		spr.selection = Selection(Rectangle(tonumber(x), tonumber(y), tonumber(w), tonumber(h)))
-	end
-end
+restoreSelection(spr)
 
 if spr.selection.isEmpty then
 	error("No active selection to move")
 end
 
 local bounds = spr.selection.bounds
-local newSel = Selection(Rectangle(bounds.x + %d, bounds.y + %d, bounds.width, bounds.height))
+local newSel = Selection()
+newSel:add(spr.selection)
+newSel.origin = Point(bounds.x + %d, bounds.y + %d)
 spr.selection = newSel
 
 -- Persist updated selection state
-local newBounds = spr.selection.bounds
-spr.data = string.format('{"selection":{"x":%%d,"y":%%d,"w":%%d,"h":%%d}}',
-	newBounds.x, newBounds.y, newBounds.width, newBounds.height)
+persistSelection(spr)
 spr:saveAs(spr.filename)
 
 print("Selection moved successfully")`, dx, dy)
 }
 
-// CutSelection generates a Lua script to cut the selected pixels to clipboard.
+// ModifySelection generates a Lua script to refine the current selection.
+//
+// The selection is rasterized to a canvas-sized mask (parts outside the canvas
+// are dropped) and modified with one of these operations:
+//   - "expand": grows the selection by amount pixels
+//   - "contract": shrinks the selection by amount pixels; the canvas edge
+//     counts as unselected
+//   - "border": keeps a ring of width amount along the inside of the selection edge
+//   - "invert": selects every canvas pixel that was not selected
+//   - "remove_islands": drops 8-connected selected regions smaller than
+//     minIslandSize pixels
+//
+// Parameters:
+//   - operation: one of the operations above
+//   - amount: distance in pixels for expand, contract, and border
+//   - shape: distance metric for expand, contract, and border - "square"
+//     (8 neighbors per step, Chebyshev distance) or "diamond" (4 neighbors
+//     per step, Manhattan distance)
+//   - minIslandSize: minimum region size in pixels kept by remove_islands
+//
+// The selection is restored from its persisted mask, modified, and persisted
+// back. The sprite is saved after the selection is modified.
+//
+// Prints JSON: {"x": N, "y": N, "width": N, "height": N, "pixel_count": N}
+// with the bounds and size of the resulting selection (all zero when empty).
+// Returns an error if:
+//   - No sprite is active
+//   - No selection exists (except for "invert")
+func (g *LuaGenerator) ModifySelection(operation string, amount int, shape string, minIslandSize int) string {
+	return GenerateSelectionHelper() + fmt.Sprintf(`
+local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+local operation = "%s"
+local amount = %d
+local shape = "%s"
+local minIslandSize = %d
+
+-- Restore selection from persisted state if needed
+restoreSelection(spr)
+
+if spr.selection.isEmpty and operation ~= "invert" then
+	error("No active selection to modify")
+end
+
+local w, h = spr.width, spr.height
+local mask = selectionMask(spr)
+local diamond = shape == "diamond"
+local offsets = {{1, 0}, {-1, 0}, {0, 1}, {0, -1}, {1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+
+local result = {}
+if operation == "expand" or operation == "contract" then
+	result = mask
+	for i = 1, amount do
+		result = morphMask(result, w, h, operation == "expand", diamond)
+	end
+elseif operation == "border" then
+	local inner = mask
+	for i = 1, amount do
+		inner = morphMask(inner, w, h, false, diamond)
+	end
+	for i = 0, w * h - 1 do
+		result[i] = mask[i] and not inner[i]
+	end
+elseif operation == "invert" then
+	for i = 0, w * h - 1 do
+		result[i] = not mask[i]
+	end
+elseif operation == "remove_islands" then
+	-- Label 8-connected regions and keep those with at least minIslandSize pixels
+	local visited = {}
+	for i = 0, w * h - 1 do
+		result[i] = false
+	end
+	for start = 0, w * h - 1 do
+		if mask[start] and not visited[start] then
+			local region = {start}
+			visited[start] = true
+			local head = 1
+			while head <= #region do
+				local i = region[head]
+				head = head + 1
+				local x, y = i %% w, math.floor(i / w)
+				for _, o in ipairs(offsets) do
+					local nx, ny = x + o[1], y + o[2]
+					if nx >= 0 and ny >= 0 and nx < w and ny < h then
+						local j = ny * w + nx
+						if mask[j] and not visited[j] then
+							visited[j] = true
+							region[#region + 1] = j
+						end
+					end
+				end
+			end
+			if #region >= minIslandSize then
+				for _, i in ipairs(region) do
+					result[i] = true
+				end
+			end
+		end
+	end
+else
+	error("Unknown selection operation: " .. operation)
+end
+
+spr.selection = selectionFromPixels(w, h, 0, 0, function(x, y)
+	return result[y * w + x]
+end)
+
+-- Persist updated selection state
+persistSelection(spr)
+spr:saveAs(spr.filename)
+
+-- Report resulting bounds and size
+local count = 0
+for i = 0, w * h - 1 do
+	if result[i] then
+		count = count + 1
+	end
+end
+local bounds = Rectangle(0, 0, 0, 0)
+if not spr.selection.isEmpty then
+	bounds = spr.selection.bounds
+end
+print(string.format('{"x":%%d,"y":%%d,"width":%%d,"height":%%d,"pixel_count":%%d}',
+	bounds.x, bounds.y, bounds.width, bounds.height, count))`, operation, amount, shape, minIslandSize)
+}
+
+// CutSelection generates a Lua script to cut the selected pixels to a PNG file.
 //
-// Removes the pixels within the current selection and copies them to the
-// clipboard. The cut area becomes transparent (filled with transparent pixels).
+// Removes the pixels within the current selection and saves them, cropped to
+// the selection bounds, as a PNG for the server-side clipboard. The cut area
+// becomes transparent (filled with transparent pixels). Only selected pixels
+// are kept, so non-rectangular selections cut their exact shape.
 //
 // Parameters:
 //   - layerName: name of the layer to cut from (automatically escaped for Lua safety)
 //   - frameNumber: 1-based frame index to cut from
+//   - outputPath: absolute path for the PNG file (automatically escaped for Lua safety)
 //
 // The operation is wrapped in a transaction for atomicity and the sprite
-// is saved after the cut is complete.
+// is saved after the cut is complete. The selection is cleared afterwards,
+// and any hidden __mcp_clipboard__ layer left by older versions is removed.
 //
-// Prints "Cut selection completed successfully" on success.
+// Prints "Cut selection completed successfully" followed by JSON:
+// {"x": N, "y": N, "width": N, "height": N, "pixel_count": N,
+// "color_mode": "rgb|grayscale|indexed", "palette": ["#RRGGBB", ...]} with
+// the bounds of the cut area, the number of selected pixels, and the source
+// color mode and palette.
 // Returns an error if:
 //   - No sprite is active
 //   - No selection exists
 //   - The layer is not found
 //   - The frame number is invalid
-func (g *LuaGenerator) CutSelection(layerName string, frameNumber int) string {
+func (g *LuaGenerator) CutSelection(layerName string, frameNumber int, outputPath string) string {
 	escapedName := EscapeString(layerName)
-	return fmt.Sprintf(`local spr = app.activeSprite
+	escapedPath := EscapeString(outputPath)
+	return GenerateSelectionHelper() + fmt.Sprintf(`
+local spr = app.activeSprite
 if not spr then
 	error("No active sprite")
 end
 
 -- Restore selection from persisted state if needed
-if spr.selection.isEmpty and spr.data ~= "" then
-	local x, y, w, h = spr.data:match('x":(%%d+),"y":(%%d+),"w":(%%d+),"h":(%%d+)')
-	if x and y and w and h then
-		spr.selection = Selection(Rectangle(tonumber(x), tonumber(y), tonumber(w), tonumber(h)))
-	end
-end
+restoreSelection(spr)
 
 if spr.selection.isEmpty then
 	error("No active selection to cut")
@@ -291,175 +839,237 @@ if not frame then
 	error("Frame not found: %d")
 end
 
--- Find or create hidden clipboard layer before cutting
-local clipboardLayer = nil
-for i, lyr in ipairs(spr.layers) do
-	if lyr.name == "__mcp_clipboard__" then
-		clipboardLayer = lyr
-		break
-	end
-end
-
-if not clipboardLayer then
-	clipboardLayer = spr:newLayer()
-	clipboardLayer.name = "__mcp_clipboard__"
-	clipboardLayer.isVisible = false
-end
-
--- Copy selected region to clipboard layer first
-local cel = layer:cel(frame)
-if cel then
-	local bounds = spr.selection.bounds
-	local clipImage = Image(bounds.width, bounds.height, spr.colorMode)
-	clipImage:drawImage(cel.image, Point(-bounds.x, -bounds.y))
-
-	-- Store in clipboard layer
-	spr:newCel(clipboardLayer, 1, clipImage, Point(bounds.x, bounds.y))
-end
+%s
+local bounds = spr.selection.bounds
+local count = exportSelectedPixels(spr, layer, frame, "%s")
+local source = clipboardSourceJSON(spr)
 
 -- Now cut from the source layer
 app.transaction(function()
 	local cel = layer:cel(frame)
 	if cel then
-		local bounds = spr.selection.bounds
+		local transparent = 0
+		if spr.colorMode == ColorMode.INDEXED then
+			transparent = spr.transparentColor
+		end
 		-- Clear pixels in selection
 		for y = bounds.y, bounds.y + bounds.height - 1 do
 			for x = bounds.x, bounds.x + bounds.width - 1 do
 				if spr.selection:contains(x, y) then
-					cel.image:drawPixel(x - cel.position.x, y - cel.position.y, Color{r=0,g=0,b=0,a=0})
+					cel.image:drawPixel(x - cel.position.x, y - cel.position.y, transparent)
 				end
 			end
 		end
 	end
+	removeLegacyClipboard(spr)
 end)
 
 -- Selection cleared after cut, clear persisted state
-spr.data = ""
+spr.selection = Selection()
+persistSelection(spr)
 spr:saveAs(spr.filename)
-print("Cut selection completed successfully")`, escapedName, escapedName, frameNumber, frameNumber)
+print("Cut selection completed successfully")
+print(string.format('{"x":%%d,"y":%%d,"width":%%d,"height":%%d,"pixel_count":%%d,%%s}',
+	bounds.x, bounds.y, bounds.width, bounds.height, count, source))`, escapedName, escapedName, frameNumber, frameNumber, clipboardExportCode, escapedPath)
 }
 
-// CopySelection generates a Lua script to copy the selected pixels to clipboard.
+// CopySelection generates a Lua script to copy the selected pixels to a PNG file.
 //
-// Copies the pixels within the current selection to the clipboard without
-// removing them. The clipboard content can then be pasted elsewhere.
+// Saves the pixels within the current selection on the given layer and frame,
+// cropped to the selection bounds, as a PNG for the server-side clipboard
+// without removing them. Only selected pixels are kept, so non-rectangular
+// selections copy their exact shape.
 //
-// The clipboard content is stored in a hidden layer (__mcp_clipboard__) which
-// persists across operations. The selection is restored from sprite.data if needed.
+// Parameters:
+//   - layerName: name of the layer to copy from (automatically escaped for Lua
+//     safety); empty uses the bottom layer
+//   - frameNumber: 1-based frame index to copy from
+//   - outputPath: absolute path for the PNG file (automatically escaped for Lua safety)
 //
-// The sprite is saved to persist the clipboard content.
+// The selection is restored from its persisted mask if needed. The sprite is
+// only saved when a hidden __mcp_clipboard__ layer left by older versions has
+// to be removed.
 //
-// Prints "Copy selection completed successfully" on success.
+// Prints "Copy selection completed successfully" followed by JSON:
+// {"x": N, "y": N, "width": N, "height": N, "pixel_count": N,
+// "color_mode": "rgb|grayscale|indexed", "palette": ["#RRGGBB", ...]} with
+// the bounds of the copied area, the number of selected pixels, and the
+// source color mode and palette.
 // Returns an error if:
 //   - No sprite is active
 //   - No selection exists
-func (g *LuaGenerator) CopySelection() string {
-	return `local spr = app.activeSprite
+//   - The layer is not found
+//   - The frame number is invalid
+func (g *LuaGenerator) CopySelection(layerName string, frameNumber int, outputPath string) string {
+	escapedName := EscapeString(layerName)
+	escapedPath := EscapeString(outputPath)
+	return GenerateSelectionHelper() + fmt.Sprintf(`
+local spr = app.activeSprite
 if not spr then
 	error("No active sprite")
 end
 
 -- Restore selection from persisted state if needed
-if spr.selection.isEmpty and spr.data ~= "" then
-	local x, y, w, h = spr.data:match('x":(%d+),"y":(%d+),"w":(%d+),"h":(%d+)')
-	if x and y and w and h then
-		spr.selection = Selection(Rectangle(tonumber(x), tonumber(y), tonumber(w), tonumber(h)))
-	end
-end
+restoreSelection(spr)
 
 if spr.selection.isEmpty then
 	error("No active selection to copy")
 end
 
--- Find or create hidden clipboard layer
-local clipboardLayer = nil
-for i, lyr in ipairs(spr.layers) do
-	if lyr.name == "__mcp_clipboard__" then
-		clipboardLayer = lyr
-		break
+-- Find layer by name (the bottom layer when no name is given)
+local layer = nil
+if "%s" == "" then
+	layer = spr.layers[1]
+else
+	for i, lyr in ipairs(spr.layers) do
+		if lyr.name == "%s" then
+			layer = lyr
+			break
+		end
 	end
 end
 
-if not clipboardLayer then
-	clipboardLayer = spr:newLayer()
-	clipboardLayer.name = "__mcp_clipboard__"
-	clipboardLayer.isVisible = false
+if not layer then
+	error("Layer not found: %s")
+end
+
+local frame = spr.frames[%d]
+if not frame then
+	error("Frame not found: %d")
+end
+
+%s
+local bounds = spr.selection.bounds
+local count = exportSelectedPixels(spr, layer, frame, "%s")
+local source = clipboardSourceJSON(spr)
+
+if removeLegacyClipboard(spr) then
+	spr:saveAs(spr.filename)
 end
+print("Copy selection completed successfully")
+print(string.format('{"x":%%d,"y":%%d,"width":%%d,"height":%%d,"pixel_count":%%d,%%s}',
+	bounds.x, bounds.y, bounds.width, bounds.height, count, source))`, escapedName, escapedName, escapedName, frameNumber, frameNumber, clipboardExportCode, escapedPath)
+}
 
--- Get the selected image from layer 1, frame 1
--- In batch mode, we need to explicitly use frame 1 since app.activeFrame/activeLayer may not be set correctly
-local sourceLayer = spr.layers[1]
-local sourceFrame = spr.frames[1]
-local sourceCel = sourceLayer:cel(sourceFrame)
+// clipboardExportCode defines the Lua helpers shared by cut and copy:
+//   - exportSelectedPixels(spr, layer, frame, path) saves the selected pixels
+//     of a cel, cropped to the selection bounds, as a PNG and returns the
+//     number of selected pixels
+//   - clipboardSourceJSON(spr) returns the "color_mode" and "palette" JSON
+//     fields describing the source sprite
+//   - removeLegacyClipboard(spr) deletes the hidden __mcp_clipboard__ layer
+//     used by older versions and reports whether one was found
+const clipboardExportCode = `-- Save the selected pixels of a cel as a PNG cropped to the selection
+local function exportSelectedPixels(spr, layer, frame, path)
+	local transparent = 0
+	if spr.colorMode == ColorMode.INDEXED then
+		transparent = spr.transparentColor
+	end
 
-if sourceCel then
-	-- Copy selected region to clipboard layer
 	local bounds = spr.selection.bounds
-	local clipImage = Image(bounds.width, bounds.height, spr.colorMode)
-	clipImage:drawImage(sourceCel.image, Point(-bounds.x, -bounds.y))
+	-- Carry the transparent index so the PNG keeps unselected pixels clear
+	local clipImage = Image(ImageSpec{
+		width = bounds.width,
+		height = bounds.height,
+		colorMode = spr.colorMode,
+		transparentColor = transparent
+	})
+	clipImage:clear(transparent)
+	local cel = layer:cel(frame)
+	if cel then
+		clipImage:drawImage(cel.image, Point(cel.position.x - bounds.x, cel.position.y - bounds.y))
+	end
 
-	-- Store in clipboard layer at frame 1
-	spr:newCel(clipboardLayer, 1, clipImage, Point(bounds.x, bounds.y))
+	-- Keep only selected pixels
+	local count = 0
+	for y = 0, bounds.height - 1 do
+		for x = 0, bounds.width - 1 do
+			if spr.selection:contains(bounds.x + x, bounds.y + y) then
+				count = count + 1
+			else
+				clipImage:drawPixel(x, y, transparent)
+			end
+		end
+	end
+
+	clipImage:saveAs{ filename = path, palette = spr.palettes[1] }
+	return count
 end
 
-spr:saveAs(spr.filename)
-print("Copy selection completed successfully")`
-}
+-- Describe the source color mode and palette as JSON fields
+local function clipboardSourceJSON(spr)
+	local colorModeStr = "rgb"
+	if spr.colorMode == ColorMode.GRAYSCALE then
+		colorModeStr = "grayscale"
+	elseif spr.colorMode == ColorMode.INDEXED then
+		colorModeStr = "indexed"
+	end
+
+	local colors = {}
+	local palette = spr.palettes[1]
+	for i = 0, #palette - 1 do
+		local color = palette:getColor(i)
+		table.insert(colors, string.format('"#%02X%02X%02X"', color.red, color.green, color.blue))
+	end
+
+	return string.format('"color_mode":"%s","palette":[%s]', colorModeStr, table.concat(colors, ","))
+end
+
+-- Remove the hidden clipboard layer left by older versions
+local function removeLegacyClipboard(spr)
+	for i, lyr in ipairs(spr.layers) do
+		if lyr.name == "__mcp_clipboard__" then
+			spr:deleteLayer(lyr)
+			return true
+		end
+	end
+	return false
+end
+`
 
-// PasteClipboard generates a Lua script to paste clipboard content.
+// PasteClipboard generates a Lua script to paste a clipboard image.
 //
-// Pastes the clipboard content (from a previous copy or cut operation) to the
-// specified layer and frame. The paste position can be explicitly set or will
-// use the current position if not specified.
+// Pastes an image previously stored by the server-side clipboard (see
+// CutSelection and CopySelection) onto the specified layer and frame with its
+// top-left corner at (x, y).
 //
 // Parameters:
 //   - layerName: name of the layer to paste to (automatically escaped for Lua safety)
 //   - frameNumber: 1-based frame index to paste to
-//   - x: optional x-coordinate for paste position (nil = current position)
-//   - y: optional y-coordinate for paste position (nil = current position)
+//   - imagePath: absolute path of the RGBA PNG to paste (automatically escaped for Lua safety)
+//   - x, y: paste position in sprite coordinates
 //
-// The clipboard content is retrieved from the hidden layer (__mcp_clipboard__).
+// In RGB sprites the image is alpha-blended onto the cel. In indexed sprites
+// each visible pixel is written as the palette index of its exact color, so
+// the image must already be remapped to the sprite palette; the transparent
+// index is only used when no other entry has that color. In grayscale sprites
+// visible pixels are converted to gray. Transparent pixels leave the target
+// unchanged, pixels outside the canvas are clipped, and any hidden
+// __mcp_clipboard__ layer left by older versions is removed.
 //
 // The operation is wrapped in a transaction for atomicity and the sprite
 // is saved after the paste is complete.
 //
-// Prints "Paste completed successfully" on success.
+// Prints "Paste completed successfully" followed by JSON:
+// {"x": N, "y": N, "width": N, "height": N} with the pasted area.
 // Returns an error if:
 //   - No sprite is active
+//   - The image file cannot be loaded
 //   - The layer is not found
 //   - The frame number is invalid
-//   - Clipboard is empty
-func (g *LuaGenerator) PasteClipboard(layerName string, frameNumber int, x, y *int) string {
+func (g *LuaGenerator) PasteClipboard(layerName string, frameNumber int, imagePath string, x, y int) string {
 	escapedName := EscapeString(layerName)
-
-	pastePos := ""
-	if x != nil && y != nil {
-		pastePos = fmt.Sprintf("local pasteX, pasteY = %d, %d", *x, *y)
-	} else {
-		pastePos = "local pasteX, pasteY = 0, 0"
-	}
+	escapedPath := EscapeString(imagePath)
 
 	return fmt.Sprintf(`local spr = app.activeSprite
 if not spr then
 	error("No active sprite")
 end
 
--- Find clipboard layer
-local clipboardLayer = nil
-for i, lyr in ipairs(spr.layers) do
-	if lyr.name == "__mcp_clipboard__" then
-		clipboardLayer = lyr
-		break
-	end
-end
-
-if not clipboardLayer then
-	error("No clipboard content available")
-end
-
-local clipCel = clipboardLayer:cel(1)
-if not clipCel then
-	error("No clipboard content available")
+-- Load clipboard image
+local img = Image{ fromFile = "%s" }
+if not img then
+	error("Failed to load clipboard image: %s")
 end
 
 -- Find target layer
@@ -480,19 +1090,87 @@ if not frame then
 	error("Frame not found: %d")
 end
 
--- Paste clipboard content to target layer
-%s
+local pasteX, pasteY = %d, %d
 
 app.transaction(function()
-	local targetCel = layer:cel(frame)
-	if not targetCel then
-		targetCel = spr:newCel(layer, frame)
+	-- Draw onto a canvas-sized copy so offset or small cels are handled
+	local cel = layer:cel(frame)
+	local canvas = Image(spr.width, spr.height, spr.colorMode)
+	if spr.colorMode == ColorMode.INDEXED then
+		canvas:clear(spr.transparentColor)
 	end
+	if cel then
+		canvas:drawImage(cel.image, cel.position)
+	end
+
+	if spr.colorMode == ColorMode.RGB then
+		canvas:drawImage(img, Point(pasteX, pasteY))
+	else
+		-- Map exact colors to palette indices, preferring the lowest
+		-- non-transparent index
+		local lookup = {}
+		if spr.colorMode == ColorMode.INDEXED then
+			local pal = spr.palettes[1]
+			for i = 0, #pal - 1 do
+				local c = pal:getColor(i)
+				local key = c.red * 65536 + c.green * 256 + c.blue
+				if i ~= spr.transparentColor and lookup[key] == nil then
+					lookup[key] = i
+				end
+			end
+			if spr.transparentColor < #pal then
+				local c = pal:getColor(spr.transparentColor)
+				local key = c.red * 65536 + c.green * 256 + c.blue
+				if lookup[key] == nil then
+					lookup[key] = spr.transparentColor
+				end
+			end
+		end
 
-	-- Draw clipboard image onto target
-	targetCel.image:drawImage(clipCel.image, Point(pasteX - targetCel.position.x, pasteY - targetCel.position.y))
+		for y = 0, img.height - 1 do
+			for x = 0, img.width - 1 do
+				local tx, ty = pasteX + x, pasteY + y
+				local px = img:getPixel(x, y)
+				local a = app.pixelColor.rgbaA(px)
+				if a > 0 and tx >= 0 and tx < spr.width and ty >= 0 and ty < spr.height then
+					local r = app.pixelColor.rgbaR(px)
+					local g = app.pixelColor.rgbaG(px)
+					local b = app.pixelColor.rgbaB(px)
+					if spr.colorMode == ColorMode.INDEXED then
+						local index = lookup[r * 65536 + g * 256 + b]
+						if index then
+							canvas:drawPixel(tx, ty, index)
+						end
+					else
+						canvas:drawPixel(tx, ty, Color{ r = r, g = g, b = b, a = a }.grayPixel)
+					end
+				end
+			end
+		end
+	end
+
+	if cel then
+		cel.image = canvas
+		cel.position = Point(0, 0)
+	else
+		spr:newCel(layer, frame, canvas, Point(0, 0))
+	end
+
+	-- Remove the hidden clipboard layer left by older versions
+	for i, lyr in ipairs(spr.layers) do
+		if lyr.name == "__mcp_clipboard__" then
+			spr:deleteLayer(lyr)
+			break
+		end
+	end
 end)
 
 spr:saveAs(spr.filename)
-print("Paste completed successfully")`, escapedName, escapedName, frameNumber, frameNumber, pastePos)
+print("Paste completed successfully")
+print(string.format('{"x":%%d,"y":%%d,"width":%%d,"height":%%d}',
+	pasteX, pasteY, img.width, img.height))`,
+		escapedPath, escapedPath,
+		escapedName, escapedName,
+		frameNumber, frameNumber,
+		x, y)
 }
@@ -0,0 +1,453 @@
+package aseprite
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Stroke locations supported by StrokeSelection.
+const (
+	StrokeInside  = "inside"  // Stroke lies inside the selection edge
+	StrokeOutside = "outside" // Stroke lies outside the selection edge
+	StrokeCenter  = "center"  // Stroke straddles the selection edge
+)
+
+// FillSelection generates a Lua script to fill the selected pixels with a color.
+//
+// Every selected pixel of the cel is replaced with the color (no blending),
+// so non-rectangular selections are filled exactly.
+//
+// Parameters:
+//   - layerName: name of the target layer (automatically escaped for Lua safety)
+//   - frameNumber: 1-based frame index to fill
+//   - color: fill color in RGBA format
+//   - usePalette: if true, snaps color to nearest palette color
+//
+// The selection is restored from its persisted mask (see GenerateSelectionHelper).
+// The operation is wrapped in a transaction for atomicity and the sprite
+// is saved after the fill.
+//
+// Prints "Selection filled successfully" followed by JSON: {"pixels_painted": N}
+// Returns an error if:
+//   - No sprite is active
+//   - No selection exists
+//   - The layer is not found
+//   - The frame number is invalid
+func (g *LuaGenerator) FillSelection(layerName string, frameNumber int, color Color, usePalette bool) string {
+	body := fmt.Sprintf(`local color = %s
+local count = 0
+local bounds = spr.selection.bounds
+for y = math.max(bounds.y, 0), math.min(bounds.y + bounds.height, spr.height) - 1 do
+	for x = math.max(bounds.x, 0), math.min(bounds.x + bounds.width, spr.width) - 1 do
+		if spr.selection:contains(x, y) then
+			canvas:putPixel(x, y, color)
+			count = count + 1
+		end
+	end
+end`, FormatColorWithPalette(color, usePalette))
+
+	return selectionEditScript(layerName, frameNumber, usePalette, body,
+		`print("Selection filled successfully")
+print(string.format('{"pixels_painted":%d}', count))`)
+}
+
+// StrokeSelection generates a Lua script to draw a stroke along the selection edge.
+//
+// The stroke is width pixels wide and follows the exact selection mask, with
+// square (8-neighbor) corners. Its location relative to the edge is
+// StrokeInside, StrokeOutside, or StrokeCenter; a centered stroke puts the
+// extra pixel of odd widths inside. The canvas edge counts as outside the
+// selection, so inside strokes also run along it.
+//
+// Parameters:
+//   - layerName: name of the target layer (automatically escaped for Lua safety)
+//   - frameNumber: 1-based frame index to draw on
+//   - color: stroke color in RGBA format
+//   - width: stroke width in pixels
+//   - location: StrokeInside, StrokeOutside, or StrokeCenter
+//   - usePalette: if true, snaps color to nearest palette color
+//
+// The selection is restored from its persisted mask and left unchanged.
+// The operation is wrapped in a transaction for atomicity and the sprite
+// is saved after the stroke is drawn.
+//
+// Prints "Selection stroked successfully" followed by JSON: {"pixels_painted": N}
+// Returns an error if:
+//   - No sprite is active
+//   - No selection exists
+//   - The layer is not found
+//   - The frame number is invalid
+func (g *LuaGenerator) StrokeSelection(layerName string, frameNumber int, color Color, width int, location string, usePalette bool) string {
+	inside, outside := width, 0
+	switch location {
+	case StrokeOutside:
+		inside, outside = 0, width
+	case StrokeCenter:
+		inside, outside = (width+1)/2, width/2
+	}
+
+	body := fmt.Sprintf(`local w, h = spr.width, spr.height
+local mask = selectionMask(spr)
+
+-- The stroke covers mask minus its erosion, plus its dilation minus mask
+local inner, outer = mask, mask
+for i = 1, %d do
+	inner = morphMask(inner, w, h, false, false)
+end
+for i = 1, %d do
+	outer = morphMask(outer, w, h, true, false)
+end
+
+local color = %s
+local count = 0
+for y = 0, h - 1 do
+	for x = 0, w - 1 do
+		local i = y * w + x
+		if (mask[i] and not inner[i]) or (outer[i] and not mask[i]) then
+			canvas:putPixel(x, y, color)
+			count = count + 1
+		end
+	end
+end`, inside, outside, FormatColorWithPalette(color, usePalette))
+
+	return selectionEditScript(layerName, frameNumber, usePalette, body,
+		`print("Selection stroked successfully")
+print(string.format('{"pixels_painted":%d}', count))`)
+}
+
+// ClearSelection generates a Lua script to make the selected pixels transparent.
+//
+// Unlike CutSelection, the pixels are not copied to the clipboard and the
+// selection is kept.
+//
+// Parameters:
+//   - layerName: name of the target layer (automatically escaped for Lua safety)
+//   - frameNumber: 1-based frame index to clear
+//
+// The operation is wrapped in a transaction for atomicity and the sprite
+// is saved after the pixels are cleared.
+//
+// Prints "Selection cleared successfully" followed by JSON: {"pixels_cleared": N}
+// Returns an error if:
+//   - No sprite is active
+//   - No selection exists
+//   - The layer is not found
+//   - The frame number is invalid
+func (g *LuaGenerator) ClearSelection(layerName string, frameNumber int) string {
+	body := `local count = 0
+local bounds = spr.selection.bounds
+for y = math.max(bounds.y, 0), math.min(bounds.y + bounds.height, spr.height) - 1 do
+	for x = math.max(bounds.x, 0), math.min(bounds.x + bounds.width, spr.width) - 1 do
+		if spr.selection:contains(x, y) then
+			canvas:putPixel(x, y, transparent)
+			count = count + 1
+		end
+	end
+end`
+
+	return selectionEditScript(layerName, frameNumber, false, body,
+		`print("Selection cleared successfully")
+print(string.format('{"pixels_cleared":%d}', count))`)
+}
+
+// TransformSelection generates a Lua script to flip, rotate, or scale the
+// selected pixels.
+//
+// Only selected pixels move: they are lifted from the cel, transformed within
+// the selection bounds, and dropped back centered on the original bounds,
+// replacing what is underneath where they are opaque. The selection mask is
+// transformed with them, so later operations apply to the new shape.
+//
+// Parameters:
+//   - layerName: name of the target layer (automatically escaped for Lua safety)
+//   - frameNumber: 1-based frame index to transform
+//   - operation: "flip_horizontal", "flip_vertical", "rotate_90", "rotate_180",
+//     "rotate_270" (clockwise), or "scale"
+//   - scaleX, scaleY: scale factors for "scale" (nearest neighbor)
+//
+// The transformed selection is persisted. The operation is wrapped in a
+// transaction for atomicity and the sprite is saved afterwards.
+//
+// Prints "Selection transformed successfully" followed by JSON:
+// {"x": N, "y": N, "width": N, "height": N, "pixel_count": N} with the bounds
+// and size of the transformed selection.
+// Returns an error if:
+//   - No sprite is active
+//   - No selection exists
+//   - The layer is not found
+//   - The frame number is invalid
+func (g *LuaGenerator) TransformSelection(layerName string, frameNumber int, operation string, scaleX, scaleY float64) string {
+	body := fmt.Sprintf(`local operation = "%s"
+local scaleX, scaleY = %f, %f
+local bounds = spr.selection.bounds
+local original = Selection()
+original:add(spr.selection)
+
+-- Size and position of the transformed pixels, centered on the original bounds
+local newW, newH = bounds.width, bounds.height
+if operation == "rotate_90" or operation == "rotate_270" then
+	newW, newH = bounds.height, bounds.width
+elseif operation == "scale" then
+	newW = math.max(1, math.floor(bounds.width * scaleX + 0.5))
+	newH = math.max(1, math.floor(bounds.height * scaleY + 0.5))
+end
+local newX = bounds.x + math.floor((bounds.width - newW) / 2)
+local newY = bounds.y + math.floor((bounds.height - newH) / 2)
+
+-- Map a destination offset to the source offset within the bounds
+local function source(dx, dy)
+	if operation == "flip_horizontal" then
+		return bounds.width - 1 - dx, dy
+	elseif operation == "flip_vertical" then
+		return dx, bounds.height - 1 - dy
+	elseif operation == "rotate_90" then
+		return dy, bounds.height - 1 - dx
+	elseif operation == "rotate_180" then
+		return bounds.width - 1 - dx, bounds.height - 1 - dy
+	elseif operation == "rotate_270" then
+		return bounds.width - 1 - dy, dx
+	end
+	return math.floor(dx * bounds.width / newW), math.floor(dy * bounds.height / newH)
+end
+
+local function onCanvas(x, y)
+	return x >= 0 and y >= 0 and x < spr.width and y < spr.height
+end
+
+local function isOpaque(px)
+	if spr.colorMode == ColorMode.INDEXED then
+		return px ~= spr.transparentColor
+	elseif spr.colorMode == ColorMode.GRAY then
+		return app.pixelColor.grayaA(px) > 0
+	end
+	return app.pixelColor.rgbaA(px) > 0
+end
+
+-- Lift the selected pixels
+local sourceImage = canvas:clone()
+for y = bounds.y, bounds.y + bounds.height - 1 do
+	for x = bounds.x, bounds.x + bounds.width - 1 do
+		if onCanvas(x, y) and original:contains(x, y) then
+			canvas:putPixel(x, y, transparent)
+		end
+	end
+end
+
+-- Drop the transformed pixels
+local moved = {}
+local count = 0
+for dy = 0, newH - 1 do
+	for dx = 0, newW - 1 do
+		local sx, sy = source(dx, dy)
+		sx, sy = bounds.x + sx, bounds.y + sy
+		if original:contains(sx, sy) then
+			moved[dy * newW + dx] = true
+			count = count + 1
+			local tx, ty = newX + dx, newY + dy
+			if onCanvas(sx, sy) and onCanvas(tx, ty) then
+				local px = sourceImage:getPixel(sx, sy)
+				if isOpaque(px) then
+					canvas:putPixel(tx, ty, px)
+				end
+			end
+		end
+	end
+end
+
+spr.selection = selectionFromPixels(newW, newH, newX, newY, function(x, y)
+	return moved[y * newW + x] == true
+end)`, operation, scaleX, scaleY)
+
+	return selectionEditScript(layerName, frameNumber, false, body,
+		`local newBounds = spr.selection.bounds
+print("Selection transformed successfully")
+print(string.format('{"x":%d,"y":%d,"width":%d,"height":%d,"pixel_count":%d}',
+	newBounds.x, newBounds.y, newBounds.width, newBounds.height, count))`)
+}
+
+// ClipToSelection wraps a drawing script so it only changes pixels inside the
+// active selection on the given layer and frame.
+//
+// The selection is restored from its persisted mask before the script runs,
+// which also limits Aseprite tools used by the script. Afterwards every pixel
+// outside the selection is put back to its previous value and the sprite is
+// saved again. The script's own output is kept.
+//
+// Parameters:
+//   - layerName: name of the layer the script draws on (automatically escaped for Lua safety)
+//   - frameNumber: 1-based frame index the script draws on
+//   - script: the drawing script to wrap
+//
+// Returns an error if:
+//   - No sprite is active
+//   - No selection exists
+//   - The layer is not found
+//   - The frame number is invalid
+//   - The wrapped script fails
+func (g *LuaGenerator) ClipToSelection(layerName string, frameNumber int, script string) string {
+	escapedName := EscapeString(layerName)
+
+	var sb strings.Builder
+	sb.WriteString(GenerateSelectionHelper())
+	sb.WriteString(fmt.Sprintf(`
+local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+-- Restore selection from persisted state if needed
+restoreSelection(spr)
+
+if spr.selection.isEmpty then
+	error("No active selection to clip to")
+end
+
+local clipSelection = Selection()
+clipSelection:add(spr.selection)
+
+-- Find layer by name
+local clipLayer = nil
+for i, lyr in ipairs(spr.layers) do
+	if lyr.name == "%s" then
+		clipLayer = lyr
+		break
+	end
+end
+
+if not clipLayer then
+	error("Layer not found: %s")
+end
+
+local clipFrame = spr.frames[%d]
+if not clipFrame then
+	error("Frame not found: %d")
+end
+
+-- Canvas-sized copy of the target cel
+local function clipCanvas()
+	local canvas = Image(spr.width, spr.height, spr.colorMode)
+	if spr.colorMode == ColorMode.INDEXED then
+		canvas:clear(spr.transparentColor)
+	end
+	local cel = clipLayer:cel(clipFrame)
+	if cel then
+		canvas:drawImage(cel.image, cel.position)
+	end
+	return canvas
+end
+
+local clipBefore = clipCanvas()
+
+do
+`, escapedName, escapedName, frameNumber, frameNumber))
+
+	sb.WriteString(script)
+
+	sb.WriteString(`
+end
+
+-- Put back every pixel outside the selection
+local clipAfter = clipCanvas()
+for y = 0, spr.height - 1 do
+	for x = 0, spr.width - 1 do
+		if not clipSelection:contains(x, y) then
+			clipAfter:putPixel(x, y, clipBefore:getPixel(x, y))
+		end
+	end
+end
+
+app.transaction(function()
+	local cel = clipLayer:cel(clipFrame)
+	if cel then
+		cel.image = clipAfter
+		cel.position = Point(0, 0)
+	else
+		spr:newCel(clipLayer, clipFrame, clipAfter, Point(0, 0))
+	end
+end)
+
+spr:saveAs(spr.filename)`)
+
+	return sb.String()
+}
+
+// selectionEditScript generates a Lua script that edits the selected pixels
+// of a cel.
+//
+// The script restores the selection, finds the layer and frame, and copies
+// the cel onto a canvas-sized image in the local variable canvas (with the
+// transparent pixel value in transparent). It then runs body, writes canvas
+// back to the cel, persists the selection, saves the sprite, and runs result
+// to print the output.
+func selectionEditScript(layerName string, frameNumber int, usePalette bool, body, result string) string {
+	var sb strings.Builder
+
+	// Add palette snapper helper if needed
+	if usePalette {
+		sb.WriteString(GeneratePaletteSnapperHelper())
+		sb.WriteString("\n")
+	}
+	sb.WriteString(GenerateSelectionHelper())
+
+	escapedName := EscapeString(layerName)
+	sb.WriteString(fmt.Sprintf(`
+local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+-- Restore selection from persisted state if needed
+restoreSelection(spr)
+
+if spr.selection.isEmpty then
+	error("No active selection")
+end
+
+-- Find layer by name
+local layer = nil
+for i, lyr in ipairs(spr.layers) do
+	if lyr.name == "%s" then
+		layer = lyr
+		break
+	end
+end
+
+if not layer then
+	error("Layer not found: %s")
+end
+
+local frame = spr.frames[%d]
+if not frame then
+	error("Frame not found: %d")
+end
+
+-- Work on a canvas-sized copy so offset or small cels are handled
+local transparent = 0
+if spr.colorMode == ColorMode.INDEXED then
+	transparent = spr.transparentColor
+end
+local cel = layer:cel(frame)
+local canvas = Image(spr.width, spr.height, spr.colorMode)
+canvas:clear(transparent)
+if cel then
+	canvas:drawImage(cel.image, cel.position)
+end
+
+%s
+
+app.transaction(function()
+	if cel then
+		cel.image = canvas
+		cel.position = Point(0, 0)
+	else
+		spr:newCel(layer, frame, canvas, Point(0, 0))
+	end
+end)
+
+persistSelection(spr)
+spr:saveAs(spr.filename)
+
+%s`, escapedName, escapedName, frameNumber, frameNumber, body, result))
+
+	return sb.String()
+}
@@ -0,0 +1,148 @@
+package aseprite
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLuaGenerator_FillSelection(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.FillSelection("Layer 1", 2, Color{R: 255, G: 0, B: 0, A: 255}, false)
+
+	for _, want := range []string{
+		"restoreSelection(spr)",
+		`error("No active selection")`,
+		`lyr.name == "Layer 1"`,
+		"spr.frames[2]",
+		"if spr.selection:contains(x, y) then",
+		"canvas:putPixel(x, y, color)",
+		"Selection filled successfully",
+		`{"pixels_painted":%d}`,
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+
+	if strings.Contains(script, "snapToPalette") {
+		t.Error("script should not include the palette snapper without usePalette")
+	}
+
+	if !strings.Contains(gen.FillSelection("Layer 1", 1, Color{R: 255, A: 255}, true), "snapToPalette") {
+		t.Error("script missing palette snapper with usePalette")
+	}
+}
+
+func TestLuaGenerator_StrokeSelection(t *testing.T) {
+	gen := NewLuaGenerator()
+	color := Color{R: 0, G: 0, B: 0, A: 255}
+
+	tests := []struct {
+		location        string
+		width           int
+		inside, outside string
+	}{
+		{StrokeInside, 3, "for i = 1, 3 do\n\tinner", "for i = 1, 0 do\n\touter"},
+		{StrokeOutside, 2, "for i = 1, 0 do\n\tinner", "for i = 1, 2 do\n\touter"},
+		{StrokeCenter, 3, "for i = 1, 2 do\n\tinner", "for i = 1, 1 do\n\touter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.location, func(t *testing.T) {
+			script := gen.StrokeSelection("Layer 1", 1, color, tt.width, tt.location, false)
+
+			if !strings.Contains(script, tt.inside) {
+				t.Errorf("script missing inside width %q", tt.inside)
+			}
+			if !strings.Contains(script, tt.outside) {
+				t.Errorf("script missing outside width %q", tt.outside)
+			}
+			if !strings.Contains(script, "local mask = selectionMask(spr)") {
+				t.Error("script missing selection mask")
+			}
+			if !strings.Contains(script, "Selection stroked successfully") {
+				t.Error("script missing success message")
+			}
+		})
+	}
+}
+
+func TestLuaGenerator_ClearSelection(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.ClearSelection("Background", 3)
+
+	for _, want := range []string{
+		`lyr.name == "Background"`,
+		"spr.frames[3]",
+		"canvas:putPixel(x, y, transparent)",
+		`{"pixels_cleared":%d}`,
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+
+	// Unlike cut, the clipboard and selection are left alone
+	if strings.Contains(script, "__mcp_clipboard__") {
+		t.Error("clear should not touch the clipboard")
+	}
+	if strings.Contains(script, "spr.selection = Selection()") {
+		t.Error("clear should keep the selection")
+	}
+}
+
+func TestLuaGenerator_TransformSelection(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	for _, op := range []string{"flip_horizontal", "flip_vertical", "rotate_90", "rotate_180", "rotate_270"} {
+		script := gen.TransformSelection("Layer 1", 1, op, 1, 1)
+		if !strings.Contains(script, `local operation = "`+op+`"`) {
+			t.Errorf("%s script missing operation", op)
+		}
+	}
+
+	script := gen.TransformSelection("Layer 1", 1, "scale", 2, 0.5)
+	for _, want := range []string{
+		"local scaleX, scaleY = 2.000000, 0.500000",
+		"original:contains(sx, sy)",
+		"spr.selection = selectionFromPixels(newW, newH, newX, newY",
+		"\npersistSelection(spr)",
+		"Selection transformed successfully",
+		`"pixel_count":%d}`,
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+}
+
+func TestLuaGenerator_ClipToSelection(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	inner := gen.DrawRectangle("Layer 1", 1, 0, 0, 10, 10, Color{R: 255, A: 255}, true, false)
+	script := gen.ClipToSelection("Layer 1", 1, inner)
+
+	if !strings.Contains(script, "do\n"+inner+"\nend") {
+		t.Error("script should run the drawing script in its own block")
+	}
+
+	before := strings.Index(script, "local clipBefore = clipCanvas()")
+	after := strings.Index(script, "local clipAfter = clipCanvas()")
+	drawn := strings.Index(script, inner)
+	if before < 0 || after < 0 || !(before < drawn && drawn < after) {
+		t.Error("script should snapshot the cel before and after drawing")
+	}
+
+	for _, want := range []string{
+		"restoreSelection(spr)",
+		`error("No active selection to clip to")`,
+		"if not clipSelection:contains(x, y) then",
+		"clipAfter:putPixel(x, y, clipBefore:getPixel(x, y))",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+}
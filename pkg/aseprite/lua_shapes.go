@@ -0,0 +1,226 @@
+package aseprite
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DrawBezier generates a Lua script to draw a quadratic or cubic Bezier curve.
+//
+// The curve is rasterized in Go as a pixel-perfect 1-pixel stroke (see
+// RasterizeQuadraticBezier and RasterizeCubicBezier) and written directly to
+// the cel image, so no Aseprite brush smoothing is involved.
+//
+// Parameters:
+//   - layerName: name of the target layer (automatically escaped for Lua safety)
+//   - frameNumber: 1-based frame index to draw on
+//   - points: start, control point(s), and end; 3 points draw a quadratic
+//     curve and 4 points a cubic curve
+//   - color: stroke color in RGBA format
+//   - usePalette: if true, snaps color to nearest palette color
+//
+// Prints "Bezier curve drawn successfully" on success.
+// Returns an error if:
+//   - No sprite is active
+//   - The layer is not found
+//   - The frame number is invalid
+func (g *LuaGenerator) DrawBezier(layerName string, frameNumber int, points []Point, color Color, usePalette bool) string {
+	var pixels []Point
+	switch len(points) {
+	case 3:
+		pixels = RasterizeQuadraticBezier(points[0], points[1], points[2])
+	case 4:
+		pixels = RasterizeCubicBezier(points[0], points[1], points[2], points[3])
+	}
+	return rasterScript(layerName, frameNumber, pixels, color, usePalette, "Bezier curve drawn successfully")
+}
+
+// DrawEllipse generates a Lua script to draw an ellipse with independent radii.
+//
+// Unlike DrawCircle, the horizontal and vertical radii can differ. The
+// ellipse is rasterized in Go with the midpoint algorithm (see
+// RasterizeEllipse) and written directly to the cel image.
+//
+// Parameters:
+//   - layerName: name of the target layer (automatically escaped for Lua safety)
+//   - frameNumber: 1-based frame index to draw on
+//   - centerX, centerY: center point coordinates
+//   - radiusX, radiusY: horizontal and vertical radii in pixels
+//   - color: fill/stroke color in RGBA format
+//   - filled: if true, fills the interior; if false, draws outline only
+//   - usePalette: if true, snaps color to nearest palette color
+//   - clip: sprite bounds; only pixels inside are rasterized, so oversized
+//     shapes cost no more than the canvas
+//
+// Prints "Ellipse drawn successfully" on success.
+// Returns an error if:
+//   - No sprite is active
+//   - The layer is not found
+//   - The frame number is invalid
+func (g *LuaGenerator) DrawEllipse(layerName string, frameNumber int, centerX, centerY, radiusX, radiusY int, color Color, filled bool, usePalette bool, clip Rectangle) string {
+	pixels := RasterizeEllipse(centerX, centerY, radiusX, radiusY, filled, clip)
+	return rasterScript(layerName, frameNumber, pixels, color, usePalette, "Ellipse drawn successfully")
+}
+
+// DrawArc generates a Lua script to draw an elliptical arc.
+//
+// The arc follows the same outline as DrawEllipse between startAngle and
+// endAngle in degrees, measured clockwise on screen from the positive X axis
+// (see RasterizeArc).
+//
+// Parameters:
+//   - layerName: name of the target layer (automatically escaped for Lua safety)
+//   - frameNumber: 1-based frame index to draw on
+//   - centerX, centerY: center point coordinates
+//   - radiusX, radiusY: horizontal and vertical radii in pixels
+//   - startAngle, endAngle: arc extent in degrees (0 = right, 90 = down)
+//   - color: stroke color in RGBA format
+//   - usePalette: if true, snaps color to nearest palette color
+//   - clip: sprite bounds; only pixels inside are rasterized, so oversized
+//     shapes cost no more than the canvas
+//
+// Prints "Arc drawn successfully" on success.
+// Returns an error if:
+//   - No sprite is active
+//   - The layer is not found
+//   - The frame number is invalid
+func (g *LuaGenerator) DrawArc(layerName string, frameNumber int, centerX, centerY, radiusX, radiusY int, startAngle, endAngle float64, color Color, usePalette bool, clip Rectangle) string {
+	pixels := RasterizeArc(centerX, centerY, radiusX, radiusY, startAngle, endAngle, clip)
+	return rasterScript(layerName, frameNumber, pixels, color, usePalette, "Arc drawn successfully")
+}
+
+// DrawPolygon generates a Lua script to draw a filled polygon.
+//
+// The polygon is closed implicitly and filled with the even-odd or non-zero
+// winding rule (see RasterizePolygon), so self-intersecting outlines such as
+// stars can be filled either way. Its edges match a closed DrawContour
+// through the same points.
+//
+// Parameters:
+//   - layerName: name of the target layer (automatically escaped for Lua safety)
+//   - frameNumber: 1-based frame index to draw on
+//   - points: polygon vertices in order
+//   - color: fill color in RGBA format
+//   - fillRule: FillRuleEvenOdd or FillRuleNonZero
+//   - usePalette: if true, snaps color to nearest palette color
+//   - clip: sprite bounds; only pixels inside are rasterized, so oversized
+//     shapes cost no more than the canvas
+//
+// Prints "Polygon drawn successfully" on success.
+// Returns an error if:
+//   - No sprite is active
+//   - The layer is not found
+//   - The frame number is invalid
+func (g *LuaGenerator) DrawPolygon(layerName string, frameNumber int, points []Point, color Color, fillRule string, usePalette bool, clip Rectangle) string {
+	pixels := RasterizePolygon(points, fillRule, clip)
+	return rasterScript(layerName, frameNumber, pixels, color, usePalette, "Polygon drawn successfully")
+}
+
+// rasterScript generates a Lua script that sets the given pixels (in sprite
+// coordinates) to a color on a layer and frame, then prints message.
+//
+// Pixels are sent as horizontal runs and drawn onto a canvas-sized copy of
+// the cel, so cels that are offset or smaller than the canvas are handled and
+// pixels outside the canvas are clipped.
+func rasterScript(layerName string, frameNumber int, pixels []Point, color Color, usePalette bool, message string) string {
+	var sb strings.Builder
+
+	// Add palette snapper helper if needed
+	if usePalette {
+		sb.WriteString(GeneratePaletteSnapperHelper())
+		sb.WriteString("\n")
+	}
+
+	escapedName := EscapeString(layerName)
+	sb.WriteString(fmt.Sprintf(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+-- Find layer by name
+local layer = nil
+for i, lyr in ipairs(spr.layers) do
+	if lyr.name == "%s" then
+		layer = lyr
+		break
+	end
+end
+
+if not layer then
+	error("Layer not found: %s")
+end
+
+local frame = spr.frames[%d]
+if not frame then
+	error("Frame not found: %d")
+end
+
+-- Pixel runs as {y, x1, x2, ...}
+local runs = {`, escapedName, escapedName, frameNumber, frameNumber))
+
+	for i, run := range pixelRuns(pixels) {
+		if i%16 == 0 {
+			sb.WriteString("\n\t")
+		} else {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(fmt.Sprintf("%d, %d, %d,", run[0], run[1], run[2]))
+	}
+
+	sb.WriteString(fmt.Sprintf(`
+}
+
+app.transaction(function()
+	local cel = layer:cel(frame)
+	local canvas = Image(spr.width, spr.height, spr.colorMode)
+	if spr.colorMode == ColorMode.INDEXED then
+		canvas:clear(spr.transparentColor)
+	end
+	if cel then
+		canvas:drawImage(cel.image, cel.position)
+	end
+
+	local color = %s
+	for i = 1, #runs, 3 do
+		local y = runs[i]
+		if y >= 0 and y < spr.height then
+			for x = math.max(runs[i + 1], 0), math.min(runs[i + 2], spr.width - 1) do
+				canvas:putPixel(x, y, color)
+			end
+		end
+	end
+
+	if cel then
+		cel.image = canvas
+		cel.position = Point(0, 0)
+	else
+		spr:newCel(layer, frame, canvas, Point(0, 0))
+	end
+end)
+
+spr:saveAs(spr.filename)
+print("%s")`,
+		FormatColorWithPalette(color, usePalette),
+		message))
+
+	return sb.String()
+}
+
+// pixelRuns groups pixels into horizontal runs {y, x1, x2}, sorted by row
+// and column. Duplicate pixels are merged.
+func pixelRuns(pixels []Point) [][3]int {
+	set := make(map[Point]bool, len(pixels))
+	for _, p := range pixels {
+		set[p] = true
+	}
+
+	var runs [][3]int
+	for _, p := range sortedPoints(set) {
+		if n := len(runs); n > 0 && runs[n-1][0] == p.Y && runs[n-1][2] == p.X-1 {
+			runs[n-1][2] = p.X
+			continue
+		}
+		runs = append(runs, [3]int{p.Y, p.X, p.X})
+	}
+	return runs
+}
@@ -0,0 +1,172 @@
+package aseprite
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TagUpdate describes the changes UpdateTag applies to a tag. Zero values
+// leave the corresponding property unchanged.
+type TagUpdate struct {
+	NewName   string // New tag name
+	FromFrame int    // New first frame (1-based)
+	ToFrame   int    // New last frame (1-based)
+	Direction string // "forward", "reverse", "pingpong", or "pingpong_reverse"
+	Repeat    *int   // Times the tag plays (0 = loop forever)
+	Color     *Color // Tag color in the timeline
+}
+
+// tagInfoHelper defines the Lua helpers that describe tags as JSON:
+//   - aniDirName(aniDir) returns the direction name used by the tools
+//   - tagInfoJSON(spr, tag) returns a {"name", "from_frame", "to_frame",
+//     "frame_count", "direction", "repeat", "color", "duration_ms"} object
+//   - tagsInfoJSON(spr) returns a JSON array of every tag
+const tagInfoHelper = `-- Direction name of an AniDir value
+local function aniDirName(aniDir)
+	if aniDir == AniDir.REVERSE then
+		return "reverse"
+	elseif aniDir == AniDir.PING_PONG then
+		return "pingpong"
+	elseif aniDir == AniDir.PING_PONG_REVERSE then
+		return "pingpong_reverse"
+	end
+	return "forward"
+end
+
+-- Describe a tag as a JSON object
+local function tagInfoJSON(spr, tag)
+	local from, to = tag.fromFrame.frameNumber, tag.toFrame.frameNumber
+	local duration = 0
+	for f = from, to do
+		duration = duration + spr.frames[f].duration
+	end
+	local name = tag.name:gsub('\\', '\\\\'):gsub('"', '\\"')
+	local c = tag.color
+	return string.format('{"name":"%s","from_frame":%d,"to_frame":%d,"frame_count":%d,"direction":"%s","repeat":%d,"color":"#%02X%02X%02X","duration_ms":%d}',
+		name, from, to, to - from + 1, aniDirName(tag.aniDir), tag.repeats, c.red, c.green, c.blue, math.floor(duration * 1000 + 0.5))
+end
+
+-- Describe every tag as a JSON array
+local function tagsInfoJSON(spr)
+	local items = {}
+	for _, tag in ipairs(spr.tags) do
+		table.insert(items, tagInfoJSON(spr, tag))
+	end
+	return "[" .. table.concat(items, ",") .. "]"
+end
+`
+
+// ListTags generates a Lua script to list the animation tags of a sprite.
+//
+// Each tag is described with its frame range, frame count, playback
+// direction, repeat count (0 = loop forever), timeline color, and total
+// duration in milliseconds (the sum of its frame durations).
+//
+// Prints JSON: {"tags": [...]} with tags in timeline order.
+// Returns an error if no sprite is active.
+func (g *LuaGenerator) ListTags() string {
+	return `local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+` + tagInfoHelper + `
+print('{"tags":' .. tagsInfoJSON(spr) .. '}')`
+}
+
+// UpdateTag generates a Lua script to change an existing animation tag.
+//
+// Renames the tag, changes its frame range, playback direction, repeat
+// count, or timeline color. Only the properties set in update change. When
+// only one end of the frame range is given, the other end is kept.
+//
+// Parameters:
+//   - tagName: name of the tag to update (automatically escaped for Lua safety)
+//   - update: the changes to apply (see TagUpdate)
+//
+// The operation is wrapped in a transaction for atomicity and the sprite
+// is saved after the tag is updated.
+//
+// Prints "Tag updated successfully" followed by the tag as JSON (see ListTags).
+// Returns an error if:
+//   - No sprite is active
+//   - The tag is not found
+//   - Another tag already uses the new name
+//   - The frame range exceeds the sprite's frame count or is inverted
+func (g *LuaGenerator) UpdateTag(tagName string, update TagUpdate) string {
+	escapedName := EscapeString(tagName)
+
+	var changes strings.Builder
+	if update.NewName != "" {
+		fmt.Fprintf(&changes, "\ttag.name = \"%s\"\n", EscapeString(update.NewName))
+	}
+	if update.Direction != "" {
+		fmt.Fprintf(&changes, "\ttag.aniDir = %s\n", aniDirLua(update.Direction))
+	}
+	if update.Repeat != nil {
+		fmt.Fprintf(&changes, "\ttag.repeats = %d\n", *update.Repeat)
+	}
+	if update.Color != nil {
+		fmt.Fprintf(&changes, "\ttag.color = Color{ r = %d, g = %d, b = %d, a = 255 }\n", update.Color.R, update.Color.G, update.Color.B)
+	}
+
+	return fmt.Sprintf(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+%s
+-- Find tag by name
+local tag = nil
+for i, t in ipairs(spr.tags) do
+	if t.name == "%s" then
+		tag = t
+		break
+	end
+end
+
+if not tag then
+	error("Tag not found: %s")
+end
+
+local newName = "%s"
+if newName ~= "" and newName ~= tag.name then
+	for i, t in ipairs(spr.tags) do
+		if t.name == newName then
+			error("Tag already exists: " .. newName)
+		end
+	end
+end
+
+local first, last = %d, %d
+if first == 0 then
+	first = tag.fromFrame.frameNumber
+end
+if last == 0 then
+	last = tag.toFrame.frameNumber
+end
+
+if last > #spr.frames then
+	error("Frame range exceeds sprite frames")
+end
+
+if first > last then
+	error(string.format("Invalid tag range: %%d-%%d", first, last))
+end
+
+app.transaction(function()
+	-- Order the assignments so the range never inverts
+	if first > tag.toFrame.frameNumber then
+		tag.toFrame = last
+		tag.fromFrame = first
+	else
+		tag.fromFrame = first
+		tag.toFrame = last
+	end
+%send)
+
+spr:saveAs(spr.filename)
+print("Tag updated successfully")
+print(tagInfoJSON(spr, tag))`, tagInfoHelper, escapedName, escapedName, EscapeString(update.NewName),
+		update.FromFrame, update.ToFrame, changes.String())
+}
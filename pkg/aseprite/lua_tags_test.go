@@ -0,0 +1,73 @@
+package aseprite
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLuaGenerator_ListTags(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.ListTags()
+
+	for _, want := range []string{
+		"local function tagsInfoJSON(spr)",
+		`print('{"tags":' .. tagsInfoJSON(spr) .. '}')`,
+		`"repeat":%d`,
+		`"duration_ms":%d`,
+		"AniDir.PING_PONG_REVERSE",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+}
+
+func TestLuaGenerator_UpdateTag(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	repeat := 3
+	color := NewColorRGB(255, 128, 0)
+	script := gen.UpdateTag("walk", TagUpdate{
+		NewName:   `run "fast"`,
+		FromFrame: 2,
+		ToFrame:   5,
+		Direction: "pingpong_reverse",
+		Repeat:    &repeat,
+		Color:     &color,
+	})
+
+	for _, want := range []string{
+		`if t.name == "walk" then`,
+		`error("Tag not found: walk")`,
+		`tag.name = "run \"fast\""`,
+		`error("Tag already exists: " .. newName)`,
+		"local first, last = 2, 5",
+		`error("Frame range exceeds sprite frames")`,
+		"tag.aniDir = AniDir.PING_PONG_REVERSE",
+		"tag.repeats = 3",
+		"tag.color = Color{ r = 255, g = 128, b = 0, a = 255 }",
+		"Tag updated successfully",
+		"print(tagInfoJSON(spr, tag))",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+}
+
+func TestLuaGenerator_UpdateTag_PartialUpdate(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.UpdateTag("idle", TagUpdate{ToFrame: 4})
+
+	if !strings.Contains(script, "local first, last = 0, 4") {
+		t.Error("script missing partial frame range")
+	}
+
+	for _, unwanted := range []string{"tag.name =", "tag.aniDir =", "tag.repeats =", "tag.color ="} {
+		if strings.Contains(script, unwanted) {
+			t.Errorf("script should not contain %q", unwanted)
+		}
+	}
+}
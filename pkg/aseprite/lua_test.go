@@ -232,6 +232,28 @@ func TestLuaGenerator_DeleteLayer(t *testing.T) {
 	}
 }
 
+func TestLuaGenerator_MoveLayerBelow(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.MoveLayerBelow("Sprite Shadow", "Sprite")
+
+	if !strings.Contains(script, `if lyr.name == "Sprite Shadow"`) {
+		t.Error("script missing layer name check")
+	}
+
+	if !strings.Contains(script, `if lyr.name == "Sprite"`) {
+		t.Error("script missing reference layer name check")
+	}
+
+	if !strings.Contains(script, "layer.stackIndex = ref.stackIndex") {
+		t.Error("script missing stackIndex assignment")
+	}
+
+	if !strings.Contains(script, "app.transaction(function()") {
+		t.Error("script not wrapped in transaction")
+	}
+}
+
 func TestLuaGenerator_DeleteFrame(t *testing.T) {
 	gen := NewLuaGenerator()
 
@@ -376,6 +398,71 @@ func TestLuaGenerator_SelectEllipse(t *testing.T) {
 	}
 }
 
+func TestLuaGenerator_SelectByColor(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.SelectByColor("Layer 1", 2, 5, 7, 16, true, 8, "add")
+
+	for _, want := range []string{
+		`lyr.name == "Layer 1"`,
+		"spr.frames[2]",
+		"local seedX, seedY = 5, 7",
+		"local tolerance = 16",
+		"local contiguous = true",
+		"local connectivity = 8",
+		`elseif "add" == "add" then`,
+		"\npersistSelection(spr)",
+		"Color selection created successfully",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+
+	global := gen.SelectByColor("Layer 1", 1, 0, 0, 0, false, 4, "replace")
+	if !strings.Contains(global, "local contiguous = false") {
+		t.Error("global script should disable contiguous flood fill")
+	}
+}
+
+func TestLuaGenerator_SelectLayerAlpha(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.SelectLayerAlpha("Outline", 3, "subtract")
+
+	for _, want := range []string{
+		`lyr.name == "Outline"`,
+		"spr.frames[3]",
+		"selectionFromPixels(img.width, img.height, cel.position.x, cel.position.y",
+		`elseif "subtract" == "subtract" then`,
+		"Layer alpha selection created successfully",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+}
+
+func TestLuaGenerator_SelectPolygon(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	triangle := []Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 0, Y: 4}}
+	script := gen.SelectPolygon(triangle, FillRuleNonZero, "intersect", testClip)
+
+	// Rows shrink from 0-4 at the top to 0-0 at the bottom
+	for _, want := range []string{
+		"0, 0, 4,",
+		"2, 0, 2,",
+		"4, 0, 0,",
+		`elseif "intersect" == "intersect" then`,
+		"Polygon selection created successfully",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+}
+
 func TestLuaGenerator_SelectAll(t *testing.T) {
 	gen := NewLuaGenerator()
 
@@ -430,10 +517,43 @@ func TestLuaGenerator_MoveSelection(t *testing.T) {
 	}
 }
 
+func TestLuaGenerator_ModifySelection(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	tests := []struct {
+		name string
+		args [4]any
+		want []string
+	}{
+		{"expand square", [4]any{"expand", 3, "square", 0}, []string{`local operation = "expand"`, "local amount = 3", `local shape = "square"`}},
+		{"contract diamond", [4]any{"contract", 2, "diamond", 0}, []string{`local operation = "contract"`, `local shape = "diamond"`}},
+		{"border", [4]any{"border", 1, "square", 0}, []string{`local operation = "border"`, "result[i] = mask[i] and not inner[i]"}},
+		{"invert", [4]any{"invert", 0, "square", 0}, []string{`local operation = "invert"`, "result[i] = not mask[i]"}},
+		{"remove islands", [4]any{"remove_islands", 0, "square", 5}, []string{`local operation = "remove_islands"`, "local minIslandSize = 5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script := gen.ModifySelection(tt.args[0].(string), tt.args[1].(int), tt.args[2].(string), tt.args[3].(int))
+			for _, want := range tt.want {
+				if !strings.Contains(script, want) {
+					t.Errorf("script missing %q", want)
+				}
+			}
+			if !strings.Contains(script, `"pixel_count":%d}`) {
+				t.Error("script missing JSON result")
+			}
+			if !strings.Contains(script, `operation ~= "invert"`) {
+				t.Error("script missing empty selection check")
+			}
+		})
+	}
+}
+
 func TestLuaGenerator_CutSelection(t *testing.T) {
 	gen := NewLuaGenerator()
 
-	script := gen.CutSelection("Layer 1", 2)
+	script := gen.CutSelection("Layer 1", 2, "/tmp/clip.png")
 
 	if !strings.Contains(script, "if spr.selection.isEmpty then") {
 		t.Error("script missing empty selection check")
@@ -447,8 +567,12 @@ func TestLuaGenerator_CutSelection(t *testing.T) {
 		t.Error("script missing frame reference")
 	}
 
-	if !strings.Contains(script, "__mcp_clipboard__") {
-		t.Error("script missing clipboard layer reference")
+	if !strings.Contains(script, `exportSelectedPixels(spr, layer, frame, "/tmp/clip.png")`) {
+		t.Error("script missing clipboard image export")
+	}
+
+	if !strings.Contains(script, "removeLegacyClipboard(spr)") {
+		t.Error("script missing legacy clipboard layer removal")
 	}
 
 	if !strings.Contains(script, "Cut selection completed successfully") {
@@ -459,14 +583,25 @@ func TestLuaGenerator_CutSelection(t *testing.T) {
 func TestLuaGenerator_CopySelection(t *testing.T) {
 	gen := NewLuaGenerator()
 
-	script := gen.CopySelection()
+	script := gen.CopySelection("Layer 1", 1, "/tmp/clip.png")
 
 	if !strings.Contains(script, "if spr.selection.isEmpty then") {
 		t.Error("script missing empty selection check")
 	}
 
-	if !strings.Contains(script, "__mcp_clipboard__") {
-		t.Error("script missing clipboard layer reference")
+	if !strings.Contains(script, `exportSelectedPixels(spr, layer, frame, "/tmp/clip.png")`) {
+		t.Error("script missing clipboard image export")
+	}
+
+	// The clipboard lives on the server, not in a hidden layer
+	if strings.Contains(script, "clipboardLayer = spr:newLayer()") {
+		t.Error("script should not create a clipboard layer")
+	}
+
+	for _, want := range []string{`palette = spr.palettes[1]`, `transparentColor = transparent`, `"color_mode":"%s","palette":[%s]`} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
 	}
 
 	if !strings.Contains(script, "Copy selection completed successfully") {
@@ -474,45 +609,133 @@ func TestLuaGenerator_CopySelection(t *testing.T) {
 	}
 }
 
-func TestLuaGenerator_PasteClipboard(t *testing.T) {
+func TestGenerateSelectionHelper(t *testing.T) {
+	helper := GenerateSelectionHelper()
+
+	for _, want := range []string{
+		"local function restoreSelection(spr)",
+		"local function persistSelection(spr)",
+		`return spr.filename .. ".selection"`,
+		`io.open(path, "w")`,
+		"os.remove(path)",
+	} {
+		if !strings.Contains(helper, want) {
+			t.Errorf("helper missing %q", want)
+		}
+	}
+
+	// The mask is stored per pixel, not as bounds in the sprite user data
+	if strings.Contains(helper, "spr.data = string.format") {
+		t.Error("helper should not write selection bounds to spr.data")
+	}
+
+	// The mask stays out of the sprite's layers
+	if strings.Contains(helper, "spr:newLayer()") {
+		t.Error("helper should not store the selection in a layer")
+	}
+}
+
+func TestLuaGenerator_SelectionPersistence(t *testing.T) {
 	gen := NewLuaGenerator()
 
-	t.Run("without position", func(t *testing.T) {
-		script := gen.PasteClipboard("Layer 1", 1, nil, nil)
+	tests := []struct {
+		name    string
+		script  string
+		restore bool
+		persist bool
+	}{
+		{"SelectRectangle", gen.SelectRectangle(0, 0, 4, 4, "subtract"), true, true},
+		{"SelectEllipse", gen.SelectEllipse(0, 0, 8, 6, "intersect"), true, true},
+		{"SelectAll", gen.SelectAll(), false, true},
+		{"Deselect", gen.Deselect(), false, true},
+		{"MoveSelection", gen.MoveSelection(1, 1), true, true},
+		{"ModifySelection", gen.ModifySelection("expand", 1, "square", 0), true, true},
+		{"CutSelection", gen.CutSelection("Layer 1", 1, "/tmp/clip.png"), true, true},
+		{"CopySelection", gen.CopySelection("Layer 1", 1, "/tmp/clip.png"), true, false},
+		{"SelectByColor", gen.SelectByColor("Layer 1", 1, 0, 0, 0, true, 4, "replace"), true, true},
+		{"SelectLayerAlpha", gen.SelectLayerAlpha("Layer 1", 1, "replace"), true, true},
+		{"SelectPolygon", gen.SelectPolygon([]Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 0, Y: 4}}, FillRuleEvenOdd, "replace", testClip), true, true},
+	}
 
-		if !strings.Contains(script, `lyr.name == "Layer 1"`) {
-			t.Error("script missing layer name check")
-		}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(tt.script, "local function restoreSelection(spr)") {
+				t.Error("script missing selection helper")
+			}
+			if tt.restore && !strings.Contains(tt.script, "\nrestoreSelection(spr)") {
+				t.Error("script does not restore the persisted selection")
+			}
+			if tt.persist && !strings.Contains(tt.script, "\npersistSelection(spr)") {
+				t.Error("script does not persist the selection")
+			}
+			if strings.Contains(tt.script, "spr.data = string.format") {
+				t.Error("script should not write selection bounds to spr.data")
+			}
+		})
+	}
+}
 
-		if !strings.Contains(script, "__mcp_clipboard__") {
-			t.Error("script missing clipboard layer reference")
-		}
+func TestLuaGenerator_SelectModes(t *testing.T) {
+	gen := NewLuaGenerator()
 
-		if !strings.Contains(script, "local pasteX, pasteY = 0, 0") {
-			t.Error("script missing default paste position")
-		}
+	// Each mode combines the restored selection with the new shape exactly once
+	script := gen.SelectEllipse(0, 0, 10, 10, "subtract")
+	if !strings.Contains(script, `elseif "subtract" == "subtract" then
+	spr.selection:subtract(sel)`) {
+		t.Error("script missing subtract branch")
+	}
+	if strings.Count(script, "spr.selection:add(sel)") != 1 {
+		t.Error("add should only be applied in add mode")
+	}
+}
 
-		if !strings.Contains(script, "Paste completed successfully") {
-			t.Error("script missing success message")
+func TestLuaGenerator_CutCopySelection_MasksUnselectedPixels(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	for name, script := range map[string]string{
+		"cut":  gen.CutSelection("Layer 1", 1, "/tmp/clip.png"),
+		"copy": gen.CopySelection("Layer 1", 1, "/tmp/clip.png"),
+	} {
+		if !strings.Contains(script, `local count = exportSelectedPixels(spr, layer, frame, "/tmp/clip.png")`) {
+			t.Errorf("%s script does not copy the selected pixels", name)
 		}
-	})
+		if !strings.Contains(script, "clipImage:drawPixel(x, y, transparent)") {
+			t.Errorf("%s script does not mask out unselected pixels", name)
+		}
+		if !strings.Contains(script, `"pixel_count":%d,%s}`) {
+			t.Errorf("%s script missing JSON result", name)
+		}
+	}
 
-	t.Run("with position", func(t *testing.T) {
-		x, y := 25, 35
-		script := gen.PasteClipboard("Layer 1", 1, &x, &y)
+	if !strings.Contains(gen.CutSelection("Layer 1", 1, "/tmp/clip.png"), "if spr.selection:contains(x, y) then") {
+		t.Error("cut script should only clear selected pixels")
+	}
+}
 
-		if !strings.Contains(script, "local pasteX, pasteY = 25, 35") {
-			t.Error("script missing paste position assignment")
-		}
+func TestLuaGenerator_PasteClipboard(t *testing.T) {
+	gen := NewLuaGenerator()
 
-		if !strings.Contains(script, "__mcp_clipboard__") {
-			t.Error("script missing clipboard layer reference")
-		}
+	script := gen.PasteClipboard("Layer 1", 2, "/tmp/clip.png", 25, 35)
 
-		if !strings.Contains(script, "Paste completed successfully") {
-			t.Error("script missing success message")
+	for _, want := range []string{
+		`Image{ fromFile = "/tmp/clip.png" }`,
+		`lyr.name == "Layer 1"`,
+		"spr.frames[2]",
+		"local pasteX, pasteY = 25, 35",
+		"canvas:drawImage(img, Point(pasteX, pasteY))",
+		"lookup[r * 65536 + g * 256 + b]",
+		"spr:deleteLayer(lyr)",
+		"Paste completed successfully",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
 		}
-	})
+	}
+
+	// Indexed lookups prefer a visible palette entry over the transparent index
+	if !strings.Contains(script, "if i ~= spr.transparentColor and lookup[key] == nil then") {
+		t.Error("script should skip the transparent index when mapping colors")
+	}
 }
 
 func TestLuaGenerator_GetPalette(t *testing.T) {
@@ -541,6 +764,26 @@ func TestLuaGenerator_GetPalette(t *testing.T) {
 	}
 }
 
+func TestLuaGenerator_GetFramePalette(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.GetFramePalette(3)
+
+	for _, want := range []string{
+		"local frame = spr.frames[3]",
+		`error("Frame not found: 3")`,
+		"for i, pal in ipairs(spr.palettes) do",
+		"local start = pal.frame and pal.frame.frameNumber or 1",
+		"if start <= frame.frameNumber and start >= paletteStart then",
+		`string.format("#%02X%02X%02X"`,
+		`'{"colors":%s,"size":%d}'`,
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+}
+
 func TestLuaGenerator_SetPaletteColor(t *testing.T) {
 	gen := NewLuaGenerator()
 
@@ -950,6 +1193,54 @@ func TestLuaGenerator_ApplyOutline(t *testing.T) {
 	}
 }
 
+func TestLuaGenerator_ExportCelImage(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.ExportCelImage("Body", 2, "/tmp/cel.png")
+
+	if !strings.Contains(script, `if lyr.name == "Body"`) {
+		t.Error("script missing layer name comparison")
+	}
+
+	if !strings.Contains(script, "spr.frames[2]") {
+		t.Error("script missing frame lookup")
+	}
+
+	if !strings.Contains(script, `cel.image:saveAs{ filename = "/tmp/cel.png", palette = spr.palettes[1] }`) {
+		t.Error("script missing cel image export with palette")
+	}
+
+	if !strings.Contains(script, `{"x":%d,"y":%d,"width":%d,"height":%d}`) {
+		t.Error("script missing cel bounds JSON output")
+	}
+}
+
+func TestLuaGenerator_ExportFrameImages(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.ExportFrameImages([]int{2, 3, 1}, "/tmp/onion")
+
+	if !strings.Contains(script, "local frames = { 2, 3, 1 }") {
+		t.Error("script missing frame list")
+	}
+
+	if !strings.Contains(script, `error("Frame not found: " .. f)`) {
+		t.Error("script missing frame validation")
+	}
+
+	if !strings.Contains(script, "img:drawSprite(spr, f)") {
+		t.Error("script missing flattened frame render")
+	}
+
+	if !strings.Contains(script, `img:saveAs(app.fs.joinPath(outputDir, "frame-" .. f .. ".png"))`) {
+		t.Error("script missing frame image export")
+	}
+
+	if !strings.Contains(script, `local outputDir = "/tmp/onion"`) {
+		t.Error("script missing output directory")
+	}
+}
+
 func TestLuaGenerator_ExportSpritesheet(t *testing.T) {
 	gen := NewLuaGenerator()
 
@@ -1032,6 +1323,31 @@ func TestLuaGenerator_ImportImage(t *testing.T) {
 	})
 }
 
+func TestLuaGenerator_StampImage(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.StampImage("/tmp/text.png", "UI", 3, -2, 7)
+
+	if !strings.Contains(script, `Image{ fromFile = "/tmp/text.png" }`) {
+		t.Error("script missing image load")
+	}
+	if !strings.Contains(script, `error("Layer not found: UI")`) {
+		t.Error("script should require an existing layer")
+	}
+	if !strings.Contains(script, "spr.frames[3]") {
+		t.Error("script missing frame number")
+	}
+	if !strings.Contains(script, "canvas:drawImage(cel.image, cel.position)") {
+		t.Error("script should keep the existing cel pixels")
+	}
+	if !strings.Contains(script, "canvas:drawImage(src, Point(-2, 7))") {
+		t.Error("script missing image position")
+	}
+	if !strings.Contains(script, "spr:newCel(layer, frame, canvas, Point(0, 0))") {
+		t.Error("script should create a cel when missing")
+	}
+}
+
 func TestLuaGenerator_SaveAs(t *testing.T) {
 	gen := NewLuaGenerator()
 
@@ -1105,6 +1421,43 @@ func TestLuaGenerator_SetFrameDuration(t *testing.T) {
 	}
 }
 
+func TestLuaGenerator_SetFrameDurations(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.SetFrameDurations(3, []int{100, 50, 1250})
+
+	if !strings.Contains(script, "local first = 3") {
+		t.Error("script missing first frame")
+	}
+
+	if !strings.Contains(script, "local durations = { 0.100, 0.050, 1.250 }") {
+		t.Error("script missing durations")
+	}
+
+	if !strings.Contains(script, `error("Frame range exceeds sprite frames")`) {
+		t.Error("script missing range check")
+	}
+
+	if !strings.Contains(script, "Frame durations set successfully") {
+		t.Error("script missing success message")
+	}
+}
+func TestLuaGenerator_GetAnimationTiming(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.GetAnimationTiming()
+
+	for _, want := range []string{
+		"local function tagsInfoJSON(spr)",
+		"math.floor(frame.duration * 1000 + 0.5)",
+		`print('{"durations_ms":[' .. table.concat(durations, ",") .. '],"tags":' .. tagsInfoJSON(spr) .. '}')`,
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+}
+
 func TestLuaGenerator_CreateTag(t *testing.T) {
 	gen := NewLuaGenerator()
 
@@ -1173,6 +1526,10 @@ func TestLuaGenerator_GetSpriteInfo(t *testing.T) {
 	if !strings.Contains(script, `"layers":`) {
 		t.Error("script missing layers array")
 	}
+
+	if !strings.Contains(script, `"tags": %s`) || !strings.Contains(script, "tagsInfoJSON(spr)") {
+		t.Error("script missing tags array")
+	}
 }
 
 func TestLuaGenerator_SetPalette(t *testing.T) {
@@ -1348,7 +1705,7 @@ func TestLuaGenerator_DrawWithDither(t *testing.T) {
 		{
 			name:     "bayer_4x4 pattern",
 			pattern:  "bayer_4x4",
-			checkFor: []string{"local matrix = {", "local matrixSize = 4"},
+			checkFor: []string{"local matrix = {{0, 8, 2, 10}, {12, 4, 14, 6}, {3, 11, 1, 9}, {15, 7, 13, 5}}", "local matrixSize = 4"},
 		},
 		{
 			name:     "checkerboard pattern",
@@ -1565,6 +1922,11 @@ func TestLuaGenerator_CreateTag_EdgeCases(t *testing.T) {
 			direction: "pingpong",
 			checkFor:  `aniDir = AniDir.PING_PONG`,
 		},
+		{
+			name:      "pingpong reverse direction",
+			direction: "pingpong_reverse",
+			checkFor:  `aniDir = AniDir.PING_PONG_REVERSE`,
+		},
 		{
 			name:      "invalid defaults to forward",
 			direction: "invalid",
@@ -1915,3 +2277,354 @@ func TestLuaGenerator_ApplyShading_AllDirections(t *testing.T) {
 		})
 	}
 }
+
+func TestLuaGenerator_AppendPaletteColors(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.AppendPaletteColors([]string{"#FF0000", "#00FF0080", "bad"})
+
+	if !strings.Contains(script, "Color{r=255, g=0, b=0, a=255},") {
+		t.Error("script missing first color")
+	}
+	if !strings.Contains(script, "Color{r=0, g=255, b=0, a=128},") {
+		t.Error("script missing second color with alpha")
+	}
+	if strings.Count(script, "Color{r=") != 2 {
+		t.Error("script should skip invalid colors")
+	}
+	if !strings.Contains(script, "#palette + #colors > 256") {
+		t.Error("script missing maximum size check")
+	}
+	if !strings.Contains(script, `"start_index":`) {
+		t.Error("script missing JSON start_index field")
+	}
+}
+
+func TestLuaGenerator_RemapColors(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.RemapColors([]ColorRemap{
+		{From: "#FF0000", To: "#0000FF"},
+		{From: "", FromIndex: 3, To: "#00FF0080"},
+		{From: "#00FF00", To: "", ToIndex: 7},
+	}, []string{"Body", `Say "hi"`}, []int{1, 3})
+
+	if !strings.Contains(script, "{from={255, 0, 0}, fromIndex=0, to={0, 0, 255}, toIndex=0},") {
+		t.Error("script missing color-to-color mapping")
+	}
+	if !strings.Contains(script, "{from=nil, fromIndex=3, to={0, 255, 0, 128}, toIndex=0},") {
+		t.Error("script missing index-to-color mapping with alpha")
+	}
+	if !strings.Contains(script, "{from={0, 255, 0}, fromIndex=0, to=nil, toIndex=7},") {
+		t.Error("script missing color-to-index mapping")
+	}
+	if !strings.Contains(script, `["Body"] = true,`) || !strings.Contains(script, `["Say \"hi\""] = true,`) {
+		t.Error("script missing escaped layer filter")
+	}
+	if !strings.Contains(script, "local frameFilter = {[1] = true, [3] = true}") {
+		t.Error("script missing frame filter")
+	}
+	if !strings.Contains(script, "seen[cel.image.id]") {
+		t.Error("script should skip linked cels already processed")
+	}
+	if !strings.Contains(script, "palette:resize(#palette + 1)") {
+		t.Error("script should append missing colors in indexed mode")
+	}
+	if !strings.Contains(script, "app.transaction") || !strings.Contains(script, "spr:saveAs(spr.filename)") {
+		t.Error("script missing transaction or save")
+	}
+	if !strings.Contains(script, `"pixels_changed":%d`) {
+		t.Error("script missing JSON output")
+	}
+}
+
+func TestLuaGenerator_RemapColors_NoFilters(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.RemapColors([]ColorRemap{{From: "#000000", To: "#FFFFFF"}}, nil, nil)
+
+	if !strings.Contains(script, "local layerFilter = nil") {
+		t.Error("script should select all layers")
+	}
+	if !strings.Contains(script, "local frameFilter = nil") {
+		t.Error("script should select all frames")
+	}
+}
+
+func TestLuaGenerator_RemapColors_SkipsTransparentIndex(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.RemapColors([]ColorRemap{{From: "", FromIndex: 0, To: "#FFFFFF"}}, nil, nil)
+
+	if !strings.Contains(script, "local transparentIndex = spr.transparentColor") {
+		t.Error("script should look up the transparent index")
+	}
+	if !strings.Contains(script, "if layer.isBackground then\n\t\t\t\ttransparentIndex = -1") {
+		t.Error("script should remap every index on background layers")
+	}
+	if !strings.Contains(script, "if to and to ~= v and v ~= transparentIndex then") {
+		t.Error("script should leave transparent pixels alone on other layers")
+	}
+}
+
+func TestLuaGenerator_GetUsedColors(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.GetUsedColors([]string{"Body"}, nil)
+
+	if !strings.Contains(script, `["Body"] = true,`) {
+		t.Error("script missing layer filter")
+	}
+	if !strings.Contains(script, "v ~= spr.transparentColor") {
+		t.Error("script should ignore the transparent index")
+	}
+	if !strings.Contains(script, `'{"colors":['`) {
+		t.Error("script missing JSON output")
+	}
+}
+
+func TestLuaGenerator_DuplicateLayer(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.DuplicateLayer("Body", "Body (hue 120)")
+
+	if !strings.Contains(script, `lyr.name == "Body"`) {
+		t.Error("script missing source layer lookup")
+	}
+	if !strings.Contains(script, "app.command.DuplicateLayer()") {
+		t.Error("script missing DuplicateLayer command")
+	}
+	if !strings.Contains(script, `app.activeLayer.name = "Body (hue 120)"`) {
+		t.Error("script missing new layer name")
+	}
+}
+
+func TestLuaGenerator_SetFramePalette(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.SetFramePalette(3, []string{"#FF0000", "#00FF0080"})
+
+	if !strings.Contains(script, "local frame = spr.frames[3]") {
+		t.Error("script missing frame lookup")
+	}
+	if !strings.Contains(script, "local palette = Palette(2)") {
+		t.Error("script missing palette creation")
+	}
+	if !strings.Contains(script, "Color{r=0, g=255, b=0, a=128}") {
+		t.Error("script missing color with alpha")
+	}
+	if !strings.Contains(script, "app.activeFrame = frame") || !strings.Contains(script, "spr:setPalette(palette)") {
+		t.Error("script should set the palette on the target frame")
+	}
+
+	if script := gen.SetFramePalette(1, nil); !strings.Contains(script, "No colors provided") {
+		t.Error("script should error without colors")
+	}
+}
+
+func TestLuaGenerator_GetPaletteUsage(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.GetPaletteUsage()
+
+	if !strings.Contains(script, "spr.colorMode ~= ColorMode.INDEXED") {
+		t.Error("script missing indexed mode check")
+	}
+	if !strings.Contains(script, "seen[cel.image.id]") {
+		t.Error("script should count linked cels once")
+	}
+	if !strings.Contains(script, `"transparent_index":`) {
+		t.Error("script missing transparent index in output")
+	}
+	if !strings.Contains(script, "if #spr.palettes > 1 then") {
+		t.Error("script should refuse sprites with per-frame palettes")
+	}
+}
+
+func TestLuaGenerator_RemapPaletteIndices(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.RemapPaletteIndices([]int{0, 1, 1, -1, 2}, []string{"#00000000", "#FF0000", "#0000FF"}, 0)
+
+	if !strings.Contains(script, "if #palette ~= 5 then") {
+		t.Error("script missing palette size check")
+	}
+	if !strings.Contains(script, "if #spr.palettes > 1 then") {
+		t.Error("script should refuse sprites with per-frame palettes")
+	}
+	if !strings.Contains(script, "local map = {[0]=0, [1]=1, [2]=1, [4]=2}") {
+		t.Error("script missing index mapping (removed entries omitted)")
+	}
+	if !strings.Contains(script, "Color{r=0, g=0, b=0, a=0}") {
+		t.Error("script missing transparent palette entry")
+	}
+	if !strings.Contains(script, "spr.transparentColor = 0") {
+		t.Error("script missing transparent index update")
+	}
+	if !strings.Contains(script, "palette:resize(#colors)") {
+		t.Error("script missing palette resize")
+	}
+
+	script = gen.RemapPaletteIndices([]int{0}, []string{"#FFFFFF"}, -1)
+	if strings.Contains(script, "spr.transparentColor =") {
+		t.Error("script should not touch the transparent index when negative")
+	}
+}
+
+func TestLuaGenerator_DrawGradient(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	opts := GradientOptions{
+		Type:  GradientRadial,
+		Start: Point{X: 16, Y: 16},
+		End:   Point{X: 32, Y: 16},
+		Stops: []GradientStop{
+			{Position: 0, Color: NewColorRGB(255, 0, 0)},
+			{Position: 0.5, Color: NewColor(0, 255, 0, 128)},
+			{Position: 1, Color: NewColorRGB(0, 0, 255)},
+		},
+		Dither: "bayer_4x4",
+		Region: Rectangle{X: 0, Y: 0, Width: 32, Height: 32},
+		Clip:   GradientClipSelection,
+	}
+	script := gen.DrawGradient("Sky", 2, opts)
+
+	checks := []string{
+		`if lyr.name == "Sky" then`,
+		"local frame = spr.frames[2]",
+		`local gradientType = "radial"`,
+		"local x1, y1, x2, y2 = 16, 16, 32, 16",
+		"{pos = 0.500000, r = 0, g = 255, b = 0, a = 128}",
+		`local clip = "selection"`,
+		"local regionX, regionY, regionW, regionH = 0, 0, 32, 32",
+		"local quantize = false",
+		"local matrixSize = 4",
+		"spr.selection:contains(px, py)",
+		`print(string.format('{"pixels_painted":%d}', painted))`,
+	}
+	for _, check := range checks {
+		if !strings.Contains(script, check) {
+			t.Errorf("DrawGradient() missing %q in generated script", check)
+		}
+	}
+}
+
+func TestLuaGenerator_DrawGradient_SmoothQuantized(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	opts := GradientOptions{
+		Type:    GradientLinear,
+		End:     Point{X: 8, Y: 0},
+		Stops:   []GradientStop{{Position: 0, Color: NewColorRGB(0, 0, 0)}, {Position: 1, Color: NewColorRGB(255, 255, 255)}},
+		Dither:  "none",
+		Region:  Rectangle{Width: 8, Height: 8},
+		Clip:    GradientClipOpaque,
+		Palette: []Color{NewColorRGB(0, 0, 0), NewColorRGB(255, 255, 255)},
+	}
+	script := gen.DrawGradient("Layer 1", 1, opts)
+
+	if !strings.Contains(script, "local matrix = nil") {
+		t.Error("script should not use a dithering matrix")
+	}
+	if !strings.Contains(script, "local quantize = true") {
+		t.Error("script should quantize when a palette is given")
+	}
+	if !strings.Contains(script, "local palette = {{0, 0, 0}, {255, 255, 255}}") {
+		t.Error("script missing explicit quantization palette")
+	}
+}
+
+func TestLuaGenerator_DrawBezier(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	points := []Point{{X: 0, Y: 10}, {X: 5, Y: 0}, {X: 10, Y: 10}}
+	script := gen.DrawBezier("Ink", 3, points, NewColorRGB(255, 0, 0), false)
+
+	checks := []string{
+		`if lyr.name == "Ink" then`,
+		"local frame = spr.frames[3]",
+		"local runs = {",
+		"10, 0, 0,",
+		"local color = Color(255, 0, 0, 255)",
+		"canvas:putPixel(x, y, color)",
+		"cel.position = Point(0, 0)",
+		`print("Bezier curve drawn successfully")`,
+	}
+	for _, check := range checks {
+		if !strings.Contains(script, check) {
+			t.Errorf("DrawBezier() missing %q in generated script", check)
+		}
+	}
+	if strings.Contains(script, "snapToPaletteForPixel") {
+		t.Error("DrawBezier() should not include the palette snapper without use_palette")
+	}
+
+	cubic := gen.DrawBezier("Ink", 1, append(points, Point{X: 20, Y: 0}), NewColorRGB(0, 0, 0), true)
+	for _, check := range []string{"local function snapToPaletteForPixel", "local color = snapToPaletteForPixel(0, 0, 0, 255)", "0, 20, 20,"} {
+		if !strings.Contains(cubic, check) {
+			t.Errorf("DrawBezier() cubic missing %q in generated script", check)
+		}
+	}
+}
+
+func TestLuaGenerator_DrawEllipse(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.DrawEllipse("Layer 1", 1, 10, 10, 4, 2, NewColorRGB(0, 255, 0), true, false, testClip)
+	checks := []string{
+		// Center row of a filled ellipse spans the full width
+		"10, 6, 14,",
+		"8, ",
+		`print("Ellipse drawn successfully")`,
+	}
+	for _, check := range checks {
+		if !strings.Contains(script, check) {
+			t.Errorf("DrawEllipse() missing %q in generated script", check)
+		}
+	}
+
+	outline := gen.DrawEllipse("Layer 1", 1, 10, 10, 4, 2, NewColorRGB(0, 255, 0), false, false, testClip)
+	if strings.Contains(outline, "10, 6, 14,") {
+		t.Error("DrawEllipse() outline should not fill the center row")
+	}
+}
+
+func TestLuaGenerator_DrawArc(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.DrawArc("Layer 1", 2, 10, 10, 5, 5, 0, 90, NewColorRGB(0, 0, 255), false, testClip)
+	checks := []string{
+		"local frame = spr.frames[2]",
+		"10, 15, 15,",
+		"15, 10, 12,",
+		`print("Arc drawn successfully")`,
+	}
+	for _, check := range checks {
+		if !strings.Contains(script, check) {
+			t.Errorf("DrawArc() missing %q in generated script", check)
+		}
+	}
+	if strings.Contains(script, "5, 10, 10,") {
+		t.Error("DrawArc() 0-90 should not include the top of the circle")
+	}
+}
+
+func TestLuaGenerator_DrawPolygon(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	square := []Point{{X: 1, Y: 1}, {X: 4, Y: 1}, {X: 4, Y: 4}, {X: 1, Y: 4}}
+	script := gen.DrawPolygon(`My "Layer"`, 1, square, NewColor(255, 255, 0, 128), FillRuleEvenOdd, false, testClip)
+
+	checks := []string{
+		`if lyr.name == "My \"Layer\"" then`,
+		"1, 1, 4, 2, 1, 4, 3, 1, 4, 4, 1, 4,",
+		"local color = Color(255, 255, 0, 128)",
+		"canvas:clear(spr.transparentColor)",
+		`print("Polygon drawn successfully")`,
+	}
+	for _, check := range checks {
+		if !strings.Contains(script, check) {
+			t.Errorf("DrawPolygon() missing %q in generated script", check)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package aseprite
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TweenFrame describes one generated in-between frame for InsertTweenFrames.
+type TweenFrame struct {
+	ImagePath  string // PNG with the in-between cel image
+	X, Y       int    // Cel position in sprite coordinates
+	Opacity    int    // Cel opacity (0-255)
+	DurationMs int    // Frame duration in milliseconds
+}
+
+// TweenKeyframes generates a Lua script to read two keyframe cels of a layer.
+//
+// Exports the cel image of the first keyframe to a PNG (with the sprite
+// palette, so indexed cels keep their colors) and reports the position, size,
+// and opacity of both keyframe cels and the duration of the first keyframe.
+// The keyframes must be adjacent frames.
+//
+// Parameters:
+//   - layerName: name of the layer to tween (automatically escaped for Lua safety)
+//   - fromFrame: 1-based frame of the first keyframe
+//   - toFrame: 1-based frame of the second keyframe (must be fromFrame + 1)
+//   - outputPath: absolute path for the first keyframe's PNG (automatically escaped for Lua safety)
+//
+// Prints JSON: {"from": {...}, "to": {...}} where each keyframe has
+// "x", "y", "width", "height", "opacity", and "duration_ms".
+// Returns an error if:
+//   - No sprite is active
+//   - The layer is not found or is a group
+//   - Either frame does not exist or the frames are not adjacent
+//   - Either keyframe has no cel on the layer
+func (g *LuaGenerator) TweenKeyframes(layerName string, fromFrame, toFrame int, outputPath string) string {
+	escapedName := EscapeString(layerName)
+	escapedPath := EscapeString(outputPath)
+
+	return fmt.Sprintf(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+-- Find layer by name
+local layer = nil
+for i, lyr in ipairs(spr.layers) do
+	if lyr.name == "%s" then
+		layer = lyr
+		break
+	end
+end
+
+if not layer then
+	error("Layer not found: %s")
+end
+
+if layer.isGroup then
+	error("Cannot tween a group layer: %s")
+end
+
+local fromFrame, toFrame = %d, %d
+if toFrame ~= fromFrame + 1 then
+	error("Keyframes must be adjacent frames")
+end
+
+if toFrame > #spr.frames then
+	error("Frame not found: " .. toFrame)
+end
+
+local fromCel = layer:cel(fromFrame)
+if not fromCel then
+	error("No cel found at layer '%s' frame " .. fromFrame)
+end
+
+local toCel = layer:cel(toFrame)
+if not toCel then
+	error("No cel found at layer '%s' frame " .. toFrame)
+end
+
+fromCel.image:saveAs{ filename = "%s", palette = spr.palettes[1] }
+
+local function keyframeJSON(cel, frame)
+	return string.format('{"x":%%d,"y":%%d,"width":%%d,"height":%%d,"opacity":%%d,"duration_ms":%%d}',
+		cel.position.x, cel.position.y, cel.image.width, cel.image.height, cel.opacity,
+		math.floor(spr.frames[frame].duration * 1000 + 0.5))
+end
+
+print(string.format('{"from":%%s,"to":%%s}', keyframeJSON(fromCel, fromFrame), keyframeJSON(toCel, toFrame)))`,
+		escapedName, escapedName, escapedName,
+		fromFrame, toFrame,
+		escapedName, escapedName,
+		escapedPath)
+}
+
+// InsertTweenFrames generates a Lua script to insert in-between frames after
+// a keyframe.
+//
+// Inserts one frame per entry of frames directly after fromFrame. On the
+// tweened layer each new frame gets a cel with the entry's image, position,
+// and opacity. Every other layer holds its cel from fromFrame, so the rest of
+// the scene stays in place during the in-betweens. Tags spanning the
+// insertion point grow to include the new frames and later tags shift back.
+//
+// Parameters:
+//   - layerName: name of the tweened layer (automatically escaped for Lua safety)
+//   - fromFrame: 1-based frame of the first keyframe
+//   - keyDurationMs: new duration of the first keyframe in milliseconds (0 keeps it)
+//   - frames: the in-betweens in playback order
+//
+// The operation is wrapped in a transaction for atomicity and the sprite
+// is saved after the frames are inserted.
+//
+// Prints "Tween frames inserted successfully" followed by JSON:
+// {"frame_count": N, "from_frame": N, "to_frame": N, "tags": [...]} with the
+// range of inserted frames and the resulting tag ranges.
+// Returns an error if:
+//   - No sprite is active
+//   - The layer is not found
+//   - The frame does not exist
+//   - An image file cannot be loaded
+func (g *LuaGenerator) InsertTweenFrames(layerName string, fromFrame, keyDurationMs int, frames []TweenFrame) string {
+	escapedName := EscapeString(layerName)
+
+	var tweens strings.Builder
+	for _, f := range frames {
+		fmt.Fprintf(&tweens, "\t{ path = \"%s\", x = %d, y = %d, opacity = %d, duration = %.3f },\n",
+			EscapeString(f.ImagePath), f.X, f.Y, f.Opacity, float64(f.DurationMs)/1000.0)
+	}
+
+	return fmt.Sprintf(`local spr = app.activeSprite
+if not spr then
+	error("No active sprite")
+end
+
+%s
+-- Find layer by name
+local layer = nil
+for i, lyr in ipairs(spr.layers) do
+	if lyr.name == "%s" then
+		layer = lyr
+		break
+	end
+end
+
+if not layer then
+	error("Layer not found: %s")
+end
+
+local from = %d
+if from > #spr.frames then
+	error("Frame not found: " .. from)
+end
+
+local keyDuration = %.3f
+local tweens = {
+%s}
+
+local tags = captureTags(spr)
+local others = {}
+for _, lyr in ipairs(collectCelLayers(spr.layers, {})) do
+	if lyr ~= layer then
+		table.insert(others, lyr)
+	end
+end
+
+app.transaction(function()
+	if keyDuration > 0 then
+		spr.frames[from].duration = keyDuration
+	end
+
+	for k, t in ipairs(tweens) do
+		local frameNumber = from + k
+		local frame = spr:newEmptyFrame(frameNumber)
+		frame.duration = t.duration
+
+		-- Hold the other layers at their keyframe cels
+		for _, lyr in ipairs(others) do
+			local held = lyr:cel(from)
+			if held then
+				local cel = spr:newCel(lyr, frameNumber, Image(held.image), held.position)
+				cel.opacity = held.opacity
+			end
+		end
+
+		local img = Image{ fromFile = t.path }
+		if not img then
+			error("Failed to load image: " .. t.path)
+		end
+
+		-- Convert color mode if needed
+		local src = img
+		if img.colorMode ~= spr.colorMode then
+			src = Image(img.width, img.height, spr.colorMode)
+			src:drawImage(img, Point(0, 0), 255, BlendMode.SRC)
+		end
+
+		local cel = spr:newCel(layer, frameNumber, src, Point(t.x, t.y))
+		cel.opacity = t.opacity
+	end
+
+	remapTags(tags, function(f)
+		if f > from then
+			return f + #tweens
+		end
+		return f
+	end, function(f) return false end)
+end)
+
+spr:saveAs(spr.filename)
+print("Tween frames inserted successfully")
+print(string.format('{"frame_count":%%d,"from_frame":%%d,"to_frame":%%d,"tags":%%s}',
+	#spr.frames, from + 1, from + #tweens, tagsJSON(spr)))`,
+		frameRangeHelper,
+		escapedName, escapedName,
+		fromFrame,
+		float64(keyDurationMs)/1000.0,
+		tweens.String())
+}
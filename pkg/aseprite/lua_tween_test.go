@@ -0,0 +1,51 @@
+package aseprite
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLuaGenerator_TweenKeyframes(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.TweenKeyframes("Body", 2, 3, "/tmp/key.png")
+
+	for _, want := range []string{
+		`if lyr.name == "Body" then`,
+		"local fromFrame, toFrame = 2, 3",
+		`error("Keyframes must be adjacent frames")`,
+		`fromCel.image:saveAs{ filename = "/tmp/key.png", palette = spr.palettes[1] }`,
+		`'{"x":%d,"y":%d,"width":%d,"height":%d,"opacity":%d,"duration_ms":%d}'`,
+		`print(string.format('{"from":%s,"to":%s}'`,
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+}
+
+func TestLuaGenerator_InsertTweenFrames(t *testing.T) {
+	gen := NewLuaGenerator()
+
+	script := gen.InsertTweenFrames("Body", 2, 50, []TweenFrame{
+		{ImagePath: "/tmp/tween-1.png", X: 3, Y: 4, Opacity: 200, DurationMs: 50},
+		{ImagePath: "/tmp/tween-2.png", X: 6, Y: -1, Opacity: 128, DurationMs: 60},
+	})
+
+	for _, want := range []string{
+		`if lyr.name == "Body" then`,
+		"local from = 2",
+		"local keyDuration = 0.050",
+		`{ path = "/tmp/tween-1.png", x = 3, y = 4, opacity = 200, duration = 0.050 },`,
+		`{ path = "/tmp/tween-2.png", x = 6, y = -1, opacity = 128, duration = 0.060 },`,
+		"local frame = spr:newEmptyFrame(frameNumber)",
+		"spr:newCel(lyr, frameNumber, Image(held.image), held.position)",
+		"spr:newCel(layer, frameNumber, src, Point(t.x, t.y))",
+		"return f + #tweens",
+		"Tween frames inserted successfully",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,377 @@
+package aseprite
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+)
+
+// GenerateNormalMap derives a tangent-space normal map from a sprite image.
+//
+// The normal map is encoded in the usual way: each RGB channel stores a normal
+// component remapped from [-1, 1] to [0, 255], so a flat surface facing the
+// viewer is (128, 128, 255). Alpha is copied from the source so the normal map
+// has exactly the same silhouette as the sprite.
+//
+// Methods:
+//   - "sobel": height from luminance, differentiated with a Sobel operator
+//   - "bevel": height from a distance field of the alpha channel (flat top, sloped rim)
+//   - "dome": one hemispherical dome per color region (see detectRegions)
+//
+// Parameters:
+//   - img: source image (usually a single cel)
+//   - method: "sobel", "bevel", or "dome"
+//   - strength: slope multiplier (0.1-10.0); higher values exaggerate relief
+//   - smoothing: box blur radius (0-8) applied to the height field, or to the
+//     normals for the dome method
+//   - bevelWidth: width of the sloped rim in pixels for the bevel method (1-32)
+//   - invertY: store +Y pointing down (DirectX convention) instead of up (OpenGL)
+//
+// Returns the encoded normal map, or an error if a parameter is out of range.
+func GenerateNormalMap(img image.Image, method string, strength float64, smoothing int, bevelWidth int, invertY bool) (*image.NRGBA, error) {
+	if strength < 0.1 || strength > 10.0 {
+		return nil, fmt.Errorf("strength must be between 0.1 and 10.0, got %f", strength)
+	}
+	if smoothing < 0 || smoothing > 8 {
+		return nil, fmt.Errorf("smoothing must be between 0 and 8, got %d", smoothing)
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	var normals [][]Vector3D
+	switch method {
+	case "sobel":
+		heights := luminanceHeightField(img)
+		heights = blurField(heights, alphaMask(img), smoothing)
+		normals = normalsFromHeightField(heights, strength)
+	case "bevel":
+		if bevelWidth < 1 || bevelWidth > 32 {
+			return nil, fmt.Errorf("bevel_width must be between 1 and 32, got %d", bevelWidth)
+		}
+		heights := bevelHeightField(img, bevelWidth)
+		heights = blurField(heights, alphaMask(img), smoothing)
+		normals = normalsFromHeightField(heights, strength)
+	case "dome":
+		normals = domeNormals(img, strength)
+		normals = blurNormals(normals, alphaMask(img), smoothing)
+	default:
+		return nil, fmt.Errorf("invalid method: %s (must be sobel, bevel, or dome)", method)
+	}
+
+	result := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			_, _, _, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			if a == 0 {
+				continue
+			}
+			result.SetNRGBA(x, y, EncodeNormal(normals[y][x], invertY, uint8(a>>8)))
+		}
+	}
+
+	return result, nil
+}
+
+// EncodeNormal packs a unit normal (image space, +Y down) into a non-premultiplied pixel.
+//
+// With invertY false the green channel follows the OpenGL convention (+Y up);
+// with invertY true it follows the DirectX convention (+Y down).
+func EncodeNormal(n Vector3D, invertY bool, alpha uint8) color.NRGBA {
+	ny := -n.Y
+	if invertY {
+		ny = n.Y
+	}
+	return color.NRGBA{
+		R: encodeNormalComponent(n.X),
+		G: encodeNormalComponent(ny),
+		B: encodeNormalComponent(n.Z),
+		A: alpha,
+	}
+}
+
+// DecodeNormal unpacks an RGB normal map pixel into a unit normal (image space, +Y down).
+//
+// It is the inverse of EncodeNormal and must be called with the same invertY setting.
+func DecodeNormal(c color.Color, invertY bool) Vector3D {
+	nc := color.NRGBAModel.Convert(c).(color.NRGBA)
+	n := Vector3D{
+		X: float64(nc.R)/127.5 - 1.0,
+		Y: float64(nc.G)/127.5 - 1.0,
+		Z: float64(nc.B)/127.5 - 1.0,
+	}
+	if !invertY {
+		n.Y = -n.Y
+	}
+	return n.Normalize()
+}
+
+// encodeNormalComponent maps a normal component from [-1, 1] to [0, 255].
+func encodeNormalComponent(v float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(255, (v+1.0)*127.5))))
+}
+
+// alphaMask returns a grid marking the non-transparent pixels of an image.
+func alphaMask(img image.Image) [][]bool {
+	bounds := img.Bounds()
+	mask := make([][]bool, bounds.Dy())
+	for y := range mask {
+		mask[y] = make([]bool, bounds.Dx())
+		for x := range mask[y] {
+			_, _, _, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			mask[y][x] = a > 0
+		}
+	}
+	return mask
+}
+
+// luminanceHeightField builds a height field from pixel luminance.
+//
+// Heights use the Rec. 709 luma coefficients (as in GenerateBrightnessMap) scaled
+// to 0.0-1.0 and weighted by alpha, so transparent pixels sit at height zero.
+func luminanceHeightField(img image.Image) [][]float64 {
+	bounds := img.Bounds()
+	heights := make([][]float64, bounds.Dy())
+	for y := range heights {
+		heights[y] = make([]float64, bounds.Dx())
+		for x := range heights[y] {
+			r, g, b, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			luma := 0.2126*float64(r) + 0.7152*float64(g) + 0.0722*float64(b)
+			heights[y][x] = luma / 65535.0 * (float64(a) / 65535.0)
+		}
+	}
+	return heights
+}
+
+// bevelHeightField builds a height field from the distance to the nearest transparent pixel.
+//
+// The height rises linearly over bevelWidth pixels and is flat beyond that.
+func bevelHeightField(img image.Image, bevelWidth int) [][]float64 {
+	dist := edgeDistance(alphaMask(img))
+
+	for y := range dist {
+		for x := range dist[y] {
+			dist[y][x] = math.Min(dist[y][x], float64(bevelWidth)) / float64(bevelWidth)
+		}
+	}
+
+	return dist
+}
+
+// edgeDistance returns, for every pixel inside mask, the distance to the nearest
+// pixel outside it (0 for pixels outside the mask).
+//
+// Distances are computed with a two-pass chamfer transform (1 for edge neighbors,
+// √2 for diagonal neighbors). Pixels outside the image count as outside the mask,
+// so a pixel touching the border or a transparent neighbor has distance 1.
+func edgeDistance(mask [][]bool) [][]float64 {
+	height := len(mask)
+	if height == 0 {
+		return nil
+	}
+	width := len(mask[0])
+
+	const diag = math.Sqrt2
+	inf := float64(width + height)
+
+	dist := make([][]float64, height)
+	for y := range dist {
+		dist[y] = make([]float64, width)
+		for x := range dist[y] {
+			if mask[y][x] {
+				dist[y][x] = inf
+			}
+		}
+	}
+
+	// at returns the distance at (x, y), treating out-of-bounds pixels as transparent.
+	at := func(x, y int) float64 {
+		if x < 0 || y < 0 || x >= width || y >= height {
+			return 0
+		}
+		return dist[y][x]
+	}
+
+	// Forward pass (top-left to bottom-right)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if !mask[y][x] {
+				continue
+			}
+			d := dist[y][x]
+			d = math.Min(d, at(x-1, y)+1)
+			d = math.Min(d, at(x, y-1)+1)
+			d = math.Min(d, at(x-1, y-1)+diag)
+			d = math.Min(d, at(x+1, y-1)+diag)
+			dist[y][x] = d
+		}
+	}
+
+	// Backward pass (bottom-right to top-left)
+	for y := height - 1; y >= 0; y-- {
+		for x := width - 1; x >= 0; x-- {
+			if !mask[y][x] {
+				continue
+			}
+			d := dist[y][x]
+			d = math.Min(d, at(x+1, y)+1)
+			d = math.Min(d, at(x, y+1)+1)
+			d = math.Min(d, at(x+1, y+1)+diag)
+			d = math.Min(d, at(x-1, y+1)+diag)
+			dist[y][x] = d
+		}
+	}
+
+	return dist
+}
+
+// normalsFromHeightField converts a height field to per-pixel unit normals.
+//
+// The gradient is estimated with the same Sobel kernels used by DetectEdges.
+// Samples outside the field are clamped to the nearest edge pixel.
+func normalsFromHeightField(heights [][]float64, strength float64) [][]Vector3D {
+	height := len(heights)
+	normals := make([][]Vector3D, height)
+	if height == 0 {
+		return normals
+	}
+	width := len(heights[0])
+
+	at := func(x, y int) float64 {
+		x = max(0, min(width-1, x))
+		y = max(0, min(height-1, y))
+		return heights[y][x]
+	}
+
+	for y := 0; y < height; y++ {
+		normals[y] = make([]Vector3D, width)
+		for x := 0; x < width; x++ {
+			nw, n, ne := at(x-1, y-1), at(x, y-1), at(x+1, y-1)
+			w, e := at(x-1, y), at(x+1, y)
+			sw, s, se := at(x-1, y+1), at(x, y+1), at(x+1, y+1)
+
+			// Sobel kernels are normalized by 8 to yield the slope per pixel
+			gx := (-nw + ne - 2*w + 2*e - sw + se) / 8.0
+			gy := (-nw - 2*n - ne + sw + 2*s + se) / 8.0
+
+			normals[y][x] = Vector3D{X: -gx * strength, Y: -gy * strength, Z: 1}.Normalize()
+		}
+	}
+
+	return normals
+}
+
+// domeNormals assigns each color region a hemispherical dome of normals.
+//
+// Regions come from detectRegions, the same segmentation used by auto-shading.
+// Each dome is an ellipsoid fitted to the region bounds; pixels not covered by
+// a region (very small regions) face the viewer.
+func domeNormals(img image.Image, strength float64) [][]Vector3D {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	normals := make([][]Vector3D, height)
+	for y := range normals {
+		normals[y] = make([]Vector3D, width)
+		for x := range normals[y] {
+			normals[y][x] = Vector3D{X: 0, Y: 0, Z: 1}
+		}
+	}
+
+	for _, region := range detectRegions(img) {
+		centerX := float64(region.Bounds.Min.X+region.Bounds.Max.X) / 2.0
+		centerY := float64(region.Bounds.Min.Y+region.Bounds.Max.Y) / 2.0
+		radiusX := math.Max(float64(region.Bounds.Dx())/2.0, 0.5)
+		radiusY := math.Max(float64(region.Bounds.Dy())/2.0, 0.5)
+
+		for _, pt := range region.Pixels {
+			// Sample at the pixel center
+			nx := (float64(pt.X) + 0.5 - centerX) / radiusX
+			ny := (float64(pt.Y) + 0.5 - centerY) / radiusY
+			d2 := nx*nx + ny*ny
+			if d2 > 1 {
+				d := math.Sqrt(d2)
+				nx, ny, d2 = nx/d, ny/d, 1
+			}
+			nz := math.Sqrt(1 - d2)
+
+			n := Vector3D{X: nx * strength, Y: ny * strength, Z: nz}
+			if n.X == 0 && n.Y == 0 && n.Z == 0 {
+				n.Z = 1
+			}
+			normals[pt.Y-bounds.Min.Y][pt.X-bounds.Min.X] = n.Normalize()
+		}
+	}
+
+	return normals
+}
+
+// blurField applies a box blur of the given radius to a scalar field.
+//
+// Only pixels inside the mask are blurred and only masked neighbors contribute,
+// so transparent background does not bleed into the sprite.
+func blurField(field [][]float64, mask [][]bool, radius int) [][]float64 {
+	if radius == 0 || len(field) == 0 {
+		return field
+	}
+	height, width := len(field), len(field[0])
+
+	result := make([][]float64, height)
+	for y := 0; y < height; y++ {
+		result[y] = make([]float64, width)
+		for x := 0; x < width; x++ {
+			if !mask[y][x] {
+				continue
+			}
+			sum, count := 0.0, 0
+			for dy := -radius; dy <= radius; dy++ {
+				for dx := -radius; dx <= radius; dx++ {
+					sx, sy := x+dx, y+dy
+					if sx < 0 || sy < 0 || sx >= width || sy >= height || !mask[sy][sx] {
+						continue
+					}
+					sum += field[sy][sx]
+					count++
+				}
+			}
+			result[y][x] = sum / float64(count)
+		}
+	}
+
+	return result
+}
+
+// blurNormals applies a masked box blur to a normal field and renormalizes it.
+func blurNormals(normals [][]Vector3D, mask [][]bool, radius int) [][]Vector3D {
+	if radius == 0 || len(normals) == 0 {
+		return normals
+	}
+	height, width := len(normals), len(normals[0])
+
+	result := make([][]Vector3D, height)
+	for y := 0; y < height; y++ {
+		result[y] = make([]Vector3D, width)
+		for x := 0; x < width; x++ {
+			if !mask[y][x] {
+				result[y][x] = normals[y][x]
+				continue
+			}
+			var sum Vector3D
+			for dy := -radius; dy <= radius; dy++ {
+				for dx := -radius; dx <= radius; dx++ {
+					sx, sy := x+dx, y+dy
+					if sx < 0 || sy < 0 || sx >= width || sy >= height || !mask[sy][sx] {
+						continue
+					}
+					sum.X += normals[sy][sx].X
+					sum.Y += normals[sy][sx].Y
+					sum.Z += normals[sy][sx].Z
+				}
+			}
+			result[y][x] = sum.Normalize()
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,179 @@
+package aseprite
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+// newSolidSquare creates a size x size image with an opaque square of the given
+// color inset by margin pixels and a transparent border.
+func newSolidSquare(size, margin int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := margin; y < size-margin; y++ {
+		for x := margin; x < size-margin; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestGenerateNormalMap_Methods(t *testing.T) {
+	img := newSolidSquare(16, 2, color.RGBA{R: 200, G: 120, B: 80, A: 255})
+
+	for _, method := range []string{"sobel", "bevel", "dome"} {
+		t.Run(method, func(t *testing.T) {
+			nm, err := GenerateNormalMap(img, method, 1.0, 0, 3, false)
+			if err != nil {
+				t.Fatalf("GenerateNormalMap() error = %v", err)
+			}
+
+			if nm.Bounds() != img.Bounds() {
+				t.Errorf("normal map bounds = %v, want %v", nm.Bounds(), img.Bounds())
+			}
+
+			// Transparent pixels stay transparent
+			if a := nm.NRGBAAt(0, 0).A; a != 0 {
+				t.Errorf("transparent pixel alpha = %d, want 0", a)
+			}
+
+			// Opaque pixels keep their alpha
+			if a := nm.NRGBAAt(8, 8).A; a != 255 {
+				t.Errorf("opaque pixel alpha = %d, want 255", a)
+			}
+
+			// Every opaque pixel faces the viewer at least partially
+			for y := 2; y < 14; y++ {
+				for x := 2; x < 14; x++ {
+					if b := nm.NRGBAAt(x, y).B; b < 128 {
+						t.Fatalf("pixel (%d,%d) blue = %d, want >= 128", x, y, b)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateNormalMap_BevelSlopes(t *testing.T) {
+	img := newSolidSquare(16, 2, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+
+	nm, err := GenerateNormalMap(img, "bevel", 2.0, 0, 3, false)
+	if err != nil {
+		t.Fatalf("GenerateNormalMap() error = %v", err)
+	}
+
+	// Center of the plateau is flat
+	center := DecodeNormal(nm.NRGBAAt(8, 8), false)
+	if center.Z < 0.99 {
+		t.Errorf("center normal = %+v, want facing viewer", center)
+	}
+
+	// Left rim slopes left, right rim slopes right
+	if left := DecodeNormal(nm.NRGBAAt(2, 8), false); left.X >= 0 {
+		t.Errorf("left rim normal X = %f, want negative", left.X)
+	}
+	if right := DecodeNormal(nm.NRGBAAt(13, 8), false); right.X <= 0 {
+		t.Errorf("right rim normal X = %f, want positive", right.X)
+	}
+
+	// Top rim faces up (OpenGL: green above 128)
+	if g := nm.NRGBAAt(8, 2).G; g <= 128 {
+		t.Errorf("top rim green = %d, want > 128", g)
+	}
+
+	// DirectX convention flips green
+	nmDX, err := GenerateNormalMap(img, "bevel", 2.0, 0, 3, true)
+	if err != nil {
+		t.Fatalf("GenerateNormalMap() error = %v", err)
+	}
+	if g := nmDX.NRGBAAt(8, 2).G; g >= 128 {
+		t.Errorf("top rim green with invert_y = %d, want < 128", g)
+	}
+}
+
+func TestGenerateNormalMap_DomeSymmetry(t *testing.T) {
+	img := newSolidSquare(12, 0, color.RGBA{R: 0, G: 128, B: 255, A: 255})
+
+	nm, err := GenerateNormalMap(img, "dome", 1.0, 1, 3, false)
+	if err != nil {
+		t.Fatalf("GenerateNormalMap() error = %v", err)
+	}
+
+	left := DecodeNormal(nm.NRGBAAt(0, 6), false)
+	right := DecodeNormal(nm.NRGBAAt(11, 6), false)
+	if math.Abs(left.X+right.X) > 0.05 {
+		t.Errorf("dome not symmetric: left X = %f, right X = %f", left.X, right.X)
+	}
+	if left.X >= 0 || right.X <= 0 {
+		t.Errorf("dome edges should face outward: left X = %f, right X = %f", left.X, right.X)
+	}
+}
+
+func TestGenerateNormalMap_InvalidParams(t *testing.T) {
+	img := newSolidSquare(8, 1, color.RGBA{R: 255, A: 255})
+
+	tests := []struct {
+		name       string
+		method     string
+		strength   float64
+		smoothing  int
+		bevelWidth int
+	}{
+		{name: "unknown method", method: "emboss", strength: 1, bevelWidth: 3},
+		{name: "strength too low", method: "sobel", strength: 0.01, bevelWidth: 3},
+		{name: "strength too high", method: "sobel", strength: 11, bevelWidth: 3},
+		{name: "smoothing too high", method: "sobel", strength: 1, smoothing: 9, bevelWidth: 3},
+		{name: "bevel width zero", method: "bevel", strength: 1, bevelWidth: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GenerateNormalMap(img, tt.method, tt.strength, tt.smoothing, tt.bevelWidth, false); err == nil {
+				t.Error("GenerateNormalMap() expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestEncodeDecodeNormal_RoundTrip(t *testing.T) {
+	normals := []Vector3D{
+		{X: 0, Y: 0, Z: 1},
+		{X: 0.6, Y: 0, Z: 0.8},
+		{X: 0, Y: -0.6, Z: 0.8},
+		Vector3D{X: -0.3, Y: 0.4, Z: 0.5}.Normalize(),
+	}
+
+	for _, invertY := range []bool{false, true} {
+		for _, n := range normals {
+			got := DecodeNormal(EncodeNormal(n, invertY, 255), invertY)
+			if math.Abs(got.X-n.X) > 0.02 || math.Abs(got.Y-n.Y) > 0.02 || math.Abs(got.Z-n.Z) > 0.02 {
+				t.Errorf("round trip (invertY=%v) of %+v = %+v", invertY, n, got)
+			}
+		}
+	}
+
+	flat := EncodeNormal(Vector3D{X: 0, Y: 0, Z: 1}, false, 255)
+	if flat.R != 128 || flat.G != 128 || flat.B != 255 {
+		t.Errorf("flat normal encoded as %+v, want (128,128,255)", flat)
+	}
+}
+
+func TestGenerateNormalMap_TranslucentPixels(t *testing.T) {
+	img := newSolidSquare(16, 2, color.RGBA{R: 64, G: 64, B: 64, A: 64})
+
+	nm, err := GenerateNormalMap(img, "bevel", 1.0, 0, 3, false)
+	if err != nil {
+		t.Fatalf("GenerateNormalMap() error = %v", err)
+	}
+
+	// Translucent pixels keep their alpha and still encode a valid normal when
+	// read through the generic color interface
+	c := color.NRGBAModel.Convert(nm.At(8, 8)).(color.NRGBA)
+	if c.A != 64 {
+		t.Errorf("translucent pixel alpha = %d, want 64", c.A)
+	}
+	if n := DecodeNormal(c, false); n.Z < 0.99 {
+		t.Errorf("translucent plateau normal = %+v, want facing viewer", n)
+	}
+}
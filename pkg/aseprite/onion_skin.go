@@ -0,0 +1,70 @@
+package aseprite
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// OnionSkinFrame is a neighboring frame drawn behind the current frame of an
+// onion skin preview.
+type OnionSkinFrame struct {
+	Image   image.Image // Flattened frame image
+	Tint    color.RGBA  // Tint color (alpha ignored)
+	Opacity float64     // Opacity of the frame (0.0-1.0)
+}
+
+// OnionSkin composites a frame over tinted, semi-transparent neighboring
+// frames.
+//
+// The neighbors are drawn in order, so pass the farthest frames first. Each
+// neighbor pixel's color is interpolated towards the neighbor's tint by
+// tintStrength and its alpha is multiplied by the neighbor's opacity. The
+// current frame is drawn on top at full opacity. All images must have the
+// same bounds.
+//
+// Parameters:
+//   - current: flattened image of the frame being previewed
+//   - neighbors: previous and next frames, farthest first
+//   - tintStrength: how far neighbor colors move towards their tint (0.0-1.0)
+func OnionSkin(current image.Image, neighbors []OnionSkinFrame, tintStrength float64) (*image.NRGBA, error) {
+	bounds := current.Bounds()
+	result := image.NewNRGBA(bounds)
+
+	for i, n := range neighbors {
+		if n.Image.Bounds() != bounds {
+			return nil, fmt.Errorf("neighbor %d must have the same bounds as the current frame, got %v and %v", i, n.Image.Bounds(), bounds)
+		}
+
+		ghost := image.NewNRGBA(bounds)
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				c := color.NRGBAModel.Convert(n.Image.At(x, y)).(color.NRGBA)
+				if c.A == 0 {
+					continue
+				}
+
+				ghost.SetNRGBA(x, y, color.NRGBA{
+					R: uint8(math.Round(float64(c.R) + (float64(n.Tint.R)-float64(c.R))*tintStrength)),
+					G: uint8(math.Round(float64(c.G) + (float64(n.Tint.G)-float64(c.G))*tintStrength)),
+					B: uint8(math.Round(float64(c.B) + (float64(n.Tint.B)-float64(c.B))*tintStrength)),
+					A: uint8(math.Round(float64(c.A) * n.Opacity)),
+				})
+			}
+		}
+
+		draw.Draw(result, bounds, ghost, bounds.Min, draw.Over)
+	}
+
+	draw.Draw(result, bounds, current, bounds.Min, draw.Over)
+	return result, nil
+}
+
+// OnionSkinOpacity returns the opacity of a neighbor distance frames away from
+// the current frame. The nearest neighbor gets opacity and each further frame
+// is multiplied by falloff.
+func OnionSkinOpacity(opacity, falloff float64, distance int) float64 {
+	return opacity * math.Pow(falloff, float64(distance-1))
+}
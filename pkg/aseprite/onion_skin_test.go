@@ -0,0 +1,85 @@
+package aseprite
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestOnionSkin_TintsNeighborsBehindCurrentFrame(t *testing.T) {
+	// The current frame covers the left half, the neighbor the whole image
+	current := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	neighbor := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			if x < 2 {
+				current.SetNRGBA(x, y, color.NRGBA{R: 10, G: 20, B: 30, A: 255})
+			}
+			neighbor.SetNRGBA(x, y, color.NRGBA{R: 200, G: 200, B: 200, A: 255})
+		}
+	}
+
+	result, err := OnionSkin(current, []OnionSkinFrame{
+		{Image: neighbor, Tint: color.RGBA{R: 255, G: 0, B: 0, A: 255}, Opacity: 0.5},
+	}, 0.5)
+	if err != nil {
+		t.Fatalf("OnionSkin() error = %v", err)
+	}
+
+	if got := result.NRGBAAt(0, 0); got != (color.NRGBA{R: 10, G: 20, B: 30, A: 255}) {
+		t.Errorf("current frame pixel = %+v, want it drawn on top unchanged", got)
+	}
+
+	got := result.NRGBAAt(3, 1)
+	if got.R != 228 || got.G != 100 || got.B != 100 {
+		t.Errorf("ghost pixel color = %+v, want (228,100,100) halfway to the tint", got)
+	}
+	if got.A < 127 || got.A > 128 {
+		t.Errorf("ghost pixel alpha = %d, want half opacity", got.A)
+	}
+}
+
+func TestOnionSkin_NearerFramesDrawnOver(t *testing.T) {
+	current := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	far := newSolidSquare(2, 0, color.RGBA{R: 0, G: 0, B: 255, A: 255})
+	near := newSolidSquare(2, 0, color.RGBA{R: 255, G: 0, B: 0, A: 255})
+
+	result, err := OnionSkin(current, []OnionSkinFrame{
+		{Image: far, Opacity: 1},
+		{Image: near, Opacity: 1},
+	}, 0)
+	if err != nil {
+		t.Fatalf("OnionSkin() error = %v", err)
+	}
+
+	if got := result.NRGBAAt(1, 1); got != (color.NRGBA{R: 255, G: 0, B: 0, A: 255}) {
+		t.Errorf("pixel = %+v, want the nearer (last) frame on top", got)
+	}
+}
+
+func TestOnionSkin_MismatchedBounds(t *testing.T) {
+	current := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	neighbor := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+
+	if _, err := OnionSkin(current, []OnionSkinFrame{{Image: neighbor, Opacity: 1}}, 0.5); err == nil {
+		t.Error("OnionSkin() expected error for mismatched bounds")
+	}
+}
+
+func TestOnionSkinOpacity(t *testing.T) {
+	tests := []struct {
+		distance int
+		want     float64
+	}{
+		{1, 0.6},
+		{2, 0.3},
+		{3, 0.15},
+	}
+
+	for _, tt := range tests {
+		if got := OnionSkinOpacity(0.6, 0.5, tt.distance); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("OnionSkinOpacity(0.6, 0.5, %d) = %v, want %v", tt.distance, got, tt.want)
+		}
+	}
+}
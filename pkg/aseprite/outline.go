@@ -0,0 +1,260 @@
+package aseprite
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// OutlineSides selects which sides of a shape receive an outline.
+//
+// Diagonal (corner) outline pixels are only drawn when both adjacent sides are
+// enabled, so disabling a side removes it cleanly without leaving stray corners.
+type OutlineSides struct {
+	Top, Bottom, Left, Right bool
+}
+
+// AllOutlineSides enables the outline on every side.
+var AllOutlineSides = OutlineSides{Top: true, Bottom: true, Left: true, Right: true}
+
+// OutlineOptions configures RenderOutline.
+type OutlineOptions struct {
+	Mode         string         // "outside", "inside", or "selout"
+	Thickness    int            // Number of outline rings (1-10)
+	Color        color.RGBA     // Flat outline color (ignored with AutoColor or selout)
+	Connectivity int            // 4 (no diagonal corners) or 8
+	Sides        OutlineSides   // Sides of the shape to outline
+	AutoColor    bool           // Derive colors from the adjacent fill
+	Darken       float64        // Lightness reduction for derived colors (0.0-1.0)
+	Palette      []PaletteColor // Optional palette derived colors are picked from
+}
+
+// RenderOutline draws an outline around or inside the opaque pixels of an image.
+//
+// Modes:
+//   - "outside": transparent pixels bordering the shape become outline pixels,
+//     growing the silhouette by Thickness pixels.
+//   - "inside": opaque pixels on the shape's border are recolored, keeping the
+//     silhouette unchanged.
+//   - "selout": selective outline; like inside, but every outline pixel takes a
+//     darkened version of the fill it replaces, so the outline follows the colors
+//     of the sprite. Combine with Sides to leave the lit side open.
+//
+// Connectivity 4 only considers edge neighbors, producing outlines without
+// diagonal corner pixels; 8 also considers diagonal neighbors.
+//
+// With AutoColor (always on for selout) each outline color is derived from the
+// neighboring fill: the fill color is darkened by Darken in CIELAB lightness and,
+// when a palette is given, replaced by the closest darker palette color.
+//
+// Returns a new image with the same bounds as img.
+func RenderOutline(img image.Image, opts OutlineOptions) (*image.NRGBA, error) {
+	switch opts.Mode {
+	case "outside", "inside", "selout":
+	default:
+		return nil, fmt.Errorf("invalid outline mode: %s (must be outside, inside, or selout)", opts.Mode)
+	}
+	if opts.Thickness < 1 || opts.Thickness > 10 {
+		return nil, fmt.Errorf("thickness must be between 1 and 10, got %d", opts.Thickness)
+	}
+	if opts.Connectivity != 4 && opts.Connectivity != 8 {
+		return nil, fmt.Errorf("connectivity must be 4 or 8, got %d", opts.Connectivity)
+	}
+	if opts.Darken < 0.0 || opts.Darken > 1.0 {
+		return nil, fmt.Errorf("darken must be between 0.0 and 1.0, got %f", opts.Darken)
+	}
+	if opts.Sides == (OutlineSides{}) {
+		return nil, fmt.Errorf("at least one outline side must be enabled")
+	}
+
+	bounds := img.Bounds()
+	result := image.NewNRGBA(bounds)
+	draw.Draw(result, bounds, img, bounds.Min, draw.Src)
+
+	autoColor := opts.AutoColor || opts.Mode == "selout"
+	flat := color.NRGBA{R: opts.Color.R, G: opts.Color.G, B: opts.Color.B, A: opts.Color.A}
+	derived := make(map[color.NRGBA]color.NRGBA)
+
+	// deriveColor darkens a fill color, caching results per color.
+	deriveColor := func(fill color.NRGBA) color.NRGBA {
+		key := color.NRGBA{R: fill.R, G: fill.G, B: fill.B, A: 255}
+		c, ok := derived[key]
+		if !ok {
+			c = darkenThroughPalette(key, opts.Darken, opts.Palette)
+			derived[key] = c
+		}
+		return c
+	}
+
+	// Shape membership is tracked separately from pixel colors so that inside
+	// rings shrink the shape and outside rings grow it.
+	shape := make([][]bool, bounds.Dy())
+	for y := range shape {
+		shape[y] = make([]bool, bounds.Dx())
+		for x := range shape[y] {
+			shape[y][x] = result.NRGBAAt(bounds.Min.X+x, bounds.Min.Y+y).A > 0
+		}
+	}
+
+	inShape := func(x, y int) bool {
+		if x < 0 || y < 0 || y >= len(shape) || x >= len(shape[y]) {
+			return false
+		}
+		return shape[y][x]
+	}
+
+	offsets := outlineOffsets(opts.Connectivity, opts.Sides)
+
+	for ring := 0; ring < opts.Thickness; ring++ {
+		type hit struct {
+			x, y int
+			c    color.NRGBA
+		}
+		var hits []hit
+
+		for y := range shape {
+			for x := range shape[y] {
+				if opts.Mode == "outside" {
+					if shape[y][x] {
+						continue
+					}
+					// The neighbor in direction d is inside the shape; the outline
+					// pixel lies on the opposite side of that neighbor.
+					for _, d := range offsets {
+						nx, ny := x-d.X, y-d.Y
+						if !inShape(nx, ny) {
+							continue
+						}
+						c := flat
+						if autoColor {
+							c = deriveColor(result.NRGBAAt(bounds.Min.X+nx, bounds.Min.Y+ny))
+						}
+						hits = append(hits, hit{x, y, c})
+						break
+					}
+				} else {
+					if !shape[y][x] {
+						continue
+					}
+					for _, d := range offsets {
+						if inShape(x+d.X, y+d.Y) {
+							continue
+						}
+						c := flat
+						if autoColor {
+							c = deriveColor(result.NRGBAAt(bounds.Min.X+x, bounds.Min.Y+y))
+						}
+						hits = append(hits, hit{x, y, c})
+						break
+					}
+				}
+			}
+		}
+
+		if len(hits) == 0 {
+			break
+		}
+
+		for _, h := range hits {
+			px, py := bounds.Min.X+h.x, bounds.Min.Y+h.y
+			if opts.Mode == "outside" {
+				shape[h.y][h.x] = true
+				result.SetNRGBA(px, py, h.c)
+			} else {
+				shape[h.y][h.x] = false
+				// Inside outlines keep the silhouette, so the original alpha is kept
+				c := h.c
+				c.A = result.NRGBAAt(px, py).A
+				result.SetNRGBA(px, py, c)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// outlineOffsets returns the neighbor directions that mark a pixel as an edge.
+//
+// A direction d means "the outside of the shape is at d": for inside outlines the
+// neighbor at +d is outside the shape, and for outside outlines the pixel lies at
+// +d from a shape pixel. Directions are filtered by the enabled sides.
+func outlineOffsets(connectivity int, sides OutlineSides) []image.Point {
+	var offsets []image.Point
+	if sides.Left {
+		offsets = append(offsets, image.Pt(-1, 0))
+	}
+	if sides.Right {
+		offsets = append(offsets, image.Pt(1, 0))
+	}
+	if sides.Top {
+		offsets = append(offsets, image.Pt(0, -1))
+	}
+	if sides.Bottom {
+		offsets = append(offsets, image.Pt(0, 1))
+	}
+
+	if connectivity == 8 {
+		if sides.Top && sides.Left {
+			offsets = append(offsets, image.Pt(-1, -1))
+		}
+		if sides.Top && sides.Right {
+			offsets = append(offsets, image.Pt(1, -1))
+		}
+		if sides.Bottom && sides.Left {
+			offsets = append(offsets, image.Pt(-1, 1))
+		}
+		if sides.Bottom && sides.Right {
+			offsets = append(offsets, image.Pt(1, 1))
+		}
+	}
+
+	return offsets
+}
+
+// darkenThroughPalette darkens a color in CIELAB lightness and, if a palette is
+// given, picks the closest palette color that is darker than the original.
+//
+// Falls back to the closest palette color overall when the palette has nothing
+// darker, so the outline never leaves the palette.
+func darkenThroughPalette(c color.NRGBA, amount float64, palette []PaletteColor) color.NRGBA {
+	base := colorful.Color{R: float64(c.R) / 255.0, G: float64(c.G) / 255.0, B: float64(c.B) / 255.0}
+	l, a, b := base.Lab()
+	target := colorful.Lab(l*(1.0-amount), a, b).Clamped()
+
+	if len(palette) > 0 {
+		bestDist := math.MaxFloat64
+		var best colorful.Color
+		found := false
+
+		for _, pc := range palette {
+			candidate, err := colorful.Hex(pc.Color)
+			if err != nil {
+				continue
+			}
+			cl, _, _ := candidate.Lab()
+			if cl >= l-0.01 {
+				continue
+			}
+			if dist := colorDistanceLab(target, candidate); dist < bestDist {
+				bestDist = dist
+				best = candidate
+				found = true
+			}
+		}
+
+		if found {
+			target = best
+		} else if hex, _, err := FindClosestPaletteColor(target, palette); err == nil {
+			if pc, err := colorful.Hex(hex); err == nil {
+				target = pc
+			}
+		}
+	}
+
+	r, g, bl := target.RGB255()
+	return color.NRGBA{R: r, G: g, B: bl, A: 255}
+}
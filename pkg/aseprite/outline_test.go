@@ -0,0 +1,145 @@
+package aseprite
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestRenderOutline_OutsideConnectivity(t *testing.T) {
+	img := newSolidSquare(8, 3, color.RGBA{R: 255, A: 255})
+	black := color.RGBA{A: 255}
+
+	eight, err := RenderOutline(img, OutlineOptions{Mode: "outside", Thickness: 1, Color: black, Connectivity: 8, Sides: AllOutlineSides})
+	if err != nil {
+		t.Fatalf("RenderOutline() error = %v", err)
+	}
+	four, err := RenderOutline(img, OutlineOptions{Mode: "outside", Thickness: 1, Color: black, Connectivity: 4, Sides: AllOutlineSides})
+	if err != nil {
+		t.Fatalf("RenderOutline() error = %v", err)
+	}
+
+	// Square covers (3..4, 3..4); (2,3) is an edge neighbor, (2,2) a diagonal corner
+	if got := eight.NRGBAAt(2, 3); got != (color.NRGBA{A: 255}) {
+		t.Errorf("8-connected edge pixel = %+v, want black", got)
+	}
+	if got := eight.NRGBAAt(2, 2).A; got != 255 {
+		t.Errorf("8-connected corner alpha = %d, want 255", got)
+	}
+	if got := four.NRGBAAt(2, 2).A; got != 0 {
+		t.Errorf("4-connected corner alpha = %d, want 0", got)
+	}
+	if got := four.NRGBAAt(3, 3); got != (color.NRGBA{R: 255, A: 255}) {
+		t.Errorf("fill pixel changed to %+v", got)
+	}
+}
+
+func TestRenderOutline_InsideKeepsSilhouette(t *testing.T) {
+	img := newSolidSquare(10, 2, color.RGBA{R: 255, G: 255, A: 255})
+	blue := color.RGBA{B: 255, A: 255}
+
+	out, err := RenderOutline(img, OutlineOptions{Mode: "inside", Thickness: 2, Color: blue, Connectivity: 8, Sides: AllOutlineSides})
+	if err != nil {
+		t.Fatalf("RenderOutline() error = %v", err)
+	}
+
+	if got := out.NRGBAAt(1, 5).A; got != 0 {
+		t.Errorf("pixel outside the shape alpha = %d, want 0", got)
+	}
+	if got := out.NRGBAAt(2, 5); got != (color.NRGBA{B: 255, A: 255}) {
+		t.Errorf("outer ring = %+v, want blue", got)
+	}
+	if got := out.NRGBAAt(3, 5); got != (color.NRGBA{B: 255, A: 255}) {
+		t.Errorf("second ring = %+v, want blue", got)
+	}
+	if got := out.NRGBAAt(5, 5); got != (color.NRGBA{R: 255, G: 255, A: 255}) {
+		t.Errorf("interior = %+v, want original fill", got)
+	}
+}
+
+func TestRenderOutline_Sides(t *testing.T) {
+	img := newSolidSquare(8, 2, color.RGBA{R: 255, A: 255})
+	black := color.RGBA{A: 255}
+
+	out, err := RenderOutline(img, OutlineOptions{Mode: "outside", Thickness: 1, Color: black, Connectivity: 8, Sides: OutlineSides{Bottom: true, Right: true}})
+	if err != nil {
+		t.Fatalf("RenderOutline() error = %v", err)
+	}
+
+	if got := out.NRGBAAt(4, 6).A; got != 255 {
+		t.Errorf("bottom outline alpha = %d, want 255", got)
+	}
+	if got := out.NRGBAAt(6, 4).A; got != 255 {
+		t.Errorf("right outline alpha = %d, want 255", got)
+	}
+	if got := out.NRGBAAt(6, 6).A; got != 255 {
+		t.Errorf("bottom-right corner alpha = %d, want 255", got)
+	}
+	if got := out.NRGBAAt(4, 1).A; got != 0 {
+		t.Errorf("top outline alpha = %d, want 0", got)
+	}
+	if got := out.NRGBAAt(1, 4).A; got != 0 {
+		t.Errorf("left outline alpha = %d, want 0", got)
+	}
+	if got := out.NRGBAAt(6, 1).A; got != 0 {
+		t.Errorf("top-right corner alpha = %d, want 0 (top disabled)", got)
+	}
+}
+
+func TestRenderOutline_SelOutUsesPalette(t *testing.T) {
+	img := newSolidSquare(8, 1, color.RGBA{R: 200, G: 40, B: 40, A: 255})
+	palette := []PaletteColor{
+		{Color: "#C82828"}, // the fill itself
+		{Color: "#641414"}, // dark red
+		{Color: "#000080"}, // dark blue
+		{Color: "#FFFFFF"},
+	}
+
+	out, err := RenderOutline(img, OutlineOptions{Mode: "selout", Thickness: 1, Connectivity: 8, Darken: 0.4, Sides: AllOutlineSides, Palette: palette})
+	if err != nil {
+		t.Fatalf("RenderOutline() error = %v", err)
+	}
+
+	if got := out.NRGBAAt(1, 4); got != (color.NRGBA{R: 0x64, G: 0x14, B: 0x14, A: 255}) {
+		t.Errorf("selout edge = %+v, want dark red from palette", got)
+	}
+	if got := out.NRGBAAt(4, 4); got != (color.NRGBA{R: 200, G: 40, B: 40, A: 255}) {
+		t.Errorf("interior = %+v, want original fill", got)
+	}
+}
+
+func TestRenderOutline_AutoColorDarkens(t *testing.T) {
+	img := newSolidSquare(8, 2, color.RGBA{R: 100, G: 180, B: 100, A: 255})
+
+	out, err := RenderOutline(img, OutlineOptions{Mode: "outside", Thickness: 1, Connectivity: 4, AutoColor: true, Darken: 0.5, Sides: AllOutlineSides})
+	if err != nil {
+		t.Fatalf("RenderOutline() error = %v", err)
+	}
+
+	got := out.NRGBAAt(1, 4)
+	if got.A != 255 || got.G >= 180 || got.G <= got.R {
+		t.Errorf("auto outline = %+v, want a darker green", got)
+	}
+}
+
+func TestRenderOutline_InvalidOptions(t *testing.T) {
+	img := newSolidSquare(4, 1, color.RGBA{R: 255, A: 255})
+
+	tests := []struct {
+		name string
+		opts OutlineOptions
+	}{
+		{name: "unknown mode", opts: OutlineOptions{Mode: "double", Thickness: 1, Connectivity: 8, Sides: AllOutlineSides}},
+		{name: "zero thickness", opts: OutlineOptions{Mode: "outside", Connectivity: 8, Sides: AllOutlineSides}},
+		{name: "bad connectivity", opts: OutlineOptions{Mode: "outside", Thickness: 1, Connectivity: 6, Sides: AllOutlineSides}},
+		{name: "no sides", opts: OutlineOptions{Mode: "outside", Thickness: 1, Connectivity: 8}},
+		{name: "darken too high", opts: OutlineOptions{Mode: "selout", Thickness: 1, Connectivity: 8, Darken: 2, Sides: AllOutlineSides}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RenderOutline(img, tt.opts); err == nil {
+				t.Error("RenderOutline() expected error, got nil")
+			}
+		})
+	}
+}
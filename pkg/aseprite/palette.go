@@ -299,3 +299,46 @@ func FindClosestPaletteColor(targetColor colorful.Color, palette []PaletteColor)
 
 	return palette[minIdx].Color, minIdx, nil
 }
+
+// SnapImageToPalette replaces every visible pixel of img with its closest palette
+// color (CIELAB distance), leaving alpha untouched. Lookups are cached per color,
+// so images with few distinct colors snap quickly.
+func SnapImageToPalette(img *image.NRGBA, palette []PaletteColor) error {
+	if len(palette) == 0 {
+		return fmt.Errorf("palette is empty")
+	}
+
+	cache := make(map[color.NRGBA]color.NRGBA)
+	bounds := img.Bounds()
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := img.NRGBAAt(x, y)
+			if c.A == 0 {
+				continue
+			}
+
+			key := color.NRGBA{R: c.R, G: c.G, B: c.B, A: 255}
+			snapped, ok := cache[key]
+			if !ok {
+				target := colorful.Color{R: float64(c.R) / 255.0, G: float64(c.G) / 255.0, B: float64(c.B) / 255.0}
+				hex, _, err := FindClosestPaletteColor(target, palette)
+				if err != nil {
+					return err
+				}
+				pc, err := colorful.Hex(hex)
+				if err != nil {
+					return fmt.Errorf("invalid palette color %s: %w", hex, err)
+				}
+				r, g, b := pc.RGB255()
+				snapped = color.NRGBA{R: r, G: g, B: b, A: 255}
+				cache[key] = snapped
+			}
+
+			snapped.A = c.A
+			img.SetNRGBA(x, y, snapped)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package aseprite
+
+import "fmt"
+
+// CyclePaletteRange returns a copy of colors with the entries from start to end
+// (0-based, inclusive) rotated by shift positions.
+//
+// Positive shifts move each color toward higher indices, wrapping the color at
+// end around to start; negative shifts move colors toward lower indices.
+// Entries outside the range are unchanged. Rotating by the length of the range
+// returns the original colors, which is what makes palette cycles loop.
+func CyclePaletteRange(colors []string, start, end, shift int) ([]string, error) {
+	if start < 0 || end >= len(colors) || start >= end {
+		return nil, fmt.Errorf("invalid cycle range %d-%d for a palette of %d colors", start, end, len(colors))
+	}
+
+	n := end - start + 1
+	shift = ((shift % n) + n) % n
+
+	cycled := make([]string, len(colors))
+	copy(cycled, colors)
+	for i := 0; i < n; i++ {
+		cycled[start+(i+shift)%n] = colors[start+i]
+	}
+	return cycled, nil
+}
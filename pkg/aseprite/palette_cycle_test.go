@@ -0,0 +1,47 @@
+package aseprite
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCyclePaletteRange(t *testing.T) {
+	colors := []string{"#000000", "#111111", "#222222", "#333333", "#444444"}
+
+	tests := []struct {
+		name  string
+		shift int
+		want  []string
+	}{
+		{"forward", 1, []string{"#000000", "#333333", "#111111", "#222222", "#444444"}},
+		{"backward", -1, []string{"#000000", "#222222", "#333333", "#111111", "#444444"}},
+		{"full cycle", 3, colors},
+		{"wraps", 4, []string{"#000000", "#333333", "#111111", "#222222", "#444444"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CyclePaletteRange(colors, 1, 3, tt.shift)
+			if err != nil {
+				t.Fatalf("CyclePaletteRange() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CyclePaletteRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if colors[1] != "#111111" {
+		t.Error("CyclePaletteRange() modified its input")
+	}
+}
+
+func TestCyclePaletteRange_InvalidRange(t *testing.T) {
+	colors := []string{"#000000", "#111111", "#222222"}
+
+	for _, r := range [][2]int{{-1, 1}, {1, 3}, {2, 2}, {2, 1}} {
+		if _, err := CyclePaletteRange(colors, r[0], r[1], 1); err == nil {
+			t.Errorf("CyclePaletteRange(%d, %d) expected error", r[0], r[1])
+		}
+	}
+}
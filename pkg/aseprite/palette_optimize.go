@@ -0,0 +1,219 @@
+package aseprite
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// Palette entry actions reported by OptimizePalette.
+const (
+	PaletteEntryKept        = "kept"        // Entry survives as its own color
+	PaletteEntryMerged      = "merged"      // Entry merged into a similar color
+	PaletteEntryRemoved     = "removed"     // Entry unused and dropped
+	PaletteEntryTransparent = "transparent" // Transparent index, always kept
+)
+
+// PaletteOptimizeOptions configures OptimizePalette.
+type PaletteOptimizeOptions struct {
+	RemoveUnused   bool    // Drop entries no pixel uses
+	MergeThreshold float64 // CIEDE2000 ΔE at or below which entries are merged (negative = never merge)
+	SortBy         string  // "none" (keep order), "usage" (most used first), or "ramp" (hue ramps, dark to light)
+}
+
+// PaletteEntryChange describes what happened to one original palette entry.
+type PaletteEntryChange struct {
+	OldIndex int    `json:"old_index"`           // Index in the original palette
+	NewIndex int    `json:"new_index"`           // Index in the optimized palette (-1 if removed)
+	Color    string `json:"color"`               // Original color
+	NewColor string `json:"new_color,omitempty"` // Color the entry maps to (omitted if removed)
+	Pixels   int    `json:"pixels"`              // Pixels using the original entry
+	Action   string `json:"action"`              // "kept", "merged", "removed", or "transparent"
+}
+
+// PaletteOptimization is the result of OptimizePalette.
+type PaletteOptimization struct {
+	Colors  []string             // Optimized palette
+	Mapping []int                // Original index -> new index (-1 if removed)
+	Changes []PaletteEntryChange // One entry per original index
+}
+
+// OptimizePalette plans the cleanup of an indexed sprite palette.
+//
+// colors are the palette entries (#RRGGBB or #RRGGBBAA) and counts the number
+// of pixels using each entry. The transparent index (ignored when negative or
+// out of range) is always kept and stays at its index when possible.
+//
+// Unused entries are dropped when RemoveUnused is set. Remaining entries are
+// visited from most to least used, and each entry within MergeThreshold ΔE of
+// an already kept entry with the same alpha is merged into it, so the more
+// common color wins. The surviving entries are then ordered by SortBy.
+func OptimizePalette(colors []string, counts []int, transparentIndex int, opts PaletteOptimizeOptions) (*PaletteOptimization, error) {
+	if len(colors) == 0 {
+		return nil, fmt.Errorf("palette is empty")
+	}
+	if len(counts) != len(colors) {
+		return nil, fmt.Errorf("got %d usage counts for %d palette colors", len(counts), len(colors))
+	}
+	switch opts.SortBy {
+	case "none", "usage", "ramp":
+	default:
+		return nil, fmt.Errorf("invalid sort: %s (must be none, usage, or ramp)", opts.SortBy)
+	}
+
+	parsed := make([]Color, len(colors))
+	lab := make([]colorful.Color, len(colors))
+	for i, s := range colors {
+		if err := parsed[i].FromHex(s); err != nil {
+			return nil, fmt.Errorf("invalid color at index %d: %w", i, err)
+		}
+		lab[i] = colorful.Color{R: float64(parsed[i].R) / 255.0, G: float64(parsed[i].G) / 255.0, B: float64(parsed[i].B) / 255.0}
+	}
+	if transparentIndex >= len(colors) {
+		transparentIndex = -1
+	}
+
+	changes := make([]PaletteEntryChange, len(colors))
+	for i := range colors {
+		changes[i] = PaletteEntryChange{OldIndex: i, NewIndex: -1, Color: paletteEntryHex(parsed[i]), Pixels: counts[i]}
+	}
+
+	// Visit entries from most to least used so merges keep the common color
+	order := make([]int, 0, len(colors))
+	for i := range colors {
+		order = append(order, i)
+	}
+	sort.SliceStable(order, func(a, b int) bool { return counts[order[a]] > counts[order[b]] })
+
+	target := make([]int, len(colors)) // Original index -> surviving original index
+	var kept []int
+	for _, i := range order {
+		target[i] = i
+		switch {
+		case i == transparentIndex:
+			changes[i].Action = PaletteEntryTransparent
+		case opts.RemoveUnused && counts[i] == 0:
+			changes[i].Action = PaletteEntryRemoved
+			target[i] = -1
+			continue
+		default:
+			changes[i].Action = PaletteEntryKept
+			for _, k := range kept {
+				if k == transparentIndex || parsed[k].A != parsed[i].A {
+					continue
+				}
+				if lab[i].DistanceCIEDE2000(lab[k])*100 <= opts.MergeThreshold {
+					changes[i].Action = PaletteEntryMerged
+					target[i] = k
+					break
+				}
+			}
+		}
+		if target[i] == i {
+			kept = append(kept, i)
+		}
+	}
+
+	// Order the survivors (transparent entry handled separately)
+	var survivors []int
+	for _, i := range kept {
+		if i != transparentIndex {
+			survivors = append(survivors, i)
+		}
+	}
+	switch opts.SortBy {
+	case "none":
+		sort.Ints(survivors)
+	case "usage":
+		sort.SliceStable(survivors, func(a, b int) bool {
+			ca, cb := pixelsMergedInto(survivors[a], target, counts), pixelsMergedInto(survivors[b], target, counts)
+			if ca != cb {
+				return ca > cb
+			}
+			return survivors[a] < survivors[b]
+		})
+	case "ramp":
+		survivors = rampOrder(survivors, lab)
+	}
+
+	newOrder := survivors
+	if transparentIndex >= 0 {
+		pos := min(transparentIndex, len(survivors))
+		newOrder = make([]int, 0, len(survivors)+1)
+		newOrder = append(newOrder, survivors[:pos]...)
+		newOrder = append(newOrder, transparentIndex)
+		newOrder = append(newOrder, survivors[pos:]...)
+	}
+	if len(newOrder) == 0 {
+		return nil, fmt.Errorf("no palette entries are used")
+	}
+
+	result := &PaletteOptimization{
+		Colors:  make([]string, len(newOrder)),
+		Mapping: make([]int, len(colors)),
+		Changes: changes,
+	}
+	newIndex := make(map[int]int, len(newOrder))
+	for n, i := range newOrder {
+		newIndex[i] = n
+		result.Colors[n] = paletteEntryHex(parsed[i])
+	}
+	for i := range colors {
+		result.Mapping[i] = -1
+		if target[i] >= 0 {
+			result.Mapping[i] = newIndex[target[i]]
+			result.Changes[i].NewIndex = result.Mapping[i]
+			result.Changes[i].NewColor = result.Colors[result.Mapping[i]]
+		}
+	}
+
+	return result, nil
+}
+
+// pixelsMergedInto returns the pixel count of entry k including the entries
+// merged into it.
+func pixelsMergedInto(k int, target, counts []int) int {
+	total := 0
+	for i, t := range target {
+		if t == k {
+			total += counts[i]
+		}
+	}
+	return total
+}
+
+// rampOrder sorts palette indices into hue ramps (largest first), each from
+// dark to light, with grays last.
+func rampOrder(indices []int, lab []colorful.Color) []int {
+	colors := make([]colorful.Color, len(indices))
+	labels := make([]string, len(indices))
+	for n, i := range indices {
+		colors[n] = lab[i]
+		labels[n] = strconv.Itoa(i)
+	}
+
+	ramps := groupRamps(colors, labels)
+
+	// Grays read best at the end of a ramp-sorted palette
+	sort.SliceStable(ramps, func(a, b int) bool { return !ramps[a].neutral && ramps[b].neutral })
+
+	ordered := make([]int, 0, len(indices))
+	for _, r := range ramps {
+		for _, label := range r.hex {
+			i, _ := strconv.Atoi(label)
+			ordered = append(ordered, i)
+		}
+	}
+	return ordered
+}
+
+// paletteEntryHex formats a palette entry as #RRGGBB, or #RRGGBBAA when it is
+// not fully opaque.
+func paletteEntryHex(c Color) string {
+	if c.A == 255 {
+		return fmt.Sprintf("#%02X%02X%02X", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("#%02X%02X%02X%02X", c.R, c.G, c.B, c.A)
+}
@@ -0,0 +1,121 @@
+package aseprite
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOptimizePalette_RemovesAndMerges(t *testing.T) {
+	colors := []string{"#00000000", "#FF0000", "#FE0101", "#00FF00", "#0000FF"}
+	counts := []int{50, 10, 2, 0, 5}
+
+	result, err := OptimizePalette(colors, counts, 0, PaletteOptimizeOptions{
+		RemoveUnused:   true,
+		MergeThreshold: 3,
+		SortBy:         "none",
+	})
+	if err != nil {
+		t.Fatalf("OptimizePalette() error = %v", err)
+	}
+
+	wantColors := []string{"#00000000", "#FF0000", "#0000FF"}
+	if !reflect.DeepEqual(result.Colors, wantColors) {
+		t.Errorf("Colors = %v, want %v", result.Colors, wantColors)
+	}
+	wantMapping := []int{0, 1, 1, -1, 2}
+	if !reflect.DeepEqual(result.Mapping, wantMapping) {
+		t.Errorf("Mapping = %v, want %v", result.Mapping, wantMapping)
+	}
+
+	wantActions := []string{PaletteEntryTransparent, PaletteEntryKept, PaletteEntryMerged, PaletteEntryRemoved, PaletteEntryKept}
+	for i, c := range result.Changes {
+		if c.Action != wantActions[i] {
+			t.Errorf("Changes[%d].Action = %s, want %s", i, c.Action, wantActions[i])
+		}
+	}
+	if c := result.Changes[2]; c.NewIndex != 1 || c.NewColor != "#FF0000" || c.Pixels != 2 {
+		t.Errorf("merged entry = %+v, want new index 1 color #FF0000 with 2 pixels", c)
+	}
+	if c := result.Changes[3]; c.NewIndex != -1 || c.NewColor != "" {
+		t.Errorf("removed entry = %+v, want new index -1 and no color", c)
+	}
+}
+
+func TestOptimizePalette_MergeKeepsMoreUsedColor(t *testing.T) {
+	colors := []string{"#808080", "#818181"}
+
+	result, err := OptimizePalette(colors, []int{1, 9}, -1, PaletteOptimizeOptions{MergeThreshold: 3, SortBy: "none"})
+	if err != nil {
+		t.Fatalf("OptimizePalette() error = %v", err)
+	}
+	if !reflect.DeepEqual(result.Colors, []string{"#818181"}) {
+		t.Errorf("Colors = %v, want the more used #818181", result.Colors)
+	}
+}
+
+func TestOptimizePalette_AlphaAndThreshold(t *testing.T) {
+	colors := []string{"#FF0000", "#FF000080", "#FF0000"}
+
+	// Different alpha never merges; negative threshold keeps exact duplicates
+	result, err := OptimizePalette(colors, []int{1, 1, 1}, -1, PaletteOptimizeOptions{MergeThreshold: -1, SortBy: "none"})
+	if err != nil {
+		t.Fatalf("OptimizePalette() error = %v", err)
+	}
+	if len(result.Colors) != 3 {
+		t.Errorf("Colors = %v, want all 3 entries with merging disabled", result.Colors)
+	}
+
+	result, _ = OptimizePalette(colors, []int{1, 1, 1}, -1, PaletteOptimizeOptions{MergeThreshold: 0, SortBy: "none"})
+	if !reflect.DeepEqual(result.Colors, []string{"#FF0000", "#FF000080"}) {
+		t.Errorf("Colors = %v, want exact duplicate merged and translucent entry kept", result.Colors)
+	}
+}
+
+func TestOptimizePalette_SortByUsage(t *testing.T) {
+	colors := []string{"#000000", "#FF0000", "#00FF00", "#0000FF"}
+	counts := []int{100, 1, 30, 5}
+
+	result, err := OptimizePalette(colors, counts, 0, PaletteOptimizeOptions{MergeThreshold: -1, SortBy: "usage"})
+	if err != nil {
+		t.Fatalf("OptimizePalette() error = %v", err)
+	}
+
+	// Transparent entry stays at index 0, the rest by usage
+	want := []string{"#000000", "#00FF00", "#0000FF", "#FF0000"}
+	if !reflect.DeepEqual(result.Colors, want) {
+		t.Errorf("Colors = %v, want %v", result.Colors, want)
+	}
+	if !reflect.DeepEqual(result.Mapping, []int{0, 3, 1, 2}) {
+		t.Errorf("Mapping = %v, want [0 3 1 2]", result.Mapping)
+	}
+}
+
+func TestOptimizePalette_SortByRamp(t *testing.T) {
+	colors := []string{"#FFFFFF", "#60D060", "#104010", "#208020", "#000000"}
+	counts := []int{1, 1, 1, 1, 1}
+
+	result, err := OptimizePalette(colors, counts, -1, PaletteOptimizeOptions{MergeThreshold: -1, SortBy: "ramp"})
+	if err != nil {
+		t.Fatalf("OptimizePalette() error = %v", err)
+	}
+
+	want := []string{"#104010", "#208020", "#60D060", "#000000", "#FFFFFF"}
+	if !reflect.DeepEqual(result.Colors, want) {
+		t.Errorf("Colors = %v, want green ramp dark to light, then grays", result.Colors)
+	}
+}
+
+func TestOptimizePalette_Errors(t *testing.T) {
+	if _, err := OptimizePalette(nil, nil, -1, PaletteOptimizeOptions{SortBy: "none"}); err == nil {
+		t.Error("expected error for empty palette")
+	}
+	if _, err := OptimizePalette([]string{"#000000"}, []int{1, 2}, -1, PaletteOptimizeOptions{SortBy: "none"}); err == nil {
+		t.Error("expected error for mismatched counts")
+	}
+	if _, err := OptimizePalette([]string{"#000000"}, []int{1}, -1, PaletteOptimizeOptions{SortBy: "hue"}); err == nil {
+		t.Error("expected error for invalid sort")
+	}
+	if _, err := OptimizePalette([]string{"#000000"}, []int{0}, -1, PaletteOptimizeOptions{RemoveUnused: true, SortBy: "none"}); err == nil {
+		t.Error("expected error when every entry is removed")
+	}
+}
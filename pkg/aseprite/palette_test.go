@@ -315,3 +315,33 @@ func TestExtractPalette_EdgeCases(t *testing.T) {
 		t.Errorf("ExtractPalette() usage percentages sum to %.2f, want ~100", totalUsage)
 	}
 }
+
+func TestSnapImageToPalette(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 3, 1))
+	img.SetNRGBA(0, 0, color.NRGBA{R: 250, G: 10, B: 5, A: 255})
+	img.SetNRGBA(1, 0, color.NRGBA{R: 20, G: 30, B: 240, A: 128})
+	// (2,0) stays fully transparent
+
+	palette := []PaletteColor{
+		{Color: "#FF0000"},
+		{Color: "#0000FF"},
+	}
+
+	if err := SnapImageToPalette(img, palette); err != nil {
+		t.Fatalf("SnapImageToPalette() error = %v", err)
+	}
+
+	if got := img.NRGBAAt(0, 0); got != (color.NRGBA{R: 255, A: 255}) {
+		t.Errorf("pixel (0,0) = %+v, want pure red", got)
+	}
+	if got := img.NRGBAAt(1, 0); got != (color.NRGBA{B: 255, A: 128}) {
+		t.Errorf("pixel (1,0) = %+v, want pure blue with alpha 128", got)
+	}
+	if got := img.NRGBAAt(2, 0); got.A != 0 {
+		t.Errorf("transparent pixel alpha = %d, want 0", got.A)
+	}
+
+	if err := SnapImageToPalette(img, nil); err == nil {
+		t.Error("SnapImageToPalette() should return error for empty palette")
+	}
+}
@@ -0,0 +1,260 @@
+package aseprite
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// Thresholds used to group colors into ramps.
+const (
+	// neutralChroma is the OKLCH chroma below which a color counts as gray.
+	neutralChroma = 0.03
+
+	// rampHueGap splits colors into separate ramps where consecutive hues
+	// differ by more than this many degrees.
+	rampHueGap = 30.0
+
+	// rampMaxHueSpan limits how many degrees of hue a single ramp may cover;
+	// wider groups are split at their largest internal gap.
+	rampMaxHueSpan = 60.0
+)
+
+// colorRamp is a group of related colors sorted from dark to light.
+type colorRamp struct {
+	colors  []colorful.Color
+	hex     []string
+	neutral bool
+}
+
+// RotateHues maps each color to the same color with its OKLCH hue rotated by
+// degrees. Lightness and chroma are kept (chroma is reduced only when needed
+// to stay in the sRGB gamut), and near-gray colors are left unchanged.
+//
+// Keys are the input colors normalized to #RRGGBB; values are #RRGGBB.
+func RotateHues(colors []string, degrees float64) (map[string]string, error) {
+	parsed, hexes, err := parseHexColors(colors)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := make(map[string]string, len(parsed))
+	for i, c := range parsed {
+		l, ch, h := c.OkLch()
+		if ch < neutralChroma {
+			mapping[hexes[i]] = hexes[i]
+			continue
+		}
+		rotated := okLchInGamut(l, ch, math.Mod(h+degrees+360, 360))
+		mapping[hexes[i]] = strings.ToUpper(rotated.Hex())
+	}
+	return mapping, nil
+}
+
+// MatchRamps maps source colors onto a target palette by ramp position.
+//
+// Both color sets are grouped into ramps: grays form one ramp, and the
+// remaining colors are split into hue families. Each ramp is sorted by
+// lightness. Source ramps are paired with target ramps by size (largest with
+// largest, reusing target ramps when the source has more), and the gray ramps
+// are paired with each other when both exist. Within a pair, a color at
+// relative position p of its source ramp maps to the color at the same
+// relative position in the target ramp, so shadows stay shadows and
+// highlights stay highlights. Single-color ramps map to the target color
+// closest in lightness. Source grays with no gray target ramp map to the
+// perceptually closest target color.
+//
+// Keys are the source colors normalized to #RRGGBB; values are target colors
+// as #RRGGBB.
+func MatchRamps(source, target []string) (map[string]string, error) {
+	if len(target) == 0 {
+		return nil, fmt.Errorf("target palette is empty")
+	}
+
+	srcColors, srcHex, err := parseHexColors(source)
+	if err != nil {
+		return nil, err
+	}
+	dstColors, dstHex, err := parseHexColors(target)
+	if err != nil {
+		return nil, err
+	}
+
+	srcRamps := groupRamps(srcColors, srcHex)
+	dstRamps := groupRamps(dstColors, dstHex)
+
+	var dstNeutral *colorRamp
+	var dstChromatic []*colorRamp
+	for _, r := range dstRamps {
+		if r.neutral {
+			dstNeutral = r
+		} else {
+			dstChromatic = append(dstChromatic, r)
+		}
+	}
+	if len(dstChromatic) == 0 {
+		dstChromatic = dstRamps
+	}
+
+	mapping := make(map[string]string, len(srcHex))
+	next := 0
+	for _, src := range srcRamps {
+		if src.neutral {
+			if dstNeutral != nil {
+				mapRampPositions(src, dstNeutral, mapping)
+			} else {
+				for i, c := range src.colors {
+					mapping[src.hex[i]] = nearestColor(c, dstColors, dstHex)
+				}
+			}
+			continue
+		}
+
+		mapRampPositions(src, dstChromatic[next%len(dstChromatic)], mapping)
+		next++
+	}
+
+	return mapping, nil
+}
+
+// mapRampPositions maps each color of src to the color at the same relative
+// position in dst.
+func mapRampPositions(src, dst *colorRamp, mapping map[string]string) {
+	n, m := len(src.colors), len(dst.colors)
+	if n == 1 {
+		l, _, _ := src.colors[0].OkLch()
+		best, bestDiff := 0, math.Inf(1)
+		for j, c := range dst.colors {
+			dl, _, _ := c.OkLch()
+			if d := math.Abs(dl - l); d < bestDiff {
+				best, bestDiff = j, d
+			}
+		}
+		mapping[src.hex[0]] = dst.hex[best]
+		return
+	}
+
+	for i := range src.colors {
+		j := int(math.Round(float64(i) / float64(n-1) * float64(m-1)))
+		mapping[src.hex[i]] = dst.hex[j]
+	}
+}
+
+// groupRamps splits colors into a gray ramp and hue-family ramps, each sorted
+// from dark to light. Ramps are returned largest first.
+func groupRamps(colors []colorful.Color, hexes []string) []*colorRamp {
+	type entry struct {
+		c   colorful.Color
+		hex string
+		l   float64
+		h   float64
+	}
+
+	var neutral, chromatic []entry
+	for i, c := range colors {
+		l, ch, h := c.OkLch()
+		e := entry{c: c, hex: hexes[i], l: l, h: h}
+		if ch < neutralChroma {
+			neutral = append(neutral, e)
+		} else {
+			chromatic = append(chromatic, e)
+		}
+	}
+
+	// Split the hue circle into families
+	sort.Slice(chromatic, func(i, j int) bool { return chromatic[i].h < chromatic[j].h })
+	var families [][]entry
+	if len(chromatic) > 0 {
+		// Start at the largest gap so a family never straddles the 0/360 seam
+		start, largest := 0, -1.0
+		for i := range chromatic {
+			prev := chromatic[(i+len(chromatic)-1)%len(chromatic)].h
+			gap := math.Mod(chromatic[i].h-prev+360, 360)
+			if len(chromatic) == 1 {
+				gap = 360
+			}
+			if gap > largest {
+				start, largest = i, gap
+			}
+		}
+		rotated := append(append([]entry{}, chromatic[start:]...), chromatic[:start]...)
+
+		var split func(group []entry)
+		split = func(group []entry) {
+			// Find the largest internal gap and the total span
+			span, cut, widest := 0.0, -1, 0.0
+			for i := 1; i < len(group); i++ {
+				gap := math.Mod(group[i].h-group[i-1].h+360, 360)
+				span += gap
+				if gap > widest {
+					cut, widest = i, gap
+				}
+			}
+			if cut > 0 && (widest > rampHueGap || span > rampMaxHueSpan) {
+				split(group[:cut])
+				split(group[cut:])
+				return
+			}
+			families = append(families, group)
+		}
+		split(rotated)
+	}
+	if len(neutral) > 0 {
+		families = append(families, neutral)
+	}
+
+	ramps := make([]*colorRamp, 0, len(families))
+	for i, family := range families {
+		sort.SliceStable(family, func(a, b int) bool { return family[a].l < family[b].l })
+		r := &colorRamp{neutral: len(neutral) > 0 && i == len(families)-1}
+		for _, e := range family {
+			r.colors = append(r.colors, e.c)
+			r.hex = append(r.hex, e.hex)
+		}
+		ramps = append(ramps, r)
+	}
+
+	sort.SliceStable(ramps, func(i, j int) bool { return len(ramps[i].colors) > len(ramps[j].colors) })
+	return ramps
+}
+
+// nearestColor returns the hex of the palette color closest to c in OKLab.
+func nearestColor(c colorful.Color, palette []colorful.Color, hexes []string) string {
+	best, bestDist := 0, math.Inf(1)
+	for i, p := range palette {
+		l1, a1, b1 := c.OkLab()
+		l2, a2, b2 := p.OkLab()
+		d := (l1-l2)*(l1-l2) + (a1-a2)*(a1-a2) + (b1-b2)*(b1-b2)
+		if d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return hexes[best]
+}
+
+// parseHexColors parses #RRGGBB or #RRGGBBAA colors (alpha is ignored) and
+// returns them with their #RRGGBB form. Duplicate colors are dropped.
+func parseHexColors(colors []string) ([]colorful.Color, []string, error) {
+	parsed := make([]colorful.Color, 0, len(colors))
+	hexes := make([]string, 0, len(colors))
+	seen := make(map[string]bool, len(colors))
+
+	for _, s := range colors {
+		var c Color
+		if err := c.FromHex(s); err != nil {
+			return nil, nil, fmt.Errorf("invalid color %q: %w", s, err)
+		}
+		hex := fmt.Sprintf("#%02X%02X%02X", c.R, c.G, c.B)
+		if seen[hex] {
+			continue
+		}
+		seen[hex] = true
+
+		parsed = append(parsed, colorful.Color{R: float64(c.R) / 255.0, G: float64(c.G) / 255.0, B: float64(c.B) / 255.0})
+		hexes = append(hexes, hex)
+	}
+	return parsed, hexes, nil
+}
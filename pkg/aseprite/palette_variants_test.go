@@ -0,0 +1,163 @@
+package aseprite
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+func TestRotateHues(t *testing.T) {
+	mapping, err := RotateHues([]string{"#C04040", "#808080", "#c04040ff"}, 120)
+	if err != nil {
+		t.Fatalf("RotateHues() error = %v", err)
+	}
+	if len(mapping) != 2 {
+		t.Fatalf("got %d mappings, want 2 (duplicates merged): %v", len(mapping), mapping)
+	}
+
+	if got := mapping["#808080"]; got != "#808080" {
+		t.Errorf("gray mapped to %s, want unchanged", got)
+	}
+
+	src, _ := colorful.Hex("#C04040")
+	dst, err := colorful.Hex(mapping["#C04040"])
+	if err != nil {
+		t.Fatalf("invalid rotated color %q", mapping["#C04040"])
+	}
+	srcL, _, srcH := src.OkLch()
+	dstL, _, dstH := dst.OkLch()
+	if math.Abs(dstL-srcL) > 0.02 {
+		t.Errorf("rotated lightness %.3f differs from %.3f", dstL, srcL)
+	}
+	if diff := math.Mod(dstH-srcH+360, 360); math.Abs(diff-120) > 5 {
+		t.Errorf("hue rotated by %.1f degrees, want about 120", diff)
+	}
+}
+
+func TestRotateHues_InvalidColor(t *testing.T) {
+	if _, err := RotateHues([]string{"red"}, 90); err == nil {
+		t.Error("RotateHues() expected error for invalid color")
+	}
+}
+
+func TestMatchRamps_KeepsRampPosition(t *testing.T) {
+	// A dark-to-light red ramp onto a dark-to-light blue ramp
+	source := []string{"#FF8080", "#400000", "#C02020", "#801010"}
+	target := []string{"#101060", "#8080FF", "#3030B0"}
+
+	mapping, err := MatchRamps(source, target)
+	if err != nil {
+		t.Fatalf("MatchRamps() error = %v", err)
+	}
+
+	want := map[string]string{
+		"#400000": "#101060",
+		"#801010": "#3030B0",
+		"#C02020": "#3030B0",
+		"#FF8080": "#8080FF",
+	}
+	for from, to := range want {
+		if mapping[from] != to {
+			t.Errorf("mapping[%s] = %s, want %s", from, mapping[from], to)
+		}
+	}
+}
+
+func TestMatchRamps_PairsRampsAndGrays(t *testing.T) {
+	// Source: 3-step green ramp, 2-step yellow ramp, and black/white
+	source := []string{
+		"#104010", "#208020", "#60D060",
+		"#807010", "#F0E060",
+		"#000000", "#FFFFFF",
+	}
+	// Target: 3-step purple ramp, 2-step orange ramp, and two grays
+	target := []string{
+		"#301040", "#702090", "#C070E0",
+		"#803000", "#F09050",
+		"#202020", "#E0E0E0",
+	}
+
+	mapping, err := MatchRamps(source, target)
+	if err != nil {
+		t.Fatalf("MatchRamps() error = %v", err)
+	}
+
+	want := map[string]string{
+		"#104010": "#301040",
+		"#208020": "#702090",
+		"#60D060": "#C070E0",
+		"#807010": "#803000",
+		"#F0E060": "#F09050",
+		"#000000": "#202020",
+		"#FFFFFF": "#E0E0E0",
+	}
+	for from, to := range want {
+		if mapping[from] != to {
+			t.Errorf("mapping[%s] = %s, want %s", from, mapping[from], to)
+		}
+	}
+}
+
+func TestMatchRamps_GraysWithoutGrayTarget(t *testing.T) {
+	mapping, err := MatchRamps([]string{"#000000", "#C03030"}, []string{"#200808", "#E04040"})
+	if err != nil {
+		t.Fatalf("MatchRamps() error = %v", err)
+	}
+	if got := mapping["#000000"]; got != "#200808" {
+		t.Errorf("black mapped to %s, want closest target #200808", got)
+	}
+}
+
+func TestMatchRamps_Errors(t *testing.T) {
+	if _, err := MatchRamps([]string{"#000000"}, nil); err == nil {
+		t.Error("MatchRamps() expected error for empty target")
+	}
+	if _, err := MatchRamps([]string{"bad"}, []string{"#000000"}); err == nil {
+		t.Error("MatchRamps() expected error for invalid source color")
+	}
+}
+
+func TestGroupRamps_SplitsHueFamilies(t *testing.T) {
+	colors, hexes, err := parseHexColors([]string{
+		"#FF0000", "#800000", // reds (wrap-around hues near 0/360)
+		"#FF0040",
+		"#00FF00", "#008000", // greens
+		"#0000FF", // blue
+		"#808080", // gray
+	})
+	if err != nil {
+		t.Fatalf("parseHexColors() error = %v", err)
+	}
+
+	ramps := groupRamps(colors, hexes)
+	if len(ramps) != 4 {
+		for _, r := range ramps {
+			t.Logf("ramp: %v neutral=%v", r.hex, r.neutral)
+		}
+		t.Fatalf("got %d ramps, want 4", len(ramps))
+	}
+	if len(ramps[0].hex) != 3 {
+		t.Errorf("largest ramp = %v, want the three reds", ramps[0].hex)
+	}
+
+	neutrals := 0
+	for _, r := range ramps {
+		if r.neutral {
+			neutrals++
+			if len(r.hex) != 1 || r.hex[0] != "#808080" {
+				t.Errorf("neutral ramp = %v, want [#808080]", r.hex)
+			}
+		}
+		for i := 1; i < len(r.colors); i++ {
+			prev, _, _ := r.colors[i-1].OkLch()
+			cur, _, _ := r.colors[i].OkLch()
+			if cur < prev {
+				t.Errorf("ramp %v not sorted by lightness", r.hex)
+			}
+		}
+	}
+	if neutrals != 1 {
+		t.Errorf("got %d neutral ramps, want 1", neutrals)
+	}
+}
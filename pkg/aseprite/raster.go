@@ -0,0 +1,350 @@
+package aseprite
+
+import (
+	"math"
+	"sort"
+)
+
+// Polygon fill rules supported by RasterizePolygon.
+const (
+	FillRuleEvenOdd = "even_odd" // A pixel is inside when a ray from it crosses the outline an odd number of times
+	FillRuleNonZero = "non_zero" // A pixel is inside when the outline winds around it a non-zero number of times
+)
+
+// RasterizeLine returns the pixels of a 1-pixel Bresenham line from a to b, inclusive.
+func RasterizeLine(a, b Point) []Point {
+	dx := absInt(b.X - a.X)
+	dy := -absInt(b.Y - a.Y)
+	sx, sy := 1, 1
+	if a.X > b.X {
+		sx = -1
+	}
+	if a.Y > b.Y {
+		sy = -1
+	}
+
+	points := make([]Point, 0, max(dx, -dy)+1)
+	x, y := a.X, a.Y
+	e := dx + dy
+	for {
+		points = append(points, Point{X: x, Y: y})
+		if x == b.X && y == b.Y {
+			return points
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y += sy
+		}
+	}
+}
+
+// RasterizeQuadraticBezier returns the pixels of a quadratic Bezier curve from
+// p0 to p2 with control point p1, in path order.
+//
+// The curve is sampled finely, gaps are closed with straight steps, and
+// L-shaped corners are removed so the result is a pixel-perfect, 8-connected
+// 1-pixel stroke.
+func RasterizeQuadraticBezier(p0, p1, p2 Point) []Point {
+	return rasterizeCurve(func(t float64) (float64, float64) {
+		u := 1 - t
+		x := u*u*float64(p0.X) + 2*u*t*float64(p1.X) + t*t*float64(p2.X)
+		y := u*u*float64(p0.Y) + 2*u*t*float64(p1.Y) + t*t*float64(p2.Y)
+		return x, y
+	}, polylineLength(p0, p1, p2))
+}
+
+// RasterizeCubicBezier returns the pixels of a cubic Bezier curve from p0 to
+// p3 with control points p1 and p2, in path order. Like
+// RasterizeQuadraticBezier, the result is a pixel-perfect 1-pixel stroke.
+func RasterizeCubicBezier(p0, p1, p2, p3 Point) []Point {
+	return rasterizeCurve(func(t float64) (float64, float64) {
+		u := 1 - t
+		a, b, c, d := u*u*u, 3*u*u*t, 3*u*t*t, t*t*t
+		x := a*float64(p0.X) + b*float64(p1.X) + c*float64(p2.X) + d*float64(p3.X)
+		y := a*float64(p0.Y) + b*float64(p1.Y) + c*float64(p2.Y) + d*float64(p3.Y)
+		return x, y
+	}, polylineLength(p0, p1, p2, p3))
+}
+
+// RasterizeEllipse returns the pixels of an axis-aligned ellipse centered on
+// (cx, cy) with horizontal radius rx and vertical radius ry, using the
+// midpoint ellipse algorithm. The outline is 8-connected and 1 pixel wide;
+// when filled is true the interior is included. A zero radius degenerates to
+// a straight line. Only pixels inside clip are returned, and filled rows are
+// clipped before they are walked, so large ellipses cost no more than clip.
+// Pixels are sorted by row, then column.
+func RasterizeEllipse(cx, cy, rx, ry int, filled bool, clip Rectangle) []Point {
+	rx, ry = absInt(rx), absInt(ry)
+
+	// halfWidth[y] is the largest x offset of the outline on row cy±y
+	halfWidth := make([]int, ry+1)
+	for i := range halfWidth {
+		halfWidth[i] = -1
+	}
+	var quadrant []Point
+	plot := func(x, y int) {
+		quadrant = append(quadrant, Point{X: x, Y: y})
+		halfWidth[y] = max(halfWidth[y], x)
+	}
+
+	switch {
+	case rx == 0:
+		for y := 0; y <= ry; y++ {
+			plot(0, y)
+		}
+	case ry == 0:
+		for x := 0; x <= rx; x++ {
+			plot(x, 0)
+		}
+	default:
+		rx2, ry2 := float64(rx*rx), float64(ry*ry)
+		x, y := 0, ry
+		px, py := 0.0, 2*rx2*float64(y)
+
+		// Region 1: slope shallower than -1
+		p := ry2 - rx2*float64(ry) + rx2/4
+		for px < py {
+			plot(x, y)
+			x++
+			px += 2 * ry2
+			if p < 0 {
+				p += ry2 + px
+			} else {
+				y--
+				py -= 2 * rx2
+				p += ry2 + px - py
+			}
+		}
+
+		// Region 2: slope steeper than -1
+		p = ry2*(float64(x)+0.5)*(float64(x)+0.5) + rx2*float64(y-1)*float64(y-1) - rx2*ry2
+		for y >= 0 {
+			plot(x, y)
+			y--
+			py -= 2 * rx2
+			if p > 0 {
+				p += rx2 - py
+			} else {
+				x++
+				px += 2 * ry2
+				p += rx2 - py + px
+			}
+		}
+	}
+
+	set := make(map[Point]bool)
+	if filled {
+		for y, w := range halfWidth {
+			if w < 0 {
+				continue
+			}
+			x0, x1 := max(cx-w, clip.X), min(cx+w, clip.X+clip.Width-1)
+			for _, row := range []int{cy + y, cy - y} {
+				if row < clip.Y || row >= clip.Y+clip.Height {
+					continue
+				}
+				for x := x0; x <= x1; x++ {
+					set[Point{X: x, Y: row}] = true
+				}
+			}
+		}
+	} else {
+		for _, q := range quadrant {
+			for _, p := range []Point{
+				{X: cx + q.X, Y: cy + q.Y},
+				{X: cx - q.X, Y: cy + q.Y},
+				{X: cx + q.X, Y: cy - q.Y},
+				{X: cx - q.X, Y: cy - q.Y},
+			} {
+				if clipContains(clip, p) {
+					set[p] = true
+				}
+			}
+		}
+	}
+	return sortedPoints(set)
+}
+
+// RasterizeArc returns the pixels of the part of an ellipse outline (see
+// RasterizeEllipse) between two angles in degrees.
+//
+// Angles are measured from the positive X axis towards the positive Y axis,
+// which is clockwise on screen: 0 is right, 90 is down, 180 is left, and 270
+// is up. The arc runs from startAngle to endAngle in that direction, wrapping
+// past 360 when endAngle is smaller; a sweep of 360 degrees or more draws
+// the whole ellipse. Only pixels inside clip are returned. Pixels are sorted
+// by row, then column.
+func RasterizeArc(cx, cy, rx, ry int, startAngle, endAngle float64, clip Rectangle) []Point {
+	outline := RasterizeEllipse(cx, cy, rx, ry, false, clip)
+
+	sweep := endAngle - startAngle
+	if sweep >= 360 || sweep <= -360 {
+		return outline
+	}
+	sweep = math.Mod(sweep+360, 360)
+	start := math.Mod(math.Mod(startAngle, 360)+360, 360)
+
+	const epsilon = 1e-9
+	points := make([]Point, 0, len(outline))
+	for _, p := range outline {
+		dx, dy := float64(p.X-cx), float64(p.Y-cy)
+		if dx == 0 && dy == 0 {
+			points = append(points, p)
+			continue
+		}
+		angle := math.Atan2(dy, dx) * 180 / math.Pi
+		offset := math.Mod(angle-start+720, 360)
+		if offset <= sweep+epsilon || offset >= 360-epsilon {
+			points = append(points, p)
+		}
+	}
+	return points
+}
+
+// RasterizePolygon returns the pixels of a filled polygon with the given
+// vertices, which is implicitly closed.
+//
+// Vertices are pixel centers, as with DrawContour. A pixel is filled when
+// its center is inside the polygon according to fillRule (FillRuleEvenOdd, or FillRuleNonZero for any other value). The
+// outline itself is always included, so the result covers the same edge
+// pixels as a closed 1-pixel contour through the vertices. Only pixels inside
+// clip are returned, and scanlines are clipped before they are filled, so
+// large polygons cost no more than clip. Pixels are sorted by row, then column.
+func RasterizePolygon(vertices []Point, fillRule string, clip Rectangle) []Point {
+	set := make(map[Point]bool)
+	if len(vertices) == 0 {
+		return nil
+	}
+
+	minY, maxY := vertices[0].Y, vertices[0].Y
+	for i, a := range vertices {
+		b := vertices[(i+1)%len(vertices)]
+		for _, p := range RasterizeLine(a, b) {
+			if clipContains(clip, p) {
+				set[p] = true
+			}
+		}
+		minY, maxY = min(minY, a.Y), max(maxY, a.Y)
+	}
+	minY, maxY = max(minY, clip.Y), min(maxY, clip.Y+clip.Height-1)
+
+	type crossing struct {
+		x       float64
+		winding int
+	}
+	for y := minY; y <= maxY; y++ {
+		yc := float64(y)
+
+		var crossings []crossing
+		for i, a := range vertices {
+			b := vertices[(i+1)%len(vertices)]
+			if a.Y == b.Y {
+				continue
+			}
+			lo, hi, winding := a, b, 1
+			if a.Y > b.Y {
+				lo, hi, winding = b, a, -1
+			}
+			if yc < float64(lo.Y) || yc >= float64(hi.Y) {
+				continue
+			}
+			t := (yc - float64(lo.Y)) / float64(hi.Y-lo.Y)
+			crossings = append(crossings, crossing{
+				x:       float64(lo.X) + t*float64(hi.X-lo.X),
+				winding: winding,
+			})
+		}
+		sort.Slice(crossings, func(i, j int) bool { return crossings[i].x < crossings[j].x })
+
+		// Fill pixels between crossings where the rule says "inside"
+		winding := 0
+		for i := 0; i+1 < len(crossings); i++ {
+			if fillRule == FillRuleEvenOdd {
+				winding ^= 1
+			} else {
+				winding += crossings[i].winding
+			}
+			if winding == 0 {
+				continue
+			}
+			x0 := max(int(math.Ceil(crossings[i].x)), clip.X)
+			x1 := min(int(math.Ceil(crossings[i+1].x))-1, clip.X+clip.Width-1)
+			for x := x0; x <= x1; x++ {
+				set[Point{X: x, Y: y}] = true
+			}
+		}
+	}
+
+	return sortedPoints(set)
+}
+
+// rasterizeCurve samples a parametric curve over t in [0, 1] and turns it into
+// a pixel-perfect 8-connected path. length is an upper bound of the curve
+// length in pixels, used to choose the sampling density.
+func rasterizeCurve(at func(t float64) (float64, float64), length float64) []Point {
+	steps := max(int(math.Ceil(length*4)), 1)
+
+	var path []Point
+	add := func(p Point) {
+		// Drop the middle of an L-shaped corner so diagonals stay 1 pixel thin
+		if n := len(path); n >= 2 {
+			a, b := path[n-2], path[n-1]
+			if absInt(a.X-p.X) == 1 && absInt(a.Y-p.Y) == 1 && (a.X == b.X || a.Y == b.Y) && (b.X == p.X || b.Y == p.Y) {
+				path = path[:n-1]
+			}
+		}
+		path = append(path, p)
+	}
+
+	for i := 0; i <= steps; i++ {
+		x, y := at(float64(i) / float64(steps))
+		p := Point{X: int(math.Round(x)), Y: int(math.Round(y))}
+
+		n := len(path)
+		switch {
+		case n > 0 && path[n-1] == p:
+			continue
+		case n > 0 && (absInt(path[n-1].X-p.X) > 1 || absInt(path[n-1].Y-p.Y) > 1):
+			for _, q := range RasterizeLine(path[n-1], p)[1:] {
+				add(q)
+			}
+		default:
+			add(p)
+		}
+	}
+	return path
+}
+
+// clipContains reports whether p lies inside clip.
+func clipContains(clip Rectangle, p Point) bool {
+	return p.X >= clip.X && p.X < clip.X+clip.Width && p.Y >= clip.Y && p.Y < clip.Y+clip.Height
+}
+
+// polylineLength returns the length of the polyline through points.
+func polylineLength(points ...Point) float64 {
+	var length float64
+	for i := 1; i < len(points); i++ {
+		length += math.Hypot(float64(points[i].X-points[i-1].X), float64(points[i].Y-points[i-1].Y))
+	}
+	return length
+}
+
+// sortedPoints returns the points of a set sorted by row, then column.
+func sortedPoints(set map[Point]bool) []Point {
+	points := make([]Point, 0, len(set))
+	for p := range set {
+		points = append(points, p)
+	}
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].Y != points[j].Y {
+			return points[i].Y < points[j].Y
+		}
+		return points[i].X < points[j].X
+	})
+	return points
+}
@@ -0,0 +1,262 @@
+package aseprite
+
+import (
+	"testing"
+)
+
+// testClip is a clip rectangle larger than every shape in these tests.
+var testClip = Rectangle{X: 0, Y: 0, Width: 64, Height: 64}
+
+// pointSet returns the points as a set.
+func pointSet(points []Point) map[Point]bool {
+	set := make(map[Point]bool, len(points))
+	for _, p := range points {
+		set[p] = true
+	}
+	return set
+}
+
+// assertPixelPerfect checks that a path is 8-connected, has no repeated
+// neighbors, and has no L-shaped corners.
+func assertPixelPerfect(t *testing.T, path []Point) {
+	t.Helper()
+	for i := 1; i < len(path); i++ {
+		a, b := path[i-1], path[i]
+		if a == b {
+			t.Errorf("path repeats %v at %d", a, i)
+		}
+		if absInt(a.X-b.X) > 1 || absInt(a.Y-b.Y) > 1 {
+			t.Errorf("path gap between %v and %v", a, b)
+		}
+		if i >= 2 {
+			c := path[i-2]
+			if absInt(c.X-b.X) == 1 && absInt(c.Y-b.Y) == 1 {
+				t.Errorf("L-shaped corner %v %v %v", c, a, b)
+			}
+		}
+	}
+}
+
+func TestRasterizeLine(t *testing.T) {
+	got := RasterizeLine(Point{X: 0, Y: 0}, Point{X: 4, Y: 2})
+	want := []Point{{0, 0}, {1, 1}, {2, 1}, {3, 2}, {4, 2}}
+	if len(got) != len(want) {
+		t.Fatalf("RasterizeLine() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("point %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if got := RasterizeLine(Point{X: 3, Y: 3}, Point{X: 3, Y: 3}); len(got) != 1 {
+		t.Errorf("single-point line = %v, want 1 point", got)
+	}
+}
+
+func TestRasterizeQuadraticBezier(t *testing.T) {
+	p0, p1, p2 := Point{X: 0, Y: 20}, Point{X: 10, Y: 0}, Point{X: 20, Y: 20}
+	path := RasterizeQuadraticBezier(p0, p1, p2)
+
+	if path[0] != p0 || path[len(path)-1] != p2 {
+		t.Errorf("endpoints = %v, %v, want %v, %v", path[0], path[len(path)-1], p0, p2)
+	}
+	assertPixelPerfect(t, path)
+
+	// Apex at t = 0.5 is (10, 10)
+	if !pointSet(path)[Point{X: 10, Y: 10}] {
+		t.Error("curve does not pass through its apex (10, 10)")
+	}
+
+	// A straight "curve" is the same as a line
+	straight := RasterizeQuadraticBezier(Point{X: 0, Y: 0}, Point{X: 5, Y: 0}, Point{X: 10, Y: 0})
+	if len(straight) != 11 {
+		t.Errorf("straight curve has %d pixels, want 11", len(straight))
+	}
+}
+
+func TestRasterizeCubicBezier(t *testing.T) {
+	p0, p3 := Point{X: 2, Y: 30}, Point{X: 40, Y: 2}
+	path := RasterizeCubicBezier(p0, Point{X: 2, Y: 2}, Point{X: 40, Y: 30}, p3)
+
+	if path[0] != p0 || path[len(path)-1] != p3 {
+		t.Errorf("endpoints = %v, %v, want %v, %v", path[0], path[len(path)-1], p0, p3)
+	}
+	assertPixelPerfect(t, path)
+
+	// Degenerate curve with all control points equal
+	if got := RasterizeCubicBezier(p0, p0, p0, p0); len(got) != 1 || got[0] != p0 {
+		t.Errorf("degenerate curve = %v, want [%v]", got, p0)
+	}
+}
+
+func TestRasterizeEllipse(t *testing.T) {
+	outline := RasterizeEllipse(10, 10, 6, 3, false, testClip)
+	set := pointSet(outline)
+
+	for _, p := range []Point{{16, 10}, {4, 10}, {10, 7}, {10, 13}} {
+		if !set[p] {
+			t.Errorf("outline missing extreme point %v", p)
+		}
+	}
+	for p := range set {
+		if p.X < 4 || p.X > 16 || p.Y < 7 || p.Y > 13 {
+			t.Errorf("outline point %v outside the bounding box", p)
+		}
+		// Symmetric about the center
+		if !set[Point{X: 20 - p.X, Y: p.Y}] || !set[Point{X: p.X, Y: 20 - p.Y}] {
+			t.Errorf("outline not symmetric at %v", p)
+		}
+	}
+	if set[Point{X: 10, Y: 10}] {
+		t.Error("outline includes the center")
+	}
+
+	filled := pointSet(RasterizeEllipse(10, 10, 6, 3, true, testClip))
+	if !filled[Point{X: 10, Y: 10}] {
+		t.Error("filled ellipse missing the center")
+	}
+	for p := range set {
+		if !filled[p] {
+			t.Errorf("filled ellipse missing outline point %v", p)
+		}
+	}
+
+	// Zero radius degenerates to a line
+	if got := RasterizeEllipse(5, 5, 0, 2, false, testClip); len(got) != 5 {
+		t.Errorf("zero-width ellipse = %v, want a 5 pixel vertical line", got)
+	}
+	if got := RasterizeEllipse(5, 5, 0, 0, true, testClip); len(got) != 1 {
+		t.Errorf("zero-size ellipse = %v, want a single pixel", got)
+	}
+}
+
+func TestRasterizeEllipse_Clipped(t *testing.T) {
+	clip := Rectangle{X: 0, Y: 0, Width: 16, Height: 8}
+
+	// A huge filled ellipse only costs the clip area
+	got := RasterizeEllipse(8, 4, 1000000, 1000000, true, clip)
+	if len(got) != clip.Width*clip.Height {
+		t.Errorf("oversized filled ellipse has %d pixels, want %d", len(got), clip.Width*clip.Height)
+	}
+
+	// Pixels outside the clip are dropped from outlines and fills alike
+	for _, filled := range []bool{false, true} {
+		full := pointSet(RasterizeEllipse(8, 4, 10, 6, filled, testClip))
+		clipped := RasterizeEllipse(8, 4, 10, 6, filled, clip)
+		for _, p := range clipped {
+			if !clipContains(clip, p) {
+				t.Errorf("filled=%v: pixel %v outside the clip", filled, p)
+			}
+			if !full[p] {
+				t.Errorf("filled=%v: pixel %v not in the unclipped ellipse", filled, p)
+			}
+		}
+	}
+}
+
+func TestRasterizeArc(t *testing.T) {
+	full := RasterizeEllipse(10, 10, 8, 5, false, testClip)
+
+	if got := RasterizeArc(10, 10, 8, 5, 0, 360, testClip); len(got) != len(full) {
+		t.Errorf("360 degree arc has %d pixels, want %d", len(got), len(full))
+	}
+
+	// 0-90 is the bottom-right quadrant (Y grows down)
+	quarter := RasterizeArc(10, 10, 8, 5, 0, 90, testClip)
+	set := pointSet(quarter)
+	if !set[Point{X: 18, Y: 10}] || !set[Point{X: 10, Y: 15}] {
+		t.Errorf("0-90 arc missing its endpoints: %v", quarter)
+	}
+	for _, p := range quarter {
+		if p.X < 10 || p.Y < 10 {
+			t.Errorf("0-90 arc point %v outside the bottom-right quadrant", p)
+		}
+	}
+
+	// Wrapping past 360: 270 -> 90 is the right half
+	right := RasterizeArc(10, 10, 8, 5, 270, 90, testClip)
+	for _, p := range right {
+		if p.X < 10 {
+			t.Errorf("270-90 arc point %v on the left half", p)
+		}
+	}
+	if !pointSet(right)[Point{X: 10, Y: 5}] {
+		t.Error("270-90 arc missing the top point")
+	}
+}
+
+func TestRasterizePolygon(t *testing.T) {
+	square := []Point{{X: 2, Y: 2}, {X: 6, Y: 2}, {X: 6, Y: 6}, {X: 2, Y: 6}}
+	got := RasterizePolygon(square, FillRuleNonZero, testClip)
+	if len(got) != 25 {
+		t.Errorf("5x5 square has %d pixels, want 25", len(got))
+	}
+	for _, p := range got {
+		if p.X < 2 || p.X > 6 || p.Y < 2 || p.Y > 6 {
+			t.Errorf("square pixel %v out of bounds", p)
+		}
+	}
+
+	triangle := pointSet(RasterizePolygon([]Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 0, Y: 10}}, FillRuleEvenOdd, testClip))
+	if !triangle[Point{X: 2, Y: 2}] || triangle[Point{X: 8, Y: 8}] {
+		t.Error("triangle fill is wrong")
+	}
+}
+
+func TestRasterizePolygon_Clipped(t *testing.T) {
+	clip := Rectangle{X: 0, Y: 0, Width: 16, Height: 8}
+
+	// A polygon far larger than the clip only fills the clip
+	huge := []Point{{X: -100000, Y: -100000}, {X: 100000, Y: -100000}, {X: 100000, Y: 100000}, {X: -100000, Y: 100000}}
+	got := RasterizePolygon(huge, FillRuleNonZero, clip)
+	if len(got) != clip.Width*clip.Height {
+		t.Errorf("oversized polygon has %d pixels, want %d", len(got), clip.Width*clip.Height)
+	}
+	for _, p := range got {
+		if !clipContains(clip, p) {
+			t.Errorf("pixel %v outside the clip", p)
+		}
+	}
+}
+
+func TestRasterizePolygon_FillRules(t *testing.T) {
+	// Pentagram: the center is wound twice, so even-odd leaves it empty
+	star := []Point{{X: 20, Y: 0}, {X: 32, Y: 38}, {X: 0, Y: 14}, {X: 40, Y: 14}, {X: 8, Y: 38}}
+	center := Point{X: 20, Y: 20}
+
+	nonZero := pointSet(RasterizePolygon(star, FillRuleNonZero, testClip))
+	evenOdd := pointSet(RasterizePolygon(star, FillRuleEvenOdd, testClip))
+
+	if !nonZero[center] {
+		t.Error("non-zero fill should include the star center")
+	}
+	if evenOdd[center] {
+		t.Error("even-odd fill should leave the star center empty")
+	}
+	// A point inside one of the tips is filled either way
+	tip := Point{X: 20, Y: 6}
+	if !nonZero[tip] || !evenOdd[tip] {
+		t.Error("star tip should be filled with both rules")
+	}
+	if len(evenOdd) >= len(nonZero) {
+		t.Errorf("even-odd fill (%d) should be smaller than non-zero fill (%d)", len(evenOdd), len(nonZero))
+	}
+
+	if got := RasterizePolygon(nil, FillRuleEvenOdd, testClip); got != nil {
+		t.Errorf("empty polygon = %v, want nil", got)
+	}
+}
+
+func TestPixelRuns(t *testing.T) {
+	runs := pixelRuns([]Point{{X: 3, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 5, Y: 1}, {X: 0, Y: 0}, {X: 2, Y: 1}})
+	want := [][3]int{{0, 0, 0}, {1, 1, 3}, {1, 5, 5}}
+	if len(runs) != len(want) {
+		t.Fatalf("pixelRuns() = %v, want %v", runs, want)
+	}
+	for i := range want {
+		if runs[i] != want[i] {
+			t.Errorf("run %d = %v, want %v", i, runs[i], want[i])
+		}
+	}
+}
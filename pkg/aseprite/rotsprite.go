@@ -0,0 +1,156 @@
+package aseprite
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// RotSprite rotates and scales a pixel art image with the RotSprite algorithm.
+//
+// The image is upscaled 8x with three passes of Scale2x, which smooths
+// diagonal edges without inventing colors, then rotated clockwise by angle
+// degrees and scaled by scaleX and scaleY around its center, sampling the
+// upscaled image with nearest neighbor. The result only contains colors of
+// the source image, so it stays on the palette of indexed sprites.
+//
+// The returned image is just large enough to hold the transformed source.
+// Rotations by multiples of 90 degrees without scaling are done exactly.
+func RotSprite(src image.Image, angle, scaleX, scaleY float64) *image.NRGBA {
+	base := normalizedNRGBA(src)
+	w, h := base.Rect.Dx(), base.Rect.Dy()
+	if w == 0 || h == 0 {
+		return base
+	}
+
+	angle = math.Mod(angle, 360)
+	if angle < 0 {
+		angle += 360
+	}
+	if scaleX == 1 && scaleY == 1 && math.Mod(angle, 90) == 0 {
+		return rotateQuarterTurns(base, int(angle)/90)
+	}
+
+	rad := angle * math.Pi / 180
+	cos, sin := math.Cos(rad), math.Sin(rad)
+
+	// Bounding box of the transformed image
+	hw, hh := float64(w)*scaleX/2, float64(h)*scaleY/2
+	outW := int(math.Ceil(2*(math.Abs(hw*cos)+math.Abs(hh*sin)) - 1e-9))
+	outH := int(math.Ceil(2*(math.Abs(hw*sin)+math.Abs(hh*cos)) - 1e-9))
+	outW = max(outW, 1)
+	outH = max(outH, 1)
+
+	const factor = 8
+	up := scale2x(scale2x(scale2x(base)))
+
+	out := image.NewNRGBA(image.Rect(0, 0, outW, outH))
+	for oy := 0; oy < outH; oy++ {
+		for ox := 0; ox < outW; ox++ {
+			// Offset of the pixel center from the output center
+			dx := float64(ox) + 0.5 - float64(outW)/2
+			dy := float64(oy) + 0.5 - float64(outH)/2
+
+			// Inverse rotation and scale back into source coordinates
+			sx := (dx*cos+dy*sin)/scaleX + float64(w)/2
+			sy := (-dx*sin+dy*cos)/scaleY + float64(h)/2
+			if sx < 0 || sy < 0 || sx >= float64(w) || sy >= float64(h) {
+				continue
+			}
+
+			out.SetNRGBA(ox, oy, up.NRGBAAt(int(sx*factor), int(sy*factor)))
+		}
+	}
+
+	return out
+}
+
+// normalizedNRGBA copies an image into an NRGBA image at the origin, clearing
+// the color of fully transparent pixels so they compare equal.
+func normalizedNRGBA(src image.Image) *image.NRGBA {
+	b := src.Bounds()
+	img := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(img, img.Rect, src, b.Min, draw.Src)
+	for i := 0; i < len(img.Pix); i += 4 {
+		if img.Pix[i+3] == 0 {
+			img.Pix[i], img.Pix[i+1], img.Pix[i+2] = 0, 0, 0
+		}
+	}
+	return img
+}
+
+// rotateQuarterTurns rotates an image clockwise by turns * 90 degrees.
+func rotateQuarterTurns(src *image.NRGBA, turns int) *image.NRGBA {
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	turns = ((turns % 4) + 4) % 4
+
+	outW, outH := w, h
+	if turns%2 == 1 {
+		outW, outH = h, w
+	}
+
+	out := image.NewNRGBA(image.Rect(0, 0, outW, outH))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var tx, ty int
+			switch turns {
+			case 0:
+				tx, ty = x, y
+			case 1:
+				tx, ty = h-1-y, x
+			case 2:
+				tx, ty = w-1-x, h-1-y
+			case 3:
+				tx, ty = y, w-1-x
+			}
+			out.SetNRGBA(tx, ty, src.NRGBAAt(x, y))
+		}
+	}
+	return out
+}
+
+// scale2x doubles an image with the Scale2x (AdvMAME2x) algorithm, which
+// fills the corners of diagonal edges from matching neighbors instead of
+// blending, so no new colors are created.
+func scale2x(src *image.NRGBA) *image.NRGBA {
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, w*2, h*2))
+
+	at := func(x, y int) color.NRGBA {
+		x = min(max(x, 0), w-1)
+		y = min(max(y, 0), h-1)
+		return src.NRGBAAt(x, y)
+	}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			p := at(x, y)
+			up, down := at(x, y-1), at(x, y+1)
+			left, right := at(x-1, y), at(x+1, y)
+
+			e0, e1, e2, e3 := p, p, p, p
+			if up != down && left != right {
+				if left == up {
+					e0 = left
+				}
+				if up == right {
+					e1 = right
+				}
+				if left == down {
+					e2 = left
+				}
+				if down == right {
+					e3 = right
+				}
+			}
+
+			dst.SetNRGBA(2*x, 2*y, e0)
+			dst.SetNRGBA(2*x+1, 2*y, e1)
+			dst.SetNRGBA(2*x, 2*y+1, e2)
+			dst.SetNRGBA(2*x+1, 2*y+1, e3)
+		}
+	}
+
+	return dst
+}
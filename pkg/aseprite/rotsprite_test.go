@@ -0,0 +1,141 @@
+package aseprite
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// rotSpriteTestImage returns a 4x3 image with a distinct color per pixel.
+func rotSpriteTestImage() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 3))
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 4; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x * 60), G: uint8(y * 100), B: 50, A: 255})
+		}
+	}
+	return img
+}
+
+func TestRotSprite_Identity(t *testing.T) {
+	src := rotSpriteTestImage()
+
+	out := RotSprite(src, 360, 1, 1)
+
+	if out.Rect != src.Rect {
+		t.Fatalf("bounds = %v, want %v", out.Rect, src.Rect)
+	}
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 4; x++ {
+			if out.NRGBAAt(x, y) != src.NRGBAAt(x, y) {
+				t.Errorf("pixel (%d,%d) = %v, want %v", x, y, out.NRGBAAt(x, y), src.NRGBAAt(x, y))
+			}
+		}
+	}
+}
+
+func TestRotSprite_QuarterTurn(t *testing.T) {
+	src := rotSpriteTestImage()
+
+	out := RotSprite(src, 90, 1, 1)
+
+	if out.Rect.Dx() != 3 || out.Rect.Dy() != 4 {
+		t.Fatalf("size = %dx%d, want 3x4", out.Rect.Dx(), out.Rect.Dy())
+	}
+	// Clockwise: the top-left pixel ends up top-right
+	if out.NRGBAAt(2, 0) != src.NRGBAAt(0, 0) {
+		t.Errorf("top-right = %v, want %v", out.NRGBAAt(2, 0), src.NRGBAAt(0, 0))
+	}
+	// and the bottom-left pixel ends up top-left
+	if out.NRGBAAt(0, 0) != src.NRGBAAt(0, 2) {
+		t.Errorf("top-left = %v, want %v", out.NRGBAAt(0, 0), src.NRGBAAt(0, 2))
+	}
+
+	back := RotSprite(RotSprite(out, -90, 1, 1), 0, 1, 1)
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 4; x++ {
+			if back.NRGBAAt(x, y) != src.NRGBAAt(x, y) {
+				t.Fatalf("rotating back changed pixel (%d,%d)", x, y)
+			}
+		}
+	}
+}
+
+func TestRotSprite_Scale(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	red := color.NRGBA{R: 255, A: 255}
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 2; x++ {
+			src.SetNRGBA(x, y, red)
+		}
+	}
+
+	out := RotSprite(src, 0, 2, 3)
+
+	if out.Rect.Dx() != 4 || out.Rect.Dy() != 6 {
+		t.Fatalf("size = %dx%d, want 4x6", out.Rect.Dx(), out.Rect.Dy())
+	}
+	for y := 0; y < 6; y++ {
+		for x := 0; x < 4; x++ {
+			if out.NRGBAAt(x, y) != red {
+				t.Fatalf("pixel (%d,%d) = %v, want %v", x, y, out.NRGBAAt(x, y), red)
+			}
+		}
+	}
+}
+
+func TestRotSprite_ArbitraryAngleKeepsColors(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	colors := []color.NRGBA{{R: 255, A: 255}, {G: 255, A: 255}, {B: 255, A: 255}}
+	for y := 1; y < 7; y++ {
+		for x := 1; x < 7; x++ {
+			src.SetNRGBA(x, y, colors[(x+y)%len(colors)])
+		}
+	}
+
+	out := RotSprite(src, 30, 1.5, 1.5)
+
+	// 8 * 1.5 = 12; rotating 30 degrees grows the box to 12*(cos+sin) = 16.4
+	if out.Rect.Dx() != 17 || out.Rect.Dy() != 17 {
+		t.Errorf("size = %dx%d, want 17x17", out.Rect.Dx(), out.Rect.Dy())
+	}
+
+	allowed := map[color.NRGBA]bool{{}: true}
+	for _, c := range colors {
+		allowed[c] = true
+	}
+	opaque := 0
+	for y := 0; y < out.Rect.Dy(); y++ {
+		for x := 0; x < out.Rect.Dx(); x++ {
+			c := out.NRGBAAt(x, y)
+			if !allowed[c] {
+				t.Fatalf("pixel (%d,%d) has new color %v", x, y, c)
+			}
+			if c.A > 0 {
+				opaque++
+			}
+		}
+	}
+
+	// 6x6 opaque pixels scaled by 1.5 cover about 81 pixels
+	if opaque < 70 || opaque > 95 {
+		t.Errorf("opaque pixels = %d, want about 81", opaque)
+	}
+}
+
+func TestScale2x_SmoothsDiagonal(t *testing.T) {
+	black := color.NRGBA{A: 255}
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	src.SetNRGBA(0, 0, black)
+	src.SetNRGBA(1, 1, black)
+
+	out := scale2x(src)
+
+	// The transparent pixel at (1,0) gets its bottom-left corner filled
+	if out.NRGBAAt(2, 1) != black {
+		t.Errorf("corner = %v, want black", out.NRGBAAt(2, 1))
+	}
+	if out.NRGBAAt(3, 0) != (color.NRGBA{}) {
+		t.Errorf("far corner = %v, want transparent", out.NRGBAAt(3, 0))
+	}
+}
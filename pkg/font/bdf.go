@@ -0,0 +1,191 @@
+package font
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseBDF reads a font in Glyph Bitmap Distribution Format (BDF 2.1).
+//
+// Glyphs with a negative ENCODING are skipped. The line height is
+// FONT_ASCENT + FONT_DESCENT, falling back to the font bounding box when those
+// properties are missing.
+func ParseBDF(r io.Reader) (*Font, error) {
+	f, _, err := parseBDF(r)
+	return f, err
+}
+
+// parseBDF parses a BDF font and also returns its COMMENT lines.
+func parseBDF(r io.Reader) (*Font, []string, error) {
+	f := &Font{
+		Glyphs:  make(map[rune]*Glyph),
+		Kerning: make(map[KerningPair]int),
+	}
+	var comments []string
+
+	ascent, descent := -1, -1
+	var bboxH, bboxY int
+	var fontName string
+
+	type pendingGlyph struct {
+		code             int
+		advance          int
+		w, h, xoff, yoff int
+		rows             [][]byte
+	}
+	var glyphs []pendingGlyph
+	var cur *pendingGlyph
+	inBitmap := false
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if inBitmap {
+			if line == "ENDCHAR" {
+				inBitmap = false
+				glyphs = append(glyphs, *cur)
+				cur = nil
+				continue
+			}
+			row, err := hex.DecodeString(line)
+			if err != nil {
+				return nil, nil, fmt.Errorf("line %d: invalid bitmap row %q", lineNum, line)
+			}
+			cur.rows = append(cur.rows, row)
+			continue
+		}
+
+		keyword, rest, _ := strings.Cut(line, " ")
+		fields := strings.Fields(rest)
+		ints := func(n int) ([]int, error) {
+			if len(fields) < n {
+				return nil, fmt.Errorf("line %d: %s needs %d values", lineNum, keyword, n)
+			}
+			values := make([]int, n)
+			for i := range values {
+				v, err := strconv.Atoi(fields[i])
+				if err != nil {
+					return nil, fmt.Errorf("line %d: invalid %s value %q", lineNum, keyword, fields[i])
+				}
+				values[i] = v
+			}
+			return values, nil
+		}
+
+		switch keyword {
+		case "COMMENT":
+			comments = append(comments, strings.TrimSpace(rest))
+		case "FONT":
+			fontName = strings.TrimSpace(rest)
+		case "FAMILY_NAME":
+			f.Name = strings.Trim(strings.TrimSpace(rest), `"`)
+		case "FONTBOUNDINGBOX":
+			v, err := ints(4)
+			if err != nil {
+				return nil, nil, err
+			}
+			bboxH, bboxY = v[1], v[3]
+		case "FONT_ASCENT", "FONT_DESCENT", "DEFAULT_CHAR":
+			v, err := ints(1)
+			if err != nil {
+				return nil, nil, err
+			}
+			switch keyword {
+			case "FONT_ASCENT":
+				ascent = v[0]
+			case "FONT_DESCENT":
+				descent = v[0]
+			default:
+				f.DefaultChar = rune(v[0])
+			}
+		case "STARTCHAR":
+			cur = &pendingGlyph{code: -1}
+		case "ENCODING", "DWIDTH", "BBX":
+			if cur == nil {
+				return nil, nil, fmt.Errorf("line %d: %s outside of a glyph", lineNum, keyword)
+			}
+			switch keyword {
+			case "ENCODING":
+				v, err := ints(1)
+				if err != nil {
+					return nil, nil, err
+				}
+				cur.code = v[0]
+			case "DWIDTH":
+				v, err := ints(1)
+				if err != nil {
+					return nil, nil, err
+				}
+				cur.advance = v[0]
+			default:
+				v, err := ints(4)
+				if err != nil {
+					return nil, nil, err
+				}
+				cur.w, cur.h, cur.xoff, cur.yoff = v[0], v[1], v[2], v[3]
+			}
+		case "BITMAP":
+			if cur == nil {
+				return nil, nil, fmt.Errorf("line %d: BITMAP outside of a glyph", lineNum)
+			}
+			inBitmap = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read BDF font: %w", err)
+	}
+	if inBitmap {
+		return nil, nil, fmt.Errorf("unexpected end of BDF font inside a glyph bitmap")
+	}
+	if len(glyphs) == 0 {
+		return nil, nil, fmt.Errorf("BDF font has no glyphs")
+	}
+
+	if ascent < 0 || descent < 0 {
+		ascent, descent = bboxH+bboxY, -bboxY
+	}
+	f.LineHeight = ascent + descent
+	f.Base = ascent
+	if f.Name == "" {
+		f.Name = fontName
+	}
+
+	for _, g := range glyphs {
+		if g.code < 0 {
+			continue
+		}
+		if len(g.rows) < g.h {
+			return nil, nil, fmt.Errorf("glyph %d has %d bitmap rows, want %d", g.code, len(g.rows), g.h)
+		}
+
+		mask := image.NewAlpha(image.Rect(0, 0, g.w, g.h))
+		for y := 0; y < g.h; y++ {
+			row := g.rows[y]
+			for x := 0; x < g.w; x++ {
+				if x/8 < len(row) && row[x/8]&(0x80>>(x%8)) != 0 {
+					mask.Pix[y*mask.Stride+x] = 255
+				}
+			}
+		}
+
+		f.Glyphs[rune(g.code)] = &Glyph{
+			Mask:    mask,
+			XOffset: g.xoff,
+			YOffset: ascent - (g.yoff + g.h),
+			Advance: g.advance,
+		}
+	}
+
+	return f, comments, nil
+}
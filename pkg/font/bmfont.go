@@ -0,0 +1,305 @@
+package font
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/png" // BMFont pages are PNG files
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// bmChar is a BMFont character record.
+type bmChar struct {
+	ID       int `xml:"id,attr"`
+	X        int `xml:"x,attr"`
+	Y        int `xml:"y,attr"`
+	Width    int `xml:"width,attr"`
+	Height   int `xml:"height,attr"`
+	XOffset  int `xml:"xoffset,attr"`
+	YOffset  int `xml:"yoffset,attr"`
+	XAdvance int `xml:"xadvance,attr"`
+	Page     int `xml:"page,attr"`
+}
+
+// bmKerning is a BMFont kerning record.
+type bmKerning struct {
+	First  int `xml:"first,attr"`
+	Second int `xml:"second,attr"`
+	Amount int `xml:"amount,attr"`
+}
+
+// bmPage is a BMFont texture page record.
+type bmPage struct {
+	ID   int    `xml:"id,attr"`
+	File string `xml:"file,attr"`
+}
+
+// bmFont is a parsed BMFont descriptor, shared by the text and XML formats.
+type bmFont struct {
+	Info struct {
+		Face string `xml:"face,attr"`
+	} `xml:"info"`
+	Common struct {
+		LineHeight int `xml:"lineHeight,attr"`
+		Base       int `xml:"base,attr"`
+	} `xml:"common"`
+	Pages    []bmPage    `xml:"pages>page"`
+	Chars    []bmChar    `xml:"chars>char"`
+	Kernings []bmKerning `xml:"kernings>kerning"`
+}
+
+// LoadFile reads a font file: BDF (.bdf) or a BMFont descriptor (.fnt or
+// .xml) whose PNG pages are resolved relative to the descriptor.
+func LoadFile(path string) (*Font, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open font file: %w", err)
+	}
+	defer file.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".bdf") {
+		f, err := ParseBDF(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+		return f, nil
+	}
+
+	dir := filepath.Dir(path)
+	f, err := ParseBMFont(file, func(name string) (image.Image, error) {
+		page, err := os.Open(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		img, _, err := image.Decode(page)
+		return img, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return f, nil
+}
+
+// ParseBMFont reads an AngelCode BMFont descriptor in text or XML format.
+// Binary descriptors are not supported.
+//
+// loadPage opens the texture page with the given file name. Glyph masks are
+// taken from the page alpha channel, or from its brightness when the page is
+// fully opaque (white glyphs on black); pixels at 50% or more are set.
+func ParseBMFont(r io.Reader, loadPage func(name string) (image.Image, error)) (*Font, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read BMFont descriptor: %w", err)
+	}
+
+	var desc bmFont
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(data, []byte("BMF")):
+		return nil, fmt.Errorf("binary BMFont descriptors are not supported; export the font as text or XML")
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		if err := xml.Unmarshal(trimmed, &desc); err != nil {
+			return nil, fmt.Errorf("invalid BMFont XML: %w", err)
+		}
+	default:
+		if err := parseBMFontText(data, &desc); err != nil {
+			return nil, err
+		}
+	}
+
+	if desc.Common.LineHeight <= 0 {
+		return nil, fmt.Errorf("BMFont descriptor is missing the common lineHeight")
+	}
+	if len(desc.Chars) == 0 {
+		return nil, fmt.Errorf("BMFont descriptor has no characters")
+	}
+
+	pages := make(map[int]image.Image, len(desc.Pages))
+	opaque := make(map[int]bool, len(desc.Pages))
+	for _, p := range desc.Pages {
+		img, err := loadPage(p.File)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load BMFont page %q: %w", p.File, err)
+		}
+		pages[p.ID] = img
+		opaque[p.ID] = isOpaque(img)
+	}
+
+	f := &Font{
+		Name:       desc.Info.Face,
+		LineHeight: desc.Common.LineHeight,
+		Base:       desc.Common.Base,
+		Glyphs:     make(map[rune]*Glyph, len(desc.Chars)),
+		Kerning:    make(map[KerningPair]int, len(desc.Kernings)),
+	}
+
+	for _, c := range desc.Chars {
+		mask := image.NewAlpha(image.Rect(0, 0, max(c.Width, 0), max(c.Height, 0)))
+		if c.Width > 0 && c.Height > 0 {
+			page, ok := pages[c.Page]
+			if !ok {
+				return nil, fmt.Errorf("character %d uses missing page %d", c.ID, c.Page)
+			}
+			for y := 0; y < c.Height; y++ {
+				for x := 0; x < c.Width; x++ {
+					if maskBit(page.At(c.X+x, c.Y+y), opaque[c.Page]) {
+						mask.Pix[y*mask.Stride+x] = 255
+					}
+				}
+			}
+		}
+
+		f.Glyphs[rune(c.ID)] = &Glyph{
+			Mask:    mask,
+			XOffset: c.XOffset,
+			YOffset: c.YOffset,
+			Advance: c.XAdvance,
+		}
+	}
+	for _, k := range desc.Kernings {
+		f.Kerning[KerningPair{First: rune(k.First), Second: rune(k.Second)}] = k.Amount
+	}
+	if _, ok := f.Glyphs['?']; ok {
+		f.DefaultChar = '?'
+	}
+
+	return f, nil
+}
+
+// parseBMFontText parses the text BMFont format: one "tag key=value ..." record per line.
+func parseBMFontText(data []byte, desc *bmFont) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		tag, attrs := parseBMFontLine(scanner.Text())
+		num := func(key string) (int, error) {
+			v, ok := attrs[key]
+			if !ok {
+				return 0, nil
+			}
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return 0, fmt.Errorf("line %d: invalid %s %s=%q", lineNum, tag, key, v)
+			}
+			return n, nil
+		}
+		nums := func(keys ...string) ([]int, error) {
+			values := make([]int, len(keys))
+			for i, key := range keys {
+				n, err := num(key)
+				if err != nil {
+					return nil, err
+				}
+				values[i] = n
+			}
+			return values, nil
+		}
+
+		switch tag {
+		case "info":
+			desc.Info.Face = attrs["face"]
+		case "common":
+			v, err := nums("lineHeight", "base")
+			if err != nil {
+				return err
+			}
+			desc.Common.LineHeight, desc.Common.Base = v[0], v[1]
+		case "page":
+			id, err := num("id")
+			if err != nil {
+				return err
+			}
+			desc.Pages = append(desc.Pages, bmPage{ID: id, File: attrs["file"]})
+		case "char":
+			v, err := nums("id", "x", "y", "width", "height", "xoffset", "yoffset", "xadvance", "page")
+			if err != nil {
+				return err
+			}
+			desc.Chars = append(desc.Chars, bmChar{
+				ID: v[0], X: v[1], Y: v[2], Width: v[3], Height: v[4],
+				XOffset: v[5], YOffset: v[6], XAdvance: v[7], Page: v[8],
+			})
+		case "kerning":
+			v, err := nums("first", "second", "amount")
+			if err != nil {
+				return err
+			}
+			desc.Kernings = append(desc.Kernings, bmKerning{First: v[0], Second: v[1], Amount: v[2]})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read BMFont descriptor: %w", err)
+	}
+	return nil
+}
+
+// parseBMFontLine splits a text BMFont record into its tag and attributes.
+// Quoted values may contain spaces.
+func parseBMFontLine(line string) (string, map[string]string) {
+	line = strings.TrimSpace(line)
+	tag, rest, _ := strings.Cut(line, " ")
+	attrs := make(map[string]string)
+
+	for {
+		rest = strings.TrimLeft(rest, " \t")
+		if rest == "" {
+			break
+		}
+		key, after, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		key = strings.TrimSpace(key)
+
+		var value string
+		if strings.HasPrefix(after, `"`) {
+			end := strings.Index(after[1:], `"`)
+			if end < 0 {
+				value, rest = after[1:], ""
+			} else {
+				value, rest = after[1:end+1], after[end+2:]
+			}
+		} else {
+			value, rest, _ = strings.Cut(after, " ")
+		}
+		attrs[key] = value
+	}
+
+	return tag, attrs
+}
+
+// isOpaque reports whether every pixel of img is fully opaque.
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a != 0xffff {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// maskBit reports whether a page pixel belongs to a glyph.
+func maskBit(c color.Color, opaquePage bool) bool {
+	if !opaquePage {
+		_, _, _, a := c.RGBA()
+		return a >= 0x8000
+	}
+	gray := color.GrayModel.Convert(c).(color.Gray)
+	return gray.Y >= 128
+}
@@ -0,0 +1,155 @@
+// Package font renders text with bitmap pixel fonts.
+//
+// Fonts are read from:
+//
+//   - BDF (Glyph Bitmap Distribution Format) files, used by the bundled library
+//   - AngelCode BMFont descriptors (.fnt, text or XML) with their PNG pages
+//
+// Glyphs are kept as 1-bit masks so text stays crisp at pixel scale, and
+// rendering supports color, outlines, alignment, line spacing, letter
+// spacing, and kerning pairs. The package is pure Go and does not depend on
+// Aseprite.
+//
+// The package embeds a small library of public-domain pixel fonts (3x5, 5x7,
+// and 8x8); see Library and Named.
+package font
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"image"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Glyph is a single character of a bitmap font.
+type Glyph struct {
+	Mask    *image.Alpha // Glyph pixels, 255 where set; bounds start at (0, 0)
+	XOffset int          // Offset from the pen position to the left edge of Mask
+	YOffset int          // Offset from the top of the line to the top edge of Mask
+	Advance int          // Horizontal pen advance after the glyph
+}
+
+// KerningPair identifies two consecutive characters.
+type KerningPair struct {
+	First, Second rune
+}
+
+// Font is a bitmap font.
+type Font struct {
+	Name        string
+	LineHeight  int                 // Distance between the tops of consecutive lines
+	Base        int                 // Distance from the top of the line to the baseline
+	Glyphs      map[rune]*Glyph     // Glyphs by character
+	Kerning     map[KerningPair]int // Advance adjustment between character pairs
+	DefaultChar rune                // Character drawn for characters without a glyph (0 = skip them)
+}
+
+// libraryFS holds the bundled fonts as BDF files. Each file may carry
+// "COMMENT Description:" and "COMMENT Aliases:" lines.
+//
+//go:embed library/*.bdf
+var libraryFS embed.FS
+
+// LibraryFont is a font bundled with the binary.
+type LibraryFont struct {
+	ID          string   // File name without extension, e.g. "5x7"
+	Description string   // One-line description
+	Aliases     []string // Alternative names accepted by Named
+	*Font
+}
+
+var loadLibrary = sync.OnceValues(func() ([]LibraryFont, error) {
+	entries, err := libraryFS.ReadDir("library")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read font library: %w", err)
+	}
+
+	library := make([]LibraryFont, 0, len(entries))
+	for _, entry := range entries {
+		data, err := libraryFS.ReadFile(path.Join("library", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read font library: %w", err)
+		}
+
+		f, comments, err := parseBDF(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("library font %s: %w", entry.Name(), err)
+		}
+
+		lf := LibraryFont{
+			ID:   strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())),
+			Font: f,
+		}
+		for _, comment := range comments {
+			if v, ok := strings.CutPrefix(comment, "Description:"); ok {
+				lf.Description = strings.TrimSpace(v)
+			} else if v, ok := strings.CutPrefix(comment, "Aliases:"); ok {
+				for _, alias := range strings.Split(v, ",") {
+					if alias = strings.TrimSpace(alias); alias != "" {
+						lf.Aliases = append(lf.Aliases, alias)
+					}
+				}
+			}
+		}
+
+		library = append(library, lf)
+	}
+
+	sort.Slice(library, func(i, j int) bool { return library[i].ID < library[j].ID })
+	return library, nil
+})
+
+// Library returns the bundled fonts sorted by ID. The returned fonts are
+// shared and must not be modified.
+func Library() ([]LibraryFont, error) {
+	return loadLibrary()
+}
+
+// Named looks up a bundled font by ID, family name, or alias.
+//
+// Matching ignores case, spaces, hyphens, and underscores, so "5x7",
+// "Pixel 5x7", and "small" all find the same font. The returned font is
+// shared and must not be modified.
+func Named(name string) (*LibraryFont, error) {
+	library, err := loadLibrary()
+	if err != nil {
+		return nil, err
+	}
+
+	key := normalizeName(name)
+	if key == "" {
+		return nil, fmt.Errorf("font name is required")
+	}
+
+	for i := range library {
+		lf := &library[i]
+		candidates := append([]string{lf.ID, lf.Name}, lf.Aliases...)
+		for _, c := range candidates {
+			if normalizeName(c) == key {
+				return lf, nil
+			}
+		}
+	}
+
+	ids := make([]string, len(library))
+	for i, lf := range library {
+		ids[i] = lf.ID
+	}
+	return nil, fmt.Errorf("unknown font: %s (available: %s)", name, strings.Join(ids, ", "))
+}
+
+// normalizeName lowercases a font name and drops everything but letters and digits.
+func normalizeName(name string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
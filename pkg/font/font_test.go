@@ -0,0 +1,469 @@
+package font
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testBDF is a two-glyph font: "A" is a 3x3 box and "i" is a 1x3 bar that
+// dips one pixel below the baseline.
+const testBDF = `STARTFONT 2.1
+FONT -test-Tiny-medium-r-normal--4-40-75-75-p-30-iso10646-1
+FONTBOUNDINGBOX 3 4 0 -1
+STARTPROPERTIES 3
+FAMILY_NAME "Tiny"
+FONT_ASCENT 3
+FONT_DESCENT 1
+ENDPROPERTIES
+CHARS 3
+STARTCHAR A
+ENCODING 65
+DWIDTH 4 0
+BBX 3 3 0 0
+BITMAP
+E0
+A0
+E0
+ENDCHAR
+STARTCHAR i
+ENCODING 105
+DWIDTH 2 0
+BBX 1 3 0 -1
+BITMAP
+80
+80
+80
+ENDCHAR
+STARTCHAR unencoded
+ENCODING -1
+DWIDTH 2 0
+BBX 1 1 0 0
+BITMAP
+80
+ENDCHAR
+ENDFONT
+`
+
+// testBMFontText is a BMFont descriptor for testBMFontPage with kerning "AB" -1.
+const testBMFontText = `info face="Test Face" size=4
+common lineHeight=5 base=4 scaleW=8 scaleH=4 pages=1
+page id=0 file="page.png"
+chars count=2
+char id=65 x=0 y=0 width=3 height=4 xoffset=0 yoffset=1 xadvance=4 page=0
+char id=66 x=4 y=0 width=2 height=4 xoffset=0 yoffset=1 xadvance=3 page=0
+kernings count=1
+kerning first=65 second=66 amount=-1
+`
+
+const testBMFontXML = `<?xml version="1.0"?>
+<font>
+  <info face="Test Face" size="4"/>
+  <common lineHeight="5" base="4" scaleW="8" scaleH="4" pages="1"/>
+  <pages>
+    <page id="0" file="page.png"/>
+  </pages>
+  <chars count="2">
+    <char id="65" x="0" y="0" width="3" height="4" xoffset="0" yoffset="1" xadvance="4" page="0"/>
+    <char id="66" x="4" y="0" width="2" height="4" xoffset="0" yoffset="1" xadvance="3" page="0"/>
+  </chars>
+  <kernings count="1">
+    <kerning first="65" second="66" amount="-1"/>
+  </kernings>
+</font>
+`
+
+// testBMFontPage returns an 8x4 page with solid white glyph cells at x 0-2
+// and x 4-5 on a transparent background.
+func testBMFontPage() *image.NRGBA {
+	page := image.NewNRGBA(image.Rect(0, 0, 8, 4))
+	for y := 0; y < 4; y++ {
+		for _, x := range []int{0, 1, 2, 4, 5} {
+			page.SetNRGBA(x, y, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+		}
+	}
+	return page
+}
+
+func parseTestBDF(t *testing.T) *Font {
+	t.Helper()
+	f, err := ParseBDF(strings.NewReader(testBDF))
+	if err != nil {
+		t.Fatalf("ParseBDF() error = %v", err)
+	}
+	return f
+}
+
+// inkBounds returns the bounds of the non-transparent pixels of img.
+func inkBounds(img *image.NRGBA) image.Rectangle {
+	var r image.Rectangle
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if img.NRGBAAt(x, y).A != 0 {
+				r = r.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return r
+}
+
+func TestLibrary(t *testing.T) {
+	library, err := Library()
+	if err != nil {
+		t.Fatalf("Library() error = %v", err)
+	}
+
+	want := []string{"3x5", "5x7", "8x8"}
+	if len(library) != len(want) {
+		t.Fatalf("Library() returned %d fonts, want %d", len(library), len(want))
+	}
+	for i, lf := range library {
+		if lf.ID != want[i] {
+			t.Errorf("library[%d].ID = %q, want %q", i, lf.ID, want[i])
+		}
+		if lf.Description == "" {
+			t.Errorf("%s: missing description", lf.ID)
+		}
+		if len(lf.Glyphs) != 95 {
+			t.Errorf("%s: %d glyphs, want 95 (printable ASCII)", lf.ID, len(lf.Glyphs))
+		}
+		for r := rune(32); r <= 126; r++ {
+			if _, ok := lf.Glyphs[r]; !ok {
+				t.Errorf("%s: missing glyph %q", lf.ID, r)
+			}
+		}
+		if lf.DefaultChar != '?' {
+			t.Errorf("%s: DefaultChar = %q, want '?'", lf.ID, lf.DefaultChar)
+		}
+	}
+}
+
+func TestNamed(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"5x7", "5x7"},
+		{"Pixel 5x7", "5x7"},
+		{"SMALL", "5x7"},
+		{"tiny", "3x5"},
+		{"bold", "8x8"},
+		{"8X8", "8x8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lf, err := Named(tt.name)
+			if err != nil {
+				t.Fatalf("Named(%q) error = %v", tt.name, err)
+			}
+			if lf.ID != tt.want {
+				t.Errorf("Named(%q).ID = %q, want %q", tt.name, lf.ID, tt.want)
+			}
+		})
+	}
+
+	if _, err := Named("comic-sans"); err == nil || !strings.Contains(err.Error(), "available") {
+		t.Errorf("Named(unknown) error = %v, want list of available fonts", err)
+	}
+	if _, err := Named(""); err == nil {
+		t.Error("Named(\"\") expected error")
+	}
+}
+
+func TestParseBDF(t *testing.T) {
+	f := parseTestBDF(t)
+
+	if f.Name != "Tiny" {
+		t.Errorf("Name = %q, want %q", f.Name, "Tiny")
+	}
+	if f.LineHeight != 4 || f.Base != 3 {
+		t.Errorf("LineHeight, Base = %d, %d, want 4, 3", f.LineHeight, f.Base)
+	}
+	if len(f.Glyphs) != 2 {
+		t.Fatalf("got %d glyphs, want 2 (unencoded glyph skipped)", len(f.Glyphs))
+	}
+
+	a := f.Glyphs['A']
+	if a.Advance != 4 || a.YOffset != 0 || a.Mask.Bounds().Dx() != 3 {
+		t.Errorf("A = advance %d, yoffset %d, width %d, want 4, 0, 3", a.Advance, a.YOffset, a.Mask.Bounds().Dx())
+	}
+	if a.Mask.AlphaAt(1, 1).A != 0 || a.Mask.AlphaAt(0, 1).A != 255 {
+		t.Error("A mask should be a hollow 3x3 box")
+	}
+
+	// "i" sits one pixel lower: top at ascent - (yoff + h) = 3 - (-1 + 3) = 1
+	if got := f.Glyphs['i'].YOffset; got != 1 {
+		t.Errorf("i YOffset = %d, want 1", got)
+	}
+}
+
+func TestParseBDF_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"no glyphs", "STARTFONT 2.1\nFONT_ASCENT 3\nFONT_DESCENT 1\nENDFONT\n"},
+		{"bad bitmap", "STARTFONT 2.1\nSTARTCHAR A\nENCODING 65\nBBX 1 1 0 0\nBITMAP\nZZ\nENDCHAR\n"},
+		{"truncated", "STARTFONT 2.1\nSTARTCHAR A\nENCODING 65\nBBX 1 1 0 0\nBITMAP\n80\n"},
+		{"short bitmap", "STARTFONT 2.1\nSTARTCHAR A\nENCODING 65\nBBX 1 2 0 0\nBITMAP\n80\nENDCHAR\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseBDF(strings.NewReader(tt.input)); err == nil {
+				t.Error("ParseBDF() expected error")
+			}
+		})
+	}
+}
+
+func TestParseBMFont(t *testing.T) {
+	loadPage := func(name string) (image.Image, error) {
+		if name != "page.png" {
+			t.Errorf("loadPage(%q), want page.png", name)
+		}
+		return testBMFontPage(), nil
+	}
+
+	for name, desc := range map[string]string{"text": testBMFontText, "xml": testBMFontXML} {
+		t.Run(name, func(t *testing.T) {
+			f, err := ParseBMFont(strings.NewReader(desc), loadPage)
+			if err != nil {
+				t.Fatalf("ParseBMFont() error = %v", err)
+			}
+
+			if f.Name != "Test Face" {
+				t.Errorf("Name = %q, want %q", f.Name, "Test Face")
+			}
+			if f.LineHeight != 5 || f.Base != 4 {
+				t.Errorf("LineHeight, Base = %d, %d, want 5, 4", f.LineHeight, f.Base)
+			}
+			b, ok := f.Glyphs['B']
+			if !ok {
+				t.Fatal("missing glyph B")
+			}
+			if b.Mask.Bounds().Dx() != 2 || b.YOffset != 1 || b.Advance != 3 {
+				t.Errorf("B = width %d, yoffset %d, advance %d, want 2, 1, 3", b.Mask.Bounds().Dx(), b.YOffset, b.Advance)
+			}
+			if b.Mask.AlphaAt(1, 3).A != 255 {
+				t.Error("B mask should be solid")
+			}
+			if got := f.Kerning[KerningPair{First: 'A', Second: 'B'}]; got != -1 {
+				t.Errorf("kerning AB = %d, want -1", got)
+			}
+			if f.DefaultChar != 0 {
+				t.Errorf("DefaultChar = %q, want none", f.DefaultChar)
+			}
+		})
+	}
+}
+
+func TestParseBMFont_Errors(t *testing.T) {
+	loadPage := func(string) (image.Image, error) { return testBMFontPage(), nil }
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"binary", "BMF\x03"},
+		{"no line height", "info face=x\nchar id=65 width=1 height=1\n"},
+		{"no chars", "common lineHeight=5 base=4\n"},
+		{"bad number", "common lineHeight=five base=4\n"},
+		{"missing page", "common lineHeight=5 base=4\nchar id=65 width=1 height=1 page=2\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseBMFont(strings.NewReader(tt.input), loadPage); err == nil {
+				t.Error("ParseBMFont() expected error")
+			}
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+
+	page, err := os.Create(filepath.Join(dir, "page.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(page, testBMFontPage()); err != nil {
+		t.Fatal(err)
+	}
+	page.Close()
+
+	fntPath := filepath.Join(dir, "test.fnt")
+	if err := os.WriteFile(fntPath, []byte(testBMFontText), 0644); err != nil {
+		t.Fatal(err)
+	}
+	bdfPath := filepath.Join(dir, "tiny.bdf")
+	if err := os.WriteFile(bdfPath, []byte(testBDF), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := LoadFile(fntPath)
+	if err != nil {
+		t.Fatalf("LoadFile(.fnt) error = %v", err)
+	}
+	if len(f.Glyphs) != 2 || f.Glyphs['A'].Mask.AlphaAt(0, 0).A != 255 {
+		t.Error("LoadFile(.fnt) did not load glyphs from the PNG page")
+	}
+
+	f, err = LoadFile(bdfPath)
+	if err != nil {
+		t.Fatalf("LoadFile(.bdf) error = %v", err)
+	}
+	if f.Name != "Tiny" {
+		t.Errorf("LoadFile(.bdf) Name = %q, want Tiny", f.Name)
+	}
+
+	if _, err := LoadFile(filepath.Join(dir, "missing.fnt")); err == nil {
+		t.Error("LoadFile(missing) expected error")
+	}
+}
+
+func TestRender(t *testing.T) {
+	f := parseTestBDF(t)
+	red := color.NRGBA{R: 255, A: 255}
+
+	rendered, err := f.Render("AA", TextOptions{Color: red})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	// 4 + 3 pixels wide, one line of LineHeight 4
+	if rendered.Width != 7 || rendered.Height != 4 || rendered.Lines != 1 {
+		t.Errorf("size = %dx%d, %d lines, want 7x4, 1 line", rendered.Width, rendered.Height, rendered.Lines)
+	}
+	if rendered.Origin != (image.Point{}) {
+		t.Errorf("Origin = %v, want (0,0)", rendered.Origin)
+	}
+	if got := rendered.Image.NRGBAAt(4, 0); got != red {
+		t.Errorf("pixel (4,0) = %v, want %v", got, red)
+	}
+	if got := rendered.Image.NRGBAAt(3, 0); got.A != 0 {
+		t.Errorf("gap pixel (3,0) = %v, want transparent", got)
+	}
+
+	spaced, err := f.Render("AA", TextOptions{Color: red, LetterSpacing: 2})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if spaced.Width != 9 {
+		t.Errorf("letter-spaced width = %d, want 9", spaced.Width)
+	}
+}
+
+func TestRender_Lines(t *testing.T) {
+	f := parseTestBDF(t)
+	white := color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+
+	tests := []struct {
+		align string
+		wantX int // left edge of the short second line
+	}{
+		{AlignLeft, 0},
+		{AlignCenter, 5},
+		{AlignRight, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.align, func(t *testing.T) {
+			rendered, err := f.Render("AAA\ni", TextOptions{Color: white, Align: tt.align, LineSpacing: 1})
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if rendered.Lines != 2 || rendered.Width != 11 || rendered.Height != 9 {
+				t.Fatalf("got %d lines %dx%d, want 2 lines 11x9", rendered.Lines, rendered.Width, rendered.Height)
+			}
+			// Second line starts at 4 + 1; "i" has YOffset 1
+			if got := rendered.Image.NRGBAAt(tt.wantX, 6); got != white {
+				t.Errorf("pixel (%d,6) = %v, want text", tt.wantX, got)
+			}
+		})
+	}
+
+	if _, err := f.Render("A", TextOptions{Align: "justify"}); err == nil {
+		t.Error("Render() with invalid alignment expected error")
+	}
+}
+
+func TestRender_Outline(t *testing.T) {
+	f := parseTestBDF(t)
+	text := color.NRGBA{R: 255, A: 255}
+	outline := color.NRGBA{A: 255}
+
+	rendered, err := f.Render("A", TextOptions{Color: text, OutlineColor: outline, OutlineWidth: 1})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if rendered.Origin != (image.Point{X: 1, Y: 1}) {
+		t.Errorf("Origin = %v, want (1,1)", rendered.Origin)
+	}
+	if got := inkBounds(rendered.Image); got != image.Rect(0, 0, 5, 5) {
+		t.Errorf("ink bounds = %v, want (0,0)-(5,5)", got)
+	}
+	if got := rendered.Image.NRGBAAt(0, 0); got != outline {
+		t.Errorf("corner = %v, want outline", got)
+	}
+	if got := rendered.Image.NRGBAAt(1, 1); got != text {
+		t.Errorf("glyph pixel = %v, want text", got)
+	}
+	// The hole inside the box is within one pixel of the glyph
+	if got := rendered.Image.NRGBAAt(2, 2); got != outline {
+		t.Errorf("inner pixel = %v, want outline", got)
+	}
+}
+
+func TestRender_KerningAndMissing(t *testing.T) {
+	f, err := ParseBMFont(strings.NewReader(testBMFontText), func(string) (image.Image, error) {
+		return testBMFontPage(), nil
+	})
+	if err != nil {
+		t.Fatalf("ParseBMFont() error = %v", err)
+	}
+	white := color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+
+	kerned, err := f.Render("AB", TextOptions{Color: white, Kerning: true})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	plain, err := f.Render("AB", TextOptions{Color: white})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if kerned.Width != 5 || plain.Width != 6 {
+		t.Errorf("widths = %d kerned, %d plain, want 5, 6", kerned.Width, plain.Width)
+	}
+
+	// No '?' glyph, so missing characters are skipped
+	rendered, err := f.Render("AxBx", TextOptions{Color: white})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if len(rendered.Missing) != 1 || rendered.Missing[0] != 'x' {
+		t.Errorf("Missing = %q, want [x]", rendered.Missing)
+	}
+	if rendered.Width != plain.Width {
+		t.Errorf("width with skipped characters = %d, want %d", rendered.Width, plain.Width)
+	}
+
+	// The bundled fonts draw '?' instead
+	lf, err := Named("5x7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	withDefault, err := lf.Render("é", TextOptions{Color: white})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	question, err := lf.Render("?", TextOptions{Color: white})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if withDefault.Width != question.Width || len(withDefault.Missing) != 1 {
+		t.Errorf("missing character rendered %d wide with %d missing, want %d wide with 1 missing", withDefault.Width, len(withDefault.Missing), question.Width)
+	}
+}
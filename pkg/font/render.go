@@ -0,0 +1,182 @@
+package font
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+)
+
+// Text alignments supported by Render.
+const (
+	AlignLeft   = "left"
+	AlignCenter = "center"
+	AlignRight  = "right"
+)
+
+// TextOptions configures Render.
+type TextOptions struct {
+	Color         color.NRGBA // Text color
+	OutlineColor  color.NRGBA // Outline color (used when OutlineWidth > 0)
+	OutlineWidth  int         // Outline thickness in pixels around every glyph (0 = none)
+	Align         string      // "left", "center", or "right" within the widest line (default: left)
+	LineSpacing   int         // Extra pixels between lines (may be negative)
+	LetterSpacing int         // Extra pixels between characters (may be negative)
+	Kerning       bool        // Apply the font's kerning pairs
+}
+
+// RenderedText is the result of Render.
+type RenderedText struct {
+	Image   *image.NRGBA // Text and outline on a transparent background
+	Origin  image.Point  // Position of the text block's top-left corner in Image
+	Width   int          // Text block width (widest line, without outline)
+	Height  int          // Text block height (all lines, without outline)
+	Lines   int          // Number of lines
+	Missing []rune       // Characters without a glyph, in order of first use
+}
+
+// placedGlyph is a glyph positioned relative to the text block.
+type placedGlyph struct {
+	glyph *Glyph
+	x, y  int
+}
+
+// Render draws text ("\n" separates lines) with the font.
+//
+// Lines are laid out from the top-left corner of the text block, each
+// LineHeight + LineSpacing below the previous one, and aligned horizontally
+// within the widest line. Characters without a glyph are drawn with the
+// font's DefaultChar when it has one and skipped otherwise; either way they
+// are reported in Missing.
+func (f *Font) Render(text string, opts TextOptions) (*RenderedText, error) {
+	switch opts.Align {
+	case "", AlignLeft, AlignCenter, AlignRight:
+	default:
+		return nil, fmt.Errorf("invalid alignment: %s (must be left, center, or right)", opts.Align)
+	}
+	if opts.OutlineWidth < 0 {
+		return nil, fmt.Errorf("outline width must be >= 0, got %d", opts.OutlineWidth)
+	}
+
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+
+	result := &RenderedText{Lines: len(lines)}
+	seenMissing := make(map[rune]bool)
+
+	// Lay out each line from x = 0, tracking where its ink ends
+	placed := make([][]placedGlyph, len(lines))
+	lineRight := make([]int, len(lines))
+	for i, line := range lines {
+		top := i * (f.LineHeight + opts.LineSpacing)
+		pen := 0
+		var prev rune
+		started := false
+		for _, r := range line {
+			g, ok := f.Glyphs[r]
+			if !ok {
+				if !seenMissing[r] {
+					seenMissing[r] = true
+					result.Missing = append(result.Missing, r)
+				}
+				if g, ok = f.Glyphs[f.DefaultChar]; !ok || f.DefaultChar == 0 {
+					continue
+				}
+			}
+
+			if started {
+				pen += opts.LetterSpacing
+				if opts.Kerning {
+					pen += f.Kerning[KerningPair{First: prev, Second: r}]
+				}
+			}
+			prev, started = r, true
+
+			placed[i] = append(placed[i], placedGlyph{glyph: g, x: pen + g.XOffset, y: top + g.YOffset})
+			if w := g.Mask.Bounds().Dx(); w > 0 {
+				lineRight[i] = max(lineRight[i], pen+g.XOffset+w)
+			}
+			pen += g.Advance
+		}
+		result.Width = max(result.Width, lineRight[i])
+	}
+	result.Height = (len(lines)-1)*(f.LineHeight+opts.LineSpacing) + f.LineHeight
+	result.Height = max(result.Height, 0)
+
+	// Align lines and find the ink bounds
+	bounds := image.Rect(0, 0, result.Width, result.Height)
+	for i := range placed {
+		shift := 0
+		switch opts.Align {
+		case AlignCenter:
+			shift = (result.Width - lineRight[i]) / 2
+		case AlignRight:
+			shift = result.Width - lineRight[i]
+		}
+		for n := range placed[i] {
+			p := &placed[i][n]
+			p.x += shift
+			size := p.glyph.Mask.Bounds().Size()
+			if size.X > 0 && size.Y > 0 {
+				bounds = bounds.Union(image.Rect(p.x, p.y, p.x+size.X, p.y+size.Y))
+			}
+		}
+	}
+	bounds = bounds.Inset(-opts.OutlineWidth)
+
+	// Draw the glyph masks
+	mask := image.NewAlpha(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	result.Origin = image.Point{X: -bounds.Min.X, Y: -bounds.Min.Y}
+	for _, line := range placed {
+		for _, p := range line {
+			gb := p.glyph.Mask.Bounds()
+			for y := 0; y < gb.Dy(); y++ {
+				for x := 0; x < gb.Dx(); x++ {
+					if p.glyph.Mask.AlphaAt(x, y).A != 0 {
+						mask.SetAlpha(p.x+x+result.Origin.X, p.y+y+result.Origin.Y, color.Alpha{A: 255})
+					}
+				}
+			}
+		}
+	}
+
+	// Color the text and its outline
+	result.Image = image.NewNRGBA(mask.Bounds())
+	outline := dilate(mask, opts.OutlineWidth)
+	for y := 0; y < mask.Bounds().Dy(); y++ {
+		for x := 0; x < mask.Bounds().Dx(); x++ {
+			switch {
+			case mask.AlphaAt(x, y).A != 0:
+				result.Image.SetNRGBA(x, y, opts.Color)
+			case outline != nil && outline[y*mask.Bounds().Dx()+x]:
+				result.Image.SetNRGBA(x, y, opts.OutlineColor)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// dilate returns the pixels within radius (Chebyshev distance) of a set mask
+// pixel, or nil when radius is 0.
+func dilate(mask *image.Alpha, radius int) []bool {
+	if radius <= 0 {
+		return nil
+	}
+
+	w, h := mask.Bounds().Dx(), mask.Bounds().Dy()
+	out := make([]bool, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if mask.AlphaAt(x, y).A == 0 {
+				continue
+			}
+			for dy := max(y-radius, 0); dy <= min(y+radius, h-1); dy++ {
+				for dx := max(x-radius, 0); dx <= min(x+radius, w-1); dx++ {
+					out[dy*w+dx] = true
+				}
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,307 @@
+package palette
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"unicode/utf16"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+const (
+	// actSize is the size of an Adobe Color Table without its count trailer.
+	actSize = MaxColors * 3
+
+	// actNoTransparency marks an ACT trailer without a transparent index.
+	actNoTransparency = 0xFFFF
+
+	aseSignature = "ASEF"
+	pngSignature = "\x89PNG\r\n\x1a\n"
+)
+
+// Adobe Swatch Exchange block types.
+const (
+	aseGroupStart uint16 = 0xC001
+	aseGroupEnd   uint16 = 0xC002
+	aseColorEntry uint16 = 0x0001
+)
+
+// decodeACT reads an Adobe Color Table.
+//
+// A 772-byte table ends with a big-endian color count and transparent index;
+// a plain 768-byte table always holds 256 colors.
+func decodeACT(r io.Reader) (*Palette, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != actSize && len(data) != actSize+4 {
+		return nil, fmt.Errorf("expected %d or %d bytes, got %d", actSize, actSize+4, len(data))
+	}
+
+	count := MaxColors
+	transparent := actNoTransparency
+	if len(data) == actSize+4 {
+		if n := int(binary.BigEndian.Uint16(data[actSize:])); n > 0 && n <= MaxColors {
+			count = n
+		}
+		transparent = int(binary.BigEndian.Uint16(data[actSize+2:]))
+	}
+
+	p := &Palette{Colors: make([]Color, count)}
+	for i := range p.Colors {
+		p.Colors[i] = Color{R: data[i*3], G: data[i*3+1], B: data[i*3+2], A: 255}
+	}
+	if transparent < count {
+		p.Colors[transparent].A = 0
+	}
+
+	return p, nil
+}
+
+// encodeACT writes an Adobe Color Table with a count trailer. The first fully
+// transparent color, if any, becomes the transparent index.
+func encodeACT(w io.Writer, p *Palette) error {
+	if len(p.Colors) > MaxColors {
+		return fmt.Errorf("ACT palettes hold at most %d colors, got %d", MaxColors, len(p.Colors))
+	}
+
+	data := make([]byte, actSize+4)
+	transparent := actNoTransparency
+	for i, c := range p.Colors {
+		data[i*3], data[i*3+1], data[i*3+2] = c.R, c.G, c.B
+		if c.A == 0 && transparent == actNoTransparency {
+			transparent = i
+		}
+	}
+	binary.BigEndian.PutUint16(data[actSize:], uint16(len(p.Colors)))
+	binary.BigEndian.PutUint16(data[actSize+2:], uint16(transparent))
+
+	_, err := w.Write(data)
+	return err
+}
+
+// decodeASE reads an Adobe Swatch Exchange file.
+//
+// Groups are flattened in file order and the first group name becomes the
+// palette name. CMYK, Gray, and LAB swatches are converted to RGB.
+func decodeASE(r io.Reader) (*Palette, error) {
+	var header struct {
+		Signature [4]byte
+		Major     uint16
+		Minor     uint16
+		Blocks    uint32
+	}
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	if string(header.Signature[:]) != aseSignature {
+		return nil, fmt.Errorf("missing %q signature", aseSignature)
+	}
+
+	p := &Palette{}
+	for i := uint32(0); i < header.Blocks; i++ {
+		var block struct {
+			Type   uint16
+			Length uint32
+		}
+		if err := binary.Read(r, binary.BigEndian, &block); err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+		data := make([]byte, block.Length)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+
+		switch block.Type {
+		case aseGroupStart:
+			name, _, err := readASEName(data)
+			if err != nil {
+				return nil, fmt.Errorf("block %d: %w", i, err)
+			}
+			if p.Name == "" {
+				p.Name = name
+			}
+		case aseColorEntry:
+			c, err := readASEColor(data)
+			if err != nil {
+				return nil, fmt.Errorf("block %d: %w", i, err)
+			}
+			p.Colors = append(p.Colors, c)
+		}
+	}
+
+	return p, nil
+}
+
+// readASEName reads a length-prefixed, null-terminated UTF-16BE string and
+// returns the string and the remaining bytes.
+func readASEName(data []byte) (string, []byte, error) {
+	if len(data) < 2 {
+		return "", nil, fmt.Errorf("truncated name")
+	}
+	n := int(binary.BigEndian.Uint16(data))
+	data = data[2:]
+	if len(data) < n*2 {
+		return "", nil, fmt.Errorf("truncated name")
+	}
+
+	units := make([]uint16, 0, n)
+	for i := 0; i < n; i++ {
+		if u := binary.BigEndian.Uint16(data[i*2:]); u != 0 {
+			units = append(units, u)
+		}
+	}
+
+	return string(utf16.Decode(units)), data[n*2:], nil
+}
+
+// readASEColor reads a color entry block.
+func readASEColor(data []byte) (Color, error) {
+	name, rest, err := readASEName(data)
+	if err != nil {
+		return Color{}, err
+	}
+	if len(rest) < 4 {
+		return Color{}, fmt.Errorf("truncated color model")
+	}
+	model := string(rest[:4])
+	rest = rest[4:]
+
+	components := map[string]int{"RGB ": 3, "CMYK": 4, "Gray": 1, "LAB ": 3}[model]
+	if components == 0 {
+		return Color{}, fmt.Errorf("unsupported color model: %q", model)
+	}
+	if len(rest) < components*4 {
+		return Color{}, fmt.Errorf("truncated %q color values", model)
+	}
+
+	v := make([]float64, components)
+	for i := range v {
+		v[i] = float64(math.Float32frombits(binary.BigEndian.Uint32(rest[i*4:])))
+	}
+
+	var c colorful.Color
+	switch model {
+	case "RGB ":
+		c = colorful.Color{R: v[0], G: v[1], B: v[2]}
+	case "CMYK":
+		c = colorful.Color{R: (1 - v[0]) * (1 - v[3]), G: (1 - v[1]) * (1 - v[3]), B: (1 - v[2]) * (1 - v[3])}
+	case "Gray":
+		c = colorful.Color{R: v[0], G: v[0], B: v[0]}
+	case "LAB ":
+		// L is stored as 0-1, a and b as -128..127
+		c = colorful.Lab(v[0], v[1]/100.0, v[2]/100.0)
+	}
+
+	r, g, b := c.Clamped().RGB255()
+	return Color{R: r, G: g, B: b, A: 255, Name: name}, nil
+}
+
+// encodeASE writes an Adobe Swatch Exchange file of RGB swatches, wrapped in a
+// group when the palette is named. Unnamed colors are named by their hex value.
+func encodeASE(w io.Writer, p *Palette) error {
+	var body bytes.Buffer
+	blocks := uint32(len(p.Colors))
+
+	writeBlock := func(blockType uint16, data []byte) {
+		_ = binary.Write(&body, binary.BigEndian, blockType)
+		_ = binary.Write(&body, binary.BigEndian, uint32(len(data)))
+		body.Write(data)
+	}
+
+	if p.Name != "" {
+		blocks += 2
+		writeBlock(aseGroupStart, aseName(p.Name))
+	}
+
+	for _, c := range p.Colors {
+		name := c.Name
+		if name == "" {
+			name = Color{R: c.R, G: c.G, B: c.B, A: 255}.Hex()
+		}
+
+		var entry bytes.Buffer
+		entry.Write(aseName(name))
+		entry.WriteString("RGB ")
+		for _, v := range []uint8{c.R, c.G, c.B} {
+			_ = binary.Write(&entry, binary.BigEndian, float32(v)/255.0)
+		}
+		// Color type 2 is a normal (non-global, non-spot) swatch
+		_ = binary.Write(&entry, binary.BigEndian, uint16(2))
+		writeBlock(aseColorEntry, entry.Bytes())
+	}
+
+	if p.Name != "" {
+		writeBlock(aseGroupEnd, nil)
+	}
+
+	var header bytes.Buffer
+	header.WriteString(aseSignature)
+	_ = binary.Write(&header, binary.BigEndian, []uint16{1, 0})
+	_ = binary.Write(&header, binary.BigEndian, blocks)
+
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return err
+	}
+	_, err := w.Write(body.Bytes())
+	return err
+}
+
+// aseName encodes a length-prefixed, null-terminated UTF-16BE string.
+func aseName(s string) []byte {
+	units := append(utf16.Encode([]rune(s)), 0)
+	data := make([]byte, 2+len(units)*2)
+	binary.BigEndian.PutUint16(data, uint16(len(units)))
+	for i, u := range units {
+		binary.BigEndian.PutUint16(data[2+i*2:], u)
+	}
+	return data
+}
+
+// decodePNG reads the distinct colors of a swatch image in row-major order of
+// first appearance, so both 1-pixel strips and larger swatch grids work.
+func decodePNG(r io.Reader) (*Palette, error) {
+	img, err := png.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Palette{}
+	seen := make(map[color.NRGBA]bool)
+	bounds := img.Bounds()
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A == 0 {
+				c = color.NRGBA{}
+			}
+			if seen[c] {
+				continue
+			}
+			seen[c] = true
+			if len(seen) > MaxColors {
+				return nil, fmt.Errorf("image has more than %d distinct colors", MaxColors)
+			}
+			p.Colors = append(p.Colors, Color{R: c.R, G: c.G, B: c.B, A: c.A})
+		}
+	}
+
+	return p, nil
+}
+
+// encodePNG writes an N×1 swatch strip with one pixel per color.
+func encodePNG(w io.Writer, p *Palette) error {
+	img := image.NewNRGBA(image.Rect(0, 0, len(p.Colors), 1))
+	for i, c := range p.Colors {
+		img.SetNRGBA(i, 0, c.NRGBA())
+	}
+	return png.Encode(w, img)
+}
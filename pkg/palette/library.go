@@ -0,0 +1,123 @@
+package palette
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// libraryFS holds the bundled palettes as GIMP palette files. Each file may
+// carry "#Description:" and "#Aliases:" comment lines.
+//
+//go:embed library/*.gpl
+var libraryFS embed.FS
+
+// LibraryPalette is a palette bundled with the binary.
+type LibraryPalette struct {
+	ID          string   // File name without extension, e.g. "pico-8"
+	Description string   // One-line description
+	Aliases     []string // Alternative names accepted by Named
+	Palette
+}
+
+var loadLibrary = sync.OnceValues(func() ([]LibraryPalette, error) {
+	entries, err := libraryFS.ReadDir("library")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read palette library: %w", err)
+	}
+
+	library := make([]LibraryPalette, 0, len(entries))
+	for _, entry := range entries {
+		data, err := libraryFS.ReadFile(path.Join("library", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read palette library: %w", err)
+		}
+
+		p, err := Decode(bytes.NewReader(data), FormatGPL)
+		if err != nil {
+			return nil, fmt.Errorf("library palette %s: %w", entry.Name(), err)
+		}
+
+		lp := LibraryPalette{
+			ID:      strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())),
+			Palette: *p,
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			line := scanner.Text()
+			if v, ok := strings.CutPrefix(line, "#Description:"); ok {
+				lp.Description = strings.TrimSpace(v)
+			} else if v, ok := strings.CutPrefix(line, "#Aliases:"); ok {
+				for _, alias := range strings.Split(v, ",") {
+					if alias = strings.TrimSpace(alias); alias != "" {
+						lp.Aliases = append(lp.Aliases, alias)
+					}
+				}
+			}
+		}
+
+		library = append(library, lp)
+	}
+
+	sort.Slice(library, func(i, j int) bool { return library[i].ID < library[j].ID })
+	return library, nil
+})
+
+// Library returns the bundled palettes sorted by ID. The returned palettes are
+// shared and must not be modified; use Named for a private copy.
+func Library() ([]LibraryPalette, error) {
+	return loadLibrary()
+}
+
+// Named looks up a bundled palette by ID, display name, or alias.
+//
+// Matching ignores case, spaces, hyphens, and underscores, so "PICO-8",
+// "pico8", and "Pico 8" all find the same palette.
+func Named(name string) (*LibraryPalette, error) {
+	library, err := loadLibrary()
+	if err != nil {
+		return nil, err
+	}
+
+	key := normalizeName(name)
+	if key == "" {
+		return nil, fmt.Errorf("palette name is required")
+	}
+
+	for i := range library {
+		lp := &library[i]
+		candidates := append([]string{lp.ID, lp.Name}, lp.Aliases...)
+		for _, c := range candidates {
+			if normalizeName(c) == key {
+				// Return a copy so callers cannot modify the shared library
+				found := *lp
+				found.Colors = append([]Color(nil), lp.Colors...)
+				return &found, nil
+			}
+		}
+	}
+
+	ids := make([]string, len(library))
+	for i, lp := range library {
+		ids[i] = lp.ID
+	}
+	return nil, fmt.Errorf("unknown palette: %s (available: %s)", name, strings.Join(ids, ", "))
+}
+
+// normalizeName lowercases a palette name and drops everything but letters and digits.
+func normalizeName(name string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,285 @@
+// Package palette reads and writes color palette files.
+//
+// Supported formats:
+//
+//   - GIMP palette (.gpl), including the "Channels: RGBA" variant
+//   - JASC-PAL (.pal) as written by Paint Shop Pro and Aseprite; Microsoft RIFF
+//     palettes with the same extension are also read
+//   - Lospec hex lists (.hex), one RRGGBB color per line
+//   - Adobe Color Table (.act), 256 RGB triplets with an optional count trailer
+//   - Adobe Swatch Exchange (.ase), RGB, CMYK, Gray, and LAB swatches
+//   - PNG swatch strips (.png), one pixel per color
+//
+// The format is detected from the file extension, falling back to the file
+// contents when the extension is missing or unknown. The package is pure Go and
+// does not depend on Aseprite.
+//
+// The package also embeds a library of classic palettes (PICO-8, Endesga 32,
+// DawnBringer, NES, Game Boy, and more); see Library and Named.
+package palette
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Format identifies a palette file format.
+type Format string
+
+// Supported palette file formats.
+const (
+	FormatGPL Format = "gpl"
+	FormatPAL Format = "pal"
+	FormatHEX Format = "hex"
+	FormatACT Format = "act"
+	FormatASE Format = "ase"
+	FormatPNG Format = "png"
+)
+
+// AllFormats lists every supported format.
+var AllFormats = []Format{FormatGPL, FormatPAL, FormatHEX, FormatACT, FormatASE, FormatPNG}
+
+// MaxColors is the largest palette the fixed-size formats (ACT) and PNG swatch
+// decoding accept, matching the Aseprite palette limit.
+const MaxColors = 256
+
+// Color is a single palette entry.
+type Color struct {
+	R, G, B, A uint8
+	Name       string // Optional swatch name (GPL and ASE only)
+}
+
+// NRGBA returns the entry as a non-premultiplied color.
+func (c Color) NRGBA() color.NRGBA {
+	return color.NRGBA{R: c.R, G: c.G, B: c.B, A: c.A}
+}
+
+// Hex returns the color as #RRGGBB, or #RRGGBBAA when it is not fully opaque.
+func (c Color) Hex() string {
+	if c.A == 255 {
+		return fmt.Sprintf("#%02X%02X%02X", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("#%02X%02X%02X%02X", c.R, c.G, c.B, c.A)
+}
+
+// ParseHex parses a #RRGGBB or #RRGGBBAA color; the leading # is optional.
+func ParseHex(s string) (Color, error) {
+	h := strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(h) != 6 && len(h) != 8 {
+		return Color{}, fmt.Errorf("invalid hex color: %q", s)
+	}
+	v, err := strconv.ParseUint(h, 16, 32)
+	if err != nil {
+		return Color{}, fmt.Errorf("invalid hex color: %q", s)
+	}
+	if len(h) == 6 {
+		return Color{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 255}, nil
+	}
+	return Color{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
+// Palette is an ordered list of colors with an optional name.
+type Palette struct {
+	Name   string
+	Colors []Color
+}
+
+// FromHex builds a palette from hex color strings.
+func FromHex(name string, colors []string) (*Palette, error) {
+	p := &Palette{Name: name, Colors: make([]Color, len(colors))}
+	for i, s := range colors {
+		c, err := ParseHex(s)
+		if err != nil {
+			return nil, fmt.Errorf("color %d: %w", i, err)
+		}
+		p.Colors[i] = c
+	}
+	return p, nil
+}
+
+// Hex returns the palette colors as hex strings (see Color.Hex).
+func (p *Palette) Hex() []string {
+	out := make([]string, len(p.Colors))
+	for i, c := range p.Colors {
+		out[i] = c.Hex()
+	}
+	return out
+}
+
+// ParseFormat parses a format name such as "gpl" or ".gpl".
+func ParseFormat(s string) (Format, error) {
+	f := Format(strings.ToLower(strings.TrimPrefix(s, ".")))
+	for _, known := range AllFormats {
+		if f == known {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported palette format: %s (must be gpl, pal, hex, act, ase, or png)", s)
+}
+
+// FormatFromExtension returns the format implied by a file name's extension.
+func FormatFromExtension(name string) (Format, bool) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".gpl":
+		return FormatGPL, true
+	case ".pal":
+		return FormatPAL, true
+	case ".hex":
+		return FormatHEX, true
+	case ".act":
+		return FormatACT, true
+	case ".ase":
+		return FormatASE, true
+	case ".png":
+		return FormatPNG, true
+	}
+	return "", false
+}
+
+// DetectFormat determines the format of palette data.
+//
+// The extension of name is used when it is recognized; otherwise the contents
+// are sniffed for format signatures. Pass an empty name to sniff only.
+func DetectFormat(name string, data []byte) (Format, error) {
+	if f, ok := FormatFromExtension(name); ok {
+		return f, nil
+	}
+
+	switch {
+	case bytes.HasPrefix(data, []byte(gplHeader)):
+		return FormatGPL, nil
+	case bytes.HasPrefix(data, []byte(jascHeader)):
+		return FormatPAL, nil
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "PAL ":
+		return FormatPAL, nil
+	case bytes.HasPrefix(data, []byte(aseSignature)):
+		return FormatASE, nil
+	case bytes.HasPrefix(data, []byte(pngSignature)):
+		return FormatPNG, nil
+	case len(data) == actSize || len(data) == actSize+4:
+		return FormatACT, nil
+	case looksLikeHex(data):
+		return FormatHEX, nil
+	}
+
+	return "", fmt.Errorf("unable to detect palette format")
+}
+
+// Decode reads a palette in the given format.
+func Decode(r io.Reader, format Format) (*Palette, error) {
+	var (
+		p   *Palette
+		err error
+	)
+
+	switch format {
+	case FormatGPL:
+		p, err = decodeGPL(r)
+	case FormatPAL:
+		p, err = decodePAL(r)
+	case FormatHEX:
+		p, err = decodeHex(r)
+	case FormatACT:
+		p, err = decodeACT(r)
+	case FormatASE:
+		p, err = decodeASE(r)
+	case FormatPNG:
+		p, err = decodePNG(r)
+	default:
+		return nil, fmt.Errorf("unsupported palette format: %s", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s palette: %w", format, err)
+	}
+
+	if len(p.Colors) == 0 {
+		return nil, fmt.Errorf("%s palette contains no colors", format)
+	}
+	return p, nil
+}
+
+// Encode writes a palette in the given format.
+//
+// Formats without an alpha channel (PAL, HEX, ASE) write colors as opaque. ACT
+// keeps at most one fully transparent color as its transparent index.
+func Encode(w io.Writer, p *Palette, format Format) error {
+	if len(p.Colors) == 0 {
+		return fmt.Errorf("palette contains no colors")
+	}
+
+	var err error
+	switch format {
+	case FormatGPL:
+		err = encodeGPL(w, p)
+	case FormatPAL:
+		err = encodePAL(w, p)
+	case FormatHEX:
+		err = encodeHex(w, p)
+	case FormatACT:
+		err = encodeACT(w, p)
+	case FormatASE:
+		err = encodeASE(w, p)
+	case FormatPNG:
+		err = encodePNG(w, p)
+	default:
+		return fmt.Errorf("unsupported palette format: %s", format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode %s palette: %w", format, err)
+	}
+	return nil
+}
+
+// ReadFile reads a palette file, detecting its format when format is empty.
+//
+// Returns the palette and the format that was used. A palette without a name
+// is named after the file.
+func ReadFile(path string, format Format) (*Palette, Format, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read palette file: %w", err)
+	}
+
+	if format == "" {
+		if format, err = DetectFormat(path, data); err != nil {
+			return nil, "", fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	p, err := Decode(bytes.NewReader(data), format)
+	if err != nil {
+		return nil, "", err
+	}
+	if p.Name == "" {
+		p.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	return p, format, nil
+}
+
+// WriteFile writes a palette file, using the file extension when format is empty.
+func WriteFile(path string, p *Palette, format Format) error {
+	if format == "" {
+		f, ok := FormatFromExtension(path)
+		if !ok {
+			return fmt.Errorf("cannot determine palette format from file name: %s", path)
+		}
+		format = f
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, p, format); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write palette file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,493 @@
+package palette
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testPalette() *Palette {
+	return &Palette{
+		Name: "Test",
+		Colors: []Color{
+			{R: 0, G: 0, B: 0, A: 255},
+			{R: 255, G: 255, B: 255, A: 255},
+			{R: 255, G: 0, B: 77, A: 255},
+			{R: 41, G: 173, B: 255, A: 255},
+			{R: 0, G: 228, B: 54, A: 255},
+		},
+	}
+}
+
+func colorsEqual(a, b []Color) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].NRGBA() != b[i].NRGBA() {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range AllFormats {
+		t.Run(string(format), func(t *testing.T) {
+			original := testPalette()
+
+			var buf bytes.Buffer
+			if err := Encode(&buf, original, format); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+
+			detected, err := DetectFormat("", buf.Bytes())
+			if err != nil {
+				t.Fatalf("DetectFormat() error = %v", err)
+			}
+			if detected != format {
+				t.Errorf("DetectFormat() = %s, want %s", detected, format)
+			}
+
+			decoded, err := Decode(&buf, format)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if !colorsEqual(decoded.Colors, original.Colors) {
+				t.Errorf("round trip colors = %v, want %v", decoded.Hex(), original.Hex())
+			}
+		})
+	}
+}
+
+func TestRoundTrip_KeepsName(t *testing.T) {
+	for _, format := range []Format{FormatGPL, FormatASE} {
+		var buf bytes.Buffer
+		if err := Encode(&buf, testPalette(), format); err != nil {
+			t.Fatalf("%s: Encode() error = %v", format, err)
+		}
+		decoded, err := Decode(&buf, format)
+		if err != nil {
+			t.Fatalf("%s: Decode() error = %v", format, err)
+		}
+		if decoded.Name != "Test" {
+			t.Errorf("%s: name = %q, want %q", format, decoded.Name, "Test")
+		}
+	}
+}
+
+func TestRoundTrip_Alpha(t *testing.T) {
+	p := &Palette{Colors: []Color{
+		{R: 0, G: 0, B: 0, A: 0},
+		{R: 10, G: 20, B: 30, A: 128},
+		{R: 255, G: 0, B: 0, A: 255},
+	}}
+
+	for _, format := range []Format{FormatGPL, FormatPNG} {
+		var buf bytes.Buffer
+		if err := Encode(&buf, p, format); err != nil {
+			t.Fatalf("%s: Encode() error = %v", format, err)
+		}
+		decoded, err := Decode(&buf, format)
+		if err != nil {
+			t.Fatalf("%s: Decode() error = %v", format, err)
+		}
+		if !colorsEqual(decoded.Colors, p.Colors) {
+			t.Errorf("%s: colors = %v, want %v", format, decoded.Hex(), p.Hex())
+		}
+	}
+}
+
+func TestACT_TransparentIndex(t *testing.T) {
+	p := &Palette{Colors: []Color{
+		{R: 255, G: 0, B: 0, A: 255},
+		{R: 0, G: 0, B: 0, A: 0},
+	}}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, p, FormatACT); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if buf.Len() != actSize+4 {
+		t.Fatalf("encoded size = %d, want %d", buf.Len(), actSize+4)
+	}
+
+	decoded, err := Decode(&buf, FormatACT)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if len(decoded.Colors) != 2 {
+		t.Fatalf("decoded %d colors, want 2", len(decoded.Colors))
+	}
+	if decoded.Colors[1].A != 0 {
+		t.Errorf("transparent color alpha = %d, want 0", decoded.Colors[1].A)
+	}
+}
+
+func TestACT_WithoutTrailer(t *testing.T) {
+	data := make([]byte, actSize)
+	data[0], data[1], data[2] = 1, 2, 3
+
+	p, err := Decode(bytes.NewReader(data), FormatACT)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if len(p.Colors) != MaxColors {
+		t.Errorf("decoded %d colors, want %d", len(p.Colors), MaxColors)
+	}
+	if p.Colors[0].Hex() != "#010203" {
+		t.Errorf("first color = %s, want #010203", p.Colors[0].Hex())
+	}
+}
+
+func TestACT_TooManyColors(t *testing.T) {
+	p := &Palette{Colors: make([]Color, MaxColors+1)}
+	if err := Encode(&bytes.Buffer{}, p, FormatACT); err == nil {
+		t.Error("expected error for more than 256 colors")
+	}
+}
+
+func TestDecodeGPL(t *testing.T) {
+	input := "GIMP Palette\nName: Sweetie\nColumns: 4\n# comment\n\n 26  28  44\tBlack\n 93  39  93  Dark Purple\n"
+
+	p, err := Decode(strings.NewReader(input), FormatGPL)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if p.Name != "Sweetie" {
+		t.Errorf("name = %q, want %q", p.Name, "Sweetie")
+	}
+	if got := p.Hex(); len(got) != 2 || got[0] != "#1A1C2C" || got[1] != "#5D275D" {
+		t.Errorf("colors = %v", got)
+	}
+	if p.Colors[1].Name != "Dark Purple" {
+		t.Errorf("color name = %q, want %q", p.Colors[1].Name, "Dark Purple")
+	}
+}
+
+func TestDecodeGPL_InvalidComponent(t *testing.T) {
+	input := "GIMP Palette\n300 0 0\n"
+	if _, err := Decode(strings.NewReader(input), FormatGPL); err == nil {
+		t.Error("expected error for out of range component")
+	}
+}
+
+func TestDecodePAL_JASC(t *testing.T) {
+	input := "JASC-PAL\r\n0100\r\n2\r\n255 0 0\r\n0 0 255\r\n"
+
+	p, err := Decode(strings.NewReader(input), FormatPAL)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got := p.Hex(); len(got) != 2 || got[0] != "#FF0000" || got[1] != "#0000FF" {
+		t.Errorf("colors = %v", got)
+	}
+}
+
+func TestDecodePAL_CountMismatch(t *testing.T) {
+	input := "JASC-PAL\n0100\n3\n255 0 0\n"
+	if _, err := Decode(strings.NewReader(input), FormatPAL); err == nil {
+		t.Error("expected error when fewer colors than the count are present")
+	}
+}
+
+func TestDecodePAL_RIFF(t *testing.T) {
+	var chunk bytes.Buffer
+	_ = binary.Write(&chunk, binary.LittleEndian, uint16(0x0300))
+	_ = binary.Write(&chunk, binary.LittleEndian, uint16(2))
+	chunk.Write([]byte{255, 0, 0, 0, 0, 255, 0, 0})
+
+	var data bytes.Buffer
+	data.WriteString("RIFF")
+	_ = binary.Write(&data, binary.LittleEndian, uint32(4+8+chunk.Len()))
+	data.WriteString("PAL data")
+	_ = binary.Write(&data, binary.LittleEndian, uint32(chunk.Len()))
+	data.Write(chunk.Bytes())
+
+	format, err := DetectFormat("", data.Bytes())
+	if err != nil || format != FormatPAL {
+		t.Fatalf("DetectFormat() = %s, %v, want pal", format, err)
+	}
+
+	p, err := Decode(&data, FormatPAL)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got := p.Hex(); len(got) != 2 || got[0] != "#FF0000" || got[1] != "#00FF00" {
+		t.Errorf("colors = %v", got)
+	}
+}
+
+func TestDecodeHex(t *testing.T) {
+	input := "; lospec palette\nff004d\n#29ADFF\n\n00e43680\n"
+
+	p, err := Decode(strings.NewReader(input), FormatHEX)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	want := []string{"#FF004D", "#29ADFF", "#00E43680"}
+	got := p.Hex()
+	if len(got) != len(want) {
+		t.Fatalf("colors = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("color %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDecodeHex_Invalid(t *testing.T) {
+	if _, err := Decode(strings.NewReader("ff004d\nnothex\n"), FormatHEX); err == nil {
+		t.Error("expected error for invalid line")
+	}
+}
+
+func TestDecodeASE_ColorModels(t *testing.T) {
+	entry := func(model string, values ...float32) []byte {
+		var b bytes.Buffer
+		b.Write(aseName("c"))
+		b.WriteString(model)
+		for _, v := range values {
+			_ = binary.Write(&b, binary.BigEndian, v)
+		}
+		_ = binary.Write(&b, binary.BigEndian, uint16(2))
+		return b.Bytes()
+	}
+
+	blocks := [][]byte{
+		entry("Gray", 0.5),
+		entry("CMYK", 0, 1, 1, 0),
+		entry("LAB ", 1, 0, 0),
+	}
+
+	var data bytes.Buffer
+	data.WriteString(aseSignature)
+	_ = binary.Write(&data, binary.BigEndian, []uint16{1, 0})
+	_ = binary.Write(&data, binary.BigEndian, uint32(len(blocks)))
+	for _, b := range blocks {
+		_ = binary.Write(&data, binary.BigEndian, aseColorEntry)
+		_ = binary.Write(&data, binary.BigEndian, uint32(len(b)))
+		data.Write(b)
+	}
+
+	p, err := Decode(&data, FormatASE)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	want := []string{"#808080", "#FF0000", "#FFFFFF"}
+	got := p.Hex()
+	if len(got) != len(want) {
+		t.Fatalf("colors = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("color %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDecodePNG_SwatchGrid(t *testing.T) {
+	// A 4x2 image of two 2x2 swatches decodes to the distinct colors in order
+	red := color.NRGBA{R: 255, A: 255}
+	green := color.NRGBA{G: 255, A: 255}
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			if x < 2 {
+				img.SetNRGBA(x, y, red)
+			} else {
+				img.SetNRGBA(x, y, green)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+
+	p, err := Decode(&buf, FormatPNG)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got := p.Hex(); len(got) != 2 || got[0] != "#FF0000" || got[1] != "#00FF00" {
+		t.Errorf("colors = %v", got)
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		data string
+		want Format
+	}{
+		{"gpl extension", "colors.GPL", "", FormatGPL},
+		{"pal extension", "colors.pal", "", FormatPAL},
+		{"hex extension", "colors.hex", "", FormatHEX},
+		{"act extension", "colors.act", "", FormatACT},
+		{"ase extension", "colors.ase", "", FormatASE},
+		{"png extension", "colors.png", "", FormatPNG},
+		{"gpl content", "colors.txt", "GIMP Palette\n0 0 0\n", FormatGPL},
+		{"jasc content", "", "JASC-PAL\n0100\n0\n", FormatPAL},
+		{"hex content", "colors.txt", "ff004d\n29adff\n", FormatHEX},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectFormat(tt.file, []byte(tt.data))
+			if err != nil {
+				t.Fatalf("DetectFormat() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectFormat() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := DetectFormat("notes.txt", []byte("hello world")); err == nil {
+		t.Error("expected error for unrecognized content")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat(".GPL"); err != nil || f != FormatGPL {
+		t.Errorf("ParseFormat(.GPL) = %s, %v", f, err)
+	}
+	if _, err := ParseFormat("bmp"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestParseHex(t *testing.T) {
+	c, err := ParseHex("#11223344")
+	if err != nil {
+		t.Fatalf("ParseHex() error = %v", err)
+	}
+	if c.R != 0x11 || c.G != 0x22 || c.B != 0x33 || c.A != 0x44 {
+		t.Errorf("ParseHex() = %+v", c)
+	}
+	for _, s := range []string{"", "#12345", "#GGGGGG"} {
+		if _, err := ParseHex(s); err == nil {
+			t.Errorf("ParseHex(%q) expected error", s)
+		}
+	}
+}
+
+func TestReadWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "retro.gpl")
+
+	p := testPalette()
+	p.Name = ""
+	if err := WriteFile(path, p, ""); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	decoded, format, err := ReadFile(path, "")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if format != FormatGPL {
+		t.Errorf("format = %s, want gpl", format)
+	}
+	if !colorsEqual(decoded.Colors, p.Colors) {
+		t.Errorf("colors = %v, want %v", decoded.Hex(), p.Hex())
+	}
+
+	// GPL writes "Untitled" for unnamed palettes; other formats fall back to the file name
+	hexPath := filepath.Join(dir, "retro.hex")
+	if err := WriteFile(hexPath, p, ""); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	decoded, _, err = ReadFile(hexPath, "")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if decoded.Name != "retro" {
+		t.Errorf("name = %q, want %q", decoded.Name, "retro")
+	}
+
+	if err := WriteFile(filepath.Join(dir, "retro.bin"), p, ""); err == nil {
+		t.Error("expected error for unknown extension")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "retro.bin")); !os.IsNotExist(err) {
+		t.Error("no file should be written for an unknown extension")
+	}
+}
+
+func TestLibrary(t *testing.T) {
+	library, err := Library()
+	if err != nil {
+		t.Fatalf("Library() error = %v", err)
+	}
+	if len(library) < 10 {
+		t.Fatalf("Library() returned %d palettes, want at least 10", len(library))
+	}
+
+	for i, lp := range library {
+		if lp.Name == "" || lp.Description == "" {
+			t.Errorf("palette %s is missing a name or description", lp.ID)
+		}
+		if len(lp.Colors) < 2 {
+			t.Errorf("palette %s has %d colors", lp.ID, len(lp.Colors))
+		}
+		if i > 0 && library[i-1].ID >= lp.ID {
+			t.Errorf("library not sorted: %s before %s", library[i-1].ID, lp.ID)
+		}
+	}
+}
+
+func TestNamed(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		size int
+	}{
+		{"PICO-8", "pico-8", 16},
+		{"pico8", "pico-8", 16},
+		{"Endesga 32", "endesga-32", 32},
+		{"edg32", "endesga-32", 32},
+		{"DB32", "db32", 32},
+		{"Game Boy DMG", "gameboy-dmg", 4},
+		{"NES", "nes", 55},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lp, err := Named(tt.name)
+			if err != nil {
+				t.Fatalf("Named() error = %v", err)
+			}
+			if lp.ID != tt.id {
+				t.Errorf("ID = %s, want %s", lp.ID, tt.id)
+			}
+			if len(lp.Colors) != tt.size {
+				t.Errorf("size = %d, want %d", len(lp.Colors), tt.size)
+			}
+		})
+	}
+
+	pico, _ := Named("pico-8")
+	if pico.Colors[8].Hex() != "#FF004D" {
+		t.Errorf("PICO-8 color 8 = %s, want #FF004D", pico.Colors[8].Hex())
+	}
+
+	// Modifying a returned palette must not affect the library
+	pico.Colors[0] = Color{R: 1, A: 255}
+	again, _ := Named("pico-8")
+	if again.Colors[0].Hex() != "#000000" {
+		t.Error("Named() returned a palette sharing storage with the library")
+	}
+
+	if _, err := Named("not-a-palette"); err == nil {
+		t.Error("expected error for unknown palette")
+	}
+}
@@ -0,0 +1,263 @@
+package palette
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	gplHeader  = "GIMP Palette"
+	jascHeader = "JASC-PAL"
+)
+
+// decodeGPL reads a GIMP palette.
+//
+// Color lines hold "R G B [name]", or "R G B A [name]" after a
+// "Channels: RGBA" header.
+func decodeGPL(r io.Reader) (*Palette, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() || strings.TrimSpace(scanner.Text()) != gplHeader {
+		return nil, fmt.Errorf("missing %q header", gplHeader)
+	}
+
+	p := &Palette{}
+	channels := 3
+	line := 1
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case text == "" || strings.HasPrefix(text, "#"):
+			continue
+		case strings.HasPrefix(text, "Name:"):
+			p.Name = strings.TrimSpace(strings.TrimPrefix(text, "Name:"))
+			continue
+		case strings.HasPrefix(text, "Columns:"):
+			continue
+		case strings.HasPrefix(text, "Channels:"):
+			if strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(text, "Channels:")), "RGBA") {
+				channels = 4
+			}
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) < channels {
+			return nil, fmt.Errorf("line %d: expected %d color components", line, channels)
+		}
+
+		values, err := parseComponents(fields[:channels])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		c := Color{R: values[0], G: values[1], B: values[2], A: 255}
+		if channels == 4 {
+			c.A = values[3]
+		}
+		c.Name = strings.Join(fields[channels:], " ")
+		p.Colors = append(p.Colors, c)
+	}
+
+	return p, scanner.Err()
+}
+
+// encodeGPL writes a GIMP palette, adding an alpha column only when needed.
+func encodeGPL(w io.Writer, p *Palette) error {
+	name := p.Name
+	if name == "" {
+		name = "Untitled"
+	}
+
+	hasAlpha := false
+	for _, c := range p.Colors {
+		if c.A != 255 {
+			hasAlpha = true
+			break
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s\nName: %s\nColumns: 16\n", gplHeader, name)
+	if hasAlpha {
+		fmt.Fprintf(bw, "Channels: RGBA\n")
+	}
+	fmt.Fprintf(bw, "#\n")
+
+	for _, c := range p.Colors {
+		label := c.Name
+		if label == "" {
+			label = Color{R: c.R, G: c.G, B: c.B, A: 255}.Hex()
+		}
+		if hasAlpha {
+			fmt.Fprintf(bw, "%3d %3d %3d %3d\t%s\n", c.R, c.G, c.B, c.A, label)
+		} else {
+			fmt.Fprintf(bw, "%3d %3d %3d\t%s\n", c.R, c.G, c.B, label)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// decodePAL reads a JASC-PAL text palette or a Microsoft RIFF palette.
+func decodePAL(r io.Reader) (*Palette, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "PAL " {
+		return decodeRIFF(data)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	var lines []string
+	for scanner.Scan() {
+		if text := strings.TrimSpace(scanner.Text()); text != "" {
+			lines = append(lines, text)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(lines) < 3 || lines[0] != jascHeader {
+		return nil, fmt.Errorf("missing %q header", jascHeader)
+	}
+	count, err := strconv.Atoi(lines[2])
+	if err != nil || count < 0 {
+		return nil, fmt.Errorf("invalid color count: %q", lines[2])
+	}
+	if len(lines)-3 < count {
+		return nil, fmt.Errorf("expected %d colors, found %d", count, len(lines)-3)
+	}
+
+	p := &Palette{Colors: make([]Color, count)}
+	for i := 0; i < count; i++ {
+		fields := strings.Fields(lines[3+i])
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("color %d: expected 3 color components", i)
+		}
+		values, err := parseComponents(fields[:3])
+		if err != nil {
+			return nil, fmt.Errorf("color %d: %w", i, err)
+		}
+		p.Colors[i] = Color{R: values[0], G: values[1], B: values[2], A: 255}
+	}
+
+	return p, nil
+}
+
+// decodeRIFF reads the "data" chunk of a Microsoft RIFF palette.
+func decodeRIFF(data []byte) (*Palette, error) {
+	pos := 12
+	for pos+8 <= len(data) {
+		id := string(data[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+		pos += 8
+		if pos+size > len(data) {
+			return nil, fmt.Errorf("truncated %q chunk", id)
+		}
+
+		if id == "data" {
+			chunk := data[pos : pos+size]
+			if len(chunk) < 4 {
+				return nil, fmt.Errorf("truncated palette data")
+			}
+			count := int(binary.LittleEndian.Uint16(chunk[2:4]))
+			if len(chunk) < 4+count*4 {
+				return nil, fmt.Errorf("expected %d colors in palette data", count)
+			}
+
+			p := &Palette{Colors: make([]Color, count)}
+			for i := range p.Colors {
+				e := chunk[4+i*4:]
+				p.Colors[i] = Color{R: e[0], G: e[1], B: e[2], A: 255}
+			}
+			return p, nil
+		}
+
+		// Chunks are padded to an even size
+		pos += size + size%2
+	}
+
+	return nil, fmt.Errorf("missing \"data\" chunk")
+}
+
+// encodePAL writes a JASC-PAL palette with CRLF line endings.
+func encodePAL(w io.Writer, p *Palette) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s\r\n0100\r\n%d\r\n", jascHeader, len(p.Colors))
+	for _, c := range p.Colors {
+		fmt.Fprintf(bw, "%d %d %d\r\n", c.R, c.G, c.B)
+	}
+	return bw.Flush()
+}
+
+// decodeHex reads one hex color per line. Blank lines and lines starting with
+// ";" are ignored.
+func decodeHex(r io.Reader) (*Palette, error) {
+	scanner := bufio.NewScanner(r)
+	p := &Palette{}
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, ";") {
+			continue
+		}
+
+		c, err := ParseHex(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		p.Colors = append(p.Colors, c)
+	}
+
+	return p, scanner.Err()
+}
+
+// encodeHex writes a Lospec hex list: lowercase RRGGBB, one color per line.
+func encodeHex(w io.Writer, p *Palette) error {
+	bw := bufio.NewWriter(w)
+	for _, c := range p.Colors {
+		fmt.Fprintf(bw, "%02x%02x%02x\n", c.R, c.G, c.B)
+	}
+	return bw.Flush()
+}
+
+// looksLikeHex reports whether every non-blank line of data is a hex color.
+func looksLikeHex(data []byte) bool {
+	found := false
+	for _, line := range strings.Split(string(data), "\n") {
+		text := strings.TrimSpace(line)
+		if text == "" || strings.HasPrefix(text, ";") {
+			continue
+		}
+		if _, err := ParseHex(text); err != nil {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// parseComponents parses decimal 0-255 color components.
+func parseComponents(fields []string) ([]uint8, error) {
+	values := make([]uint8, len(fields))
+	for i, f := range fields {
+		v, err := strconv.Atoi(f)
+		if err != nil || v < 0 || v > 255 {
+			return nil, fmt.Errorf("invalid color component: %q", f)
+		}
+		values[i] = uint8(v)
+	}
+	return values, nil
+}
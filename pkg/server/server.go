@@ -120,8 +120,16 @@ func (s *Server) Run(ctx context.Context) error {
 //   - Transform tools (image downsampling)
 //   - Analysis tools (palette extraction, edge detection)
 //   - Dithering tools (gradient and texture patterns)
+//   - Text tools (bitmap font rendering)
 //   - Palette tools (color management and harmonies)
+//   - Palette file tools (GPL, PAL, HEX, ACT, ASE, PNG import/export)
+//   - Palette library tools (bundled classic palettes)
+//   - Recolor tools (color remapping and palette variants)
+//   - Accessibility tools (color vision simulation, contrast audit)
 //   - Antialiasing tools (edge smoothing suggestions)
+//   - Lighting tools (normal maps, lit previews)
+//   - Effect tools (drop shadow, rim light, inner glow)
+//   - Lint tools (pixel art critique)
 //
 // This method is not intended for external use.
 func (s *Server) registerTools() {
@@ -154,9 +162,24 @@ func (s *Server) registerTools() {
 	// Register dithering tools
 	tools.RegisterDitheringTools(s.mcp, s.client, s.gen, s.config, s.logger)
 
+	// Register text tools
+	tools.RegisterTextTools(s.mcp, s.client, s.gen, s.config, s.logger)
+
 	// Register palette tools
 	tools.RegisterPaletteTools(s.mcp, s.client, s.gen, s.config, s.logger)
 
+	// Register palette file tools
+	tools.RegisterPaletteFileTools(s.mcp, s.client, s.gen, s.config, s.logger)
+
+	// Register palette library tools
+	tools.RegisterPaletteLibraryTools(s.mcp, s.client, s.gen, s.config, s.logger)
+
+	// Register recolor tools
+	tools.RegisterRecolorTools(s.mcp, s.client, s.gen, s.config, s.logger)
+
+	// Register accessibility tools
+	tools.RegisterAccessibilityTools(s.mcp, s.client, s.gen, s.config, s.logger)
+
 	// Register quantization tools
 	tools.RegisterQuantizationTools(s.mcp, s.client, s.gen, s.config, s.logger)
 
@@ -165,6 +188,15 @@ func (s *Server) registerTools() {
 
 	// Register antialiasing tools
 	tools.RegisterAntialiasingTools(s.mcp, s.client, s.gen, s.config, s.logger)
+
+	// Register lighting tools
+	tools.RegisterLightingTools(s.mcp, s.client, s.gen, s.config, s.logger)
+
+	// Register layer effect tools
+	tools.RegisterEffectTools(s.mcp, s.client, s.gen, s.config, s.logger)
+
+	// Register lint tools
+	tools.RegisterLintTools(s.mcp, s.client, s.gen, s.config, s.logger)
 }
 
 // Client returns the underlying Aseprite client for testing.
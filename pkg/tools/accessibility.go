@@ -0,0 +1,242 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/willibrandon/mtlog/core"
+	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
+	"github.com/willibrandon/pixel-mcp/pkg/config"
+)
+
+// SimulateColorVisionInput defines the input parameters for the simulate_color_vision tool.
+type SimulateColorVisionInput struct {
+	SpritePath  string   `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	FrameNumber int      `json:"frame_number" jsonschema:"Frame to render (1-based, all visible layers)"`
+	Deficiency  string   `json:"deficiency" jsonschema:"Color vision deficiency: protanopia, deuteranopia, tritanopia, or achromatopsia"`
+	Severity    *float64 `json:"severity,omitempty" jsonschema:"Severity from 0.0 (normal vision) to 1.0 (full deficiency) (default: 1.0)"`
+	OutputPath  string   `json:"output_path,omitempty" jsonschema:"PNG file to write (default: <sprite>_<deficiency>.png next to the sprite)"`
+}
+
+// SimulateColorVisionOutput defines the output for the simulate_color_vision tool.
+type SimulateColorVisionOutput struct {
+	OutputPath string `json:"output_path" jsonschema:"PNG file the simulation was written to"`
+	Deficiency string `json:"deficiency" jsonschema:"Simulated deficiency"`
+	Width      int    `json:"width" jsonschema:"Image width in pixels"`
+	Height     int    `json:"height" jsonschema:"Image height in pixels"`
+}
+
+// PaletteAccessibilityReportInput defines the input parameters for the palette_accessibility_report tool.
+type PaletteAccessibilityReportInput struct {
+	SpritePath   string   `json:"sprite_path,omitempty" jsonschema:"Sprite whose palette is checked (required unless colors or palette_name is given)"`
+	Colors       []string `json:"colors,omitempty" jsonschema:"Colors to check instead of the sprite palette (#RRGGBB)"`
+	PaletteName  string   `json:"palette_name,omitempty" jsonschema:"Bundled palette to check instead of the sprite palette (see list_palettes)"`
+	Deficiencies []string `json:"deficiencies,omitempty" jsonschema:"Deficiencies to check: protanopia, deuteranopia, tritanopia, achromatopsia (default: all)"`
+	Threshold    *float64 `json:"threshold,omitempty" jsonschema:"CIEDE2000 ΔE below which two colors count as indistinguishable (default: 10)"`
+	MaxPairs     int      `json:"max_pairs,omitempty" jsonschema:"Maximum pairs listed per section; counts stay complete (default: 50)"`
+}
+
+// PaletteAccessibilityReportOutput defines the output for the palette_accessibility_report tool.
+type PaletteAccessibilityReportOutput struct {
+	ColorCount    int                         `json:"color_count" jsonschema:"Number of palette colors checked"`
+	Threshold     float64                     `json:"threshold" jsonschema:"CIEDE2000 ΔE threshold used"`
+	Deficiencies  []aseprite.DeficiencyReport `json:"deficiencies" jsonschema:"Confusable pairs and simulated palette for each deficiency"`
+	Contrast      []aseprite.ContrastPair     `json:"contrast" jsonschema:"WCAG contrast ratio of color pairs, lowest (failing) first"`
+	ContrastLevel map[string]int              `json:"contrast_level" jsonschema:"Number of color pairs at each WCAG level (AAA, AA, AA_large, fail)"`
+}
+
+// RegisterAccessibilityTools registers the color vision and accessibility tools with the MCP server.
+func RegisterAccessibilityTools(server *mcp.Server, client *aseprite.Client, gen *aseprite.LuaGenerator, cfg *config.Config, logger core.Logger) {
+	// Register simulate_color_vision tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "simulate_color_vision",
+			Description: "Render a frame (all visible layers) as seen with a color vision deficiency and write it to a PNG: protanopia (no red cones), deuteranopia (no green cones), tritanopia (no blue cones), or achromatopsia (no color vision). Uses the Machado et al. model with adjustable severity. Use it to check that characters, pickups, and UI stay readable for color-blind players.",
+		},
+		maybeWrapWithTiming("simulate_color_vision", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input SimulateColorVisionInput) (*mcp.CallToolResult, *SimulateColorVisionOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("simulate_color_vision tool called",
+				"sprite", input.SpritePath,
+				"frame", input.FrameNumber,
+				"deficiency", input.Deficiency)
+
+			// Set defaults
+			if input.Severity == nil {
+				defaultSeverity := 1.0
+				input.Severity = &defaultSeverity
+			}
+			if input.OutputPath == "" {
+				ext := filepath.Ext(input.SpritePath)
+				input.OutputPath = strings.TrimSuffix(input.SpritePath, ext) + "_" + input.Deficiency + ".png"
+			}
+
+			// Validate inputs
+			if input.FrameNumber < 1 {
+				return nil, nil, fmt.Errorf("frame_number must be >= 1, got %d", input.FrameNumber)
+			}
+			if !slices.Contains(aseprite.AllColorDeficiencies, input.Deficiency) {
+				return nil, nil, fmt.Errorf("invalid deficiency: %s (must be protanopia, deuteranopia, tritanopia, or achromatopsia)", input.Deficiency)
+			}
+			if *input.Severity < 0 || *input.Severity > 1 {
+				return nil, nil, fmt.Errorf("severity must be between 0.0 and 1.0, got %f", *input.Severity)
+			}
+
+			// Check sprite file exists
+			if _, err := os.Stat(input.SpritePath); os.IsNotExist(err) {
+				return nil, nil, fmt.Errorf("sprite file not found: %s", input.SpritePath)
+			}
+
+			tempDir, err := os.MkdirTemp("", "pixel-mcp-vision-*")
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create temp directory: %w", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			// Flatten the frame to a PNG
+			framePNG := filepath.Join(tempDir, "frame.png")
+			if _, err := client.ExecuteLua(ctx, gen.ExportSprite(framePNG, input.FrameNumber), input.SpritePath); err != nil {
+				opLogger.Error("Failed to export frame", "error", err)
+				return nil, nil, fmt.Errorf("failed to export frame: %w", err)
+			}
+
+			img, err := loadPNG(framePNG)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			simulated, err := aseprite.SimulateColorVisionImage(img, input.Deficiency, *input.Severity)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if err := savePNG(input.OutputPath, simulated); err != nil {
+				return nil, nil, err
+			}
+
+			bounds := simulated.Bounds()
+			opLogger.Information("Color vision simulated successfully",
+				"sprite", input.SpritePath,
+				"deficiency", input.Deficiency,
+				"output_path", input.OutputPath)
+
+			return nil, &SimulateColorVisionOutput{
+				OutputPath: input.OutputPath,
+				Deficiency: input.Deficiency,
+				Width:      bounds.Dx(),
+				Height:     bounds.Dy(),
+			}, nil
+		}),
+	)
+
+	// Register palette_accessibility_report tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "palette_accessibility_report",
+			Description: "Audit a palette for accessibility. For each color vision deficiency (protanopia, deuteranopia, tritanopia, achromatopsia), lists the color pairs that become indistinguishable (CIEDE2000 ΔE below a threshold), closest first, with their normal-vision ΔE and the palette as seen with the deficiency. Also reports WCAG contrast ratios and levels (AAA, AA, AA_large, fail) for every color pair, lowest contrast first so failing pairs are listed before max_pairs cuts the list. Checks the sprite palette, an explicit color list, or a bundled palette.",
+		},
+		maybeWrapWithTiming("palette_accessibility_report", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input PaletteAccessibilityReportInput) (*mcp.CallToolResult, *PaletteAccessibilityReportOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("palette_accessibility_report tool called",
+				"sprite", input.SpritePath,
+				"colors", len(input.Colors),
+				"palette_name", input.PaletteName,
+				"deficiencies", input.Deficiencies)
+
+			// Set defaults
+			if input.Threshold == nil {
+				defaultThreshold := 10.0
+				input.Threshold = &defaultThreshold
+			}
+			if input.MaxPairs == 0 {
+				input.MaxPairs = 50
+			}
+
+			// Validate inputs
+			sources := 0
+			for _, set := range []bool{input.SpritePath != "", len(input.Colors) > 0, input.PaletteName != ""} {
+				if set {
+					sources++
+				}
+			}
+			if sources != 1 {
+				return nil, nil, fmt.Errorf("exactly one of sprite_path, colors, or palette_name is required")
+			}
+			for _, d := range input.Deficiencies {
+				if !slices.Contains(aseprite.AllColorDeficiencies, d) {
+					return nil, nil, fmt.Errorf("invalid deficiency: %s (must be protanopia, deuteranopia, tritanopia, or achromatopsia)", d)
+				}
+			}
+			if *input.Threshold <= 0 {
+				return nil, nil, fmt.Errorf("threshold must be positive, got %f", *input.Threshold)
+			}
+			if input.MaxPairs < 1 {
+				return nil, nil, fmt.Errorf("max_pairs must be >= 1, got %d", input.MaxPairs)
+			}
+
+			colors := input.Colors
+			switch {
+			case input.PaletteName != "":
+				named, err := namedPaletteColors(input.PaletteName)
+				if err != nil {
+					return nil, nil, err
+				}
+				colors = named
+
+			case input.SpritePath != "":
+				if _, err := os.Stat(input.SpritePath); os.IsNotExist(err) {
+					return nil, nil, fmt.Errorf("sprite file not found: %s", input.SpritePath)
+				}
+				pal, err := loadSpritePalette(ctx, client, gen, input.SpritePath)
+				if err != nil {
+					return nil, nil, err
+				}
+				colors = make([]string, len(pal))
+				for i, c := range pal {
+					colors[i] = c.Color
+				}
+
+			default:
+				for i, c := range colors {
+					if !isValidHexColor(c) {
+						return nil, nil, fmt.Errorf("invalid color at index %d: %s (expected #RRGGBB format)", i, c)
+					}
+				}
+			}
+
+			report, err := aseprite.AnalyzePaletteAccessibility(colors, aseprite.AccessibilityOptions{
+				Deficiencies: input.Deficiencies,
+				Threshold:    *input.Threshold,
+				MaxPairs:     input.MaxPairs,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("accessibility analysis failed: %w", err)
+			}
+
+			result := &PaletteAccessibilityReportOutput{
+				ColorCount:    len(colors),
+				Threshold:     report.Threshold,
+				Deficiencies:  report.Deficiencies,
+				Contrast:      report.Contrast,
+				ContrastLevel: report.ContrastLevel,
+			}
+
+			confusable := 0
+			for _, d := range report.Deficiencies {
+				confusable += d.ConfusableCount
+			}
+			opLogger.Information("Palette accessibility report generated",
+				"colors", result.ColorCount,
+				"confusable_pairs", confusable,
+				"failing_contrast_pairs", report.ContrastLevel[aseprite.WCAGLevelFail])
+
+			return nil, result, nil
+		}),
+	)
+}
@@ -2,7 +2,10 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -23,13 +26,69 @@ type SetFrameDurationOutput struct {
 	Success bool `json:"success" jsonschema:"Whether the frame duration was set successfully"`
 }
 
+// SetFrameDurationsInput defines the input parameters for the set_frame_durations tool.
+type SetFrameDurationsInput struct {
+	SpritePath  string    `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	FromFrame   int       `json:"from_frame,omitempty" jsonschema:"First frame of the range (1-based); alternative to tag"`
+	ToFrame     int       `json:"to_frame,omitempty" jsonschema:"Last frame of the range (1-based, inclusive; default: from_frame plus the length of durations_ms, or from_frame)"`
+	Tag         string    `json:"tag,omitempty" jsonschema:"Name of a tag whose frames to set; alternative to from_frame/to_frame"`
+	DurationsMs []int     `json:"durations_ms,omitempty" jsonschema:"One duration in milliseconds per frame of the range (1-65535 each)"`
+	DurationMs  int       `json:"duration_ms,omitempty" jsonschema:"Constant duration in milliseconds for every frame of the range (1-65535)"`
+	TotalMs     int       `json:"total_ms,omitempty" jsonschema:"Total time in milliseconds to distribute over the range, evenly or along the easing curve"`
+	Easing      string    `json:"easing,omitempty" jsonschema:"Curve distributing total_ms: linear, ease_in (first frames longest), ease_out (last frames longest), ease_in_out, or cubic_bezier (default: linear)"`
+	Bezier      []float64 `json:"bezier,omitempty" jsonschema:"Control points [x1, y1, x2, y2] for cubic_bezier easing, as in CSS cubic-bezier(); all values 0-1"`
+}
+
+// SetFrameDurationsOutput defines the output for the set_frame_durations tool.
+type SetFrameDurationsOutput struct {
+	FromFrame   int     `json:"from_frame" jsonschema:"First modified frame"`
+	ToFrame     int     `json:"to_frame" jsonschema:"Last modified frame"`
+	DurationsMs []int   `json:"durations_ms" jsonschema:"Duration applied to each frame in milliseconds"`
+	TotalMs     int     `json:"total_ms" jsonschema:"Total duration of the range in milliseconds"`
+	FPS         float64 `json:"fps" jsonschema:"Average frames per second over the range"`
+}
+
+// GetAnimationTimingInput defines the input parameters for the get_animation_timing tool.
+type GetAnimationTimingInput struct {
+	SpritePath string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+}
+
+// FrameTiming describes the timing of one frame.
+type FrameTiming struct {
+	Frame      int     `json:"frame" jsonschema:"Frame number (1-based)"`
+	DurationMs int     `json:"duration_ms" jsonschema:"Frame duration in milliseconds"`
+	FPS        float64 `json:"fps" jsonschema:"Frame rate equivalent of the duration"`
+}
+
+// TagTiming describes the timing of one tag.
+type TagTiming struct {
+	Name       string  `json:"name" jsonschema:"Tag name"`
+	FromFrame  int     `json:"from_frame" jsonschema:"First frame of the tag"`
+	ToFrame    int     `json:"to_frame" jsonschema:"Last frame of the tag"`
+	FrameCount int     `json:"frame_count" jsonschema:"Number of frames in the tag"`
+	Direction  string  `json:"direction" jsonschema:"Playback direction"`
+	Repeat     int     `json:"repeat" jsonschema:"Number of times the tag plays (0 = loop forever)"`
+	DurationMs int     `json:"duration_ms" jsonschema:"Sum of the tag's frame durations in milliseconds"`
+	LoopMs     int     `json:"loop_ms" jsonschema:"Length of one playback loop in milliseconds; ping-pong loops play the inner frames twice"`
+	FPS        float64 `json:"fps" jsonschema:"Average frames per second of the tag"`
+}
+
+// GetAnimationTimingOutput defines the output for the get_animation_timing tool.
+type GetAnimationTimingOutput struct {
+	FrameCount int           `json:"frame_count" jsonschema:"Number of frames in the sprite"`
+	TotalMs    int           `json:"total_ms" jsonschema:"Total loop length of the whole animation in milliseconds"`
+	FPS        float64       `json:"fps" jsonschema:"Average frames per second of the whole animation"`
+	Frames     []FrameTiming `json:"frames" jsonschema:"Timing of every frame"`
+	Tags       []TagTiming   `json:"tags" jsonschema:"Timing of every tag"`
+}
+
 // CreateTagInput defines the input parameters for the create_tag tool.
 type CreateTagInput struct {
 	SpritePath string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
 	TagName    string `json:"tag_name" jsonschema:"Name for the animation tag"`
 	FromFrame  int    `json:"from_frame" jsonschema:"Starting frame number (1-based, inclusive)"`
 	ToFrame    int    `json:"to_frame" jsonschema:"Ending frame number (1-based, inclusive)"`
-	Direction  string `json:"direction" jsonschema:"Playback direction: forward, reverse, or pingpong"`
+	Direction  string `json:"direction" jsonschema:"Playback direction: forward, reverse, pingpong, or pingpong_reverse"`
 }
 
 // CreateTagOutput defines the output for the create_tag tool.
@@ -68,11 +127,186 @@ type DeleteTagOutput struct {
 	Success bool `json:"success" jsonschema:"Deletion success status"`
 }
 
+// TagInfo describes an animation tag.
+type TagInfo struct {
+	Name       string `json:"name" jsonschema:"Tag name"`
+	FromFrame  int    `json:"from_frame" jsonschema:"First frame of the tag (1-based, inclusive)"`
+	ToFrame    int    `json:"to_frame" jsonschema:"Last frame of the tag (1-based, inclusive)"`
+	FrameCount int    `json:"frame_count" jsonschema:"Number of frames in the tag"`
+	Direction  string `json:"direction" jsonschema:"Playback direction: forward, reverse, pingpong, or pingpong_reverse"`
+	Repeat     int    `json:"repeat" jsonschema:"Number of times the tag plays (0 = loop forever)"`
+	Color      string `json:"color" jsonschema:"Tag color in the timeline (#RRGGBB)"`
+	DurationMs int    `json:"duration_ms" jsonschema:"Total duration of the tag's frames in milliseconds"`
+}
+
+// ListTagsInput defines the input parameters for the list_tags tool.
+type ListTagsInput struct {
+	SpritePath string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+}
+
+// ListTagsOutput defines the output for the list_tags tool.
+type ListTagsOutput struct {
+	Tags []TagInfo `json:"tags" jsonschema:"Animation tags in timeline order"`
+}
+
+// UpdateTagInput defines the input parameters for the update_tag tool.
+type UpdateTagInput struct {
+	SpritePath string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	TagName    string `json:"tag_name" jsonschema:"Name of the tag to update"`
+	NewName    string `json:"new_name,omitempty" jsonschema:"New name for the tag"`
+	FromFrame  *int   `json:"from_frame,omitempty" jsonschema:"New first frame (1-based, inclusive)"`
+	ToFrame    *int   `json:"to_frame,omitempty" jsonschema:"New last frame (1-based, inclusive)"`
+	Direction  string `json:"direction,omitempty" jsonschema:"New playback direction: forward, reverse, pingpong, or pingpong_reverse"`
+	Repeat     *int   `json:"repeat,omitempty" jsonschema:"Number of times the tag plays (0 = loop forever, max 65535)"`
+	Color      string `json:"color,omitempty" jsonschema:"Tag color in the timeline (#RRGGBB)"`
+}
+
+// UpdateTagOutput defines the output for the update_tag tool.
+type UpdateTagOutput struct {
+	Tag TagInfo `json:"tag" jsonschema:"The tag after the update"`
+}
+
 // LinkCelOutput defines the output for the link_cel tool.
 type LinkCelOutput struct {
 	Success bool `json:"success" jsonschema:"Whether the cel was linked successfully"`
 }
 
+// TagRange describes an animation tag and the frames it spans.
+type TagRange struct {
+	Name      string `json:"name" jsonschema:"Tag name"`
+	FromFrame int    `json:"from_frame" jsonschema:"First frame of the tag (1-based, inclusive)"`
+	ToFrame   int    `json:"to_frame" jsonschema:"Last frame of the tag (1-based, inclusive)"`
+}
+
+// MoveFramesInput defines the input parameters for the move_frames tool.
+type MoveFramesInput struct {
+	SpritePath  string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	FromFrame   int    `json:"from_frame" jsonschema:"First frame of the range to move (1-based, inclusive)"`
+	ToFrame     int    `json:"to_frame" jsonschema:"Last frame of the range to move (1-based, inclusive)"`
+	TargetFrame int    `json:"target_frame" jsonschema:"Frame number the first moved frame ends up at (1-based)"`
+}
+
+// MoveFramesOutput defines the output for the move_frames tool.
+type MoveFramesOutput struct {
+	FrameCount int        `json:"frame_count" jsonschema:"Number of frames in the sprite"`
+	FromFrame  int        `json:"from_frame" jsonschema:"First frame of the moved range at its new position"`
+	ToFrame    int        `json:"to_frame" jsonschema:"Last frame of the moved range at its new position"`
+	Tags       []TagRange `json:"tags" jsonschema:"All tags with their frame ranges after the move"`
+}
+
+// ReverseFramesInput defines the input parameters for the reverse_frames tool.
+type ReverseFramesInput struct {
+	SpritePath string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	FromFrame  int    `json:"from_frame" jsonschema:"First frame of the range to reverse (1-based, inclusive)"`
+	ToFrame    int    `json:"to_frame" jsonschema:"Last frame of the range to reverse (1-based, inclusive)"`
+}
+
+// ReverseFramesOutput defines the output for the reverse_frames tool.
+type ReverseFramesOutput struct {
+	FrameCount int        `json:"frame_count" jsonschema:"Number of frames in the sprite"`
+	Tags       []TagRange `json:"tags" jsonschema:"All tags with their frame ranges after the reversal"`
+}
+
+// DeleteFramesInput defines the input parameters for the delete_frames tool.
+type DeleteFramesInput struct {
+	SpritePath string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	FromFrame  int    `json:"from_frame" jsonschema:"First frame to delete (1-based, inclusive)"`
+	ToFrame    int    `json:"to_frame" jsonschema:"Last frame to delete (1-based, inclusive)"`
+}
+
+// DeleteFramesOutput defines the output for the delete_frames tool.
+type DeleteFramesOutput struct {
+	FrameCount    int        `json:"frame_count" jsonschema:"Number of frames left in the sprite"`
+	FramesDeleted int        `json:"frames_deleted" jsonschema:"Number of frames deleted"`
+	TagsDeleted   int        `json:"tags_deleted" jsonschema:"Number of tags deleted because all their frames were removed"`
+	Tags          []TagRange `json:"tags" jsonschema:"Remaining tags with their frame ranges"`
+}
+
+// InsertEmptyFramesInput defines the input parameters for the insert_empty_frames tool.
+type InsertEmptyFramesInput struct {
+	SpritePath string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	AtFrame    int    `json:"at_frame" jsonschema:"Frame number the first inserted frame becomes (1-based; frame count + 1 appends)"`
+	Count      int    `json:"count,omitempty" jsonschema:"Number of empty frames to insert (1-256, default: 1)"`
+	DurationMs int    `json:"duration_ms,omitempty" jsonschema:"Duration of each new frame in milliseconds (1-65535, default: 100)"`
+}
+
+// InsertEmptyFramesOutput defines the output for the insert_empty_frames tool.
+type InsertEmptyFramesOutput struct {
+	FrameCount int        `json:"frame_count" jsonschema:"Number of frames in the sprite"`
+	FromFrame  int        `json:"from_frame" jsonschema:"First inserted frame"`
+	ToFrame    int        `json:"to_frame" jsonschema:"Last inserted frame"`
+	Tags       []TagRange `json:"tags" jsonschema:"All tags with their frame ranges after the insertion"`
+}
+
+// TweenCelsInput defines the input parameters for the tween_cels tool.
+type TweenCelsInput struct {
+	SpritePath string    `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName  string    `json:"layer_name" jsonschema:"Name of the layer to tween"`
+	FromFrame  int       `json:"from_frame" jsonschema:"Frame of the first keyframe (1-based)"`
+	ToFrame    int       `json:"to_frame" jsonschema:"Frame of the second keyframe; must be from_frame + 1"`
+	InBetweens int       `json:"in_betweens" jsonschema:"Number of in-between frames to generate (1-64)"`
+	Easing     string    `json:"easing,omitempty" jsonschema:"Easing curve: linear, ease_in, ease_out, ease_in_out, or cubic_bezier (default: linear)"`
+	Bezier     []float64 `json:"bezier,omitempty" jsonschema:"Control points [x1, y1, x2, y2] for cubic_bezier easing, as in CSS cubic-bezier(); x values 0-1"`
+	Rotation   float64   `json:"rotation,omitempty" jsonschema:"Rotation in degrees clockwise reached at the second keyframe; in-betweens rotate the first keyframe's image with RotSprite (default: 0)"`
+	Scale      *float64  `json:"scale,omitempty" jsonschema:"Scale reached at the second keyframe (0.1-8); in-betweens scale the first keyframe's image with RotSprite (default: 1)"`
+	DurationMs *int      `json:"duration_ms,omitempty" jsonschema:"Duration of each in-between in milliseconds (1-65535); by default the first keyframe's duration is split evenly between it and the in-betweens"`
+}
+
+// TweenCelsOutput defines the output for the tween_cels tool.
+type TweenCelsOutput struct {
+	FrameCount         int        `json:"frame_count" jsonschema:"Number of frames in the sprite"`
+	FromFrame          int        `json:"from_frame" jsonschema:"First inserted in-between frame"`
+	ToFrame            int        `json:"to_frame" jsonschema:"Last inserted in-between frame"`
+	KeyframeDurationMs int        `json:"keyframe_duration_ms" jsonschema:"Duration of the first keyframe after the tween"`
+	DurationsMs        []int      `json:"durations_ms" jsonschema:"Duration of each in-between frame in milliseconds"`
+	Tags               []TagRange `json:"tags" jsonschema:"All tags with their frame ranges after the insertion"`
+}
+
+// RenderOnionSkinInput defines the input parameters for the render_onion_skin tool.
+type RenderOnionSkinInput struct {
+	SpritePath   string   `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	FrameNumber  int      `json:"frame_number" jsonschema:"Frame to preview (1-based)"`
+	Before       *int     `json:"before,omitempty" jsonschema:"Number of previous frames to show (0-8, default: 1)"`
+	After        *int     `json:"after,omitempty" jsonschema:"Number of next frames to show (0-8, default: 1)"`
+	BeforeTint   string   `json:"before_tint,omitempty" jsonschema:"Tint for previous frames in hex format #RRGGBB (default: #FF0000)"`
+	AfterTint    string   `json:"after_tint,omitempty" jsonschema:"Tint for next frames in hex format #RRGGBB (default: #0000FF)"`
+	TintStrength *float64 `json:"tint_strength,omitempty" jsonschema:"How far neighbor colors move towards their tint 0.0-1.0 (default: 0.5)"`
+	Opacity      *float64 `json:"opacity,omitempty" jsonschema:"Opacity of the nearest neighbor frames 0.0-1.0 (default: 0.5)"`
+	Falloff      *float64 `json:"falloff,omitempty" jsonschema:"Opacity multiplier for each further frame 0.0-1.0 (default: 0.5)"`
+	Loop         bool     `json:"loop,omitempty" jsonschema:"Wrap around the animation so the first frame shows the last frames as previous (default: false)"`
+	Scale        int      `json:"scale,omitempty" jsonschema:"Integer upscale factor for the preview 1-16; the scaled preview may be at most 4096 pixels wide and tall (default: 1)"`
+	OutputPath   string   `json:"output_path,omitempty" jsonschema:"If set, write the preview to this PNG file instead of returning it as image content"`
+}
+
+// RenderOnionSkinOutput defines the output for the render_onion_skin tool.
+type RenderOnionSkinOutput struct {
+	FrameNumber    int    `json:"frame_number" jsonschema:"Previewed frame"`
+	PreviousFrames []int  `json:"previous_frames" jsonschema:"Previous frames shown, nearest first"`
+	NextFrames     []int  `json:"next_frames" jsonschema:"Next frames shown, nearest first"`
+	Width          int    `json:"width" jsonschema:"Width of the preview in pixels"`
+	Height         int    `json:"height" jsonschema:"Height of the preview in pixels"`
+	OutputPath     string `json:"output_path,omitempty" jsonschema:"PNG file the preview was written to"`
+}
+
+// CreatePaletteCycleInput defines the input parameters for the create_palette_cycle tool.
+type CreatePaletteCycleInput struct {
+	SpritePath string `json:"sprite_path" jsonschema:"Path to the indexed Aseprite sprite file"`
+	StartIndex int    `json:"start_index" jsonschema:"First palette index of the cycling range (0-based, inclusive)"`
+	EndIndex   int    `json:"end_index" jsonschema:"Last palette index of the cycling range (0-based, inclusive)"`
+	Direction  string `json:"direction,omitempty" jsonschema:"Cycle direction: forward (colors move toward higher indices) or backward (default: forward)"`
+	Steps      *int   `json:"steps,omitempty" jsonschema:"Number of frames in the cycle (default: range length, one seamless loop)"`
+	DurationMs *int   `json:"duration_ms,omitempty" jsonschema:"Duration of each cycle frame in milliseconds (default: 100)"`
+	TagName    string `json:"tag_name,omitempty" jsonschema:"Name of the animation tag created for the cycle (default: palette_cycle)"`
+}
+
+// CreatePaletteCycleOutput defines the output for the create_palette_cycle tool.
+type CreatePaletteCycleOutput struct {
+	FromFrame     int    `json:"from_frame" jsonschema:"First frame of the cycle (1-based)"`
+	ToFrame       int    `json:"to_frame" jsonschema:"Last frame of the cycle (1-based)"`
+	FramesCreated int    `json:"frames_created" jsonschema:"Number of frames added to the sprite"`
+	TagName       string `json:"tag_name" jsonschema:"Name of the created tag"`
+}
+
 // RegisterAnimationTools registers all animation tools with the MCP server.
 func RegisterAnimationTools(server *mcp.Server, client *aseprite.Client, gen *aseprite.LuaGenerator, cfg *config.Config, logger core.Logger) {
 	// Register set_frame_duration tool
@@ -141,13 +375,8 @@ func RegisterAnimationTools(server *mcp.Server, client *aseprite.Client, gen *as
 			}
 
 			// Validate direction
-			validDirections := map[string]bool{
-				"forward":  true,
-				"reverse":  true,
-				"pingpong": true,
-			}
-			if !validDirections[input.Direction] {
-				return nil, nil, fmt.Errorf("invalid direction: %s (valid: forward, reverse, pingpong)", input.Direction)
+			if !validTagDirections[input.Direction] {
+				return nil, nil, fmt.Errorf("invalid direction: %s (valid: forward, reverse, pingpong, pingpong_reverse)", input.Direction)
 			}
 
 			// Generate Lua script
@@ -303,4 +532,862 @@ func RegisterAnimationTools(server *mcp.Server, client *aseprite.Client, gen *as
 			return nil, &DeleteTagOutput{Success: true}, nil
 		}),
 	)
+
+	// Register list_tags tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "list_tags",
+			Description: "List the animation tags of a sprite with their frame ranges, playback directions, repeat counts, colors, and total durations in milliseconds.",
+		},
+		maybeWrapWithTiming("list_tags", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input ListTagsInput) (*mcp.CallToolResult, *ListTagsOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("list_tags tool called", "sprite_path", input.SpritePath)
+
+			// Generate Lua script
+			script := gen.ListTags()
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to list tags", "error", err)
+				return nil, nil, fmt.Errorf("failed to list tags: %w", err)
+			}
+
+			var result ListTagsOutput
+			if err := parseJSON(output, &result); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse tag list: %w", err)
+			}
+
+			opLogger.Debug("Tags listed successfully", "sprite", input.SpritePath, "tag_count", len(result.Tags))
+
+			return nil, &result, nil
+		}),
+	)
+
+	// Register update_tag tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "update_tag",
+			Description: "Change an existing animation tag: rename it, change its frame range (either end can be given alone), playback direction (forward, reverse, pingpong, pingpong_reverse), repeat count (0 = loop forever), or timeline color. Only the given properties change. Returns the updated tag.",
+		},
+		maybeWrapWithTiming("update_tag", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input UpdateTagInput) (*mcp.CallToolResult, *UpdateTagOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("update_tag tool called", "sprite_path", input.SpritePath, "tag_name", input.TagName)
+
+			// Validate inputs
+			if input.TagName == "" {
+				return nil, nil, fmt.Errorf("tag_name cannot be empty")
+			}
+
+			if input.NewName == "" && input.FromFrame == nil && input.ToFrame == nil && input.Direction == "" && input.Repeat == nil && input.Color == "" {
+				return nil, nil, fmt.Errorf("at least one of new_name, from_frame, to_frame, direction, repeat, or color must be provided")
+			}
+
+			update := aseprite.TagUpdate{
+				NewName:   input.NewName,
+				Direction: input.Direction,
+				Repeat:    input.Repeat,
+			}
+
+			if input.FromFrame != nil {
+				if *input.FromFrame < 1 {
+					return nil, nil, fmt.Errorf("from_frame must be at least 1, got %d", *input.FromFrame)
+				}
+				update.FromFrame = *input.FromFrame
+			}
+
+			if input.ToFrame != nil {
+				if *input.ToFrame < 1 {
+					return nil, nil, fmt.Errorf("to_frame must be at least 1, got %d", *input.ToFrame)
+				}
+				update.ToFrame = *input.ToFrame
+			}
+
+			if input.FromFrame != nil && input.ToFrame != nil && *input.ToFrame < *input.FromFrame {
+				return nil, nil, fmt.Errorf("to_frame must be >= from_frame, got from=%d to=%d", *input.FromFrame, *input.ToFrame)
+			}
+
+			if input.Direction != "" && !validTagDirections[input.Direction] {
+				return nil, nil, fmt.Errorf("invalid direction: %s (valid: forward, reverse, pingpong, pingpong_reverse)", input.Direction)
+			}
+
+			if input.Repeat != nil && (*input.Repeat < 0 || *input.Repeat > 65535) {
+				return nil, nil, fmt.Errorf("repeat must be between 0 and 65535, got %d", *input.Repeat)
+			}
+
+			if input.Color != "" {
+				var color aseprite.Color
+				if err := color.FromHex(input.Color); err != nil {
+					return nil, nil, fmt.Errorf("invalid color: %w", err)
+				}
+				update.Color = &color
+			}
+
+			// Generate Lua script
+			script := gen.UpdateTag(input.TagName, update)
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to update tag", "error", err)
+				return nil, nil, fmt.Errorf("failed to update tag: %w", err)
+			}
+
+			// Check for success message
+			if !strings.Contains(output, "Tag updated successfully") {
+				opLogger.Warning("Unexpected output from update_tag", "output", output)
+			}
+
+			var result UpdateTagOutput
+			if err := parseJSON(output, &result.Tag); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse updated tag: %w", err)
+			}
+
+			opLogger.Information("Tag updated successfully", "sprite", input.SpritePath, "tag_name", input.TagName, "name", result.Tag.Name, "from_frame", result.Tag.FromFrame, "to_frame", result.Tag.ToFrame)
+
+			return nil, &result, nil
+		}),
+	)
+
+	// Register create_palette_cycle tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "create_palette_cycle",
+			Description: "Create a classic color-cycling animation (water, lava, waterfalls) in an indexed sprite. Starting from the last frame, appends frames that copy its pixels and gives each frame its own palette: the palette of the last frame with the colors in an index range rotated by one more step. Sets every cycle frame to the given duration and tags the cycle. With the default step count the cycle loops seamlessly.",
+		},
+		maybeWrapWithTiming("create_palette_cycle", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input CreatePaletteCycleInput) (*mcp.CallToolResult, *CreatePaletteCycleOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("create_palette_cycle tool called", "sprite_path", input.SpritePath, "start_index", input.StartIndex, "end_index", input.EndIndex, "direction", input.Direction)
+
+			// Set defaults
+			if input.Direction == "" {
+				input.Direction = "forward"
+			}
+			if input.Steps == nil {
+				defaultSteps := input.EndIndex - input.StartIndex + 1
+				input.Steps = &defaultSteps
+			}
+			if input.DurationMs == nil {
+				defaultDuration := 100
+				input.DurationMs = &defaultDuration
+			}
+			if input.TagName == "" {
+				input.TagName = "palette_cycle"
+			}
+
+			// Validate inputs
+			if input.StartIndex < 0 || input.EndIndex > 255 || input.StartIndex >= input.EndIndex {
+				return nil, nil, fmt.Errorf("cycle range must satisfy 0 <= start_index < end_index <= 255, got %d-%d", input.StartIndex, input.EndIndex)
+			}
+
+			var shift int
+			switch input.Direction {
+			case "forward":
+				shift = 1
+			case "backward":
+				shift = -1
+			default:
+				return nil, nil, fmt.Errorf("invalid direction: %s (must be forward or backward)", input.Direction)
+			}
+
+			if *input.Steps < 2 || *input.Steps > 256 {
+				return nil, nil, fmt.Errorf("steps must be between 2 and 256, got %d", *input.Steps)
+			}
+
+			if *input.DurationMs < 1 || *input.DurationMs > 65535 {
+				return nil, nil, fmt.Errorf("duration_ms must be between 1 and 65535, got %d", *input.DurationMs)
+			}
+
+			if _, err := os.Stat(input.SpritePath); err != nil {
+				return nil, nil, fmt.Errorf("sprite file not found: %w", err)
+			}
+
+			info, err := getSpriteInfoHelper(ctx, client, gen, input.SpritePath)
+			if err != nil {
+				return nil, nil, err
+			}
+			if info.ColorMode != "indexed" {
+				return nil, nil, fmt.Errorf("palette cycling requires an indexed sprite (sprite is %s)", info.ColorMode)
+			}
+
+			// The cycle starts from the palette in effect at the last frame
+			output, err := client.ExecuteLua(ctx, gen.GetFramePalette(info.FrameCount), input.SpritePath)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get palette: %w", err)
+			}
+			var pal GetPaletteOutput
+			if err := parseJSON(output, &pal); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse palette: %w", err)
+			}
+			if input.EndIndex >= len(pal.Colors) {
+				return nil, nil, fmt.Errorf("end_index %d is outside the palette (%d colors)", input.EndIndex, len(pal.Colors))
+			}
+
+			// The last frame is the first step of the cycle; each added frame
+			// copies it and rotates the palette one step further
+			fromFrame := info.FrameCount
+			if _, err := client.ExecuteLua(ctx, gen.SetFrameDuration(fromFrame, *input.DurationMs), input.SpritePath); err != nil {
+				opLogger.Error("Failed to set frame duration", "error", err)
+				return nil, nil, fmt.Errorf("failed to set frame duration: %w", err)
+			}
+
+			for step := 1; step < *input.Steps; step++ {
+				colors, err := aseprite.CyclePaletteRange(pal.Colors, input.StartIndex, input.EndIndex, shift*step)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if _, err := client.ExecuteLua(ctx, gen.AddFrame(*input.DurationMs), input.SpritePath); err != nil {
+					opLogger.Error("Failed to add frame", "error", err)
+					return nil, nil, fmt.Errorf("failed to add frame: %w", err)
+				}
+
+				if _, err := client.ExecuteLua(ctx, gen.SetFramePalette(fromFrame+step, colors), input.SpritePath); err != nil {
+					opLogger.Error("Failed to set frame palette", "error", err)
+					return nil, nil, fmt.Errorf("failed to set frame palette: %w", err)
+				}
+			}
+
+			toFrame := fromFrame + *input.Steps - 1
+			if _, err := client.ExecuteLua(ctx, gen.CreateTag(input.TagName, fromFrame, toFrame, "forward"), input.SpritePath); err != nil {
+				opLogger.Error("Failed to create tag", "error", err)
+				return nil, nil, fmt.Errorf("failed to create tag: %w", err)
+			}
+
+			opLogger.Information("Palette cycle created successfully", "sprite", input.SpritePath, "from_frame", fromFrame, "to_frame", toFrame, "tag", input.TagName)
+
+			return nil, &CreatePaletteCycleOutput{
+				FromFrame:     fromFrame,
+				ToFrame:       toFrame,
+				FramesCreated: *input.Steps - 1,
+				TagName:       input.TagName,
+			}, nil
+		}),
+	)
+
+	// Register move_frames tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "move_frames",
+			Description: "Move a range of frames to a new position in the timeline, with their cels and durations. Tags entirely inside the range move with it and tags still covering contiguous frames keep them; other tags shrink when frames leave them and grow when frames land inside them. Returns the new position and all tag ranges.",
+		},
+		maybeWrapWithTiming("move_frames", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input MoveFramesInput) (*mcp.CallToolResult, *MoveFramesOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("move_frames tool called", "sprite_path", input.SpritePath, "from_frame", input.FromFrame, "to_frame", input.ToFrame, "target_frame", input.TargetFrame)
+
+			// Validate inputs
+			if err := validateFrameRange(input.FromFrame, input.ToFrame); err != nil {
+				return nil, nil, err
+			}
+
+			if input.TargetFrame < 1 {
+				return nil, nil, fmt.Errorf("target_frame must be at least 1, got %d", input.TargetFrame)
+			}
+
+			// Generate Lua script
+			script := gen.MoveFrames(input.FromFrame, input.ToFrame, input.TargetFrame)
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to move frames", "error", err)
+				return nil, nil, fmt.Errorf("failed to move frames: %w", err)
+			}
+
+			// Check for success message
+			if !strings.Contains(output, "Frames moved successfully") {
+				opLogger.Warning("Unexpected output from move_frames", "output", output)
+			}
+
+			var result MoveFramesOutput
+			if err := parseJSON(output, &result); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse move result: %w", err)
+			}
+
+			opLogger.Information("Frames moved successfully", "sprite", input.SpritePath, "from_frame", input.FromFrame, "to_frame", input.ToFrame, "target_frame", input.TargetFrame)
+
+			return nil, &result, nil
+		}),
+	)
+
+	// Register reverse_frames tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "reverse_frames",
+			Description: "Reverse the order of a range of frames, including their cels and durations. Tags entirely inside the range are mirrored to keep covering the same frames. Returns all tag ranges.",
+		},
+		maybeWrapWithTiming("reverse_frames", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input ReverseFramesInput) (*mcp.CallToolResult, *ReverseFramesOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("reverse_frames tool called", "sprite_path", input.SpritePath, "from_frame", input.FromFrame, "to_frame", input.ToFrame)
+
+			// Validate inputs
+			if err := validateFrameRange(input.FromFrame, input.ToFrame); err != nil {
+				return nil, nil, err
+			}
+
+			// Generate Lua script
+			script := gen.ReverseFrames(input.FromFrame, input.ToFrame)
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to reverse frames", "error", err)
+				return nil, nil, fmt.Errorf("failed to reverse frames: %w", err)
+			}
+
+			// Check for success message
+			if !strings.Contains(output, "Frames reversed successfully") {
+				opLogger.Warning("Unexpected output from reverse_frames", "output", output)
+			}
+
+			var result ReverseFramesOutput
+			if err := parseJSON(output, &result); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse reverse result: %w", err)
+			}
+
+			opLogger.Information("Frames reversed successfully", "sprite", input.SpritePath, "from_frame", input.FromFrame, "to_frame", input.ToFrame)
+
+			return nil, &result, nil
+		}),
+	)
+
+	// Register delete_frames tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "delete_frames",
+			Description: "Delete a range of frames with their cels. Tags entirely inside the range are deleted; other tags shrink to their remaining frames and later tags shift back. At least one frame must remain. Returns the remaining frame count and tag ranges.",
+		},
+		maybeWrapWithTiming("delete_frames", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input DeleteFramesInput) (*mcp.CallToolResult, *DeleteFramesOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("delete_frames tool called", "sprite_path", input.SpritePath, "from_frame", input.FromFrame, "to_frame", input.ToFrame)
+
+			// Validate inputs
+			if err := validateFrameRange(input.FromFrame, input.ToFrame); err != nil {
+				return nil, nil, err
+			}
+
+			// Generate Lua script
+			script := gen.DeleteFrames(input.FromFrame, input.ToFrame)
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to delete frames", "error", err)
+				return nil, nil, fmt.Errorf("failed to delete frames: %w", err)
+			}
+
+			// Check for success message
+			if !strings.Contains(output, "Frames deleted successfully") {
+				opLogger.Warning("Unexpected output from delete_frames", "output", output)
+			}
+
+			var result DeleteFramesOutput
+			if err := parseJSON(output, &result); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse delete result: %w", err)
+			}
+
+			opLogger.Information("Frames deleted successfully", "sprite", input.SpritePath, "from_frame", input.FromFrame, "to_frame", input.ToFrame, "tags_deleted", result.TagsDeleted)
+
+			return nil, &result, nil
+		}),
+	)
+
+	// Register insert_empty_frames tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "insert_empty_frames",
+			Description: "Insert one or more empty frames so the first becomes frame at_frame (use frame count + 1 to append). Later frames and tags shift back; tags spanning the insertion point grow to include the new frames. Returns the inserted range and all tag ranges.",
+		},
+		maybeWrapWithTiming("insert_empty_frames", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input InsertEmptyFramesInput) (*mcp.CallToolResult, *InsertEmptyFramesOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("insert_empty_frames tool called", "sprite_path", input.SpritePath, "at_frame", input.AtFrame, "count", input.Count, "duration_ms", input.DurationMs)
+
+			// Set defaults
+			if input.Count == 0 {
+				input.Count = 1
+			}
+			if input.DurationMs == 0 {
+				input.DurationMs = 100
+			}
+
+			// Validate inputs
+			if input.AtFrame < 1 {
+				return nil, nil, fmt.Errorf("at_frame must be at least 1, got %d", input.AtFrame)
+			}
+
+			if input.Count < 1 || input.Count > 256 {
+				return nil, nil, fmt.Errorf("count must be between 1 and 256, got %d", input.Count)
+			}
+
+			if input.DurationMs < 1 || input.DurationMs > 65535 {
+				return nil, nil, fmt.Errorf("duration_ms must be between 1 and 65535, got %d", input.DurationMs)
+			}
+
+			// Generate Lua script
+			script := gen.InsertEmptyFrames(input.AtFrame, input.Count, input.DurationMs)
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to insert empty frames", "error", err)
+				return nil, nil, fmt.Errorf("failed to insert empty frames: %w", err)
+			}
+
+			// Check for success message
+			if !strings.Contains(output, "Empty frames inserted successfully") {
+				opLogger.Warning("Unexpected output from insert_empty_frames", "output", output)
+			}
+
+			var result InsertEmptyFramesOutput
+			if err := parseJSON(output, &result); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse insert result: %w", err)
+			}
+
+			opLogger.Information("Empty frames inserted successfully", "sprite", input.SpritePath, "at_frame", input.AtFrame, "count", input.Count)
+
+			return nil, &result, nil
+		}),
+	)
+
+	// Register tween_cels tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "tween_cels",
+			Description: "Generate in-between frames for a layer between two adjacent keyframes. The cel position (by center) and opacity are interpolated from the first keyframe to the second, and the first keyframe's image can be rotated and scaled toward the given rotation and scale with RotSprite. Easing curves: linear, ease_in, ease_out, ease_in_out, or a custom cubic_bezier. The in-betweens are inserted after the first keyframe; other layers hold their first-keyframe cels, tags spanning the keyframes grow, and by default the first keyframe's duration is split between it and the in-betweens.",
+		},
+		maybeWrapWithTiming("tween_cels", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input TweenCelsInput) (*mcp.CallToolResult, *TweenCelsOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("tween_cels tool called", "sprite_path", input.SpritePath, "layer_name", input.LayerName, "from_frame", input.FromFrame, "to_frame", input.ToFrame, "in_betweens", input.InBetweens, "easing", input.Easing)
+
+			// Set defaults
+			if input.Easing == "" {
+				input.Easing = "linear"
+			}
+			scale := 1.0
+			if input.Scale != nil {
+				scale = *input.Scale
+			}
+
+			// Validate inputs
+			if input.LayerName == "" {
+				return nil, nil, fmt.Errorf("layer_name cannot be empty")
+			}
+
+			if input.FromFrame < 1 {
+				return nil, nil, fmt.Errorf("from_frame must be at least 1, got %d", input.FromFrame)
+			}
+
+			if input.ToFrame != input.FromFrame+1 {
+				return nil, nil, fmt.Errorf("to_frame must be from_frame + 1 (keyframes must be adjacent), got from=%d to=%d", input.FromFrame, input.ToFrame)
+			}
+
+			if input.InBetweens < 1 || input.InBetweens > 64 {
+				return nil, nil, fmt.Errorf("in_betweens must be between 1 and 64, got %d", input.InBetweens)
+			}
+
+			easing, err := parseEasing(input.Easing, input.Bezier)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if scale < 0.1 || scale > 8 {
+				return nil, nil, fmt.Errorf("scale must be between 0.1 and 8, got %g", scale)
+			}
+
+			if input.DurationMs != nil && (*input.DurationMs < 1 || *input.DurationMs > 65535) {
+				return nil, nil, fmt.Errorf("duration_ms must be between 1 and 65535, got %d", *input.DurationMs)
+			}
+
+			tempDir, err := os.MkdirTemp("", "pixel-mcp-tween-*")
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create temp directory: %w", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			// Read both keyframes and the first keyframe's image
+			keyPNG := filepath.Join(tempDir, "keyframe.png")
+			output, err := client.ExecuteLua(ctx, gen.TweenKeyframes(input.LayerName, input.FromFrame, input.ToFrame, keyPNG), input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to read keyframes", "error", err)
+				return nil, nil, fmt.Errorf("failed to read keyframes: %w", err)
+			}
+
+			var keys tweenKeyframes
+			if err := parseJSON(output, &keys); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse keyframes: %w", err)
+			}
+
+			if keys.From.Width > maxTweenCelSize || keys.From.Height > maxTweenCelSize {
+				return nil, nil, fmt.Errorf("keyframe cel is %dx%d; tween_cels supports cels up to %dx%d", keys.From.Width, keys.From.Height, maxTweenCelSize, maxTweenCelSize)
+			}
+
+			src, err := loadPNG(keyPNG)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// Compute durations
+			keyDurationMs := 0
+			durations := make([]int, input.InBetweens)
+			if input.DurationMs != nil {
+				for i := range durations {
+					durations[i] = *input.DurationMs
+				}
+			} else {
+				keyDurationMs, durations = splitTweenDuration(keys.From.DurationMs, input.InBetweens)
+			}
+
+			// Render the in-betweens
+			frames, err := planTweenFrames(src, keys, input.InBetweens, easing, input.Rotation, scale, durations, tempDir)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// Generate Lua script
+			script := gen.InsertTweenFrames(input.LayerName, input.FromFrame, keyDurationMs, frames)
+
+			// Execute Lua script with the sprite
+			output, err = client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to insert tween frames", "error", err)
+				return nil, nil, fmt.Errorf("failed to insert tween frames: %w", err)
+			}
+
+			// Check for success message
+			if !strings.Contains(output, "Tween frames inserted successfully") {
+				opLogger.Warning("Unexpected output from tween_cels", "output", output)
+			}
+
+			var result TweenCelsOutput
+			if err := parseJSON(output, &result); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse tween result: %w", err)
+			}
+
+			result.KeyframeDurationMs = keys.From.DurationMs
+			if keyDurationMs > 0 {
+				result.KeyframeDurationMs = keyDurationMs
+			}
+			result.DurationsMs = durations
+
+			opLogger.Information("Cels tweened successfully", "sprite", input.SpritePath, "layer_name", input.LayerName, "from_frame", result.FromFrame, "to_frame", result.ToFrame)
+
+			return nil, &result, nil
+		}),
+	)
+
+	// Register set_frame_durations tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "set_frame_durations",
+			Description: "Set the durations of a range of frames, given by from_frame/to_frame or by a tag. Provide exactly one of: durations_ms (one value per frame), duration_ms (same value for every frame), or total_ms (a total time split evenly, or along an easing curve: ease_in holds the first frames longest, ease_out the last ones). Returns the applied durations, total, and average FPS.",
+		},
+		maybeWrapWithTiming("set_frame_durations", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input SetFrameDurationsInput) (*mcp.CallToolResult, *SetFrameDurationsOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("set_frame_durations tool called", "sprite_path", input.SpritePath, "from_frame", input.FromFrame, "to_frame", input.ToFrame, "tag", input.Tag)
+
+			// Validate inputs
+			if (input.Tag == "") == (input.FromFrame == 0) {
+				return nil, nil, fmt.Errorf("provide either tag or from_frame")
+			}
+
+			if input.Tag != "" && input.ToFrame != 0 {
+				return nil, nil, fmt.Errorf("to_frame cannot be combined with tag")
+			}
+
+			modes := 0
+			for _, set := range []bool{len(input.DurationsMs) > 0, input.DurationMs != 0, input.TotalMs != 0} {
+				if set {
+					modes++
+				}
+			}
+			if modes != 1 {
+				return nil, nil, fmt.Errorf("provide exactly one of durations_ms, duration_ms, or total_ms")
+			}
+
+			if (input.Easing != "" || len(input.Bezier) > 0) && input.TotalMs == 0 {
+				return nil, nil, fmt.Errorf("easing and bezier can only be used with total_ms")
+			}
+
+			// Resolve the frame range
+			fromFrame, toFrame := input.FromFrame, input.ToFrame
+			if input.Tag != "" {
+				tag, err := findTag(ctx, client, gen, input.SpritePath, input.Tag)
+				if err != nil {
+					return nil, nil, err
+				}
+				fromFrame, toFrame = tag.FromFrame, tag.ToFrame
+			} else if toFrame == 0 {
+				toFrame = fromFrame + max(len(input.DurationsMs), 1) - 1
+			}
+
+			if err := validateFrameRange(fromFrame, toFrame); err != nil {
+				return nil, nil, err
+			}
+
+			durations, err := frameDurations(input, toFrame-fromFrame+1)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// Generate Lua script
+			script := gen.SetFrameDurations(fromFrame, durations)
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to set frame durations", "error", err)
+				return nil, nil, fmt.Errorf("failed to set frame durations: %w", err)
+			}
+
+			// Check for success message
+			if !strings.Contains(output, "Frame durations set successfully") {
+				opLogger.Warning("Unexpected output from set_frame_durations", "output", output)
+			}
+
+			var result SetFrameDurationsOutput
+			if err := parseJSON(output, &result); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse frame durations result: %w", err)
+			}
+			result.DurationsMs = durations
+			result.FPS = framesPerSecond(len(durations), result.TotalMs)
+
+			opLogger.Information("Frame durations set successfully", "sprite", input.SpritePath, "from_frame", result.FromFrame, "to_frame", result.ToFrame, "total_ms", result.TotalMs)
+
+			return nil, &result, nil
+		}),
+	)
+
+	// Register get_animation_timing tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "get_animation_timing",
+			Description: "Report animation timing: the duration and FPS equivalent of every frame, the duration, loop length (ping-pong loops play inner frames twice), repeat count, and average FPS of every tag, and the total loop length and average FPS of the whole animation.",
+		},
+		maybeWrapWithTiming("get_animation_timing", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input GetAnimationTimingInput) (*mcp.CallToolResult, *GetAnimationTimingOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("get_animation_timing tool called", "sprite_path", input.SpritePath)
+
+			// Generate Lua script
+			script := gen.GetAnimationTiming()
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to get animation timing", "error", err)
+				return nil, nil, fmt.Errorf("failed to get animation timing: %w", err)
+			}
+
+			var timing struct {
+				DurationsMs []int     `json:"durations_ms"`
+				Tags        []TagInfo `json:"tags"`
+			}
+			if err := parseJSON(output, &timing); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse animation timing: %w", err)
+			}
+
+			result := animationTiming(timing.DurationsMs, timing.Tags)
+
+			opLogger.Debug("Animation timing retrieved successfully", "sprite", input.SpritePath, "frame_count", result.FrameCount, "total_ms", result.TotalMs)
+
+			return nil, result, nil
+		}),
+	)
+
+	// Register render_onion_skin tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "render_onion_skin",
+			Description: "Render an onion-skin preview of a frame for reviewing animation. Composites the flattened frame over tinted, semi-transparent previous and next frames: the nearest neighbors get the given opacity and each further frame is faded by falloff. Frame counts, tints, tint strength, wrap-around for looping animations, and an integer preview scale are configurable. Returns the preview as PNG image content, or writes it to output_path. The sprite is not modified.",
+		},
+		maybeWrapWithTiming("render_onion_skin", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input RenderOnionSkinInput) (*mcp.CallToolResult, *RenderOnionSkinOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("render_onion_skin tool called", "sprite_path", input.SpritePath, "frame_number", input.FrameNumber, "loop", input.Loop, "output_path", input.OutputPath)
+
+			// Set defaults
+			if input.Before == nil {
+				defaultBefore := 1
+				input.Before = &defaultBefore
+			}
+			if input.After == nil {
+				defaultAfter := 1
+				input.After = &defaultAfter
+			}
+			if input.BeforeTint == "" {
+				input.BeforeTint = "#FF0000"
+			}
+			if input.AfterTint == "" {
+				input.AfterTint = "#0000FF"
+			}
+			if input.TintStrength == nil {
+				defaultStrength := 0.5
+				input.TintStrength = &defaultStrength
+			}
+			if input.Opacity == nil {
+				defaultOpacity := 0.5
+				input.Opacity = &defaultOpacity
+			}
+			if input.Falloff == nil {
+				defaultFalloff := 0.5
+				input.Falloff = &defaultFalloff
+			}
+			if input.Scale == 0 {
+				input.Scale = 1
+			}
+
+			// Validate inputs
+			if input.FrameNumber < 1 {
+				return nil, nil, fmt.Errorf("frame_number must be >= 1, got %d", input.FrameNumber)
+			}
+			if *input.Before < 0 || *input.Before > 8 {
+				return nil, nil, fmt.Errorf("before must be between 0 and 8, got %d", *input.Before)
+			}
+			if *input.After < 0 || *input.After > 8 {
+				return nil, nil, fmt.Errorf("after must be between 0 and 8, got %d", *input.After)
+			}
+
+			var beforeTint, afterTint aseprite.Color
+			if err := beforeTint.FromHex(input.BeforeTint); err != nil {
+				return nil, nil, fmt.Errorf("invalid before_tint: %w", err)
+			}
+			if err := afterTint.FromHex(input.AfterTint); err != nil {
+				return nil, nil, fmt.Errorf("invalid after_tint: %w", err)
+			}
+
+			if *input.TintStrength < 0 || *input.TintStrength > 1 {
+				return nil, nil, fmt.Errorf("tint_strength must be between 0.0 and 1.0, got %f", *input.TintStrength)
+			}
+			if *input.Opacity < 0 || *input.Opacity > 1 {
+				return nil, nil, fmt.Errorf("opacity must be between 0.0 and 1.0, got %f", *input.Opacity)
+			}
+			if *input.Falloff < 0 || *input.Falloff > 1 {
+				return nil, nil, fmt.Errorf("falloff must be between 0.0 and 1.0, got %f", *input.Falloff)
+			}
+			if input.Scale < 1 || input.Scale > 16 {
+				return nil, nil, fmt.Errorf("scale must be between 1 and 16, got %d", input.Scale)
+			}
+
+			if _, err := os.Stat(input.SpritePath); os.IsNotExist(err) {
+				return nil, nil, fmt.Errorf("sprite file not found: %s", input.SpritePath)
+			}
+
+			info, err := getSpriteInfoHelper(ctx, client, gen, input.SpritePath)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get sprite info: %w", err)
+			}
+			if input.FrameNumber > info.FrameCount {
+				return nil, nil, fmt.Errorf("frame_number %d exceeds sprite frame count %d", input.FrameNumber, info.FrameCount)
+			}
+			if err := checkOnionSkinScale(info.Width, info.Height, input.Scale); err != nil {
+				return nil, nil, err
+			}
+
+			previous, next := onionSkinFrames(input.FrameNumber, info.FrameCount, *input.Before, *input.After, input.Loop)
+
+			tempDir, err := os.MkdirTemp("", "pixel-mcp-onion-*")
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create temp directory: %w", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			// Generate Lua script
+			frames := append(append([]int{input.FrameNumber}, previous...), next...)
+			script := gen.ExportFrameImages(frames, tempDir)
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to export frames", "error", err)
+				return nil, nil, fmt.Errorf("failed to export frames: %w", err)
+			}
+
+			// Check for success message
+			if !strings.Contains(output, "Frames exported successfully") {
+				opLogger.Warning("Unexpected output from render_onion_skin", "output", output)
+			}
+
+			preview, err := renderOnionSkin(tempDir, input.FrameNumber, previous, next, beforeTint, afterTint, *input.TintStrength, *input.Opacity, *input.Falloff)
+			if err != nil {
+				return nil, nil, err
+			}
+			preview = scaleNearest(preview, input.Scale)
+
+			result := &RenderOnionSkinOutput{
+				FrameNumber:    input.FrameNumber,
+				PreviousFrames: previous,
+				NextFrames:     next,
+				Width:          preview.Rect.Dx(),
+				Height:         preview.Rect.Dy(),
+			}
+			if result.PreviousFrames == nil {
+				result.PreviousFrames = []int{}
+			}
+			if result.NextFrames == nil {
+				result.NextFrames = []int{}
+			}
+
+			if input.OutputPath != "" {
+				if err := os.MkdirAll(filepath.Dir(input.OutputPath), 0755); err != nil {
+					return nil, nil, fmt.Errorf("failed to create output directory: %w", err)
+				}
+				if err := savePNG(input.OutputPath, preview); err != nil {
+					return nil, nil, err
+				}
+				result.OutputPath = input.OutputPath
+
+				opLogger.Information("Onion skin written successfully", "sprite", input.SpritePath, "frame", input.FrameNumber, "output", input.OutputPath)
+
+				return nil, result, nil
+			}
+
+			data, err := encodePNG(preview)
+			if err != nil {
+				return nil, nil, err
+			}
+			summary, err := json.Marshal(result)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal result: %w", err)
+			}
+
+			opLogger.Information("Onion skin rendered successfully", "sprite", input.SpritePath, "frame", input.FrameNumber, "previous", previous, "next", next)
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: string(summary)},
+					&mcp.ImageContent{Data: data, MIMEType: "image/png"},
+				},
+			}, result, nil
+		}),
+	)
+}
+
+// validTagDirections lists the playback directions accepted for tags.
+var validTagDirections = map[string]bool{
+	"forward":          true,
+	"reverse":          true,
+	"pingpong":         true,
+	"pingpong_reverse": true,
+}
+
+// validateFrameRange checks a 1-based inclusive frame range.
+func validateFrameRange(fromFrame, toFrame int) error {
+	if fromFrame < 1 {
+		return fmt.Errorf("from_frame must be at least 1, got %d", fromFrame)
+	}
+
+	if toFrame < fromFrame {
+		return fmt.Errorf("to_frame must be >= from_frame, got from=%d to=%d", fromFrame, toFrame)
+	}
+
+	return nil
 }
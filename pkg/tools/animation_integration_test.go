@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -607,3 +608,499 @@ func TestIntegration_DeleteTag_NotFound(t *testing.T) {
 
 	t.Logf("✓ Correctly rejected deletion of non-existent tag")
 }
+
+// createTaggedFrameSprite creates a sprite with frameCount frames, each with
+// duration 100ms * frame number, and the given tags.
+func createTaggedFrameSprite(t *testing.T, client *aseprite.Client, gen *aseprite.LuaGenerator, name string, frameCount int, tags map[string][2]int) string {
+	t.Helper()
+	ctx := context.Background()
+
+	spritePath := testutil.TempSpritePath(t, name)
+	if _, err := client.ExecuteLua(ctx, gen.CreateCanvas(16, 16, aseprite.ColorModeRGB, spritePath), ""); err != nil {
+		t.Fatalf("Failed to create canvas: %v", err)
+	}
+	t.Cleanup(func() { os.Remove(spritePath) })
+
+	for i := 1; i < frameCount; i++ {
+		if _, err := client.ExecuteLua(ctx, gen.AddFrame(100), spritePath); err != nil {
+			t.Fatalf("Failed to add frame: %v", err)
+		}
+	}
+	for i := 1; i <= frameCount; i++ {
+		if _, err := client.ExecuteLua(ctx, gen.SetFrameDuration(i, 100*i), spritePath); err != nil {
+			t.Fatalf("Failed to set frame duration: %v", err)
+		}
+	}
+	for tagName, r := range tags {
+		if _, err := client.ExecuteLua(ctx, gen.CreateTag(tagName, r[0], r[1], "forward"), spritePath); err != nil {
+			t.Fatalf("Failed to create tag: %v", err)
+		}
+	}
+
+	return spritePath
+}
+
+// frameDurationsScript prints the duration of every frame in milliseconds.
+const frameDurationsScript = `local spr = app.activeSprite
+local out = {}
+for i, frame in ipairs(spr.frames) do
+	table.insert(out, tostring(math.floor(frame.duration * 1000 + 0.5)))
+end
+print("durations=" .. table.concat(out, ","))`
+
+// tagRangesOf indexes tag ranges by name.
+func tagRangesOf(tags []TagRange) map[string][2]int {
+	ranges := make(map[string][2]int)
+	for _, tag := range tags {
+		ranges[tag.Name] = [2]int{tag.FromFrame, tag.ToFrame}
+	}
+	return ranges
+}
+
+func TestIntegration_FrameRangeOperations(t *testing.T) {
+	cfg := testutil.LoadTestConfig(t)
+	client := aseprite.NewClient(cfg.AsepritePath, cfg.TempDir, 30*time.Second)
+	gen := aseprite.NewLuaGenerator()
+	ctx := context.Background()
+
+	tags := map[string][2]int{"intro": {1, 2}, "loop": {3, 5}, "all": {1, 6}}
+
+	run := func(t *testing.T, spritePath, script, success string, result any) {
+		t.Helper()
+		output, err := client.ExecuteLua(ctx, script, spritePath)
+		if err != nil {
+			t.Fatalf("ExecuteLua error = %v", err)
+		}
+		if !strings.Contains(output, success) {
+			t.Fatalf("Expected %q, got: %s", success, output)
+		}
+		if err := parseJSON(output, result); err != nil {
+			t.Fatalf("Failed to parse output: %v", err)
+		}
+	}
+
+	durations := func(t *testing.T, spritePath string) string {
+		t.Helper()
+		output, err := client.ExecuteLua(ctx, frameDurationsScript, spritePath)
+		if err != nil {
+			t.Fatalf("Failed to read durations: %v", err)
+		}
+		return strings.TrimSpace(output[strings.Index(output, "durations="):])
+	}
+
+	t.Run("move", func(t *testing.T) {
+		spritePath := createTaggedFrameSprite(t, client, gen, "test-move-frames.aseprite", 6, tags)
+
+		var result MoveFramesOutput
+		run(t, spritePath, gen.MoveFrames(1, 2, 5), "Frames moved successfully", &result)
+
+		if result.FromFrame != 5 || result.ToFrame != 6 {
+			t.Errorf("Moved range = %d-%d, want 5-6", result.FromFrame, result.ToFrame)
+		}
+		ranges := tagRangesOf(result.Tags)
+		want := map[string][2]int{"intro": {5, 6}, "loop": {1, 3}, "all": {1, 6}}
+		for name, r := range want {
+			if ranges[name] != r {
+				t.Errorf("Tag %q = %v, want %v", name, ranges[name], r)
+			}
+		}
+		if got := durations(t, spritePath); got != "durations=300,400,500,600,100,200" {
+			t.Errorf("Got %s", got)
+		}
+	})
+
+	t.Run("reverse", func(t *testing.T) {
+		spritePath := createTaggedFrameSprite(t, client, gen, "test-reverse-frames.aseprite", 6, tags)
+
+		var result ReverseFramesOutput
+		run(t, spritePath, gen.ReverseFrames(1, 5), "Frames reversed successfully", &result)
+
+		ranges := tagRangesOf(result.Tags)
+		want := map[string][2]int{"intro": {4, 5}, "loop": {1, 3}, "all": {1, 6}}
+		for name, r := range want {
+			if ranges[name] != r {
+				t.Errorf("Tag %q = %v, want %v", name, ranges[name], r)
+			}
+		}
+		if got := durations(t, spritePath); got != "durations=500,400,300,200,100,600" {
+			t.Errorf("Got %s", got)
+		}
+	})
+
+	t.Run("reverse keeps selection", func(t *testing.T) {
+		spritePath := createTaggedFrameSprite(t, client, gen, "test-reverse-selection.aseprite", 6, tags)
+
+		if _, err := client.ExecuteLua(ctx, gen.SelectEllipse(2, 2, 12, 12, "replace"), spritePath); err != nil {
+			t.Fatalf("ExecuteLua(SelectEllipse) error = %v", err)
+		}
+		want := map[aseprite.Point]bool{
+			{X: 8, Y: 8}: true,  // center
+			{X: 2, Y: 2}: false, // bounding box corner
+		}
+
+		var reversed ReverseFramesOutput
+		run(t, spritePath, gen.ReverseFrames(1, 5), "Frames reversed successfully", &reversed)
+		assertSelected(t, ctx, client, spritePath, want)
+
+		var deleted DeleteFramesOutput
+		run(t, spritePath, gen.DeleteFrames(1, 1), "Frames deleted successfully", &deleted)
+		assertSelected(t, ctx, client, spritePath, want)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		spritePath := createTaggedFrameSprite(t, client, gen, "test-delete-frames.aseprite", 6, tags)
+
+		var result DeleteFramesOutput
+		run(t, spritePath, gen.DeleteFrames(1, 3), "Frames deleted successfully", &result)
+
+		if result.FrameCount != 3 || result.FramesDeleted != 3 || result.TagsDeleted != 1 {
+			t.Errorf("Got frame_count=%d frames_deleted=%d tags_deleted=%d, want 3, 3, 1",
+				result.FrameCount, result.FramesDeleted, result.TagsDeleted)
+		}
+		ranges := tagRangesOf(result.Tags)
+		if _, ok := ranges["intro"]; ok {
+			t.Errorf("Tag intro should have been deleted")
+		}
+		want := map[string][2]int{"loop": {1, 2}, "all": {1, 3}}
+		for name, r := range want {
+			if ranges[name] != r {
+				t.Errorf("Tag %q = %v, want %v", name, ranges[name], r)
+			}
+		}
+		if got := durations(t, spritePath); got != "durations=400,500,600" {
+			t.Errorf("Got %s", got)
+		}
+	})
+
+	t.Run("delete every frame", func(t *testing.T) {
+		spritePath := createTaggedFrameSprite(t, client, gen, "test-delete-all-frames.aseprite", 2, nil)
+
+		_, err := client.ExecuteLua(ctx, gen.DeleteFrames(1, 2), spritePath)
+		if err == nil {
+			t.Fatal("Expected error deleting every frame")
+		}
+	})
+
+	t.Run("insert", func(t *testing.T) {
+		spritePath := createTaggedFrameSprite(t, client, gen, "test-insert-frames.aseprite", 6, tags)
+
+		var result InsertEmptyFramesOutput
+		run(t, spritePath, gen.InsertEmptyFrames(4, 2, 50), "Empty frames inserted successfully", &result)
+
+		if result.FrameCount != 8 || result.FromFrame != 4 || result.ToFrame != 5 {
+			t.Errorf("Got frame_count=%d range=%d-%d, want 8, 4-5", result.FrameCount, result.FromFrame, result.ToFrame)
+		}
+		ranges := tagRangesOf(result.Tags)
+		want := map[string][2]int{"intro": {1, 2}, "loop": {3, 7}, "all": {1, 8}}
+		for name, r := range want {
+			if ranges[name] != r {
+				t.Errorf("Tag %q = %v, want %v", name, ranges[name], r)
+			}
+		}
+		if got := durations(t, spritePath); got != "durations=100,200,300,50,50,400,500,600" {
+			t.Errorf("Got %s", got)
+		}
+	})
+}
+
+func TestIntegration_ListAndUpdateTags(t *testing.T) {
+	cfg := testutil.LoadTestConfig(t)
+	client := aseprite.NewClient(cfg.AsepritePath, cfg.TempDir, 30*time.Second)
+	gen := aseprite.NewLuaGenerator()
+	ctx := context.Background()
+
+	spritePath := createTaggedFrameSprite(t, client, gen, "test-update-tag.aseprite", 3,
+		map[string][2]int{"walk": {1, 2}, "idle": {3, 3}})
+
+	// List the tags as created
+	output, err := client.ExecuteLua(ctx, gen.ListTags(), spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(ListTags) error = %v", err)
+	}
+	var list ListTagsOutput
+	if err := parseJSON(output, &list); err != nil {
+		t.Fatalf("Failed to parse tag list: %v", err)
+	}
+	if len(list.Tags) != 2 {
+		t.Fatalf("Expected 2 tags, got %d", len(list.Tags))
+	}
+	for _, tag := range list.Tags {
+		if tag.Name == "walk" && (tag.DurationMs != 300 || tag.Direction != "forward" || tag.FrameCount != 2) {
+			t.Errorf("Unexpected walk tag: %+v", tag)
+		}
+	}
+
+	// Rename, extend, and restyle walk
+	repeat := 2
+	color := aseprite.NewColorRGB(255, 0, 0)
+	output, err = client.ExecuteLua(ctx, gen.UpdateTag("walk", aseprite.TagUpdate{
+		NewName:   "run",
+		ToFrame:   3,
+		Direction: "pingpong_reverse",
+		Repeat:    &repeat,
+		Color:     &color,
+	}), spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(UpdateTag) error = %v", err)
+	}
+	if !strings.Contains(output, "Tag updated successfully") {
+		t.Errorf("Expected success message, got: %s", output)
+	}
+
+	// The changes are saved and reported by get_sprite_info
+	output, err = client.ExecuteLua(ctx, gen.GetSpriteInfo(), spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(GetSpriteInfo) error = %v", err)
+	}
+	var info GetSpriteInfoOutput
+	if err := parseJSON(output, &info); err != nil {
+		t.Fatalf("Failed to parse sprite info: %v", err)
+	}
+
+	want := TagInfo{Name: "run", FromFrame: 1, ToFrame: 3, FrameCount: 3, Direction: "pingpong_reverse", Repeat: 2, Color: "#FF0000", DurationMs: 600}
+	found := false
+	for _, tag := range info.Tags {
+		if tag.Name == "run" {
+			found = true
+			if tag != want {
+				t.Errorf("Tag = %+v, want %+v", tag, want)
+			}
+		}
+		if tag.Name == "walk" {
+			t.Errorf("Tag walk should have been renamed")
+		}
+	}
+	if !found {
+		t.Errorf("Tag run not found in sprite info: %+v", info.Tags)
+	}
+
+	// Renaming onto an existing tag fails
+	_, err = client.ExecuteLua(ctx, gen.UpdateTag("run", aseprite.TagUpdate{NewName: "idle"}), spritePath)
+	if err == nil {
+		t.Error("Expected error renaming to an existing tag name")
+	}
+
+	// Moving the range past the last frame fails
+	_, err = client.ExecuteLua(ctx, gen.UpdateTag("idle", aseprite.TagUpdate{ToFrame: 4}), spritePath)
+	if err == nil {
+		t.Error("Expected error for range past the last frame")
+	}
+}
+
+func TestIntegration_TweenCels(t *testing.T) {
+	cfg := testutil.LoadTestConfig(t)
+	client := aseprite.NewClient(cfg.AsepritePath, cfg.TempDir, 30*time.Second)
+	gen := aseprite.NewLuaGenerator()
+	ctx := context.Background()
+
+	spritePath := testutil.TempSpritePath(t, "test-tween-cels.aseprite")
+	if _, err := client.ExecuteLua(ctx, gen.CreateCanvas(32, 32, aseprite.ColorModeRGB, spritePath), ""); err != nil {
+		t.Fatalf("Failed to create canvas: %v", err)
+	}
+	defer os.Remove(spritePath)
+
+	// Two keyframes: a red 4x4 square moving from (0,0) to (12,20) while
+	// fading, and a background layer with a cel only on the first keyframe
+	setup := `local spr = app.activeSprite
+local layer = spr.layers[1]
+local back = spr:newLayer()
+back.name = "Back"
+spr:newEmptyFrame(2)
+spr.frames[1].duration = 0.4
+
+local red = Image(4, 4, spr.colorMode)
+red:clear(app.pixelColor.rgba(255, 0, 0, 255))
+spr:newCel(layer, 1, red, Point(0, 0))
+local moved = spr:newCel(layer, 2, red, Point(12, 20))
+moved.opacity = 55
+
+local green = Image(2, 2, spr.colorMode)
+green:clear(app.pixelColor.rgba(0, 255, 0, 255))
+spr:newCel(back, 1, green, Point(30, 30))
+
+spr:newTag(1, 2).name = "all"
+spr:saveAs(spr.filename)
+print("ok")`
+	if _, err := client.ExecuteLua(ctx, setup, spritePath); err != nil {
+		t.Fatalf("Failed to set up keyframes: %v", err)
+	}
+
+	tempDir := t.TempDir()
+	keyPNG := filepath.Join(tempDir, "keyframe.png")
+	output, err := client.ExecuteLua(ctx, gen.TweenKeyframes("Layer 1", 1, 2, keyPNG), spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(TweenKeyframes) error = %v", err)
+	}
+	var keys tweenKeyframes
+	if err := parseJSON(output, &keys); err != nil {
+		t.Fatalf("Failed to parse keyframes: %v", err)
+	}
+	if keys.From.DurationMs != 400 || keys.To.X != 12 || keys.To.Y != 20 || keys.To.Opacity != 55 {
+		t.Fatalf("Unexpected keyframes: %+v", keys)
+	}
+
+	src, err := loadPNG(keyPNG)
+	if err != nil {
+		t.Fatalf("Failed to load keyframe image: %v", err)
+	}
+
+	keyDuration, durations := splitTweenDuration(keys.From.DurationMs, 3)
+	frames, err := planTweenFrames(src, keys, 3, aseprite.EasingPresets["linear"], 0, 1, durations, tempDir)
+	if err != nil {
+		t.Fatalf("planTweenFrames() error = %v", err)
+	}
+
+	output, err = client.ExecuteLua(ctx, gen.InsertTweenFrames("Layer 1", 1, keyDuration, frames), spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(InsertTweenFrames) error = %v", err)
+	}
+	if !strings.Contains(output, "Tween frames inserted successfully") {
+		t.Errorf("Expected success message, got: %s", output)
+	}
+	var result TweenCelsOutput
+	if err := parseJSON(output, &result); err != nil {
+		t.Fatalf("Failed to parse result: %v", err)
+	}
+	if result.FrameCount != 5 || result.FromFrame != 2 || result.ToFrame != 4 {
+		t.Errorf("Got frame_count=%d range=%d-%d, want 5, 2-4", result.FrameCount, result.FromFrame, result.ToFrame)
+	}
+	if ranges := tagRangesOf(result.Tags); ranges["all"] != [2]int{1, 5} {
+		t.Errorf("Tag all = %v, want [1 5]", ranges["all"])
+	}
+
+	// Check the keyframe and in-between cels, the held background, and the
+	// durations
+	probe := `local spr = app.activeSprite
+local out = {}
+for f = 1, 4 do
+	local cel = spr.layers[1]:cel(f)
+	local back = spr.layers[2]:cel(f)
+	table.insert(out, string.format("%d:%d,%d,%d,%d,%s", f, cel.position.x, cel.position.y, cel.opacity,
+		math.floor(spr.frames[f].duration * 1000 + 0.5), back and "back" or "none"))
+end
+print(table.concat(out, ";"))`
+	output, err = client.ExecuteLua(ctx, probe, spritePath)
+	if err != nil {
+		t.Fatalf("Failed to probe cels: %v", err)
+	}
+	want := "1:0,0,255,100,back;2:3,5,205,100,back;3:6,10,155,100,back;4:9,15,105,100,back"
+	if got := strings.TrimSpace(output); got != want {
+		t.Errorf("Got %s, want %s", got, want)
+	}
+}
+
+func TestIntegration_SetFrameDurationsAndTiming(t *testing.T) {
+	cfg := testutil.LoadTestConfig(t)
+	client := aseprite.NewClient(cfg.AsepritePath, cfg.TempDir, 30*time.Second)
+	gen := aseprite.NewLuaGenerator()
+	ctx := context.Background()
+
+	spritePath := createTaggedFrameSprite(t, client, gen, "test-frame-durations.aseprite", 5,
+		map[string][2]int{"swing": {2, 5}})
+	if _, err := client.ExecuteLua(ctx, gen.UpdateTag("swing", aseprite.TagUpdate{Direction: "pingpong"}), spritePath); err != nil {
+		t.Fatalf("Failed to update tag: %v", err)
+	}
+
+	// Distribute 1000 ms over the tag with ease_in
+	durations := aseprite.DistributeDuration(1000, 4, aseprite.EasingPresets["ease_in"])
+	output, err := client.ExecuteLua(ctx, gen.SetFrameDurations(2, durations), spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(SetFrameDurations) error = %v", err)
+	}
+	if !strings.Contains(output, "Frame durations set successfully") {
+		t.Errorf("Expected success message, got: %s", output)
+	}
+	var result SetFrameDurationsOutput
+	if err := parseJSON(output, &result); err != nil {
+		t.Fatalf("Failed to parse result: %v", err)
+	}
+	if result.FromFrame != 2 || result.ToFrame != 5 || result.TotalMs != 1000 {
+		t.Errorf("Got range %d-%d total %d, want 2-5 total 1000", result.FromFrame, result.ToFrame, result.TotalMs)
+	}
+
+	// A range past the last frame fails
+	if _, err := client.ExecuteLua(ctx, gen.SetFrameDurations(4, []int{100, 100, 100}), spritePath); err == nil {
+		t.Error("Expected error for range past the last frame")
+	}
+
+	output, err = client.ExecuteLua(ctx, gen.GetAnimationTiming(), spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(GetAnimationTiming) error = %v", err)
+	}
+	var timing struct {
+		DurationsMs []int     `json:"durations_ms"`
+		Tags        []TagInfo `json:"tags"`
+	}
+	if err := parseJSON(output, &timing); err != nil {
+		t.Fatalf("Failed to parse timing: %v", err)
+	}
+
+	report := animationTiming(timing.DurationsMs, timing.Tags)
+	if report.TotalMs != 1100 {
+		t.Errorf("TotalMs = %d, want 1100", report.TotalMs)
+	}
+	for i, d := range durations {
+		if report.Frames[i+1].DurationMs != d {
+			t.Errorf("Frame %d duration = %d, want %d", i+2, report.Frames[i+1].DurationMs, d)
+		}
+	}
+	if len(report.Tags) != 1 {
+		t.Fatalf("Expected 1 tag, got %d", len(report.Tags))
+	}
+	wantLoop := 1000 + durations[1] + durations[2]
+	if report.Tags[0].DurationMs != 1000 || report.Tags[0].LoopMs != wantLoop {
+		t.Errorf("Tag timing = %+v, want duration 1000 and loop %d", report.Tags[0], wantLoop)
+	}
+}
+
+func TestIntegration_RenderOnionSkin(t *testing.T) {
+	cfg := testutil.LoadTestConfig(t)
+	client := aseprite.NewClient(cfg.AsepritePath, cfg.TempDir, 30*time.Second)
+	gen := aseprite.NewLuaGenerator()
+	ctx := context.Background()
+
+	spritePath := createTaggedFrameSprite(t, client, gen, "test-onion-skin.aseprite", 3, nil)
+
+	// Frame N has a white pixel at (N-1, 0)
+	white := aseprite.Color{R: 255, G: 255, B: 255, A: 255}
+	for f := 1; f <= 3; f++ {
+		pixels := []aseprite.Pixel{{Point: aseprite.Point{X: f - 1, Y: 0}, Color: white}}
+		if _, err := client.ExecuteLua(ctx, gen.DrawPixels("Layer 1", f, pixels, false), spritePath); err != nil {
+			t.Fatalf("Failed to draw frame %d: %v", f, err)
+		}
+	}
+
+	tempDir := t.TempDir()
+	output, err := client.ExecuteLua(ctx, gen.ExportFrameImages([]int{2, 1, 3}, tempDir), spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(ExportFrameImages) error = %v", err)
+	}
+	if !strings.Contains(output, "Frames exported successfully") {
+		t.Errorf("Expected success message, got: %s", output)
+	}
+
+	red := aseprite.Color{R: 255, A: 255}
+	blue := aseprite.Color{B: 255, A: 255}
+	preview, err := renderOnionSkin(tempDir, 2, []int{1}, []int{3}, red, blue, 1, 0.5, 0.5)
+	if err != nil {
+		t.Fatalf("renderOnionSkin() error = %v", err)
+	}
+
+	if preview.Rect.Dx() != 16 || preview.Rect.Dy() != 16 {
+		t.Errorf("Preview size = %v, want 16x16", preview.Rect)
+	}
+	if got := preview.NRGBAAt(0, 0); got.R != 255 || got.B != 0 || got.A == 0 || got.A == 255 {
+		t.Errorf("Previous frame pixel = %+v, want translucent red", got)
+	}
+	if got := preview.NRGBAAt(1, 0); got.R != 255 || got.G != 255 || got.B != 255 || got.A != 255 {
+		t.Errorf("Current frame pixel = %+v, want opaque white", got)
+	}
+	if got := preview.NRGBAAt(2, 0); got.B != 255 || got.R != 0 || got.A == 0 || got.A == 255 {
+		t.Errorf("Next frame pixel = %+v, want translucent blue", got)
+	}
+
+	// Frames past the end fail
+	if _, err := client.ExecuteLua(ctx, gen.ExportFrameImages([]int{4}, tempDir), spritePath); err == nil {
+		t.Error("Expected error for missing frame")
+	}
+}
@@ -612,3 +612,431 @@ func TestLinkCel_ViaMCP(t *testing.T) {
 	json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &output)
 	assert.True(t, output.Success)
 }
+
+func TestValidateFrameRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    int
+		to      int
+		wantErr string
+	}{
+		{name: "single frame", from: 1, to: 1},
+		{name: "multiple frames", from: 2, to: 5},
+		{name: "from below 1", from: 0, to: 2, wantErr: "from_frame must be at least 1"},
+		{name: "to before from", from: 3, to: 2, wantErr: "to_frame must be >= from_frame"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFrameRange(tt.from, tt.to)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestFrameRangeTools_InvalidInput_ViaMCP(t *testing.T) {
+	_, session, _ := createAnimationTestSession(t)
+	defer session.Close()
+
+	tests := []struct {
+		name      string
+		tool      string
+		arguments map[string]any
+	}{
+		{
+			name:      "move_frames inverted range",
+			tool:      "move_frames",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "from_frame": 3, "to_frame": 2, "target_frame": 1},
+		},
+		{
+			name:      "move_frames invalid target",
+			tool:      "move_frames",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "from_frame": 1, "to_frame": 2, "target_frame": 0},
+		},
+		{
+			name:      "reverse_frames invalid start",
+			tool:      "reverse_frames",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "from_frame": 0, "to_frame": 2},
+		},
+		{
+			name:      "delete_frames inverted range",
+			tool:      "delete_frames",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "from_frame": 4, "to_frame": 1},
+		},
+		{
+			name:      "insert_empty_frames invalid position",
+			tool:      "insert_empty_frames",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "at_frame": 0},
+		},
+		{
+			name:      "insert_empty_frames count too large",
+			tool:      "insert_empty_frames",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "at_frame": 1, "count": 257},
+		},
+		{
+			name:      "insert_empty_frames duration too large",
+			tool:      "insert_empty_frames",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "at_frame": 1, "duration_ms": 70000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+				Name:      tt.tool,
+				Arguments: tt.arguments,
+			})
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+		})
+	}
+}
+
+func TestMoveFrames_ViaMCP(t *testing.T) {
+	_, session, client := createAnimationTestSession(t)
+	defer session.Close()
+
+	cfg := testutil.LoadTestConfig(t)
+	gen := aseprite.NewLuaGenerator()
+
+	spritePath := cfg.TempDir + "/test-move-frames.aseprite"
+	script := gen.CreateCanvas(32, 32, aseprite.ColorModeRGB, spritePath)
+	_, err := client.ExecuteLua(context.Background(), script, "")
+	require.NoError(t, err)
+	defer os.Remove(spritePath)
+
+	for i := 0; i < 3; i++ {
+		_, err = client.ExecuteLua(context.Background(), gen.AddFrame(100), spritePath)
+		require.NoError(t, err)
+	}
+
+	_, err = client.ExecuteLua(context.Background(), gen.CreateTag("walk", 1, 2, "forward"), spritePath)
+	require.NoError(t, err)
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "move_frames",
+		Arguments: map[string]any{
+			"sprite_path":  spritePath,
+			"from_frame":   1,
+			"to_frame":     2,
+			"target_frame": 3,
+		},
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var output MoveFramesOutput
+	json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &output)
+	assert.Equal(t, 4, output.FrameCount)
+	assert.Equal(t, 3, output.FromFrame)
+	assert.Equal(t, 4, output.ToFrame)
+	require.Len(t, output.Tags, 1)
+	assert.Equal(t, TagRange{Name: "walk", FromFrame: 3, ToFrame: 4}, output.Tags[0])
+}
+
+func TestUpdateTag_InvalidInput_ViaMCP(t *testing.T) {
+	_, session, _ := createAnimationTestSession(t)
+	defer session.Close()
+
+	tests := []struct {
+		name      string
+		arguments map[string]any
+		errMsg    string
+	}{
+		{
+			name:      "empty tag name",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "tag_name": "", "new_name": "run"},
+			errMsg:    "tag_name cannot be empty",
+		},
+		{
+			name:      "no changes",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "tag_name": "walk"},
+			errMsg:    "at least one of",
+		},
+		{
+			name:      "invalid from frame",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "tag_name": "walk", "from_frame": 0},
+			errMsg:    "from_frame must be at least 1",
+		},
+		{
+			name:      "inverted range",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "tag_name": "walk", "from_frame": 4, "to_frame": 2},
+			errMsg:    "to_frame must be >= from_frame",
+		},
+		{
+			name:      "invalid direction",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "tag_name": "walk", "direction": "sideways"},
+			errMsg:    "invalid direction",
+		},
+		{
+			name:      "negative repeat",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "tag_name": "walk", "repeat": -1},
+			errMsg:    "repeat must be between 0 and 65535",
+		},
+		{
+			name:      "invalid color",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "tag_name": "walk", "color": "orange"},
+			errMsg:    "invalid color",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+				Name:      "update_tag",
+				Arguments: tt.arguments,
+			})
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, tt.errMsg)
+		})
+	}
+}
+
+func TestListTags_ViaMCP(t *testing.T) {
+	_, session, client := createAnimationTestSession(t)
+	defer session.Close()
+
+	cfg := testutil.LoadTestConfig(t)
+	gen := aseprite.NewLuaGenerator()
+
+	spritePath := cfg.TempDir + "/test-list-tags.aseprite"
+	script := gen.CreateCanvas(32, 32, aseprite.ColorModeRGB, spritePath)
+	_, err := client.ExecuteLua(context.Background(), script, "")
+	require.NoError(t, err)
+	defer os.Remove(spritePath)
+
+	_, err = client.ExecuteLua(context.Background(), gen.AddFrame(150), spritePath)
+	require.NoError(t, err)
+
+	_, err = client.ExecuteLua(context.Background(), gen.CreateTag("walk", 1, 2, "pingpong_reverse"), spritePath)
+	require.NoError(t, err)
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "list_tags",
+		Arguments: map[string]any{
+			"sprite_path": spritePath,
+		},
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var output ListTagsOutput
+	json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &output)
+	require.Len(t, output.Tags, 1)
+	assert.Equal(t, "walk", output.Tags[0].Name)
+	assert.Equal(t, "pingpong_reverse", output.Tags[0].Direction)
+	assert.Equal(t, 250, output.Tags[0].DurationMs)
+}
+
+func TestTweenCels_InvalidInput_ViaMCP(t *testing.T) {
+	_, session, _ := createAnimationTestSession(t)
+	defer session.Close()
+
+	base := func(overrides map[string]any) map[string]any {
+		args := map[string]any{
+			"sprite_path": "/tmp/x.aseprite",
+			"layer_name":  "Layer 1",
+			"from_frame":  1,
+			"to_frame":    2,
+			"in_betweens": 3,
+		}
+		for k, v := range overrides {
+			args[k] = v
+		}
+		return args
+	}
+
+	tests := []struct {
+		name      string
+		arguments map[string]any
+		errMsg    string
+	}{
+		{name: "empty layer", arguments: base(map[string]any{"layer_name": ""}), errMsg: "layer_name cannot be empty"},
+		{name: "keyframes not adjacent", arguments: base(map[string]any{"to_frame": 3}), errMsg: "keyframes must be adjacent"},
+		{name: "no in-betweens", arguments: base(map[string]any{"in_betweens": 0}), errMsg: "in_betweens must be between 1 and 64"},
+		{name: "invalid easing", arguments: base(map[string]any{"easing": "bounce"}), errMsg: "invalid easing"},
+		{name: "bezier missing points", arguments: base(map[string]any{"easing": "cubic_bezier"}), errMsg: "requires bezier with 4 values"},
+		{name: "scale out of range", arguments: base(map[string]any{"scale": 20.0}), errMsg: "scale must be between 0.1 and 8"},
+		{name: "invalid duration", arguments: base(map[string]any{"duration_ms": 0}), errMsg: "duration_ms must be between 1 and 65535"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+				Name:      "tween_cels",
+				Arguments: tt.arguments,
+			})
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, tt.errMsg)
+		})
+	}
+}
+
+func TestSetFrameDurations_InvalidInput_ViaMCP(t *testing.T) {
+	_, session, _ := createAnimationTestSession(t)
+	defer session.Close()
+
+	tests := []struct {
+		name      string
+		arguments map[string]any
+		errMsg    string
+	}{
+		{
+			name:      "no range",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "duration_ms": 100},
+			errMsg:    "provide either tag or from_frame",
+		},
+		{
+			name:      "range and tag",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "from_frame": 1, "tag": "walk", "duration_ms": 100},
+			errMsg:    "provide either tag or from_frame",
+		},
+		{
+			name:      "no durations",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "from_frame": 1, "to_frame": 3},
+			errMsg:    "provide exactly one of durations_ms, duration_ms, or total_ms",
+		},
+		{
+			name:      "two duration modes",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "from_frame": 1, "to_frame": 3, "duration_ms": 100, "total_ms": 300},
+			errMsg:    "provide exactly one of durations_ms, duration_ms, or total_ms",
+		},
+		{
+			name:      "easing without total",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "from_frame": 1, "to_frame": 3, "duration_ms": 100, "easing": "ease_in"},
+			errMsg:    "easing and bezier can only be used with total_ms",
+		},
+		{
+			name:      "inverted range",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "from_frame": 3, "to_frame": 1, "duration_ms": 100},
+			errMsg:    "to_frame must be >= from_frame",
+		},
+		{
+			name:      "list length mismatch",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "from_frame": 1, "to_frame": 3, "durations_ms": []int{100, 100}},
+			errMsg:    "durations_ms has 2 values but the range has 3 frames",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+				Name:      "set_frame_durations",
+				Arguments: tt.arguments,
+			})
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, tt.errMsg)
+		})
+	}
+}
+
+func TestRenderOnionSkin_InvalidInput_ViaMCP(t *testing.T) {
+	_, session, _ := createAnimationTestSession(t)
+	defer session.Close()
+
+	tests := []struct {
+		name      string
+		arguments map[string]any
+		errMsg    string
+	}{
+		{
+			name:      "frame zero",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "frame_number": 0},
+			errMsg:    "frame_number must be >= 1",
+		},
+		{
+			name:      "too many previous frames",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "frame_number": 1, "before": 9},
+			errMsg:    "before must be between 0 and 8",
+		},
+		{
+			name:      "invalid tint",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "frame_number": 1, "after_tint": "blue"},
+			errMsg:    "invalid after_tint",
+		},
+		{
+			name:      "opacity out of range",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "frame_number": 1, "opacity": 1.5},
+			errMsg:    "opacity must be between 0.0 and 1.0",
+		},
+		{
+			name:      "scale out of range",
+			arguments: map[string]any{"sprite_path": "/tmp/x.aseprite", "frame_number": 1, "scale": 32},
+			errMsg:    "scale must be between 1 and 16",
+		},
+		{
+			name:      "missing sprite",
+			arguments: map[string]any{"sprite_path": "/tmp/does-not-exist-onion.aseprite", "frame_number": 1},
+			errMsg:    "sprite file not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+				Name:      "render_onion_skin",
+				Arguments: tt.arguments,
+			})
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, tt.errMsg)
+		})
+	}
+}
+
+func TestCreatePaletteCycle_ViaMCP_UsesFramePalette(t *testing.T) {
+	_, session, client := createAnimationTestSession(t)
+	defer session.Close()
+
+	cfg := testutil.LoadTestConfig(t)
+	gen := aseprite.NewLuaGenerator()
+	ctx := context.Background()
+	spritePath := cfg.TempDir + "/test-palette-cycle.aseprite"
+
+	// Frame 2 has its own palette, which the cycle must start from
+	_, err := client.ExecuteLua(ctx, gen.CreateCanvas(8, 8, aseprite.ColorModeIndexed, spritePath), "")
+	require.NoError(t, err)
+	defer os.Remove(spritePath)
+
+	_, err = client.ExecuteLua(ctx, gen.SetPalette([]string{"#000000", "#FF0000", "#00FF00", "#0000FF"}), spritePath)
+	require.NoError(t, err)
+	_, err = client.ExecuteLua(ctx, gen.AddFrame(100), spritePath)
+	require.NoError(t, err)
+	_, err = client.ExecuteLua(ctx, gen.SetFramePalette(2, []string{"#000000", "#FFFF00", "#00FFFF", "#FF00FF"}), spritePath)
+	require.NoError(t, err)
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name: "create_palette_cycle",
+		Arguments: map[string]any{
+			"sprite_path": spritePath,
+			"start_index": 1,
+			"end_index":   3,
+			"steps":       3,
+		},
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var output CreatePaletteCycleOutput
+	json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &output)
+	assert.Equal(t, 2, output.FromFrame)
+	assert.Equal(t, 4, output.ToFrame)
+
+	paletteOutput, err := client.ExecuteLua(ctx, gen.GetFramePalette(3), spritePath)
+	require.NoError(t, err)
+
+	var palette GetPaletteOutput
+	require.NoError(t, parseJSON(paletteOutput, &palette))
+	assert.Equal(t, []string{"#000000", "#FF00FF", "#FFFF00", "#00FFFF"}, palette.Colors)
+}
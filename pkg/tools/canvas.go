@@ -9,11 +9,19 @@
 //   - Inspection tools (inspection.go): Reading pixel data and sprite metadata
 //   - Analysis tools (analysis.go): Reference image palette extraction and edge detection
 //   - Dithering tools (dithering.go): 15 dithering patterns for gradients and textures
+//   - Text tools (text.go): Text rendering with bundled pixel fonts and BMFont files
 //   - Palette tools (palette_tools.go): Palette management and color harmony analysis
+//   - Palette file tools (palette_files.go): Palette import/export in GPL, PAL, HEX, ACT, ASE, and PNG formats
+//   - Palette library tools (palette_library.go): Bundled classic palettes usable by name
+//   - Recolor tools (recolor.go): Color remapping and palette-swapped sprite variants
+//   - Accessibility tools (accessibility.go): Color-blindness simulation and palette contrast audits
 //   - Transform tools (transform.go): Image downsampling for pixel art conversion
 //   - Export tools (export.go): Sprite export to PNG, GIF, and other formats
 //   - Selection tools (selection.go): Selection mask creation and manipulation
 //   - Antialiasing tools (antialiasing.go): Edge detection and smoothing suggestions
+//   - Lighting tools (lighting.go): Normal maps and lit previews for 2D dynamic lighting
+//   - Effect tools (effects.go): Drop shadow, rim light, and inner glow/bevel layer effects
+//   - Lint tools (lint.go): Pixel art critique with located, severity-ranked issues
 //
 // All tools follow a common pattern:
 //  1. Input struct defines tool parameters with JSON schema annotations
@@ -98,12 +106,13 @@ type GetSpriteInfoInput struct {
 // Contains complete metadata about a sprite including dimensions, color mode,
 // and animation/layer information.
 type GetSpriteInfoOutput struct {
-	Width      int      `json:"width" jsonschema:"Sprite width in pixels"`                       // Sprite width in pixels
-	Height     int      `json:"height" jsonschema:"Sprite height in pixels"`                     // Sprite height in pixels
-	ColorMode  string   `json:"color_mode" jsonschema:"Color mode (rgb, grayscale, or indexed)"` // Color mode: "rgb", "grayscale", or "indexed"
-	FrameCount int      `json:"frame_count" jsonschema:"Number of frames in the sprite"`         // Total number of animation frames
-	LayerCount int      `json:"layer_count" jsonschema:"Number of layers in the sprite"`         // Total number of layers
-	Layers     []string `json:"layers" jsonschema:"Names of all layers in the sprite"`           // Names of all layers from bottom to top
+	Width      int       `json:"width" jsonschema:"Sprite width in pixels"`                                     // Sprite width in pixels
+	Height     int       `json:"height" jsonschema:"Sprite height in pixels"`                                   // Sprite height in pixels
+	ColorMode  string    `json:"color_mode" jsonschema:"Color mode (rgb, grayscale, or indexed)"`               // Color mode: "rgb", "grayscale", or "indexed"
+	FrameCount int       `json:"frame_count" jsonschema:"Number of frames in the sprite"`                       // Total number of animation frames
+	LayerCount int       `json:"layer_count" jsonschema:"Number of layers in the sprite"`                       // Total number of layers
+	Layers     []string  `json:"layers" jsonschema:"Names of all layers in the sprite"`                         // Names of all layers from bottom to top
+	Tags       []TagInfo `json:"tags" jsonschema:"Animation tags with frame ranges, directions, and durations"` // Animation tags in timeline order
 }
 
 // RegisterCanvasTools registers all canvas management tools with the MCP server.
@@ -240,7 +249,7 @@ func RegisterCanvasTools(server *mcp.Server, client *aseprite.Client, gen *asepr
 		server,
 		&mcp.Tool{
 			Name:        "get_sprite_info",
-			Description: "Retrieve metadata about an existing Aseprite sprite including dimensions, color mode, frame count, layer count, and layer names.",
+			Description: "Retrieve metadata about an existing Aseprite sprite including dimensions, color mode, frame count, layer count, layer names, and animation tags (frame ranges, directions, and total durations in milliseconds).",
 		},
 		maybeWrapWithTiming("get_sprite_info", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input GetSpriteInfoInput) (*mcp.CallToolResult, *GetSpriteInfoOutput, error) {
 			opLogger := logger.WithContext(ctx)
@@ -0,0 +1,155 @@
+package tools
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
+)
+
+// defaultClipboardSlot is the clipboard slot used when a tool call does not
+// name one.
+const defaultClipboardSlot = "default"
+
+// clipboardEntry is the content of one clipboard slot.
+type clipboardEntry struct {
+	image      *image.NRGBA   // selected pixels cropped to the selection bounds, unselected pixels transparent
+	origin     aseprite.Point // sprite position the pixels were copied from
+	colorMode  string         // color mode of the source sprite
+	palette    []string       // palette of the source sprite as hex colors
+	pixelCount int            // number of selected pixels
+}
+
+// clipboardStore holds named clipboard slots in server memory, so pixels
+// copied from one sprite can be pasted into another without leaving hidden
+// layers behind. It is safe for concurrent use.
+type clipboardStore struct {
+	mu    sync.RWMutex
+	slots map[string]*clipboardEntry
+}
+
+// newClipboardStore creates an empty clipboard store.
+func newClipboardStore() *clipboardStore {
+	return &clipboardStore{slots: make(map[string]*clipboardEntry)}
+}
+
+// set stores an entry in a slot, replacing any previous content.
+func (s *clipboardStore) set(slot string, entry *clipboardEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.slots[slot] = entry
+}
+
+// get returns the entry in a slot.
+func (s *clipboardStore) get(slot string) (*clipboardEntry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.slots[slot]
+	return entry, ok
+}
+
+// names returns the names of all filled slots in sorted order.
+func (s *clipboardStore) names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.slots))
+	for name := range s.slots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// emptySlotError reports a paste from a slot that holds nothing.
+func (s *clipboardStore) emptySlotError(slot string) error {
+	names := s.names()
+	if len(names) == 0 {
+		return fmt.Errorf("clipboard slot %q is empty; copy or cut a selection first", slot)
+	}
+	return fmt.Errorf("clipboard slot %q is empty (filled slots: %s)", slot, strings.Join(names, ", "))
+}
+
+// clipboardExport is the JSON printed by the CutSelection and CopySelection
+// scripts alongside the exported PNG.
+type clipboardExport struct {
+	X          int      `json:"x"`
+	Y          int      `json:"y"`
+	Width      int      `json:"width"`
+	Height     int      `json:"height"`
+	PixelCount int      `json:"pixel_count"`
+	ColorMode  string   `json:"color_mode"`
+	Palette    []string `json:"palette"`
+}
+
+// loadClipboardEntry decodes the PNG written by a cut or copy script into a
+// clipboard entry. Indexed source images are resolved to their palette colors.
+func loadClipboardEntry(pngPath string, export clipboardExport) (*clipboardEntry, error) {
+	img, err := loadPNG(pngPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read clipboard image: %w", err)
+	}
+
+	bounds := img.Bounds()
+	rgba := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+
+	return &clipboardEntry{
+		image:      rgba,
+		origin:     aseprite.Point{X: export.X, Y: export.Y},
+		colorMode:  export.ColorMode,
+		palette:    export.Palette,
+		pixelCount: export.PixelCount,
+	}, nil
+}
+
+// imageFor returns a copy of the entry's pixels ready to paste into a sprite
+// with the given color mode and palette, and the number of distinct colors
+// that had to change.
+//
+// Indexed targets get every visible pixel snapped to the closest palette
+// color, unless the pixels came from an indexed sprite with the same palette.
+// RGB and grayscale targets take the colors as they are.
+func (e *clipboardEntry) imageFor(colorMode string, palette []aseprite.PaletteColor) (*image.NRGBA, int, error) {
+	img := image.NewNRGBA(e.image.Rect)
+	copy(img.Pix, e.image.Pix)
+
+	if colorMode != "indexed" || (e.colorMode == "indexed" && samePalette(e.palette, palette)) {
+		return img, 0, nil
+	}
+
+	before := bytes.Clone(img.Pix)
+	if err := aseprite.SnapImageToPalette(img, palette); err != nil {
+		return nil, 0, fmt.Errorf("failed to remap clipboard colors: %w", err)
+	}
+
+	changed := make(map[color.NRGBA]bool)
+	for i := 0; i < len(before); i += 4 {
+		if before[i+3] == 0 {
+			continue
+		}
+		if !bytes.Equal(before[i:i+3], img.Pix[i:i+3]) {
+			changed[color.NRGBA{R: before[i], G: before[i+1], B: before[i+2], A: 255}] = true
+		}
+	}
+
+	return img, len(changed), nil
+}
+
+// samePalette reports whether a hex palette matches palette entry for entry.
+func samePalette(hexColors []string, palette []aseprite.PaletteColor) bool {
+	if len(hexColors) != len(palette) {
+		return false
+	}
+	for i, c := range hexColors {
+		if !strings.EqualFold(c, palette[i].Color) {
+			return false
+		}
+	}
+	return true
+}
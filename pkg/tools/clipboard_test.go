@@ -0,0 +1,115 @@
+package tools
+
+import (
+	"image"
+	"image/color"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
+)
+
+// testClipboardEntry returns a 2x1 entry with a near-red pixel and a
+// transparent pixel.
+func testClipboardEntry(colorMode string, palette []string) *clipboardEntry {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.SetNRGBA(0, 0, color.NRGBA{R: 250, G: 10, B: 10, A: 255})
+	return &clipboardEntry{
+		image:      img,
+		origin:     aseprite.Point{X: 3, Y: 4},
+		colorMode:  colorMode,
+		palette:    palette,
+		pixelCount: 2,
+	}
+}
+
+func TestClipboardStore(t *testing.T) {
+	store := newClipboardStore()
+
+	_, ok := store.get(defaultClipboardSlot)
+	assert.False(t, ok)
+	assert.EqualError(t, store.emptySlotError("hat"), `clipboard slot "hat" is empty; copy or cut a selection first`)
+
+	first := testClipboardEntry("rgb", nil)
+	store.set("sword", first)
+	store.set("hat", testClipboardEntry("rgb", nil))
+
+	got, ok := store.get("sword")
+	require.True(t, ok)
+	assert.Same(t, first, got)
+	assert.Equal(t, []string{"hat", "sword"}, store.names())
+	assert.EqualError(t, store.emptySlotError("shield"), `clipboard slot "shield" is empty (filled slots: hat, sword)`)
+
+	// Setting a slot again replaces its content
+	second := testClipboardEntry("rgb", nil)
+	store.set("sword", second)
+	got, _ = store.get("sword")
+	assert.Same(t, second, got)
+}
+
+func TestClipboardEntry_ImageFor(t *testing.T) {
+	palette := []aseprite.PaletteColor{{Color: "#000000"}, {Color: "#FF0000"}, {Color: "#0000FF"}}
+
+	t.Run("rgb target keeps colors", func(t *testing.T) {
+		entry := testClipboardEntry("rgb", nil)
+
+		img, remapped, err := entry.imageFor("rgb", nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, remapped)
+		assert.Equal(t, color.NRGBA{R: 250, G: 10, B: 10, A: 255}, img.NRGBAAt(0, 0))
+
+		// The stored pixels are not shared with the returned copy
+		img.SetNRGBA(0, 0, color.NRGBA{A: 255})
+		assert.Equal(t, color.NRGBA{R: 250, G: 10, B: 10, A: 255}, entry.image.NRGBAAt(0, 0))
+	})
+
+	t.Run("indexed target snaps to its palette", func(t *testing.T) {
+		entry := testClipboardEntry("rgb", nil)
+
+		img, remapped, err := entry.imageFor("indexed", palette)
+		require.NoError(t, err)
+		assert.Equal(t, 1, remapped)
+		assert.Equal(t, color.NRGBA{R: 255, G: 0, B: 0, A: 255}, img.NRGBAAt(0, 0))
+		assert.Equal(t, uint8(0), img.NRGBAAt(1, 0).A, "transparent pixels stay transparent")
+	})
+
+	t.Run("indexed source with the same palette is not remapped", func(t *testing.T) {
+		entry := testClipboardEntry("indexed", []string{"#000000", "#ff0000", "#0000FF"})
+
+		img, remapped, err := entry.imageFor("indexed", palette)
+		require.NoError(t, err)
+		assert.Equal(t, 0, remapped)
+		assert.Equal(t, color.NRGBA{R: 250, G: 10, B: 10, A: 255}, img.NRGBAAt(0, 0))
+	})
+
+	t.Run("empty target palette", func(t *testing.T) {
+		_, _, err := testClipboardEntry("rgb", nil).imageFor("indexed", nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadClipboardEntry(t *testing.T) {
+	// Indexed PNGs are resolved to their palette colors
+	src := image.NewPaletted(image.Rect(0, 0, 2, 2), color.Palette{
+		color.NRGBA{A: 0},
+		color.NRGBA{R: 0, G: 128, B: 255, A: 255},
+	})
+	src.SetColorIndex(1, 1, 1)
+
+	path := filepath.Join(t.TempDir(), "clip.png")
+	require.NoError(t, savePNG(path, src))
+
+	entry, err := loadClipboardEntry(path, clipboardExport{X: 7, Y: 9, PixelCount: 4, ColorMode: "indexed", Palette: []string{"#000000", "#0080FF"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, aseprite.Point{X: 7, Y: 9}, entry.origin)
+	assert.Equal(t, "indexed", entry.colorMode)
+	assert.Equal(t, 4, entry.pixelCount)
+	assert.Equal(t, color.NRGBA{R: 0, G: 128, B: 255, A: 255}, entry.image.NRGBAAt(1, 1))
+	assert.Equal(t, uint8(0), entry.image.NRGBAAt(0, 0).A)
+
+	_, err = loadClipboardEntry(filepath.Join(t.TempDir(), "missing.png"), clipboardExport{})
+	assert.Error(t, err)
+}
@@ -5,12 +5,19 @@ package tools
 
 import (
 	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/willibrandon/mtlog"
 	"github.com/willibrandon/mtlog/core"
+	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
 )
 
 // wrapWithTiming wraps a tool handler with timing and request tracking.
@@ -71,3 +78,96 @@ func maybeWrapWithTiming[I any, O any](
 	}
 	return handler
 }
+
+// loadCelImage exports the cel at the given layer and frame and decodes it.
+//
+// The cel is written as a PNG into tempDir (which the caller owns and removes).
+// Returns the decoded image together with the cel's position in the sprite, so
+// results computed in Go can be written back at the same offset with ImportImage.
+func loadCelImage(ctx context.Context, client *aseprite.Client, gen *aseprite.LuaGenerator, spritePath, layerName string, frameNumber int, tempDir string) (image.Image, aseprite.Point, error) {
+	celPNG := filepath.Join(tempDir, fmt.Sprintf("cel-%d.png", time.Now().UnixNano()))
+
+	output, err := client.ExecuteLua(ctx, gen.ExportCelImage(layerName, frameNumber, celPNG), spritePath)
+	if err != nil {
+		return nil, aseprite.Point{}, fmt.Errorf("failed to export cel image: %w", err)
+	}
+
+	var bounds aseprite.Rectangle
+	if err := parseJSON(output, &bounds); err != nil {
+		return nil, aseprite.Point{}, fmt.Errorf("failed to parse cel bounds: %w", err)
+	}
+
+	img, err := loadPNG(celPNG)
+	if err != nil {
+		return nil, aseprite.Point{}, err
+	}
+
+	return img, aseprite.Point{X: bounds.X, Y: bounds.Y}, nil
+}
+
+// loadPNG opens and decodes a PNG file.
+func loadPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image: %w", err)
+	}
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	return img, nil
+}
+
+// savePNG encodes an image as PNG at the given path.
+func savePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create image file: %w", err)
+	}
+	defer f.Close()
+
+	if err := png.Encode(f, img); err != nil {
+		return fmt.Errorf("failed to encode PNG: %w", err)
+	}
+
+	return nil
+}
+
+// loadCelCanvas exports a cel and places it on a transparent sprite-sized canvas
+// at its cel position, so cels from different layers line up pixel for pixel.
+func loadCelCanvas(ctx context.Context, client *aseprite.Client, gen *aseprite.LuaGenerator, spritePath, layerName string, frameNumber, width, height int, tempDir string) (*image.NRGBA, error) {
+	img, celPos, err := loadCelImage(ctx, client, gen, spritePath, layerName, frameNumber, tempDir)
+	if err != nil {
+		return nil, err
+	}
+
+	canvas := image.NewNRGBA(image.Rect(0, 0, width, height))
+	offset := image.Pt(celPos.X, celPos.Y)
+	draw.Draw(canvas, img.Bounds().Add(offset), img, img.Bounds().Min, draw.Src)
+
+	return canvas, nil
+}
+
+// loadSpritePalette reads the sprite's palette as PaletteColor entries for use
+// with aseprite.FindClosestPaletteColor and aseprite.SnapImageToPalette.
+func loadSpritePalette(ctx context.Context, client *aseprite.Client, gen *aseprite.LuaGenerator, spritePath string) ([]aseprite.PaletteColor, error) {
+	output, err := client.ExecuteLua(ctx, gen.GetPalette(), spritePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get palette: %w", err)
+	}
+
+	var pal GetPaletteOutput
+	if err := parseJSON(output, &pal); err != nil {
+		return nil, fmt.Errorf("failed to parse palette: %w", err)
+	}
+
+	palette := make([]aseprite.PaletteColor, len(pal.Colors))
+	for i, c := range pal.Colors {
+		palette[i] = aseprite.PaletteColor{Color: c}
+	}
+
+	return palette, nil
+}
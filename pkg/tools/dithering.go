@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/willibrandon/mtlog/core"
@@ -22,6 +23,33 @@ type DrawWithDitherInput struct {
 	Density     float64     `json:"density,omitempty" jsonschema:"Ratio of color1 to color2 (0.0-1.0, default: 0.5)"`
 }
 
+// GradientStopInput defines one color stop of a gradient.
+type GradientStopInput struct {
+	Color    string   `json:"color" jsonschema:"Stop color (hex #RRGGBB or #RRGGBBAA)"`
+	Position *float64 `json:"position,omitempty" jsonschema:"Position along the gradient from 0.0 to 1.0 (default: first 0, last 1, others evenly spaced)"`
+}
+
+// DrawGradientInput defines the input parameters for the draw_gradient tool.
+type DrawGradientInput struct {
+	SpritePath  string              `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName   string              `json:"layer_name" jsonschema:"Name of the layer to draw on"`
+	FrameNumber int                 `json:"frame_number" jsonschema:"Frame number to draw on (1-based)"`
+	Type        string              `json:"type" jsonschema:"Gradient shape: linear, radial, angular, or diamond"`
+	Start       PointInput          `json:"start" jsonschema:"Linear start point, or center of radial/angular/diamond gradients"`
+	End         PointInput          `json:"end" jsonschema:"Linear end point; for other shapes its distance from start is the radius and its direction where the angular sweep begins"`
+	Stops       []GradientStopInput `json:"stops" jsonschema:"Color stops (at least 2) in order along the gradient"`
+	Dither      string              `json:"dither,omitempty" jsonschema:"Dithering between adjacent stops: none|bayer_2x2|bayer_4x4|bayer_8x8 (default: none)"`
+	Region      *RegionInput        `json:"region,omitempty" jsonschema:"Area to fill (default: whole canvas)"`
+	Clip        string              `json:"clip,omitempty" jsonschema:"Pixels to paint: region (all), selection (selected only), or opaque (non-transparent only, keeping their alpha) (default: region)"`
+	UsePalette  bool                `json:"use_palette,omitempty" jsonschema:"Quantize colors to the sprite palette (default: false)"`
+	PaletteName string              `json:"palette_name,omitempty" jsonschema:"Quantize colors to this bundled palette instead of the sprite palette, e.g. pico-8 (see list_palettes)"`
+}
+
+// DrawGradientOutput defines the output for the draw_gradient tool.
+type DrawGradientOutput struct {
+	PixelsPainted int `json:"pixels_painted" jsonschema:"Number of pixels painted"`
+}
+
 // RegionInput defines a rectangular region.
 type RegionInput struct {
 	X      int `json:"x" jsonschema:"X coordinate of top-left corner"`
@@ -132,6 +160,171 @@ func RegisterDitheringTools(server *mcp.Server, client *aseprite.Client, gen *as
 			return nil, &struct{ Success bool }{Success: true}, nil
 		}),
 	)
+
+	// Register draw_gradient tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "draw_gradient",
+			Description: "Fill an area with a multi-color gradient. Supports linear, radial, angular (conic), and diamond shapes between any number of color stops. Choose smooth blending or Bayer dithering (bayer_2x2, bayer_4x4, bayer_8x8) between adjacent stops for a pixel-art look that only uses the stop colors. Colors can be quantized to the sprite palette or a bundled palette. Painting is limited to a region, the active selection, or the layer's non-transparent pixels (keeping their alpha), e.g. to shade an existing shape.",
+		},
+		maybeWrapWithTiming("draw_gradient", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input DrawGradientInput) (*mcp.CallToolResult, *DrawGradientOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("draw_gradient tool called",
+				"sprite", input.SpritePath,
+				"layer", input.LayerName,
+				"frame", input.FrameNumber,
+				"type", input.Type,
+				"stops", len(input.Stops),
+				"dither", input.Dither,
+				"clip", input.Clip)
+
+			// Set defaults
+			if input.Dither == "" {
+				input.Dither = "none"
+			}
+			if input.Clip == "" {
+				input.Clip = aseprite.GradientClipRegion
+			}
+
+			// Validate inputs
+			if input.FrameNumber < 1 {
+				return nil, nil, fmt.Errorf("frame_number must be >= 1, got %d", input.FrameNumber)
+			}
+			if input.LayerName == "" {
+				return nil, nil, fmt.Errorf("layer_name is required")
+			}
+			switch input.Type {
+			case aseprite.GradientLinear, aseprite.GradientRadial, aseprite.GradientAngular, aseprite.GradientDiamond:
+			default:
+				return nil, nil, fmt.Errorf("invalid type: %s (must be linear, radial, angular, or diamond)", input.Type)
+			}
+			if input.Start == input.End {
+				return nil, nil, fmt.Errorf("start and end must be different points")
+			}
+			switch input.Dither {
+			case "none", "bayer_2x2", "bayer_4x4", "bayer_8x8":
+			default:
+				return nil, nil, fmt.Errorf("invalid dither: %s (must be none, bayer_2x2, bayer_4x4, or bayer_8x8)", input.Dither)
+			}
+			switch input.Clip {
+			case aseprite.GradientClipRegion, aseprite.GradientClipSelection, aseprite.GradientClipOpaque:
+			default:
+				return nil, nil, fmt.Errorf("invalid clip: %s (must be region, selection, or opaque)", input.Clip)
+			}
+			if input.Region != nil && (input.Region.Width <= 0 || input.Region.Height <= 0) {
+				return nil, nil, fmt.Errorf("region width and height must be positive, got %dx%d",
+					input.Region.Width, input.Region.Height)
+			}
+
+			stops, err := gradientStops(input.Stops)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			opts := aseprite.GradientOptions{
+				Type:       input.Type,
+				Start:      aseprite.Point{X: input.Start.X, Y: input.Start.Y},
+				End:        aseprite.Point{X: input.End.X, Y: input.End.Y},
+				Stops:      stops,
+				Dither:     input.Dither,
+				Clip:       input.Clip,
+				UsePalette: input.UsePalette,
+			}
+			if input.Region != nil {
+				opts.Region = aseprite.Rectangle{X: input.Region.X, Y: input.Region.Y, Width: input.Region.Width, Height: input.Region.Height}
+			}
+			if input.PaletteName != "" {
+				colors, err := namedPaletteColors(input.PaletteName)
+				if err != nil {
+					return nil, nil, err
+				}
+				opts.Palette = make([]aseprite.Color, len(colors))
+				for i, c := range colors {
+					if err := opts.Palette[i].FromHex(c); err != nil {
+						return nil, nil, fmt.Errorf("invalid palette color %s: %w", c, err)
+					}
+				}
+			}
+
+			// Check sprite file exists
+			if _, err := os.Stat(input.SpritePath); os.IsNotExist(err) {
+				return nil, nil, fmt.Errorf("sprite file not found: %s", input.SpritePath)
+			}
+
+			output, err := client.ExecuteLua(ctx, gen.DrawGradient(input.LayerName, input.FrameNumber, opts), input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to draw gradient", "error", err)
+				return nil, nil, fmt.Errorf("failed to draw gradient: %w", err)
+			}
+
+			var result DrawGradientOutput
+			if err := parseJSON(output, &result); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse gradient result: %w", err)
+			}
+
+			opLogger.Information("Gradient drawn successfully",
+				"sprite", input.SpritePath,
+				"layer", input.LayerName,
+				"type", input.Type,
+				"pixels_painted", result.PixelsPainted)
+
+			return nil, &result, nil
+		}),
+	)
+}
+
+// gradientStops validates gradient stop inputs and fills in omitted positions.
+//
+// The first and last stops default to 0 and 1, and stops without a position
+// are spaced evenly between their positioned neighbors. Positions must be
+// within 0-1 and must not decrease.
+func gradientStops(inputs []GradientStopInput) ([]aseprite.GradientStop, error) {
+	if len(inputs) < 2 {
+		return nil, fmt.Errorf("at least 2 stops are required, got %d", len(inputs))
+	}
+
+	stops := make([]aseprite.GradientStop, len(inputs))
+	known := make([]bool, len(inputs))
+	for i, in := range inputs {
+		if !isValidHexColor(in.Color) {
+			return nil, fmt.Errorf("invalid color at stop %d: %s (expected #RRGGBB or #RRGGBBAA)", i, in.Color)
+		}
+		if err := stops[i].Color.FromHex(in.Color); err != nil {
+			return nil, fmt.Errorf("invalid color at stop %d: %w", i, err)
+		}
+		if in.Position != nil {
+			if *in.Position < 0 || *in.Position > 1 {
+				return nil, fmt.Errorf("stop %d position must be between 0.0 and 1.0, got %f", i, *in.Position)
+			}
+			stops[i].Position = *in.Position
+			known[i] = true
+		}
+	}
+	if !known[0] {
+		stops[0].Position, known[0] = 0, true
+	}
+	last := len(stops) - 1
+	if !known[last] {
+		stops[last].Position, known[last] = 1, true
+	}
+
+	// Space runs of unpositioned stops evenly between their neighbors
+	prev := 0
+	for i := 1; i < len(stops); i++ {
+		if !known[i] {
+			continue
+		}
+		for j := prev + 1; j < i; j++ {
+			stops[j].Position = stops[prev].Position + (stops[i].Position-stops[prev].Position)*float64(j-prev)/float64(i-prev)
+		}
+		if stops[i].Position < stops[prev].Position {
+			return nil, fmt.Errorf("stop positions must not decrease: stop %d at %f follows %f", i, stops[i].Position, stops[prev].Position)
+		}
+		prev = i
+	}
+
+	return stops, nil
 }
 
 // isValidHexColor checks if a string is a valid hex color format.
@@ -374,3 +374,43 @@ func TestIsValidHexColor(t *testing.T) {
 		})
 	}
 }
+
+func TestGradientStops_FillsPositions(t *testing.T) {
+	mid := 0.8
+	stops, err := gradientStops([]GradientStopInput{
+		{Color: "#000000"},
+		{Color: "#400000"},
+		{Color: "#800000"},
+		{Color: "#C00000", Position: &mid},
+		{Color: "#FFFFFF"},
+	})
+	require.NoError(t, err)
+
+	want := []float64{0, 0.4 / 1.5, 0.8 / 1.5, 0.8, 1}
+	require.Len(t, stops, len(want))
+	for i, w := range want {
+		assert.InDelta(t, w, stops[i].Position, 1e-9, "stop %d", i)
+	}
+	assert.Equal(t, aseprite.NewColorRGB(0xC0, 0, 0), stops[3].Color)
+}
+
+func TestGradientStops_Errors(t *testing.T) {
+	low, high, outside := 0.2, 0.6, 1.5
+
+	tests := []struct {
+		name  string
+		stops []GradientStopInput
+	}{
+		{"single stop", []GradientStopInput{{Color: "#000000"}}},
+		{"invalid color", []GradientStopInput{{Color: "#000000"}, {Color: "white"}}},
+		{"position out of range", []GradientStopInput{{Color: "#000000", Position: &outside}, {Color: "#FFFFFF"}}},
+		{"decreasing positions", []GradientStopInput{{Color: "#000000", Position: &high}, {Color: "#FFFFFF", Position: &low}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := gradientStops(tt.stops)
+			assert.Error(t, err)
+		})
+	}
+}
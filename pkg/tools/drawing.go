@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -26,11 +27,13 @@ type PixelInput struct {
 // for efficient bulk pixel operations. When UsePalette is true, colors are
 // snapped to the nearest palette color using LAB color space distance.
 type DrawPixelsInput struct {
-	SpritePath  string       `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`                                // Path to the sprite file to modify
-	LayerName   string       `json:"layer_name" jsonschema:"Name of the layer to draw on"`                                     // Target layer name
-	FrameNumber int          `json:"frame_number" jsonschema:"Frame number to draw on (1-based)"`                              // 1-based frame index
-	Pixels      []PixelInput `json:"pixels" jsonschema:"Array of pixels to draw"`                                              // Pixels to draw with positions and colors
-	UsePalette  bool         `json:"use_palette,omitempty" jsonschema:"Snap colors to nearest palette color (default: false)"` // Snap to palette if true
+	SpritePath      string       `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`                                                                              // Path to the sprite file to modify
+	LayerName       string       `json:"layer_name" jsonschema:"Name of the layer to draw on"`                                                                                   // Target layer name
+	FrameNumber     int          `json:"frame_number" jsonschema:"Frame number to draw on (1-based)"`                                                                            // 1-based frame index
+	Pixels          []PixelInput `json:"pixels" jsonschema:"Array of pixels to draw"`                                                                                            // Pixels to draw with positions and colors
+	UsePalette      bool         `json:"use_palette,omitempty" jsonschema:"Snap colors to nearest palette color (default: false)"`                                               // Snap to palette if true
+	PaletteName     string       `json:"palette_name,omitempty" jsonschema:"Snap colors to this bundled palette instead of the sprite palette, e.g. pico-8 (see list_palettes)"` // Bundled palette to snap to
+	ClipToSelection bool         `json:"clip_to_selection,omitempty" jsonschema:"Only change pixels inside the active selection (default: false)"`                               // Limit drawing to the selection
 }
 
 // DrawPixelsOutput defines the output for the draw_pixels tool.
@@ -42,16 +45,18 @@ type DrawPixelsOutput struct {
 
 // DrawLineInput defines the input parameters for the draw_line tool.
 type DrawLineInput struct {
-	SpritePath  string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
-	LayerName   string `json:"layer_name" jsonschema:"Name of the layer to draw on"`
-	FrameNumber int    `json:"frame_number" jsonschema:"Frame number to draw on (1-based)"`
-	X1          int    `json:"x1" jsonschema:"X coordinate of line start point"`
-	Y1          int    `json:"y1" jsonschema:"Y coordinate of line start point"`
-	X2          int    `json:"x2" jsonschema:"X coordinate of line end point"`
-	Y2          int    `json:"y2" jsonschema:"Y coordinate of line end point"`
-	Color       string `json:"color" jsonschema:"Hex color string in format #RRGGBB or #RRGGBBAA"`
-	Thickness   int    `json:"thickness" jsonschema:"Line thickness in pixels (1-100)"`
-	UsePalette  bool   `json:"use_palette,omitempty" jsonschema:"Snap colors to nearest palette color (default: false)"`
+	SpritePath      string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName       string `json:"layer_name" jsonschema:"Name of the layer to draw on"`
+	FrameNumber     int    `json:"frame_number" jsonschema:"Frame number to draw on (1-based)"`
+	X1              int    `json:"x1" jsonschema:"X coordinate of line start point"`
+	Y1              int    `json:"y1" jsonschema:"Y coordinate of line start point"`
+	X2              int    `json:"x2" jsonschema:"X coordinate of line end point"`
+	Y2              int    `json:"y2" jsonschema:"Y coordinate of line end point"`
+	Color           string `json:"color" jsonschema:"Hex color string in format #RRGGBB or #RRGGBBAA"`
+	Thickness       int    `json:"thickness" jsonschema:"Line thickness in pixels (1-100)"`
+	UsePalette      bool   `json:"use_palette,omitempty" jsonschema:"Snap colors to nearest palette color (default: false)"`
+	PaletteName     string `json:"palette_name,omitempty" jsonschema:"Snap colors to this bundled palette instead of the sprite palette, e.g. pico-8 (see list_palettes)"`
+	ClipToSelection bool   `json:"clip_to_selection,omitempty" jsonschema:"Only change pixels inside the active selection (default: false)"`
 }
 
 // DrawLineOutput defines the output for the draw_line tool.
@@ -67,14 +72,16 @@ type PointInput struct {
 
 // DrawContourInput defines the input parameters for the draw_contour tool.
 type DrawContourInput struct {
-	SpritePath  string       `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
-	LayerName   string       `json:"layer_name" jsonschema:"Name of the layer to draw on"`
-	FrameNumber int          `json:"frame_number" jsonschema:"Frame number to draw on (1-based)"`
-	Points      []PointInput `json:"points" jsonschema:"Array of points to connect (minimum 2 points)"`
-	Color       string       `json:"color" jsonschema:"Hex color string in format #RRGGBB or #RRGGBBAA"`
-	Thickness   int          `json:"thickness" jsonschema:"Line thickness in pixels (1-100)"`
-	Closed      bool         `json:"closed" jsonschema:"Connect last point to first to form a closed polygon (default: false)"`
-	UsePalette  bool         `json:"use_palette,omitempty" jsonschema:"Snap colors to nearest palette color (default: false)"`
+	SpritePath      string       `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName       string       `json:"layer_name" jsonschema:"Name of the layer to draw on"`
+	FrameNumber     int          `json:"frame_number" jsonschema:"Frame number to draw on (1-based)"`
+	Points          []PointInput `json:"points" jsonschema:"Array of points to connect (minimum 2 points)"`
+	Color           string       `json:"color" jsonschema:"Hex color string in format #RRGGBB or #RRGGBBAA"`
+	Thickness       int          `json:"thickness" jsonschema:"Line thickness in pixels (1-100)"`
+	Closed          bool         `json:"closed" jsonschema:"Connect last point to first to form a closed polygon (default: false)"`
+	UsePalette      bool         `json:"use_palette,omitempty" jsonschema:"Snap colors to nearest palette color (default: false)"`
+	PaletteName     string       `json:"palette_name,omitempty" jsonschema:"Snap colors to this bundled palette instead of the sprite palette, e.g. pico-8 (see list_palettes)"`
+	ClipToSelection bool         `json:"clip_to_selection,omitempty" jsonschema:"Only change pixels inside the active selection (default: false)"`
 }
 
 // DrawContourOutput defines the output for the draw_contour tool.
@@ -84,16 +91,18 @@ type DrawContourOutput struct {
 
 // DrawRectangleInput defines the input parameters for the draw_rectangle tool.
 type DrawRectangleInput struct {
-	SpritePath  string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
-	LayerName   string `json:"layer_name" jsonschema:"Name of the layer to draw on"`
-	FrameNumber int    `json:"frame_number" jsonschema:"Frame number to draw on (1-based)"`
-	X           int    `json:"x" jsonschema:"X coordinate of rectangle top-left corner"`
-	Y           int    `json:"y" jsonschema:"Y coordinate of rectangle top-left corner"`
-	Width       int    `json:"width" jsonschema:"Width of rectangle in pixels"`
-	Height      int    `json:"height" jsonschema:"Height of rectangle in pixels"`
-	Color       string `json:"color" jsonschema:"Hex color string in format #RRGGBB or #RRGGBBAA"`
-	Filled      bool   `json:"filled" jsonschema:"Fill interior (true) or draw outline only (false)"`
-	UsePalette  bool   `json:"use_palette,omitempty" jsonschema:"Snap colors to nearest palette color (default: false)"`
+	SpritePath      string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName       string `json:"layer_name" jsonschema:"Name of the layer to draw on"`
+	FrameNumber     int    `json:"frame_number" jsonschema:"Frame number to draw on (1-based)"`
+	X               int    `json:"x" jsonschema:"X coordinate of rectangle top-left corner"`
+	Y               int    `json:"y" jsonschema:"Y coordinate of rectangle top-left corner"`
+	Width           int    `json:"width" jsonschema:"Width of rectangle in pixels"`
+	Height          int    `json:"height" jsonschema:"Height of rectangle in pixels"`
+	Color           string `json:"color" jsonschema:"Hex color string in format #RRGGBB or #RRGGBBAA"`
+	Filled          bool   `json:"filled" jsonschema:"Fill interior (true) or draw outline only (false)"`
+	UsePalette      bool   `json:"use_palette,omitempty" jsonschema:"Snap colors to nearest palette color (default: false)"`
+	PaletteName     string `json:"palette_name,omitempty" jsonschema:"Snap colors to this bundled palette instead of the sprite palette, e.g. pico-8 (see list_palettes)"`
+	ClipToSelection bool   `json:"clip_to_selection,omitempty" jsonschema:"Only change pixels inside the active selection (default: false)"`
 }
 
 // DrawRectangleOutput defines the output for the draw_rectangle tool.
@@ -103,15 +112,17 @@ type DrawRectangleOutput struct {
 
 // DrawCircleInput defines the input parameters for the draw_circle tool.
 type DrawCircleInput struct {
-	SpritePath  string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
-	LayerName   string `json:"layer_name" jsonschema:"Name of the layer to draw on"`
-	FrameNumber int    `json:"frame_number" jsonschema:"Frame number to draw on (1-based)"`
-	CenterX     int    `json:"center_x" jsonschema:"X coordinate of circle center"`
-	CenterY     int    `json:"center_y" jsonschema:"Y coordinate of circle center"`
-	Radius      int    `json:"radius" jsonschema:"Radius of circle in pixels"`
-	Color       string `json:"color" jsonschema:"Hex color string in format #RRGGBB or #RRGGBBAA"`
-	Filled      bool   `json:"filled" jsonschema:"Fill interior (true) or draw outline only (false)"`
-	UsePalette  bool   `json:"use_palette,omitempty" jsonschema:"Snap colors to nearest palette color (default: false)"`
+	SpritePath      string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName       string `json:"layer_name" jsonschema:"Name of the layer to draw on"`
+	FrameNumber     int    `json:"frame_number" jsonschema:"Frame number to draw on (1-based)"`
+	CenterX         int    `json:"center_x" jsonschema:"X coordinate of circle center"`
+	CenterY         int    `json:"center_y" jsonschema:"Y coordinate of circle center"`
+	Radius          int    `json:"radius" jsonschema:"Radius of circle in pixels"`
+	Color           string `json:"color" jsonschema:"Hex color string in format #RRGGBB or #RRGGBBAA"`
+	Filled          bool   `json:"filled" jsonschema:"Fill interior (true) or draw outline only (false)"`
+	UsePalette      bool   `json:"use_palette,omitempty" jsonschema:"Snap colors to nearest palette color (default: false)"`
+	PaletteName     string `json:"palette_name,omitempty" jsonschema:"Snap colors to this bundled palette instead of the sprite palette, e.g. pico-8 (see list_palettes)"`
+	ClipToSelection bool   `json:"clip_to_selection,omitempty" jsonschema:"Only change pixels inside the active selection (default: false)"`
 }
 
 // DrawCircleOutput defines the output for the draw_circle tool.
@@ -121,14 +132,16 @@ type DrawCircleOutput struct {
 
 // FillAreaInput defines the input parameters for the fill_area tool.
 type FillAreaInput struct {
-	SpritePath  string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
-	LayerName   string `json:"layer_name" jsonschema:"Name of the layer to draw on"`
-	FrameNumber int    `json:"frame_number" jsonschema:"Frame number to draw on (1-based)"`
-	X           int    `json:"x" jsonschema:"X coordinate of starting point"`
-	Y           int    `json:"y" jsonschema:"Y coordinate of starting point"`
-	Color       string `json:"color" jsonschema:"Hex color string in format #RRGGBB or #RRGGBBAA"`
-	Tolerance   int    `json:"tolerance" jsonschema:"Color matching tolerance (0-255, default 0)"`
-	UsePalette  bool   `json:"use_palette,omitempty" jsonschema:"Snap colors to nearest palette color (default: false)"`
+	SpritePath      string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName       string `json:"layer_name" jsonschema:"Name of the layer to draw on"`
+	FrameNumber     int    `json:"frame_number" jsonschema:"Frame number to draw on (1-based)"`
+	X               int    `json:"x" jsonschema:"X coordinate of starting point"`
+	Y               int    `json:"y" jsonschema:"Y coordinate of starting point"`
+	Color           string `json:"color" jsonschema:"Hex color string in format #RRGGBB or #RRGGBBAA"`
+	Tolerance       int    `json:"tolerance" jsonschema:"Color matching tolerance (0-255, default 0)"`
+	UsePalette      bool   `json:"use_palette,omitempty" jsonschema:"Snap colors to nearest palette color (default: false)"`
+	PaletteName     string `json:"palette_name,omitempty" jsonschema:"Snap colors to this bundled palette instead of the sprite palette, e.g. pico-8 (see list_palettes)"`
+	ClipToSelection bool   `json:"clip_to_selection,omitempty" jsonschema:"Only change pixels inside the active selection (default: false)"`
 }
 
 // FillAreaOutput defines the output for the fill_area tool.
@@ -136,6 +149,84 @@ type FillAreaOutput struct {
 	Success bool `json:"success" jsonschema:"Whether the fill operation was successful"`
 }
 
+// DrawBezierInput defines the input parameters for the draw_bezier tool.
+type DrawBezierInput struct {
+	SpritePath      string       `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName       string       `json:"layer_name" jsonschema:"Name of the layer to draw on"`
+	FrameNumber     int          `json:"frame_number" jsonschema:"Frame number to draw on (1-based)"`
+	Points          []PointInput `json:"points" jsonschema:"Start point, control point(s), and end point: 3 points for a quadratic curve, 4 for a cubic curve"`
+	Color           string       `json:"color" jsonschema:"Hex color string in format #RRGGBB or #RRGGBBAA"`
+	UsePalette      bool         `json:"use_palette,omitempty" jsonschema:"Snap colors to nearest palette color (default: false)"`
+	PaletteName     string       `json:"palette_name,omitempty" jsonschema:"Snap colors to this bundled palette instead of the sprite palette, e.g. pico-8 (see list_palettes)"`
+	ClipToSelection bool         `json:"clip_to_selection,omitempty" jsonschema:"Only change pixels inside the active selection (default: false)"`
+}
+
+// DrawBezierOutput defines the output for the draw_bezier tool.
+type DrawBezierOutput struct {
+	Success bool `json:"success" jsonschema:"Whether the curve was drawn successfully"`
+}
+
+// DrawEllipseInput defines the input parameters for the draw_ellipse tool.
+type DrawEllipseInput struct {
+	SpritePath      string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName       string `json:"layer_name" jsonschema:"Name of the layer to draw on"`
+	FrameNumber     int    `json:"frame_number" jsonschema:"Frame number to draw on (1-based)"`
+	CenterX         int    `json:"center_x" jsonschema:"X coordinate of ellipse center"`
+	CenterY         int    `json:"center_y" jsonschema:"Y coordinate of ellipse center"`
+	RadiusX         int    `json:"radius_x" jsonschema:"Horizontal radius in pixels (1-65535)"`
+	RadiusY         int    `json:"radius_y" jsonschema:"Vertical radius in pixels (1-65535)"`
+	Color           string `json:"color" jsonschema:"Hex color string in format #RRGGBB or #RRGGBBAA"`
+	Filled          bool   `json:"filled" jsonschema:"Fill interior (true) or draw outline only (false)"`
+	UsePalette      bool   `json:"use_palette,omitempty" jsonschema:"Snap colors to nearest palette color (default: false)"`
+	PaletteName     string `json:"palette_name,omitempty" jsonschema:"Snap colors to this bundled palette instead of the sprite palette, e.g. pico-8 (see list_palettes)"`
+	ClipToSelection bool   `json:"clip_to_selection,omitempty" jsonschema:"Only change pixels inside the active selection (default: false)"`
+}
+
+// DrawEllipseOutput defines the output for the draw_ellipse tool.
+type DrawEllipseOutput struct {
+	Success bool `json:"success" jsonschema:"Whether the ellipse was drawn successfully"`
+}
+
+// DrawArcInput defines the input parameters for the draw_arc tool.
+type DrawArcInput struct {
+	SpritePath      string  `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName       string  `json:"layer_name" jsonschema:"Name of the layer to draw on"`
+	FrameNumber     int     `json:"frame_number" jsonschema:"Frame number to draw on (1-based)"`
+	CenterX         int     `json:"center_x" jsonschema:"X coordinate of arc center"`
+	CenterY         int     `json:"center_y" jsonschema:"Y coordinate of arc center"`
+	RadiusX         int     `json:"radius_x" jsonschema:"Horizontal radius in pixels (1-65535)"`
+	RadiusY         int     `json:"radius_y" jsonschema:"Vertical radius in pixels (1-65535)"`
+	StartAngle      float64 `json:"start_angle" jsonschema:"Start angle in degrees, clockwise from the right (0 = right, 90 = down, 180 = left, 270 = up)"`
+	EndAngle        float64 `json:"end_angle" jsonschema:"End angle in degrees; the arc runs clockwise from start_angle and wraps past 360 when smaller"`
+	Color           string  `json:"color" jsonschema:"Hex color string in format #RRGGBB or #RRGGBBAA"`
+	UsePalette      bool    `json:"use_palette,omitempty" jsonschema:"Snap colors to nearest palette color (default: false)"`
+	PaletteName     string  `json:"palette_name,omitempty" jsonschema:"Snap colors to this bundled palette instead of the sprite palette, e.g. pico-8 (see list_palettes)"`
+	ClipToSelection bool    `json:"clip_to_selection,omitempty" jsonschema:"Only change pixels inside the active selection (default: false)"`
+}
+
+// DrawArcOutput defines the output for the draw_arc tool.
+type DrawArcOutput struct {
+	Success bool `json:"success" jsonschema:"Whether the arc was drawn successfully"`
+}
+
+// DrawPolygonInput defines the input parameters for the draw_polygon tool.
+type DrawPolygonInput struct {
+	SpritePath      string       `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName       string       `json:"layer_name" jsonschema:"Name of the layer to draw on"`
+	FrameNumber     int          `json:"frame_number" jsonschema:"Frame number to draw on (1-based)"`
+	Points          []PointInput `json:"points" jsonschema:"Polygon vertices in order (minimum 3 points, closed automatically)"`
+	Color           string       `json:"color" jsonschema:"Hex color string in format #RRGGBB or #RRGGBBAA"`
+	FillRule        string       `json:"fill_rule,omitempty" jsonschema:"How overlapping or self-intersecting areas are filled: non_zero or even_odd (default: non_zero)"`
+	UsePalette      bool         `json:"use_palette,omitempty" jsonschema:"Snap colors to nearest palette color (default: false)"`
+	PaletteName     string       `json:"palette_name,omitempty" jsonschema:"Snap colors to this bundled palette instead of the sprite palette, e.g. pico-8 (see list_palettes)"`
+	ClipToSelection bool         `json:"clip_to_selection,omitempty" jsonschema:"Only change pixels inside the active selection (default: false)"`
+}
+
+// DrawPolygonOutput defines the output for the draw_polygon tool.
+type DrawPolygonOutput struct {
+	Success bool `json:"success" jsonschema:"Whether the polygon was drawn successfully"`
+}
+
 // RegisterDrawingTools registers all drawing tools with the MCP server.
 //
 // Registers the following drawing primitives:
@@ -143,11 +234,17 @@ type FillAreaOutput struct {
 //   - draw_line: Draw straight lines with thickness control
 //   - draw_contour: Draw multi-segment polylines (open or closed)
 //   - draw_rectangle: Draw rectangles (filled or outline)
-//   - draw_ellipse: Draw ellipses and circles (filled or outline)
+//   - draw_circle: Draw circles (filled or outline)
 //   - fill: Flood fill with color replacement
+//   - draw_bezier: Draw pixel-perfect quadratic and cubic Bezier curves
+//   - draw_ellipse: Draw ellipses with independent radii (filled or outline)
+//   - draw_arc: Draw elliptical arcs between two angles
+//   - draw_polygon: Fill polygons with the even-odd or non-zero rule
 //
 // All drawing tools support palette-aware color snapping via the UsePalette flag,
 // which snaps arbitrary colors to the nearest palette color using LAB color space.
+// PaletteName snaps to a bundled palette (see list_palettes) instead. With
+// ClipToSelection set, only pixels inside the active selection are changed.
 func RegisterDrawingTools(server *mcp.Server, client *aseprite.Client, gen *aseprite.LuaGenerator, cfg *config.Config, logger core.Logger) {
 	// Register draw_pixels tool
 	mcp.AddTool(
@@ -187,8 +284,21 @@ func RegisterDrawingTools(server *mcp.Server, client *aseprite.Client, gen *asep
 				}
 			}
 
+			// Snap to bundled palette
+			colors := make([]*aseprite.Color, len(pixels))
+			for i := range pixels {
+				colors[i] = &pixels[i].Color
+			}
+			usePalette, err := applyPaletteName(input.PaletteName, input.UsePalette, colors...)
+			if err != nil {
+				return nil, nil, err
+			}
+
 			// Generate Lua script
-			script := gen.DrawPixels(input.LayerName, input.FrameNumber, pixels, input.UsePalette)
+			script := gen.DrawPixels(input.LayerName, input.FrameNumber, pixels, usePalette)
+			if input.ClipToSelection {
+				script = gen.ClipToSelection(input.LayerName, input.FrameNumber, script)
+			}
 
 			// Execute Lua script with the sprite
 			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
@@ -238,8 +348,17 @@ func RegisterDrawingTools(server *mcp.Server, client *aseprite.Client, gen *asep
 				return nil, nil, fmt.Errorf("invalid color format: %w", err)
 			}
 
+			// Snap to bundled palette
+			usePalette, err := applyPaletteName(input.PaletteName, input.UsePalette, &color)
+			if err != nil {
+				return nil, nil, err
+			}
+
 			// Generate Lua script
-			script := gen.DrawLine(input.LayerName, input.FrameNumber, input.X1, input.Y1, input.X2, input.Y2, color, input.Thickness, input.UsePalette)
+			script := gen.DrawLine(input.LayerName, input.FrameNumber, input.X1, input.Y1, input.X2, input.Y2, color, input.Thickness, usePalette)
+			if input.ClipToSelection {
+				script = gen.ClipToSelection(input.LayerName, input.FrameNumber, script)
+			}
 
 			// Execute Lua script with the sprite
 			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
@@ -293,6 +412,12 @@ func RegisterDrawingTools(server *mcp.Server, client *aseprite.Client, gen *asep
 				return nil, nil, fmt.Errorf("invalid color format: %w", err)
 			}
 
+			// Snap to bundled palette
+			usePalette, err := applyPaletteName(input.PaletteName, input.UsePalette, &color)
+			if err != nil {
+				return nil, nil, err
+			}
+
 			// Convert PointInput to aseprite.Point
 			points := make([]aseprite.Point, len(input.Points))
 			for i, p := range input.Points {
@@ -300,7 +425,10 @@ func RegisterDrawingTools(server *mcp.Server, client *aseprite.Client, gen *asep
 			}
 
 			// Generate Lua script
-			script := gen.DrawContour(input.LayerName, input.FrameNumber, points, color, input.Thickness, input.Closed, input.UsePalette)
+			script := gen.DrawContour(input.LayerName, input.FrameNumber, points, color, input.Thickness, input.Closed, usePalette)
+			if input.ClipToSelection {
+				script = gen.ClipToSelection(input.LayerName, input.FrameNumber, script)
+			}
 
 			// Execute Lua script with the sprite
 			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
@@ -350,8 +478,17 @@ func RegisterDrawingTools(server *mcp.Server, client *aseprite.Client, gen *asep
 				return nil, nil, fmt.Errorf("invalid color format: %w", err)
 			}
 
+			// Snap to bundled palette
+			usePalette, err := applyPaletteName(input.PaletteName, input.UsePalette, &color)
+			if err != nil {
+				return nil, nil, err
+			}
+
 			// Generate Lua script
-			script := gen.DrawRectangle(input.LayerName, input.FrameNumber, input.X, input.Y, input.Width, input.Height, color, input.Filled, input.UsePalette)
+			script := gen.DrawRectangle(input.LayerName, input.FrameNumber, input.X, input.Y, input.Width, input.Height, color, input.Filled, usePalette)
+			if input.ClipToSelection {
+				script = gen.ClipToSelection(input.LayerName, input.FrameNumber, script)
+			}
 
 			// Execute Lua script with the sprite
 			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
@@ -401,8 +538,17 @@ func RegisterDrawingTools(server *mcp.Server, client *aseprite.Client, gen *asep
 				return nil, nil, fmt.Errorf("invalid color format: %w", err)
 			}
 
+			// Snap to bundled palette
+			usePalette, err := applyPaletteName(input.PaletteName, input.UsePalette, &color)
+			if err != nil {
+				return nil, nil, err
+			}
+
 			// Generate Lua script
-			script := gen.DrawCircle(input.LayerName, input.FrameNumber, input.CenterX, input.CenterY, input.Radius, color, input.Filled, input.UsePalette)
+			script := gen.DrawCircle(input.LayerName, input.FrameNumber, input.CenterX, input.CenterY, input.Radius, color, input.Filled, usePalette)
+			if input.ClipToSelection {
+				script = gen.ClipToSelection(input.LayerName, input.FrameNumber, script)
+			}
 
 			// Execute Lua script with the sprite
 			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
@@ -452,8 +598,17 @@ func RegisterDrawingTools(server *mcp.Server, client *aseprite.Client, gen *asep
 				return nil, nil, fmt.Errorf("invalid color format: %w", err)
 			}
 
+			// Snap to bundled palette
+			usePalette, err := applyPaletteName(input.PaletteName, input.UsePalette, &color)
+			if err != nil {
+				return nil, nil, err
+			}
+
 			// Generate Lua script
-			script := gen.FillArea(input.LayerName, input.FrameNumber, input.X, input.Y, color, input.Tolerance, input.UsePalette)
+			script := gen.FillArea(input.LayerName, input.FrameNumber, input.X, input.Y, color, input.Tolerance, usePalette)
+			if input.ClipToSelection {
+				script = gen.ClipToSelection(input.LayerName, input.FrameNumber, script)
+			}
 
 			// Execute Lua script with the sprite
 			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
@@ -472,4 +627,339 @@ func RegisterDrawingTools(server *mcp.Server, client *aseprite.Client, gen *asep
 			return nil, &FillAreaOutput{Success: true}, nil
 		}),
 	)
+
+	// Register draw_bezier tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "draw_bezier",
+			Description: "Draw a quadratic (3 points) or cubic (4 points) Bezier curve as a pixel-perfect 1-pixel line with no doubled corners.",
+		},
+		maybeWrapWithTiming("draw_bezier", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input DrawBezierInput) (*mcp.CallToolResult, *DrawBezierOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("draw_bezier tool called", "sprite_path", input.SpritePath, "layer_name", input.LayerName, "frame_number", input.FrameNumber, "points", len(input.Points))
+
+			// Validate inputs
+			if input.LayerName == "" {
+				return nil, nil, fmt.Errorf("layer_name cannot be empty")
+			}
+
+			if input.FrameNumber < 1 {
+				return nil, nil, fmt.Errorf("frame_number must be at least 1, got %d", input.FrameNumber)
+			}
+
+			if len(input.Points) != 3 && len(input.Points) != 4 {
+				return nil, nil, fmt.Errorf("points must have 3 (quadratic) or 4 (cubic) entries, got %d", len(input.Points))
+			}
+
+			if err := validateShapePoints(input.Points); err != nil {
+				return nil, nil, err
+			}
+
+			// Parse color
+			var color aseprite.Color
+			if err := color.FromHex(input.Color); err != nil {
+				return nil, nil, fmt.Errorf("invalid color format: %w", err)
+			}
+
+			// Snap to bundled palette
+			usePalette, err := applyPaletteName(input.PaletteName, input.UsePalette, &color)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// Convert PointInput to aseprite.Point
+			points := make([]aseprite.Point, len(input.Points))
+			for i, p := range input.Points {
+				points[i] = aseprite.Point{X: p.X, Y: p.Y}
+			}
+
+			// Generate Lua script
+			script := gen.DrawBezier(input.LayerName, input.FrameNumber, points, color, usePalette)
+			if input.ClipToSelection {
+				script = gen.ClipToSelection(input.LayerName, input.FrameNumber, script)
+			}
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to draw bezier curve", "error", err)
+				return nil, nil, fmt.Errorf("failed to draw bezier curve: %w", err)
+			}
+
+			// Check for success message
+			if !strings.Contains(output, "Bezier curve drawn successfully") {
+				opLogger.Warning("Unexpected output from draw_bezier", "output", output)
+			}
+
+			opLogger.Information("Bezier curve drawn successfully", "sprite", input.SpritePath, "layer", input.LayerName, "frame", input.FrameNumber, "points", len(points))
+
+			return nil, &DrawBezierOutput{Success: true}, nil
+		}),
+	)
+
+	// Register draw_ellipse tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "draw_ellipse",
+			Description: "Draw an ellipse with independent horizontal and vertical radii, filled or as a 1-pixel outline.",
+		},
+		maybeWrapWithTiming("draw_ellipse", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input DrawEllipseInput) (*mcp.CallToolResult, *DrawEllipseOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("draw_ellipse tool called", "sprite_path", input.SpritePath, "layer_name", input.LayerName, "frame_number", input.FrameNumber, "radius_x", input.RadiusX, "radius_y", input.RadiusY, "filled", input.Filled)
+
+			// Validate inputs
+			if input.LayerName == "" {
+				return nil, nil, fmt.Errorf("layer_name cannot be empty")
+			}
+
+			if input.FrameNumber < 1 {
+				return nil, nil, fmt.Errorf("frame_number must be at least 1, got %d", input.FrameNumber)
+			}
+
+			if input.RadiusX < 1 || input.RadiusY < 1 {
+				return nil, nil, fmt.Errorf("radius_x and radius_y must be at least 1, got radius_x=%d radius_y=%d", input.RadiusX, input.RadiusY)
+			}
+
+			if input.RadiusX > maxShapeExtent || input.RadiusY > maxShapeExtent {
+				return nil, nil, fmt.Errorf("radius_x and radius_y must be at most %d, got radius_x=%d radius_y=%d", maxShapeExtent, input.RadiusX, input.RadiusY)
+			}
+
+			if input.CenterX < -maxShapeExtent || input.CenterX > maxShapeExtent || input.CenterY < -maxShapeExtent || input.CenterY > maxShapeExtent {
+				return nil, nil, fmt.Errorf("center_x and center_y must be between -%d and %d, got center_x=%d center_y=%d", maxShapeExtent, maxShapeExtent, input.CenterX, input.CenterY)
+			}
+
+			// Parse color
+			var color aseprite.Color
+			if err := color.FromHex(input.Color); err != nil {
+				return nil, nil, fmt.Errorf("invalid color format: %w", err)
+			}
+
+			// Snap to bundled palette
+			usePalette, err := applyPaletteName(input.PaletteName, input.UsePalette, &color)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// Clip the shape to the canvas
+			clip, err := spriteBounds(ctx, client, gen, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to get sprite info", "error", err)
+				return nil, nil, fmt.Errorf("failed to get sprite info: %w", err)
+			}
+
+			// Generate Lua script
+			script := gen.DrawEllipse(input.LayerName, input.FrameNumber, input.CenterX, input.CenterY, input.RadiusX, input.RadiusY, color, input.Filled, usePalette, clip)
+			if input.ClipToSelection {
+				script = gen.ClipToSelection(input.LayerName, input.FrameNumber, script)
+			}
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to draw ellipse", "error", err)
+				return nil, nil, fmt.Errorf("failed to draw ellipse: %w", err)
+			}
+
+			// Check for success message
+			if !strings.Contains(output, "Ellipse drawn successfully") {
+				opLogger.Warning("Unexpected output from draw_ellipse", "output", output)
+			}
+
+			opLogger.Information("Ellipse drawn successfully", "sprite", input.SpritePath, "layer", input.LayerName, "frame", input.FrameNumber, "radius_x", input.RadiusX, "radius_y", input.RadiusY, "filled", input.Filled)
+
+			return nil, &DrawEllipseOutput{Success: true}, nil
+		}),
+	)
+
+	// Register draw_arc tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "draw_arc",
+			Description: "Draw a 1-pixel elliptical arc between two angles. Angles are in degrees, clockwise from the right (0 = right, 90 = down); the arc wraps past 360 when end_angle is smaller than start_angle.",
+		},
+		maybeWrapWithTiming("draw_arc", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input DrawArcInput) (*mcp.CallToolResult, *DrawArcOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("draw_arc tool called", "sprite_path", input.SpritePath, "layer_name", input.LayerName, "frame_number", input.FrameNumber, "radius_x", input.RadiusX, "radius_y", input.RadiusY, "start_angle", input.StartAngle, "end_angle", input.EndAngle)
+
+			// Validate inputs
+			if input.LayerName == "" {
+				return nil, nil, fmt.Errorf("layer_name cannot be empty")
+			}
+
+			if input.FrameNumber < 1 {
+				return nil, nil, fmt.Errorf("frame_number must be at least 1, got %d", input.FrameNumber)
+			}
+
+			if input.RadiusX < 1 || input.RadiusY < 1 {
+				return nil, nil, fmt.Errorf("radius_x and radius_y must be at least 1, got radius_x=%d radius_y=%d", input.RadiusX, input.RadiusY)
+			}
+
+			if input.RadiusX > maxShapeExtent || input.RadiusY > maxShapeExtent {
+				return nil, nil, fmt.Errorf("radius_x and radius_y must be at most %d, got radius_x=%d radius_y=%d", maxShapeExtent, input.RadiusX, input.RadiusY)
+			}
+
+			if input.CenterX < -maxShapeExtent || input.CenterX > maxShapeExtent || input.CenterY < -maxShapeExtent || input.CenterY > maxShapeExtent {
+				return nil, nil, fmt.Errorf("center_x and center_y must be between -%d and %d, got center_x=%d center_y=%d", maxShapeExtent, maxShapeExtent, input.CenterX, input.CenterY)
+			}
+
+			if math.IsNaN(input.StartAngle) || math.IsInf(input.StartAngle, 0) || math.IsNaN(input.EndAngle) || math.IsInf(input.EndAngle, 0) {
+				return nil, nil, fmt.Errorf("start_angle and end_angle must be finite numbers")
+			}
+
+			// Parse color
+			var color aseprite.Color
+			if err := color.FromHex(input.Color); err != nil {
+				return nil, nil, fmt.Errorf("invalid color format: %w", err)
+			}
+
+			// Snap to bundled palette
+			usePalette, err := applyPaletteName(input.PaletteName, input.UsePalette, &color)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// Clip the shape to the canvas
+			clip, err := spriteBounds(ctx, client, gen, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to get sprite info", "error", err)
+				return nil, nil, fmt.Errorf("failed to get sprite info: %w", err)
+			}
+
+			// Generate Lua script
+			script := gen.DrawArc(input.LayerName, input.FrameNumber, input.CenterX, input.CenterY, input.RadiusX, input.RadiusY, input.StartAngle, input.EndAngle, color, usePalette, clip)
+			if input.ClipToSelection {
+				script = gen.ClipToSelection(input.LayerName, input.FrameNumber, script)
+			}
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to draw arc", "error", err)
+				return nil, nil, fmt.Errorf("failed to draw arc: %w", err)
+			}
+
+			// Check for success message
+			if !strings.Contains(output, "Arc drawn successfully") {
+				opLogger.Warning("Unexpected output from draw_arc", "output", output)
+			}
+
+			opLogger.Information("Arc drawn successfully", "sprite", input.SpritePath, "layer", input.LayerName, "frame", input.FrameNumber, "start_angle", input.StartAngle, "end_angle", input.EndAngle)
+
+			return nil, &DrawArcOutput{Success: true}, nil
+		}),
+	)
+
+	// Register draw_polygon tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "draw_polygon",
+			Description: "Draw a filled polygon from a list of points (the same points as draw_contour). Self-intersecting shapes are filled with the non_zero or even_odd rule; the edges match a closed 1-pixel contour.",
+		},
+		maybeWrapWithTiming("draw_polygon", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input DrawPolygonInput) (*mcp.CallToolResult, *DrawPolygonOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("draw_polygon tool called", "sprite_path", input.SpritePath, "layer_name", input.LayerName, "frame_number", input.FrameNumber, "points", len(input.Points), "fill_rule", input.FillRule)
+
+			// Set defaults
+			if input.FillRule == "" {
+				input.FillRule = aseprite.FillRuleNonZero
+			}
+
+			// Validate inputs
+			if input.LayerName == "" {
+				return nil, nil, fmt.Errorf("layer_name cannot be empty")
+			}
+
+			if input.FrameNumber < 1 {
+				return nil, nil, fmt.Errorf("frame_number must be at least 1, got %d", input.FrameNumber)
+			}
+
+			if len(input.Points) < 3 {
+				return nil, nil, fmt.Errorf("at least 3 points are required, got %d", len(input.Points))
+			}
+
+			if input.FillRule != aseprite.FillRuleNonZero && input.FillRule != aseprite.FillRuleEvenOdd {
+				return nil, nil, fmt.Errorf("invalid fill_rule: %s (must be non_zero or even_odd)", input.FillRule)
+			}
+
+			if err := validateShapePoints(input.Points); err != nil {
+				return nil, nil, err
+			}
+
+			// Parse color
+			var color aseprite.Color
+			if err := color.FromHex(input.Color); err != nil {
+				return nil, nil, fmt.Errorf("invalid color format: %w", err)
+			}
+
+			// Snap to bundled palette
+			usePalette, err := applyPaletteName(input.PaletteName, input.UsePalette, &color)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// Convert PointInput to aseprite.Point
+			points := make([]aseprite.Point, len(input.Points))
+			for i, p := range input.Points {
+				points[i] = aseprite.Point{X: p.X, Y: p.Y}
+			}
+
+			// Clip the shape to the canvas
+			clip, err := spriteBounds(ctx, client, gen, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to get sprite info", "error", err)
+				return nil, nil, fmt.Errorf("failed to get sprite info: %w", err)
+			}
+
+			// Generate Lua script
+			script := gen.DrawPolygon(input.LayerName, input.FrameNumber, points, color, input.FillRule, usePalette, clip)
+			if input.ClipToSelection {
+				script = gen.ClipToSelection(input.LayerName, input.FrameNumber, script)
+			}
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to draw polygon", "error", err)
+				return nil, nil, fmt.Errorf("failed to draw polygon: %w", err)
+			}
+
+			// Check for success message
+			if !strings.Contains(output, "Polygon drawn successfully") {
+				opLogger.Warning("Unexpected output from draw_polygon", "output", output)
+			}
+
+			opLogger.Information("Polygon drawn successfully", "sprite", input.SpritePath, "layer", input.LayerName, "frame", input.FrameNumber, "points", len(points), "fill_rule", input.FillRule)
+
+			return nil, &DrawPolygonOutput{Success: true}, nil
+		}),
+	)
+}
+
+// maxShapeExtent is the largest coordinate magnitude or radius accepted by the
+// shape tools, matching Aseprite's maximum canvas size.
+const maxShapeExtent = 65535
+
+// validateShapePoints checks that every point lies within maxShapeExtent of
+// the origin, so rasterizing a shape outline stays bounded.
+func validateShapePoints(points []PointInput) error {
+	for i, p := range points {
+		if p.X < -maxShapeExtent || p.X > maxShapeExtent || p.Y < -maxShapeExtent || p.Y > maxShapeExtent {
+			return fmt.Errorf("point %d (%d, %d) is outside the range -%d to %d", i+1, p.X, p.Y, maxShapeExtent, maxShapeExtent)
+		}
+	}
+	return nil
+}
+
+// spriteBounds returns the canvas rectangle of a sprite, used to clip shapes
+// before they are rasterized.
+func spriteBounds(ctx context.Context, client *aseprite.Client, gen *aseprite.LuaGenerator, spritePath string) (aseprite.Rectangle, error) {
+	info, err := getSpriteInfoHelper(ctx, client, gen, spritePath)
+	if err != nil {
+		return aseprite.Rectangle{}, err
+	}
+	return aseprite.Rectangle{X: 0, Y: 0, Width: info.Width, Height: info.Height}, nil
 }
@@ -797,3 +797,214 @@ func TestDrawContour_ViaMCP(t *testing.T) {
 	json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &output)
 	assert.True(t, output.Success)
 }
+
+func TestDrawBezier_ViaMCP(t *testing.T) {
+	_, session, client := createDrawingTestSession(t)
+	defer session.Close()
+
+	cfg := testutil.LoadTestConfig(t)
+	gen := aseprite.NewLuaGenerator()
+
+	script := gen.CreateCanvas(64, 64, aseprite.ColorModeRGB, cfg.TempDir+"/test-bezier.aseprite")
+	_, err := client.ExecuteLua(context.Background(), script, "")
+	require.NoError(t, err)
+	defer os.Remove(cfg.TempDir + "/test-bezier.aseprite")
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "draw_bezier",
+		Arguments: map[string]any{
+			"sprite_path":  cfg.TempDir + "/test-bezier.aseprite",
+			"layer_name":   "Layer 1",
+			"frame_number": 1,
+			"points": []map[string]any{
+				{"x": 4, "y": 60},
+				{"x": 10, "y": 4},
+				{"x": 54, "y": 4},
+				{"x": 60, "y": 60},
+			},
+			"color": "#FF8000",
+		},
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var output struct {
+		Success bool `json:"success"`
+	}
+	json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &output)
+	assert.True(t, output.Success)
+}
+
+func TestDrawEllipse_ViaMCP(t *testing.T) {
+	_, session, client := createDrawingTestSession(t)
+	defer session.Close()
+
+	cfg := testutil.LoadTestConfig(t)
+	gen := aseprite.NewLuaGenerator()
+
+	script := gen.CreateCanvas(64, 64, aseprite.ColorModeRGB, cfg.TempDir+"/test-ellipse.aseprite")
+	_, err := client.ExecuteLua(context.Background(), script, "")
+	require.NoError(t, err)
+	defer os.Remove(cfg.TempDir + "/test-ellipse.aseprite")
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "draw_ellipse",
+		Arguments: map[string]any{
+			"sprite_path":  cfg.TempDir + "/test-ellipse.aseprite",
+			"layer_name":   "Layer 1",
+			"frame_number": 1,
+			"center_x":     32,
+			"center_y":     32,
+			"radius_x":     20,
+			"radius_y":     8,
+			"color":        "#00FF80",
+			"filled":       true,
+		},
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var output struct {
+		Success bool `json:"success"`
+	}
+	json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &output)
+	assert.True(t, output.Success)
+}
+
+func TestDrawArc_ViaMCP(t *testing.T) {
+	_, session, client := createDrawingTestSession(t)
+	defer session.Close()
+
+	cfg := testutil.LoadTestConfig(t)
+	gen := aseprite.NewLuaGenerator()
+
+	script := gen.CreateCanvas(64, 64, aseprite.ColorModeRGB, cfg.TempDir+"/test-arc.aseprite")
+	_, err := client.ExecuteLua(context.Background(), script, "")
+	require.NoError(t, err)
+	defer os.Remove(cfg.TempDir + "/test-arc.aseprite")
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "draw_arc",
+		Arguments: map[string]any{
+			"sprite_path":  cfg.TempDir + "/test-arc.aseprite",
+			"layer_name":   "Layer 1",
+			"frame_number": 1,
+			"center_x":     32,
+			"center_y":     32,
+			"radius_x":     24,
+			"radius_y":     16,
+			"start_angle":  180,
+			"end_angle":    360,
+			"color":        "#8000FF",
+		},
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var output struct {
+		Success bool `json:"success"`
+	}
+	json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &output)
+	assert.True(t, output.Success)
+}
+
+func TestDrawPolygon_ViaMCP(t *testing.T) {
+	_, session, client := createDrawingTestSession(t)
+	defer session.Close()
+
+	cfg := testutil.LoadTestConfig(t)
+	gen := aseprite.NewLuaGenerator()
+
+	script := gen.CreateCanvas(64, 64, aseprite.ColorModeRGB, cfg.TempDir+"/test-polygon.aseprite")
+	_, err := client.ExecuteLua(context.Background(), script, "")
+	require.NoError(t, err)
+	defer os.Remove(cfg.TempDir + "/test-polygon.aseprite")
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "draw_polygon",
+		Arguments: map[string]any{
+			"sprite_path":  cfg.TempDir + "/test-polygon.aseprite",
+			"layer_name":   "Layer 1",
+			"frame_number": 1,
+			"points": []map[string]any{
+				{"x": 32, "y": 4},
+				{"x": 48, "y": 58},
+				{"x": 4, "y": 24},
+				{"x": 60, "y": 24},
+				{"x": 16, "y": 58},
+			},
+			"color":     "#FFFF00",
+			"fill_rule": "even_odd",
+		},
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var output struct {
+		Success bool `json:"success"`
+	}
+	json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &output)
+	assert.True(t, output.Success)
+}
+
+func TestDrawShapes_RejectOversized_ViaMCP(t *testing.T) {
+	_, session, _ := createDrawingTestSession(t)
+	defer session.Close()
+
+	// shapeArgs adds the arguments shared by every shape tool
+	shapeArgs := func(args map[string]any) map[string]any {
+		args["sprite_path"] = "/tmp/x.aseprite"
+		args["layer_name"] = "Layer 1"
+		args["frame_number"] = 1
+		args["color"] = "#FF0000"
+		return args
+	}
+
+	tests := []struct {
+		name   string
+		tool   string
+		args   map[string]any
+		errMsg string
+	}{
+		{
+			name:   "ellipse radius",
+			tool:   "draw_ellipse",
+			args:   shapeArgs(map[string]any{"center_x": 8, "center_y": 8, "radius_x": 1000000, "radius_y": 1000000, "filled": true}),
+			errMsg: "radius_x and radius_y must be at most 65535",
+		},
+		{
+			name:   "arc center",
+			tool:   "draw_arc",
+			args:   shapeArgs(map[string]any{"center_x": 1000000, "center_y": 8, "radius_x": 4, "radius_y": 4, "start_angle": 0, "end_angle": 90}),
+			errMsg: "center_x and center_y must be between -65535 and 65535",
+		},
+		{
+			name:   "polygon point",
+			tool:   "draw_polygon",
+			args:   shapeArgs(map[string]any{"points": []map[string]any{{"x": 0, "y": 0}, {"x": 1000000, "y": 0}, {"x": 0, "y": 1000000}}}),
+			errMsg: "point 2 (1000000, 0) is outside the range -65535 to 65535",
+		},
+		{
+			name:   "bezier point",
+			tool:   "draw_bezier",
+			args:   shapeArgs(map[string]any{"points": []map[string]any{{"x": 0, "y": 0}, {"x": 5, "y": -1000000}, {"x": 10, "y": 0}}}),
+			errMsg: "point 2 (5, -1000000) is outside the range",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+				Name:      tt.tool,
+				Arguments: tt.args,
+			})
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, tt.errMsg)
+		})
+	}
+}
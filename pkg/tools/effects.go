@@ -0,0 +1,361 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/willibrandon/mtlog/core"
+	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
+	"github.com/willibrandon/pixel-mcp/pkg/config"
+)
+
+// ApplyDropShadowInput defines the input parameters for the apply_drop_shadow tool.
+type ApplyDropShadowInput struct {
+	SpritePath  string   `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName   string   `json:"layer_name" jsonschema:"Name of the layer casting the shadow"`
+	FrameNumber int      `json:"frame_number" jsonschema:"Frame number (1-based index)"`
+	OffsetX     int      `json:"offset_x,omitempty" jsonschema:"Horizontal shadow offset in pixels, positive = right (default: 1 when both offsets are 0)"`
+	OffsetY     int      `json:"offset_y,omitempty" jsonschema:"Vertical shadow offset in pixels, positive = down (default: 1 when both offsets are 0)"`
+	Color       string   `json:"color,omitempty" jsonschema:"Shadow color in hex format #RRGGBB (default: #000000)"`
+	Opacity     *float64 `json:"opacity,omitempty" jsonschema:"Shadow opacity 0.0-1.0; with dithered it sets the dither density (default: 0.5)"`
+	Dithered    bool     `json:"dithered,omitempty" jsonschema:"Use an opaque 4x4 Bayer dither pattern instead of partial transparency (default: false)"`
+	OutputLayer string   `json:"output_layer,omitempty" jsonschema:"Write only the shadow to this layer (placed below layer_name) instead of modifying layer_name"`
+	PaletteSnap bool     `json:"palette_snap,omitempty" jsonschema:"Snap resulting colors to the sprite palette (always on for indexed sprites) (default: false)"`
+}
+
+// ApplyRimLightInput defines the input parameters for the apply_rim_light tool.
+type ApplyRimLightInput struct {
+	SpritePath     string  `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName      string  `json:"layer_name" jsonschema:"Name of the layer to light"`
+	FrameNumber    int     `json:"frame_number" jsonschema:"Frame number (1-based index)"`
+	LightDirection string  `json:"light_direction,omitempty" jsonschema:"Light direction: top_left, top, top_right, left, right, bottom_left, bottom, bottom_right (default: top_left)"`
+	Width          int     `json:"width,omitempty" jsonschema:"Rim width in pixels 1-16 (default: 1)"`
+	Color          string  `json:"color,omitempty" jsonschema:"Rim color in hex format #RRGGBB (default: #FFFFFF)"`
+	Intensity      float64 `json:"intensity,omitempty" jsonschema:"Rim strength 0.0-1.0 (default: 0.8)"`
+	OutputLayer    string  `json:"output_layer,omitempty" jsonschema:"Write only the rim to this layer instead of modifying layer_name"`
+	PaletteSnap    bool    `json:"palette_snap,omitempty" jsonschema:"Snap resulting colors to the sprite palette (always on for indexed sprites) (default: false)"`
+}
+
+// ApplyInnerGlowInput defines the input parameters for the apply_inner_glow tool.
+type ApplyInnerGlowInput struct {
+	SpritePath     string  `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName      string  `json:"layer_name" jsonschema:"Name of the layer to apply the effect to"`
+	FrameNumber    int     `json:"frame_number" jsonschema:"Frame number (1-based index)"`
+	Style          string  `json:"style,omitempty" jsonschema:"Effect style: glow (color fading inwards from edges) or bevel (lit and shaded inner rim) (default: glow)"`
+	Width          int     `json:"width,omitempty" jsonschema:"Effect width in pixels 1-16 (default: 2)"`
+	Color          string  `json:"color,omitempty" jsonschema:"Glow color in hex format #RRGGBB (default: #FFFFFF)"`
+	HighlightColor string  `json:"highlight_color,omitempty" jsonschema:"Bevel highlight color in hex format #RRGGBB (default: #FFFFFF)"`
+	ShadowColor    string  `json:"shadow_color,omitempty" jsonschema:"Bevel shadow color in hex format #RRGGBB (default: #000000)"`
+	LightDirection string  `json:"light_direction,omitempty" jsonschema:"Bevel light direction: top_left, top, top_right, left, right, bottom_left, bottom, bottom_right (default: top_left)"`
+	Intensity      float64 `json:"intensity,omitempty" jsonschema:"Effect strength 0.0-1.0 (default: 0.5)"`
+	PerRegion      bool    `json:"per_region,omitempty" jsonschema:"Treat boundaries between color regions as edges, like apply_auto_shading (default: false)"`
+	OutputLayer    string  `json:"output_layer,omitempty" jsonschema:"Write only the effect to this layer instead of modifying layer_name"`
+	PaletteSnap    bool    `json:"palette_snap,omitempty" jsonschema:"Snap resulting colors to the sprite palette (always on for indexed sprites) (default: false)"`
+}
+
+// LayerEffectOutput defines the output shared by the layer effect tools.
+type LayerEffectOutput struct {
+	Success     bool   `json:"success"`
+	OutputLayer string `json:"output_layer" jsonschema:"Layer the result was written to"`
+	PaletteSnap bool   `json:"palette_snap" jsonschema:"Whether colors were snapped to the sprite palette"`
+}
+
+// RegisterEffectTools registers all layer effect tools with the MCP server.
+func RegisterEffectTools(server *mcp.Server, client *aseprite.Client, gen *aseprite.LuaGenerator, cfg *config.Config, logger core.Logger) {
+	// Register apply_drop_shadow tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "apply_drop_shadow",
+			Description: "Add a drop shadow behind a layer's pixels. The shadow is the layer's silhouette offset by (offset_x, offset_y) in a flat color, either semi-transparent or as an opaque Bayer dither for a crisp pixel-art look. By default the shadow is merged into the layer beneath the existing pixels; with output_layer it is written to its own layer placed below the source layer.",
+		},
+		maybeWrapWithTiming("apply_drop_shadow", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input ApplyDropShadowInput) (*mcp.CallToolResult, *LayerEffectOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("apply_drop_shadow tool called",
+				"sprite", input.SpritePath,
+				"layer", input.LayerName,
+				"frame", input.FrameNumber,
+				"offset_x", input.OffsetX,
+				"offset_y", input.OffsetY,
+				"dithered", input.Dithered)
+
+			// Set defaults
+			if input.OffsetX == 0 && input.OffsetY == 0 {
+				input.OffsetX, input.OffsetY = 1, 1
+			}
+			if input.Color == "" {
+				input.Color = "#000000"
+			}
+			opacity := 0.5
+			if input.Opacity != nil {
+				opacity = *input.Opacity
+			}
+
+			// Validate inputs
+			if err := validateLayerEffectTarget(input.LayerName, input.FrameNumber); err != nil {
+				return nil, nil, err
+			}
+			if !isValidHexColor(input.Color) {
+				return nil, nil, fmt.Errorf("invalid color: %s (must be #RRGGBB)", input.Color)
+			}
+			if opacity < 0.0 || opacity > 1.0 {
+				return nil, nil, fmt.Errorf("opacity must be between 0.0 and 1.0, got %f", opacity)
+			}
+
+			shadowColor := hexToRGBA(input.Color)
+			result, err := applyLayerEffect(ctx, client, gen, input.SpritePath, input.LayerName, input.FrameNumber, input.OutputLayer, input.PaletteSnap, true,
+				func(img *image.NRGBA) (*image.NRGBA, error) {
+					return aseprite.DropShadow(img, input.OffsetX, input.OffsetY, shadowColor, opacity, input.Dithered)
+				})
+			if err != nil {
+				opLogger.Error("Failed to apply drop shadow", "error", err)
+				return nil, nil, err
+			}
+
+			opLogger.Information("Drop shadow applied successfully",
+				"sprite", input.SpritePath,
+				"layer", input.LayerName,
+				"output_layer", result.OutputLayer)
+
+			return nil, result, nil
+		}),
+	)
+
+	// Register apply_rim_light tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "apply_rim_light",
+			Description: "Add rim (edge) lighting to a layer: opaque pixels along the edges that face the light direction are tinted with the rim color, fading inwards over the given width. Respects the layer's alpha. By default the layer is modified in place; with output_layer only the rim is written to that layer.",
+		},
+		maybeWrapWithTiming("apply_rim_light", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input ApplyRimLightInput) (*mcp.CallToolResult, *LayerEffectOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("apply_rim_light tool called",
+				"sprite", input.SpritePath,
+				"layer", input.LayerName,
+				"frame", input.FrameNumber,
+				"light_direction", input.LightDirection,
+				"width", input.Width)
+
+			// Set defaults
+			if input.LightDirection == "" {
+				input.LightDirection = "top_left"
+			}
+			if input.Width == 0 {
+				input.Width = 1
+			}
+			if input.Color == "" {
+				input.Color = "#FFFFFF"
+			}
+			if input.Intensity == 0 {
+				input.Intensity = 0.8
+			}
+
+			// Validate inputs
+			if err := validateLayerEffectTarget(input.LayerName, input.FrameNumber); err != nil {
+				return nil, nil, err
+			}
+			if !isValidHexColor(input.Color) {
+				return nil, nil, fmt.Errorf("invalid color: %s (must be #RRGGBB)", input.Color)
+			}
+
+			rimColor := hexToRGBA(input.Color)
+			result, err := applyLayerEffect(ctx, client, gen, input.SpritePath, input.LayerName, input.FrameNumber, input.OutputLayer, input.PaletteSnap, false,
+				func(img *image.NRGBA) (*image.NRGBA, error) {
+					return aseprite.RimLight(img, input.LightDirection, input.Width, rimColor, input.Intensity)
+				})
+			if err != nil {
+				opLogger.Error("Failed to apply rim light", "error", err)
+				return nil, nil, err
+			}
+
+			opLogger.Information("Rim light applied successfully",
+				"sprite", input.SpritePath,
+				"layer", input.LayerName,
+				"output_layer", result.OutputLayer)
+
+			return nil, result, nil
+		}),
+	)
+
+	// Register apply_inner_glow tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "apply_inner_glow",
+			Description: "Add an inner glow or inner bevel to a layer. Glow tints pixels near the edges with a color that fades inwards; bevel lights the inner rim on the side facing the light and shades the opposite side. With per_region, boundaries between color regions (as detected by apply_auto_shading) also count as edges. Respects the layer's alpha. By default the layer is modified in place; with output_layer only the effect is written to that layer.",
+		},
+		maybeWrapWithTiming("apply_inner_glow", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input ApplyInnerGlowInput) (*mcp.CallToolResult, *LayerEffectOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("apply_inner_glow tool called",
+				"sprite", input.SpritePath,
+				"layer", input.LayerName,
+				"frame", input.FrameNumber,
+				"style", input.Style,
+				"width", input.Width)
+
+			// Set defaults
+			if input.Style == "" {
+				input.Style = "glow"
+			}
+			if input.Width == 0 {
+				input.Width = 2
+			}
+			if input.Color == "" {
+				input.Color = "#FFFFFF"
+			}
+			if input.HighlightColor == "" {
+				input.HighlightColor = "#FFFFFF"
+			}
+			if input.ShadowColor == "" {
+				input.ShadowColor = "#000000"
+			}
+			if input.LightDirection == "" {
+				input.LightDirection = "top_left"
+			}
+			if input.Intensity == 0 {
+				input.Intensity = 0.5
+			}
+
+			// Validate inputs
+			if err := validateLayerEffectTarget(input.LayerName, input.FrameNumber); err != nil {
+				return nil, nil, err
+			}
+			if input.Style != "glow" && input.Style != "bevel" {
+				return nil, nil, fmt.Errorf("invalid style: %s (must be glow or bevel)", input.Style)
+			}
+			for name, c := range map[string]string{"color": input.Color, "highlight_color": input.HighlightColor, "shadow_color": input.ShadowColor} {
+				if !isValidHexColor(c) {
+					return nil, nil, fmt.Errorf("invalid %s: %s (must be #RRGGBB)", name, c)
+				}
+			}
+
+			render := func(img *image.NRGBA) (*image.NRGBA, error) {
+				if input.Style == "bevel" {
+					return aseprite.InnerBevel(img, input.Width, input.LightDirection, hexToRGBA(input.HighlightColor), hexToRGBA(input.ShadowColor), input.Intensity, input.PerRegion)
+				}
+				return aseprite.InnerGlow(img, input.Width, hexToRGBA(input.Color), input.Intensity, input.PerRegion)
+			}
+
+			result, err := applyLayerEffect(ctx, client, gen, input.SpritePath, input.LayerName, input.FrameNumber, input.OutputLayer, input.PaletteSnap, false, render)
+			if err != nil {
+				opLogger.Error("Failed to apply inner glow", "error", err)
+				return nil, nil, err
+			}
+
+			opLogger.Information("Inner glow applied successfully",
+				"sprite", input.SpritePath,
+				"layer", input.LayerName,
+				"style", input.Style,
+				"output_layer", result.OutputLayer)
+
+			return nil, result, nil
+		}),
+	)
+}
+
+// validateLayerEffectTarget checks the layer and frame shared by all layer effect tools.
+func validateLayerEffectTarget(layerName string, frameNumber int) error {
+	if layerName == "" {
+		return fmt.Errorf("layer_name is required")
+	}
+	if frameNumber < 1 {
+		return fmt.Errorf("frame_number must be >= 1, got %d", frameNumber)
+	}
+	return nil
+}
+
+// hexToRGBA converts a validated #RRGGBB color to an opaque color.RGBA.
+func hexToRGBA(hex string) color.RGBA {
+	r, g, b, _ := parseHexColor(hex)
+	return color.RGBA{R: r, G: g, B: b, A: 255}
+}
+
+// applyLayerEffect runs a Go-side layer effect and writes the result back to the sprite.
+//
+// The layer's cel is exported onto a sprite-sized canvas so effects such as drop
+// shadows can extend past the original cel bounds. render receives that canvas
+// and returns the effect pixels only. If outputLayer is empty (or equals
+// layerName), the effect is merged into the layer: underneath the existing pixels
+// when under is true, otherwise blended onto them without changing alpha. If
+// outputLayer names another layer, only the effect is written there, and layers
+// rendered underneath are moved below layerName.
+//
+// Colors are snapped to the sprite palette when paletteSnap is set or the sprite
+// is indexed.
+func applyLayerEffect(ctx context.Context, client *aseprite.Client, gen *aseprite.LuaGenerator, spritePath, layerName string, frameNumber int, outputLayer string, paletteSnap, under bool, render func(*image.NRGBA) (*image.NRGBA, error)) (*LayerEffectOutput, error) {
+	if _, err := os.Stat(spritePath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("sprite file not found: %s", spritePath)
+	}
+
+	info, err := getSpriteInfoHelper(ctx, client, gen, spritePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sprite info: %w", err)
+	}
+
+	tempDir, err := os.MkdirTemp("", "pixel-mcp-effects-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Step 1: Export the layer onto a sprite-sized canvas
+	base, err := loadCelCanvas(ctx, client, gen, spritePath, layerName, frameNumber, info.Width, info.Height, tempDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Render the effect in Go
+	effect, err := render(base)
+	if err != nil {
+		return nil, fmt.Errorf("effect rendering failed: %w", err)
+	}
+
+	inPlace := outputLayer == "" || outputLayer == layerName
+	target := outputLayer
+	output := effect
+	if inPlace {
+		target = layerName
+		if under {
+			output = aseprite.CompositeUnder(base, effect)
+		} else if output, err = aseprite.BlendEffect(base, effect); err != nil {
+			return nil, fmt.Errorf("effect blending failed: %w", err)
+		}
+	}
+
+	// Step 3: Snap to the palette when requested or required by the color mode
+	snap := paletteSnap || info.ColorMode == "indexed"
+	if snap {
+		palette, err := loadSpritePalette(ctx, client, gen, spritePath)
+		if err != nil {
+			return nil, err
+		}
+		if err := aseprite.SnapImageToPalette(output, palette); err != nil {
+			return nil, fmt.Errorf("palette snap failed: %w", err)
+		}
+	}
+
+	// Step 4: Write the result back
+	resultPNG := filepath.Join(tempDir, "effect.png")
+	if err := savePNG(resultPNG, output); err != nil {
+		return nil, err
+	}
+
+	x, y := 0, 0
+	if _, err := client.ExecuteLua(ctx, gen.ImportImage(resultPNG, target, frameNumber, &x, &y), spritePath); err != nil {
+		return nil, fmt.Errorf("failed to write effect layer: %w", err)
+	}
+
+	if !inPlace && under {
+		if _, err := client.ExecuteLua(ctx, gen.MoveLayerBelow(target, layerName), spritePath); err != nil {
+			return nil, fmt.Errorf("failed to move effect layer: %w", err)
+		}
+	}
+
+	return &LayerEffectOutput{Success: true, OutputLayer: target, PaletteSnap: snap}, nil
+}
@@ -0,0 +1,375 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	"os"
+	"path/filepath"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/willibrandon/mtlog/core"
+	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
+	"github.com/willibrandon/pixel-mcp/pkg/config"
+)
+
+// GenerateNormalMapInput defines the input parameters for the generate_normal_map tool.
+type GenerateNormalMapInput struct {
+	SpritePath  string  `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName   string  `json:"layer_name" jsonschema:"Name of the layer to derive the normal map from"`
+	FrameNumber int     `json:"frame_number" jsonschema:"Frame number (1-based index)"`
+	Method      string  `json:"method,omitempty" jsonschema:"Height derivation: sobel (height from luminance), bevel (distance-field bevel from alpha), or dome (per-region dome) (default: sobel)"`
+	Strength    float64 `json:"strength,omitempty" jsonschema:"Slope multiplier 0.1-10.0 (default: 1.0)"`
+	Smoothing   int     `json:"smoothing,omitempty" jsonschema:"Blur radius 0-8 applied before deriving normals (default: 0)"`
+	BevelWidth  int     `json:"bevel_width,omitempty" jsonschema:"Rim width in pixels for the bevel method 1-32 (default: 3)"`
+	InvertY     bool    `json:"invert_y,omitempty" jsonschema:"Use DirectX convention (green points down) instead of OpenGL (default: false)"`
+	OutputLayer string  `json:"output_layer,omitempty" jsonschema:"Name of the layer to write the normal map to (default: '<layer_name> Normal')"`
+	OutputPath  string  `json:"output_path,omitempty" jsonschema:"If set, write the normal map to this PNG file (sprite-sized) instead of a layer"`
+}
+
+// GenerateNormalMapOutput defines the output for the generate_normal_map tool.
+type GenerateNormalMapOutput struct {
+	Success     bool   `json:"success"`
+	Method      string `json:"method" jsonschema:"Method used to derive the normal map"`
+	OutputLayer string `json:"output_layer,omitempty" jsonschema:"Layer the normal map was written to"`
+	OutputPath  string `json:"output_path,omitempty" jsonschema:"PNG file the normal map was written to"`
+}
+
+// LightInput describes a single light for the render_lit tool.
+type LightInput struct {
+	Type      string   `json:"type" jsonschema:"Light type: point or directional"`
+	X         float64  `json:"x,omitempty" jsonschema:"Point light X position in sprite pixels"`
+	Y         float64  `json:"y,omitempty" jsonschema:"Point light Y position in sprite pixels"`
+	Height    *float64 `json:"height,omitempty" jsonschema:"Point light height above the sprite in pixels (default: 16)"`
+	Direction string   `json:"direction,omitempty" jsonschema:"Directional light direction: top_left, top, top_right, left, right, bottom_left, bottom, bottom_right (default: top_left)"`
+	Color     string   `json:"color,omitempty" jsonschema:"Light color in hex format #RRGGBB (default: #FFFFFF)"`
+	Intensity float64  `json:"intensity,omitempty" jsonschema:"Brightness multiplier 0.0-10.0 (default: 1.0)"`
+	Falloff   float64  `json:"falloff,omitempty" jsonschema:"Point light radius in pixels where light fades to zero; 0 disables attenuation (default: 0)"`
+}
+
+// RenderLitInput defines the input parameters for the render_lit tool.
+type RenderLitInput struct {
+	SpritePath       string       `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	ColorLayer       string       `json:"color_layer" jsonschema:"Name of the layer holding the unlit colors"`
+	NormalLayer      string       `json:"normal_layer" jsonschema:"Name of the layer holding the normal map (e.g. from generate_normal_map)"`
+	FrameNumber      int          `json:"frame_number" jsonschema:"Frame number (1-based index)"`
+	Lights           []LightInput `json:"lights" jsonschema:"Lights to render (at least one)"`
+	AmbientColor     string       `json:"ambient_color,omitempty" jsonschema:"Ambient light color in hex format #RRGGBB (default: #FFFFFF)"`
+	AmbientIntensity *float64     `json:"ambient_intensity,omitempty" jsonschema:"Ambient light strength 0.0-1.0 (default: 0.25)"`
+	InvertY          bool         `json:"invert_y,omitempty" jsonschema:"Normal map uses DirectX convention (green points down) (default: false)"`
+	PaletteSnap      bool         `json:"palette_snap,omitempty" jsonschema:"Snap lit colors to the sprite palette (always on for indexed sprites) (default: false)"`
+	OutputLayer      string       `json:"output_layer,omitempty" jsonschema:"Name of the layer to write the lit result to (default: 'Lit')"`
+	OutputPath       string       `json:"output_path,omitempty" jsonschema:"If set, write the lit result to this PNG file instead of a layer"`
+}
+
+// RenderLitOutput defines the output for the render_lit tool.
+type RenderLitOutput struct {
+	Success     bool   `json:"success"`
+	LightCount  int    `json:"light_count" jsonschema:"Number of lights rendered"`
+	PaletteSnap bool   `json:"palette_snap" jsonschema:"Whether colors were snapped to the sprite palette"`
+	OutputLayer string `json:"output_layer,omitempty" jsonschema:"Layer the lit result was written to"`
+	OutputPath  string `json:"output_path,omitempty" jsonschema:"PNG file the lit result was written to"`
+}
+
+// RegisterLightingTools registers all normal map and lighting tools with the MCP server.
+func RegisterLightingTools(server *mcp.Server, client *aseprite.Client, gen *aseprite.LuaGenerator, cfg *config.Config, logger core.Logger) {
+	// Register generate_normal_map tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "generate_normal_map",
+			Description: "Derive a tangent-space normal map from a layer for 2D dynamic lighting. Methods: sobel (height from luminance), bevel (distance-field bevel from the alpha channel), dome (one rounded dome per color region, like apply_auto_shading's region detection). Strength exaggerates relief and smoothing blurs the height field. The result is written to a new RGB layer (default '<layer> Normal') or to a sprite-sized companion PNG file.",
+		},
+		maybeWrapWithTiming("generate_normal_map", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input GenerateNormalMapInput) (*mcp.CallToolResult, *GenerateNormalMapOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("generate_normal_map tool called",
+				"sprite", input.SpritePath,
+				"layer", input.LayerName,
+				"frame", input.FrameNumber,
+				"method", input.Method,
+				"strength", input.Strength)
+
+			// Set defaults
+			if input.Method == "" {
+				input.Method = "sobel"
+			}
+			if input.Strength == 0 {
+				input.Strength = 1.0
+			}
+			if input.BevelWidth == 0 {
+				input.BevelWidth = 3
+			}
+			if input.OutputLayer == "" {
+				input.OutputLayer = input.LayerName + " Normal"
+			}
+
+			// Validate inputs
+			if input.LayerName == "" {
+				return nil, nil, fmt.Errorf("layer_name is required")
+			}
+			if input.FrameNumber < 1 {
+				return nil, nil, fmt.Errorf("frame_number must be >= 1, got %d", input.FrameNumber)
+			}
+			validMethods := map[string]bool{"sobel": true, "bevel": true, "dome": true}
+			if !validMethods[input.Method] {
+				return nil, nil, fmt.Errorf("invalid method: %s (must be sobel, bevel, or dome)", input.Method)
+			}
+			if input.Strength < 0.1 || input.Strength > 10.0 {
+				return nil, nil, fmt.Errorf("strength must be between 0.1 and 10.0, got %f", input.Strength)
+			}
+			if input.Smoothing < 0 || input.Smoothing > 8 {
+				return nil, nil, fmt.Errorf("smoothing must be between 0 and 8, got %d", input.Smoothing)
+			}
+			if input.BevelWidth < 1 || input.BevelWidth > 32 {
+				return nil, nil, fmt.Errorf("bevel_width must be between 1 and 32, got %d", input.BevelWidth)
+			}
+			if input.OutputPath == "" && input.OutputLayer == input.LayerName {
+				return nil, nil, fmt.Errorf("output_layer must differ from layer_name")
+			}
+
+			// Check sprite file exists
+			if _, err := os.Stat(input.SpritePath); os.IsNotExist(err) {
+				return nil, nil, fmt.Errorf("sprite file not found: %s", input.SpritePath)
+			}
+
+			info, err := getSpriteInfoHelper(ctx, client, gen, input.SpritePath)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get sprite info: %w", err)
+			}
+			if input.OutputPath == "" && info.ColorMode != "rgb" {
+				return nil, nil, fmt.Errorf("normal map layers require an RGB sprite (sprite is %s); use output_path to write a companion file instead", info.ColorMode)
+			}
+
+			tempDir, err := os.MkdirTemp("", "pixel-mcp-normals-*")
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create temp directory: %w", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			// Step 1: Export the source cel
+			img, celPos, err := loadCelImage(ctx, client, gen, input.SpritePath, input.LayerName, input.FrameNumber, tempDir)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// Step 2: Derive the normal map in Go
+			normalMap, err := aseprite.GenerateNormalMap(img, input.Method, input.Strength, input.Smoothing, input.BevelWidth, input.InvertY)
+			if err != nil {
+				return nil, nil, fmt.Errorf("normal map generation failed: %w", err)
+			}
+
+			result := &GenerateNormalMapOutput{Success: true, Method: input.Method}
+
+			// Step 3: Write the result to a companion file or a new layer
+			if input.OutputPath != "" {
+				canvas := image.NewRGBA(image.Rect(0, 0, info.Width, info.Height))
+				offset := image.Pt(celPos.X, celPos.Y)
+				draw.Draw(canvas, normalMap.Bounds().Add(offset), normalMap, image.Point{}, draw.Src)
+
+				if err := savePNG(input.OutputPath, canvas); err != nil {
+					return nil, nil, fmt.Errorf("failed to write normal map: %w", err)
+				}
+				result.OutputPath = input.OutputPath
+			} else {
+				normalPNG := filepath.Join(tempDir, "normal.png")
+				if err := savePNG(normalPNG, normalMap); err != nil {
+					return nil, nil, err
+				}
+
+				script := gen.ImportImage(normalPNG, input.OutputLayer, input.FrameNumber, &celPos.X, &celPos.Y)
+				if _, err := client.ExecuteLua(ctx, script, input.SpritePath); err != nil {
+					opLogger.Error("Failed to write normal map layer", "error", err)
+					return nil, nil, fmt.Errorf("failed to write normal map layer: %w", err)
+				}
+				result.OutputLayer = input.OutputLayer
+			}
+
+			opLogger.Information("Normal map generated successfully",
+				"sprite", input.SpritePath,
+				"layer", input.LayerName,
+				"method", input.Method,
+				"output_layer", result.OutputLayer,
+				"output_path", result.OutputPath)
+
+			return nil, result, nil
+		}),
+	)
+
+	// Register render_lit tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "render_lit",
+			Description: "Preview a sprite under 2D dynamic lighting. Combines a color layer with a normal map layer (see generate_normal_map) and one or more point or directional lights (position, color, intensity, falloff) using Lambertian shading plus ambient light. Optionally snaps the lit colors to the sprite palette. The composite is written to a new layer (default 'Lit') or to a PNG file.",
+		},
+		maybeWrapWithTiming("render_lit", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input RenderLitInput) (*mcp.CallToolResult, *RenderLitOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("render_lit tool called",
+				"sprite", input.SpritePath,
+				"color_layer", input.ColorLayer,
+				"normal_layer", input.NormalLayer,
+				"frame", input.FrameNumber,
+				"lights", len(input.Lights))
+
+			// Set defaults
+			if input.AmbientColor == "" {
+				input.AmbientColor = "#FFFFFF"
+			}
+			ambientIntensity := 0.25
+			if input.AmbientIntensity != nil {
+				ambientIntensity = *input.AmbientIntensity
+			}
+			if input.OutputLayer == "" {
+				input.OutputLayer = "Lit"
+			}
+
+			// Validate inputs
+			if input.ColorLayer == "" {
+				return nil, nil, fmt.Errorf("color_layer is required")
+			}
+			if input.NormalLayer == "" {
+				return nil, nil, fmt.Errorf("normal_layer is required")
+			}
+			if input.FrameNumber < 1 {
+				return nil, nil, fmt.Errorf("frame_number must be >= 1, got %d", input.FrameNumber)
+			}
+			if len(input.Lights) == 0 {
+				return nil, nil, fmt.Errorf("at least one light is required")
+			}
+			if !isValidHexColor(input.AmbientColor) {
+				return nil, nil, fmt.Errorf("invalid ambient_color: %s (must be #RRGGBB)", input.AmbientColor)
+			}
+			if input.OutputPath == "" && (input.OutputLayer == input.ColorLayer || input.OutputLayer == input.NormalLayer) {
+				return nil, nil, fmt.Errorf("output_layer must differ from color_layer and normal_layer")
+			}
+
+			lights, err := buildLights(input.Lights)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// Check sprite file exists
+			if _, err := os.Stat(input.SpritePath); os.IsNotExist(err) {
+				return nil, nil, fmt.Errorf("sprite file not found: %s", input.SpritePath)
+			}
+
+			info, err := getSpriteInfoHelper(ctx, client, gen, input.SpritePath)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get sprite info: %w", err)
+			}
+
+			tempDir, err := os.MkdirTemp("", "pixel-mcp-lit-*")
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create temp directory: %w", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			// Step 1: Export both cels onto sprite-sized canvases so they line up
+			albedo, err := loadCelCanvas(ctx, client, gen, input.SpritePath, input.ColorLayer, input.FrameNumber, info.Width, info.Height, tempDir)
+			if err != nil {
+				return nil, nil, err
+			}
+			normals, err := loadCelCanvas(ctx, client, gen, input.SpritePath, input.NormalLayer, input.FrameNumber, info.Width, info.Height, tempDir)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// Step 2: Light the sprite in Go
+			lit, err := aseprite.RenderLit(albedo, normals, lights, hexToRGBA(input.AmbientColor), ambientIntensity, input.InvertY)
+			if err != nil {
+				return nil, nil, fmt.Errorf("lighting failed: %w", err)
+			}
+
+			// Step 3: Snap to the palette when requested or required by the color mode
+			snap := input.PaletteSnap || info.ColorMode == "indexed"
+			if snap {
+				palette, err := loadSpritePalette(ctx, client, gen, input.SpritePath)
+				if err != nil {
+					return nil, nil, err
+				}
+				if err := aseprite.SnapImageToPalette(lit, palette); err != nil {
+					return nil, nil, fmt.Errorf("palette snap failed: %w", err)
+				}
+			}
+
+			result := &RenderLitOutput{Success: true, LightCount: len(lights), PaletteSnap: snap}
+
+			// Step 4: Write the result to a file or a new layer
+			if input.OutputPath != "" {
+				if err := savePNG(input.OutputPath, lit); err != nil {
+					return nil, nil, fmt.Errorf("failed to write lit image: %w", err)
+				}
+				result.OutputPath = input.OutputPath
+			} else {
+				litPNG := filepath.Join(tempDir, "lit.png")
+				if err := savePNG(litPNG, lit); err != nil {
+					return nil, nil, err
+				}
+
+				x, y := 0, 0
+				script := gen.ImportImage(litPNG, input.OutputLayer, input.FrameNumber, &x, &y)
+				if _, err := client.ExecuteLua(ctx, script, input.SpritePath); err != nil {
+					opLogger.Error("Failed to write lit layer", "error", err)
+					return nil, nil, fmt.Errorf("failed to write lit layer: %w", err)
+				}
+				result.OutputLayer = input.OutputLayer
+			}
+
+			opLogger.Information("Lit render completed successfully",
+				"sprite", input.SpritePath,
+				"lights", len(lights),
+				"palette_snap", snap,
+				"output_layer", result.OutputLayer,
+				"output_path", result.OutputPath)
+
+			return nil, result, nil
+		}),
+	)
+}
+
+// buildLights validates light inputs, applies defaults, and converts them to aseprite.Light.
+func buildLights(inputs []LightInput) ([]aseprite.Light, error) {
+	lights := make([]aseprite.Light, len(inputs))
+	for i, in := range inputs {
+		if in.Color == "" {
+			in.Color = "#FFFFFF"
+		}
+		if in.Intensity == 0 {
+			in.Intensity = 1.0
+		}
+		if in.Direction == "" {
+			in.Direction = "top_left"
+		}
+		height := 16.0
+		if in.Height != nil {
+			height = *in.Height
+		}
+
+		if in.Type != "point" && in.Type != "directional" {
+			return nil, fmt.Errorf("lights[%d]: invalid type: %s (must be point or directional)", i, in.Type)
+		}
+		if !isValidHexColor(in.Color) {
+			return nil, fmt.Errorf("lights[%d]: invalid color: %s (must be #RRGGBB)", i, in.Color)
+		}
+		if in.Intensity < 0 || in.Intensity > 10.0 {
+			return nil, fmt.Errorf("lights[%d]: intensity must be between 0.0 and 10.0, got %f", i, in.Intensity)
+		}
+		if in.Falloff < 0 {
+			return nil, fmt.Errorf("lights[%d]: falloff must be >= 0, got %f", i, in.Falloff)
+		}
+		if height <= 0 {
+			return nil, fmt.Errorf("lights[%d]: height must be > 0, got %f", i, height)
+		}
+
+		lights[i] = aseprite.Light{
+			Type:      in.Type,
+			Position:  aseprite.Vector3D{X: in.X, Y: in.Y, Z: height},
+			Direction: in.Direction,
+			Color:     hexToRGBA(in.Color),
+			Intensity: in.Intensity,
+			Falloff:   in.Falloff,
+		}
+	}
+	return lights, nil
+}
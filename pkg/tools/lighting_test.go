@@ -0,0 +1,49 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildLights_Defaults(t *testing.T) {
+	lights, err := buildLights([]LightInput{
+		{Type: "point", X: 4, Y: 6},
+		{Type: "directional"},
+	})
+	require.NoError(t, err)
+	require.Len(t, lights, 2)
+
+	assert.Equal(t, 4.0, lights[0].Position.X)
+	assert.Equal(t, 6.0, lights[0].Position.Y)
+	assert.Equal(t, 16.0, lights[0].Position.Z)
+	assert.Equal(t, 1.0, lights[0].Intensity)
+	assert.Equal(t, uint8(255), lights[0].Color.R)
+	assert.Equal(t, uint8(255), lights[0].Color.G)
+	assert.Equal(t, uint8(255), lights[0].Color.B)
+
+	assert.Equal(t, "top_left", lights[1].Direction)
+}
+
+func TestBuildLights_Invalid(t *testing.T) {
+	zero := 0.0
+
+	tests := []struct {
+		name  string
+		light LightInput
+	}{
+		{name: "unknown type", light: LightInput{Type: "spot"}},
+		{name: "bad color", light: LightInput{Type: "point", Color: "red"}},
+		{name: "intensity too high", light: LightInput{Type: "point", Intensity: 11}},
+		{name: "negative falloff", light: LightInput{Type: "point", Falloff: -2}},
+		{name: "zero height", light: LightInput{Type: "point", Height: &zero}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildLights([]LightInput{tt.light})
+			assert.Error(t, err)
+		})
+	}
+}
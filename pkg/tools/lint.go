@@ -0,0 +1,193 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"os"
+	"slices"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/willibrandon/mtlog/core"
+	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
+	"github.com/willibrandon/pixel-mcp/pkg/config"
+)
+
+// LintPixelArtInput defines the input parameters for the lint_pixel_art tool.
+type LintPixelArtInput struct {
+	SpritePath      string   `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName       string   `json:"layer_name" jsonschema:"Name of the layer to analyze"`
+	FrameNumber     int      `json:"frame_number" jsonschema:"Frame number to analyze (1-based)"`
+	Checks          []string `json:"checks,omitempty" jsonschema:"Checks to run: orphan_pixels, jaggies, doubles, banding, pillow_shading, off_palette, near_duplicate_colors, low_contrast (default: all)"`
+	NearDuplicateDE float64  `json:"near_duplicate_delta_e,omitempty" jsonschema:"CIE76 ΔE below which two used colors count as near-duplicates 0-100 (default: 3.0)"`
+	MinContrast     int      `json:"min_contrast,omitempty" jsonschema:"Minimum luminance difference 0-255 between adjacent color regions (default: 12)"`
+	MaxIssues       int      `json:"max_issues,omitempty" jsonschema:"Maximum number of issues to return; counts always cover all issues (default: 100)"`
+}
+
+// LintPixelArtOutput defines the output for the lint_pixel_art tool.
+type LintPixelArtOutput struct {
+	Issues       []aseprite.LintIssue `json:"issues" jsonschema:"Issues sorted by severity then position"`
+	CheckCounts  map[string]int       `json:"check_counts" jsonschema:"Number of issues found per check"`
+	ErrorCount   int                  `json:"error_count" jsonschema:"Number of error-level issues"`
+	WarningCount int                  `json:"warning_count" jsonschema:"Number of warning-level issues"`
+	InfoCount    int                  `json:"info_count" jsonschema:"Number of info-level issues"`
+	Truncated    bool                 `json:"truncated" jsonschema:"True if more issues were found than max_issues"`
+}
+
+// RegisterLintTools registers the pixel art lint tool with the MCP server.
+func RegisterLintTools(server *mcp.Server, client *aseprite.Client, gen *aseprite.LuaGenerator, cfg *config.Config, logger core.Logger) {
+	// Register lint_pixel_art tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "lint_pixel_art",
+			Description: "Review a layer/frame for common pixel art problems and report each issue with coordinates and severity (error, warning, info). Checks: orphan_pixels (stray or isolated pixels), jaggies (uneven line segments and stair-steps that could be antialiased), doubles (redundant corner pixels in 1px lines), banding (1px strips of ramp colors), pillow_shading (shading that darkens evenly toward every edge), off_palette (colors missing from the sprite palette), near_duplicate_colors, and low_contrast (adjacent regions that blend together). Use it to self-review artwork before export.",
+		},
+		maybeWrapWithTiming("lint_pixel_art", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input LintPixelArtInput) (*mcp.CallToolResult, *LintPixelArtOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("lint_pixel_art tool called",
+				"sprite", input.SpritePath,
+				"layer", input.LayerName,
+				"frame", input.FrameNumber,
+				"checks", input.Checks)
+
+			// Set defaults
+			if len(input.Checks) == 0 {
+				input.Checks = aseprite.AllLintChecks
+			}
+			if input.MaxIssues == 0 {
+				input.MaxIssues = 100
+			}
+
+			// Validate inputs
+			if input.LayerName == "" {
+				return nil, nil, fmt.Errorf("layer_name is required")
+			}
+			if input.FrameNumber < 1 {
+				return nil, nil, fmt.Errorf("frame_number must be >= 1, got %d", input.FrameNumber)
+			}
+			for _, check := range input.Checks {
+				if !slices.Contains(aseprite.AllLintChecks, check) {
+					return nil, nil, fmt.Errorf("invalid check: %s", check)
+				}
+			}
+			if input.MaxIssues < 1 {
+				return nil, nil, fmt.Errorf("max_issues must be >= 1, got %d", input.MaxIssues)
+			}
+
+			// Check sprite file exists
+			if _, err := os.Stat(input.SpritePath); os.IsNotExist(err) {
+				return nil, nil, fmt.Errorf("sprite file not found: %s", input.SpritePath)
+			}
+
+			info, err := getSpriteInfoHelper(ctx, client, gen, input.SpritePath)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get sprite info: %w", err)
+			}
+
+			tempDir, err := os.MkdirTemp("", "pixel-mcp-lint-*")
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create temp directory: %w", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			img, err := loadCelCanvas(ctx, client, gen, input.SpritePath, input.LayerName, input.FrameNumber, info.Width, info.Height, tempDir)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			opts := aseprite.LintOptions{
+				Checks:          input.Checks,
+				NearDuplicateDE: input.NearDuplicateDE,
+				MinContrast:     input.MinContrast,
+			}
+			if slices.Contains(input.Checks, aseprite.LintOffPalette) {
+				if opts.Palette, err = loadSpritePalette(ctx, client, gen, input.SpritePath); err != nil {
+					return nil, nil, err
+				}
+			}
+
+			issues, err := aseprite.LintPixelArt(img, opts)
+			if err != nil {
+				return nil, nil, fmt.Errorf("lint failed: %w", err)
+			}
+
+			// Stair-step edges from the antialiasing analysis count as jaggies
+			if slices.Contains(input.Checks, aseprite.LintJaggies) {
+				issues = append(issues, stairStepIssues(img)...)
+				aseprite.SortLintIssues(issues)
+			}
+
+			result := summarizeLintIssues(issues, input.MaxIssues)
+
+			opLogger.Information("Pixel art lint completed",
+				"sprite", input.SpritePath,
+				"layer", input.LayerName,
+				"errors", result.ErrorCount,
+				"warnings", result.WarningCount,
+				"info", result.InfoCount)
+
+			return nil, result, nil
+		}),
+	)
+}
+
+// stairStepIssues reports diagonal stair-steps found by the antialiasing
+// analysis (detectJaggedEdges) as info-level jaggies with a suggested color.
+func stairStepIssues(img *image.NRGBA) []aseprite.LintIssue {
+	bounds := img.Bounds()
+	grid := make(map[int]map[int]string)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		grid[y] = make(map[int]string)
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := img.NRGBAAt(x, y)
+			grid[y][x] = fmt.Sprintf("#%02X%02X%02X%02X", c.R, c.G, c.B, c.A)
+		}
+	}
+
+	region := Region{X: bounds.Min.X, Y: bounds.Min.Y, Width: bounds.Dx(), Height: bounds.Dy()}
+	suggestions := detectJaggedEdges(grid, region, 128, false)
+
+	issues := make([]aseprite.LintIssue, len(suggestions))
+	for i, s := range suggestions {
+		issues[i] = aseprite.LintIssue{
+			Check:    aseprite.LintJaggies,
+			Severity: aseprite.LintSeverityInfo,
+			X:        s.X,
+			Y:        s.Y,
+			Message:  fmt.Sprintf("stair-step edge (%s); could be smoothed with an intermediate color", s.Direction),
+			Colors:   []string{s.NeighborColor[:7], s.SuggestedColor[:7]},
+		}
+	}
+	return issues
+}
+
+// summarizeLintIssues counts issues by check and severity and truncates the list.
+func summarizeLintIssues(issues []aseprite.LintIssue, maxIssues int) *LintPixelArtOutput {
+	result := &LintPixelArtOutput{
+		Issues:      issues,
+		CheckCounts: make(map[string]int),
+	}
+	if result.Issues == nil {
+		result.Issues = []aseprite.LintIssue{}
+	}
+
+	for _, issue := range issues {
+		result.CheckCounts[issue.Check]++
+		switch issue.Severity {
+		case aseprite.LintSeverityError:
+			result.ErrorCount++
+		case aseprite.LintSeverityWarning:
+			result.WarningCount++
+		default:
+			result.InfoCount++
+		}
+	}
+
+	if len(result.Issues) > maxIssues {
+		result.Issues = result.Issues[:maxIssues]
+		result.Truncated = true
+	}
+
+	return result
+}
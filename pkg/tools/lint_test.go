@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
+)
+
+func TestSummarizeLintIssues(t *testing.T) {
+	issues := []aseprite.LintIssue{
+		{Check: aseprite.LintOffPalette, Severity: aseprite.LintSeverityError},
+		{Check: aseprite.LintDoubles, Severity: aseprite.LintSeverityWarning},
+		{Check: aseprite.LintDoubles, Severity: aseprite.LintSeverityWarning},
+		{Check: aseprite.LintNearDuplicates, Severity: aseprite.LintSeverityInfo},
+	}
+
+	result := summarizeLintIssues(issues, 2)
+
+	assert.Len(t, result.Issues, 2)
+	assert.True(t, result.Truncated)
+	assert.Equal(t, 1, result.ErrorCount)
+	assert.Equal(t, 2, result.WarningCount)
+	assert.Equal(t, 1, result.InfoCount)
+	assert.Equal(t, 2, result.CheckCounts[aseprite.LintDoubles])
+
+	empty := summarizeLintIssues(nil, 10)
+	assert.NotNil(t, empty.Issues)
+	assert.False(t, empty.Truncated)
+}
+
+func TestStairStepIssues(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	red := color.NRGBA{R: 255, A: 255}
+	// Pattern: ##
+	//          .#
+	img.SetNRGBA(1, 1, red)
+	img.SetNRGBA(2, 1, red)
+	img.SetNRGBA(2, 2, red)
+
+	issues := stairStepIssues(img)
+	require.NotEmpty(t, issues)
+
+	found := false
+	for _, issue := range issues {
+		assert.Equal(t, aseprite.LintJaggies, issue.Check)
+		assert.Equal(t, aseprite.LintSeverityInfo, issue.Severity)
+		if issue.X == 1 && issue.Y == 2 {
+			found = true
+			assert.Equal(t, "#FF0000", issue.Colors[0])
+		}
+	}
+	assert.True(t, found, "expected stair-step suggestion at (1,2), got %+v", issues)
+}
@@ -0,0 +1,134 @@
+package tools
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"path/filepath"
+
+	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
+)
+
+// onionSkinFrames picks the neighbors of frame shown by render_onion_skin,
+// nearest first. Without loop the neighbors stop at the first and last
+// frames. With loop they wrap around the animation, and a frame that is
+// reachable from both sides is shown only once, on the side where it is
+// nearer (previous on ties).
+func onionSkinFrames(frame, frameCount, before, after int, loop bool) ([]int, []int) {
+	var previous, next []int
+	used := map[int]bool{frame: true}
+
+	// neighbor returns the frame at offset from the current frame, or 0 if
+	// there is none or it is already shown
+	neighbor := func(offset int) int {
+		f := frame + offset
+		if f < 1 || f > frameCount {
+			if !loop {
+				return 0
+			}
+			f = ((f-1)%frameCount+frameCount)%frameCount + 1
+		}
+		if used[f] {
+			return 0
+		}
+		used[f] = true
+		return f
+	}
+
+	for d := 1; d <= max(before, after); d++ {
+		if d <= before {
+			if f := neighbor(-d); f != 0 {
+				previous = append(previous, f)
+			}
+		}
+		if d <= after {
+			if f := neighbor(d); f != 0 {
+				next = append(next, f)
+			}
+		}
+	}
+
+	return previous, next
+}
+
+// renderOnionSkin loads the frame images written by ExportFrameImages into
+// tempDir and composites the onion skin preview of frame. The k-th previous
+// and next frames get opacity aseprite.OnionSkinOpacity(opacity, falloff, k)
+// and are drawn farthest first, so nearer frames cover farther ones.
+func renderOnionSkin(tempDir string, frame int, previous, next []int, beforeTint, afterTint aseprite.Color, tintStrength, opacity, falloff float64) (*image.NRGBA, error) {
+	load := func(f int) (image.Image, error) {
+		return loadPNG(filepath.Join(tempDir, fmt.Sprintf("frame-%d.png", f)))
+	}
+
+	current, err := load(frame)
+	if err != nil {
+		return nil, err
+	}
+
+	var neighbors []aseprite.OnionSkinFrame
+	for k := max(len(previous), len(next)); k >= 1; k-- {
+		for _, side := range []struct {
+			frames []int
+			tint   aseprite.Color
+		}{{previous, beforeTint}, {next, afterTint}} {
+			if k > len(side.frames) {
+				continue
+			}
+
+			img, err := load(side.frames[k-1])
+			if err != nil {
+				return nil, err
+			}
+
+			neighbors = append(neighbors, aseprite.OnionSkinFrame{
+				Image:   img,
+				Tint:    color.RGBA{R: side.tint.R, G: side.tint.G, B: side.tint.B, A: 255},
+				Opacity: aseprite.OnionSkinOpacity(opacity, falloff, k),
+			})
+		}
+	}
+
+	return aseprite.OnionSkin(current, neighbors, tintStrength)
+}
+
+// maxOnionSkinPreviewSize is the largest width or height of an upscaled onion
+// skin preview, which bounds the image and its PNG encoding in memory.
+const maxOnionSkinPreviewSize = 4096
+
+// checkOnionSkinScale returns an error when upscaling a width x height frame
+// by scale would make the preview wider or taller than maxOnionSkinPreviewSize.
+// Unscaled previews are always allowed; they are no larger than the sprite.
+func checkOnionSkinScale(width, height, scale int) error {
+	if scale > 1 && (width*scale > maxOnionSkinPreviewSize || height*scale > maxOnionSkinPreviewSize) {
+		return fmt.Errorf("scale %d would make the preview %dx%d, larger than the %dx%d maximum", scale, width*scale, height*scale, maxOnionSkinPreviewSize, maxOnionSkinPreviewSize)
+	}
+	return nil
+}
+
+// scaleNearest upscales an image by an integer factor with nearest neighbor
+// sampling so pixel art previews stay crisp.
+func scaleNearest(src *image.NRGBA, factor int) *image.NRGBA {
+	if factor == 1 {
+		return src
+	}
+
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, w*factor, h*factor))
+	for y := 0; y < h*factor; y++ {
+		for x := 0; x < w*factor; x++ {
+			dst.SetNRGBA(x, y, src.NRGBAAt(src.Rect.Min.X+x/factor, src.Rect.Min.Y+y/factor))
+		}
+	}
+	return dst
+}
+
+// encodePNG encodes an image as PNG bytes for image content results.
+func encodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode PNG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
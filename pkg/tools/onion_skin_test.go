@@ -0,0 +1,88 @@
+package tools
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
+)
+
+func TestOnionSkinFrames(t *testing.T) {
+	tests := []struct {
+		name               string
+		frame, count       int
+		before, after      int
+		loop               bool
+		wantPrev, wantNext []int
+	}{
+		{name: "middle", frame: 5, count: 10, before: 2, after: 3, wantPrev: []int{4, 3}, wantNext: []int{6, 7, 8}},
+		{name: "clipped at start", frame: 1, count: 10, before: 2, after: 1, wantNext: []int{2}},
+		{name: "clipped at end", frame: 10, count: 10, before: 1, after: 2, wantPrev: []int{9}},
+		{name: "loop wraps", frame: 1, count: 10, before: 2, after: 1, loop: true, wantPrev: []int{10, 9}, wantNext: []int{2}},
+		{name: "loop shows each frame once", frame: 1, count: 4, before: 3, after: 3, loop: true, wantPrev: []int{4, 3}, wantNext: []int{2}},
+		{name: "single frame", frame: 1, count: 1, before: 2, after: 2, loop: true},
+		{name: "none", frame: 3, count: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev, next := onionSkinFrames(tt.frame, tt.count, tt.before, tt.after, tt.loop)
+			assert.Equal(t, tt.wantPrev, prev)
+			assert.Equal(t, tt.wantNext, next)
+		})
+	}
+}
+
+func TestRenderOnionSkin(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// Frame N has a single white pixel at x = N-1
+	for f := 1; f <= 4; f++ {
+		img := image.NewNRGBA(image.Rect(0, 0, 4, 1))
+		img.SetNRGBA(f-1, 0, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+		require.NoError(t, savePNG(filepath.Join(tempDir, fmt.Sprintf("frame-%d.png", f)), img))
+	}
+
+	red := aseprite.Color{R: 255, A: 255}
+	blue := aseprite.Color{B: 255, A: 255}
+	preview, err := renderOnionSkin(tempDir, 2, []int{1}, []int{3, 4}, red, blue, 1, 0.8, 0.5)
+	require.NoError(t, err)
+
+	assert.Equal(t, color.NRGBA{R: 255, A: 204}, preview.NRGBAAt(0, 0), "previous frame fully tinted red")
+	assert.Equal(t, color.NRGBA{R: 255, G: 255, B: 255, A: 255}, preview.NRGBAAt(1, 0), "current frame on top")
+	assert.Equal(t, color.NRGBA{B: 255, A: 204}, preview.NRGBAAt(2, 0), "nearest next frame at the given opacity")
+	assert.Equal(t, color.NRGBA{B: 255, A: 102}, preview.NRGBAAt(3, 0), "second next frame faded by falloff")
+
+	_, err = renderOnionSkin(tempDir, 5, nil, nil, red, blue, 1, 0.8, 0.5)
+	assert.Error(t, err, "missing frame image")
+}
+
+func TestScaleNearest(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	src.SetNRGBA(1, 0, color.NRGBA{R: 255, A: 255})
+
+	scaled := scaleNearest(src, 3)
+	assert.Equal(t, image.Rect(0, 0, 6, 3), scaled.Rect)
+	assert.Equal(t, uint8(0), scaled.NRGBAAt(2, 2).A)
+	assert.Equal(t, color.NRGBA{R: 255, A: 255}, scaled.NRGBAAt(3, 0))
+	assert.Equal(t, color.NRGBA{R: 255, A: 255}, scaled.NRGBAAt(5, 2))
+
+	assert.Same(t, src, scaleNearest(src, 1))
+}
+
+func TestCheckOnionSkinScale(t *testing.T) {
+	assert.NoError(t, checkOnionSkinScale(64, 64, 16))
+	assert.NoError(t, checkOnionSkinScale(256, 128, 16))
+	assert.NoError(t, checkOnionSkinScale(8192, 8192, 1), "unscaled previews are not limited")
+
+	err := checkOnionSkinScale(2048, 2048, 16)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "32768x32768, larger than the 4096x4096 maximum")
+
+	assert.Error(t, checkOnionSkinScale(100, 2049, 2), "either side can exceed the limit")
+}
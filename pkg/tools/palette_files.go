@@ -0,0 +1,202 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/willibrandon/mtlog/core"
+	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
+	"github.com/willibrandon/pixel-mcp/pkg/config"
+	"github.com/willibrandon/pixel-mcp/pkg/palette"
+)
+
+// LoadPaletteFileInput defines the input parameters for the load_palette_file tool.
+type LoadPaletteFileInput struct {
+	FilePath   string `json:"file_path" jsonschema:"Path to the palette file (.gpl, .pal, .hex, .act, .ase, or .png swatch strip)"`
+	SpritePath string `json:"sprite_path,omitempty" jsonschema:"Sprite to apply the palette to; omit to only read the file"`
+	Format     string `json:"format,omitempty" jsonschema:"File format: gpl, pal, hex, act, ase, or png (default: detected from extension and content)"`
+}
+
+// LoadPaletteFileOutput defines the output for the load_palette_file tool.
+type LoadPaletteFileOutput struct {
+	Name    string   `json:"name" jsonschema:"Palette name from the file, or the file name"`
+	Format  string   `json:"format" jsonschema:"Format the file was read as"`
+	Colors  []string `json:"colors" jsonschema:"Palette colors (#RRGGBB, or #RRGGBBAA for translucent entries)"`
+	Size    int      `json:"size" jsonschema:"Number of colors in the palette"`
+	Applied bool     `json:"applied" jsonschema:"True if the palette was set on the sprite"`
+}
+
+// SavePaletteFileInput defines the input parameters for the save_palette_file tool.
+type SavePaletteFileInput struct {
+	FilePath   string   `json:"file_path" jsonschema:"Path of the palette file to write"`
+	SpritePath string   `json:"sprite_path,omitempty" jsonschema:"Sprite whose palette is saved (required unless colors is given)"`
+	Colors     []string `json:"colors,omitempty" jsonschema:"Colors to save instead of the sprite palette (#RRGGBB or #RRGGBBAA)"`
+	Format     string   `json:"format,omitempty" jsonschema:"File format: gpl, pal, hex, act, ase, or png (default: from file extension)"`
+	Name       string   `json:"name,omitempty" jsonschema:"Palette name stored by formats that support it (default: file name)"`
+}
+
+// SavePaletteFileOutput defines the output for the save_palette_file tool.
+type SavePaletteFileOutput struct {
+	FilePath string `json:"file_path" jsonschema:"Path of the written palette file"`
+	Format   string `json:"format" jsonschema:"Format the file was written as"`
+	Size     int    `json:"size" jsonschema:"Number of colors written"`
+}
+
+// RegisterPaletteFileTools registers the palette file import/export tools with the MCP server.
+func RegisterPaletteFileTools(server *mcp.Server, client *aseprite.Client, gen *aseprite.LuaGenerator, cfg *config.Config, logger core.Logger) {
+	// Register load_palette_file tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "load_palette_file",
+			Description: "Load a palette file and optionally apply it to a sprite. Supports GIMP .gpl, JASC .pal (and RIFF .pal), Lospec .hex, Photoshop .act, Adobe Swatch Exchange .ase, and PNG swatch strips (distinct colors in reading order). The format is detected from the extension and file contents unless given explicitly.",
+		},
+		maybeWrapWithTiming("load_palette_file", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input LoadPaletteFileInput) (*mcp.CallToolResult, *LoadPaletteFileOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("load_palette_file tool called",
+				"file", input.FilePath,
+				"sprite", input.SpritePath,
+				"format", input.Format)
+
+			// Validate inputs
+			if input.FilePath == "" {
+				return nil, nil, fmt.Errorf("file_path is required")
+			}
+			format, err := parsePaletteFormat(input.Format)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			pal, format, err := palette.ReadFile(input.FilePath, format)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			result := &LoadPaletteFileOutput{
+				Name:   pal.Name,
+				Format: string(format),
+				Colors: pal.Hex(),
+				Size:   len(pal.Colors),
+			}
+
+			if input.SpritePath != "" {
+				if len(pal.Colors) > 256 {
+					return nil, nil, fmt.Errorf("palette can have at most 256 colors, got %d", len(pal.Colors))
+				}
+
+				// Check sprite file exists
+				if _, err := os.Stat(input.SpritePath); os.IsNotExist(err) {
+					return nil, nil, fmt.Errorf("sprite file not found: %s", input.SpritePath)
+				}
+
+				if _, err := client.ExecuteLua(ctx, gen.SetPalette(result.Colors), input.SpritePath); err != nil {
+					opLogger.Error("Failed to set palette", "error", err)
+					return nil, nil, fmt.Errorf("failed to set palette: %w", err)
+				}
+				result.Applied = true
+			}
+
+			opLogger.Information("Palette file loaded successfully",
+				"file", input.FilePath,
+				"format", result.Format,
+				"colors", result.Size,
+				"applied", result.Applied)
+
+			return nil, result, nil
+		}),
+	)
+
+	// Register save_palette_file tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "save_palette_file",
+			Description: "Save a sprite's palette (or an explicit list of colors) to a palette file. Supports GIMP .gpl, JASC .pal, Lospec .hex, Photoshop .act, Adobe Swatch Exchange .ase, and PNG swatch strips (N×1, one pixel per color). The format is taken from the file extension unless given explicitly.",
+		},
+		maybeWrapWithTiming("save_palette_file", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input SavePaletteFileInput) (*mcp.CallToolResult, *SavePaletteFileOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("save_palette_file tool called",
+				"file", input.FilePath,
+				"sprite", input.SpritePath,
+				"format", input.Format,
+				"color_count", len(input.Colors))
+
+			// Set defaults
+			if input.Name == "" {
+				input.Name = strings.TrimSuffix(filepath.Base(input.FilePath), filepath.Ext(input.FilePath))
+			}
+
+			// Validate inputs
+			if input.FilePath == "" {
+				return nil, nil, fmt.Errorf("file_path is required")
+			}
+			if input.SpritePath == "" && len(input.Colors) == 0 {
+				return nil, nil, fmt.Errorf("either sprite_path or colors is required")
+			}
+			format, err := parsePaletteFormat(input.Format)
+			if err != nil {
+				return nil, nil, err
+			}
+			if format == "" {
+				f, ok := palette.FormatFromExtension(input.FilePath)
+				if !ok {
+					return nil, nil, fmt.Errorf("cannot determine palette format from file name: %s (set format explicitly)", input.FilePath)
+				}
+				format = f
+			}
+
+			colors := input.Colors
+			if len(colors) == 0 {
+				// Check sprite file exists
+				if _, err := os.Stat(input.SpritePath); os.IsNotExist(err) {
+					return nil, nil, fmt.Errorf("sprite file not found: %s", input.SpritePath)
+				}
+
+				output, err := client.ExecuteLua(ctx, gen.GetPalette(), input.SpritePath)
+				if err != nil {
+					opLogger.Error("Failed to get palette", "error", err)
+					return nil, nil, fmt.Errorf("failed to get palette: %w", err)
+				}
+
+				var current GetPaletteOutput
+				if err := parseJSON(output, &current); err != nil {
+					return nil, nil, fmt.Errorf("failed to parse palette output: %w", err)
+				}
+				colors = current.Colors
+			}
+
+			pal, err := palette.FromHex(input.Name, colors)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid palette: %w", err)
+			}
+
+			if err := palette.WriteFile(input.FilePath, pal, format); err != nil {
+				return nil, nil, err
+			}
+
+			opLogger.Information("Palette file saved successfully",
+				"file", input.FilePath,
+				"format", format,
+				"colors", len(pal.Colors))
+
+			return nil, &SavePaletteFileOutput{
+				FilePath: input.FilePath,
+				Format:   string(format),
+				Size:     len(pal.Colors),
+			}, nil
+		}),
+	)
+}
+
+// parsePaletteFormat parses an optional palette format name; an empty name
+// returns an empty format so the caller can detect it.
+func parsePaletteFormat(name string) (palette.Format, error) {
+	if name == "" {
+		return "", nil
+	}
+	return palette.ParseFormat(name)
+}
@@ -0,0 +1,199 @@
+package tools
+
+import (
+	"context"
+	"strings"
+
+	"github.com/lucasb-eyer/go-colorful"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/willibrandon/mtlog/core"
+	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
+	"github.com/willibrandon/pixel-mcp/pkg/config"
+	"github.com/willibrandon/pixel-mcp/pkg/palette"
+)
+
+// ListPalettesInput defines the input parameters for the list_palettes tool.
+type ListPalettesInput struct {
+	Filter        string `json:"filter,omitempty" jsonschema:"Only list palettes whose ID, name, alias, or description contains this text (case-insensitive)"`
+	IncludeColors bool   `json:"include_colors,omitempty" jsonschema:"Include the hex colors of each palette (default: false)"`
+}
+
+// NamedPaletteInfo describes a bundled palette.
+type NamedPaletteInfo struct {
+	ID          string   `json:"id" jsonschema:"Palette ID accepted by palette_name parameters"`
+	Name        string   `json:"name" jsonschema:"Display name"`
+	Description string   `json:"description" jsonschema:"Short description"`
+	Aliases     []string `json:"aliases,omitempty" jsonschema:"Alternative names that are also accepted"`
+	Size        int      `json:"size" jsonschema:"Number of colors"`
+	Colors      []string `json:"colors,omitempty" jsonschema:"Hex colors in palette order"`
+}
+
+// ListPalettesOutput defines the output for the list_palettes tool.
+type ListPalettesOutput struct {
+	Palettes []NamedPaletteInfo `json:"palettes" jsonschema:"Bundled palettes sorted by ID"`
+	Count    int                `json:"count" jsonschema:"Number of palettes listed"`
+}
+
+// GetNamedPaletteInput defines the input parameters for the get_named_palette tool.
+type GetNamedPaletteInput struct {
+	Name string `json:"name" jsonschema:"Palette ID, name, or alias, e.g. pico-8, Endesga 32, db32, nes, gameboy"`
+}
+
+// RegisterPaletteLibraryTools registers the bundled palette library tools with the MCP server.
+func RegisterPaletteLibraryTools(server *mcp.Server, client *aseprite.Client, gen *aseprite.LuaGenerator, cfg *config.Config, logger core.Logger) {
+	// Register list_palettes tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "list_palettes",
+			Description: "List the classic palettes bundled with the server (PICO-8, Endesga 32, DawnBringer 16/32, NES, Game Boy DMG, Commodore 64, CGA, ZX Spectrum, and more). Works offline. Use the returned IDs as palette_name in set_palette, quantize_palette, and the draw_* tools instead of typing hex values from memory.",
+		},
+		maybeWrapWithTiming("list_palettes", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input ListPalettesInput) (*mcp.CallToolResult, *ListPalettesOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("list_palettes tool called",
+				"filter", input.Filter,
+				"include_colors", input.IncludeColors)
+
+			library, err := palette.Library()
+			if err != nil {
+				return nil, nil, err
+			}
+
+			filter := strings.ToLower(input.Filter)
+			result := &ListPalettesOutput{Palettes: []NamedPaletteInfo{}}
+			for i := range library {
+				lp := &library[i]
+				if filter != "" && !libraryPaletteMatches(lp, filter) {
+					continue
+				}
+
+				info := namedPaletteInfo(lp)
+				if !input.IncludeColors {
+					info.Colors = nil
+				}
+				result.Palettes = append(result.Palettes, info)
+			}
+			result.Count = len(result.Palettes)
+
+			opLogger.Information("Palettes listed successfully",
+				"count", result.Count)
+
+			return nil, result, nil
+		}),
+	)
+
+	// Register get_named_palette tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "get_named_palette",
+			Description: "Get the exact hex colors of a bundled palette by ID, name, or alias (e.g. pico-8, Endesga 32, db32, nes, gameboy). Matching ignores case, spaces, and hyphens. Use list_palettes to see what is available.",
+		},
+		maybeWrapWithTiming("get_named_palette", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input GetNamedPaletteInput) (*mcp.CallToolResult, *NamedPaletteInfo, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("get_named_palette tool called",
+				"name", input.Name)
+
+			lp, err := palette.Named(input.Name)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			info := namedPaletteInfo(lp)
+
+			opLogger.Information("Named palette retrieved successfully",
+				"id", info.ID,
+				"colors", info.Size)
+
+			return nil, &info, nil
+		}),
+	)
+}
+
+// namedPaletteInfo converts a library palette to its tool output form.
+func namedPaletteInfo(lp *palette.LibraryPalette) NamedPaletteInfo {
+	return NamedPaletteInfo{
+		ID:          lp.ID,
+		Name:        lp.Name,
+		Description: lp.Description,
+		Aliases:     lp.Aliases,
+		Size:        len(lp.Colors),
+		Colors:      lp.Hex(),
+	}
+}
+
+// libraryPaletteMatches reports whether a lowercase filter appears in a
+// palette's ID, name, aliases, or description.
+func libraryPaletteMatches(lp *palette.LibraryPalette, filter string) bool {
+	fields := append([]string{lp.ID, lp.Name, lp.Description}, lp.Aliases...)
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(f), filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// namedPaletteColors returns the hex colors of a bundled palette.
+func namedPaletteColors(name string) ([]string, error) {
+	lp, err := palette.Named(name)
+	if err != nil {
+		return nil, err
+	}
+	return lp.Hex(), nil
+}
+
+// namedPaletteSnapper returns a function that snaps colors to the closest color
+// (CIELAB distance) of a bundled palette, keeping their alpha.
+//
+// Returns nil without error when name is empty, so callers can skip snapping.
+func namedPaletteSnapper(name string) (func(aseprite.Color) aseprite.Color, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	colors, err := namedPaletteColors(name)
+	if err != nil {
+		return nil, err
+	}
+
+	pal := make([]aseprite.PaletteColor, len(colors))
+	for i, c := range colors {
+		pal[i] = aseprite.PaletteColor{Color: c}
+	}
+
+	return func(c aseprite.Color) aseprite.Color {
+		target := colorful.Color{R: float64(c.R) / 255.0, G: float64(c.G) / 255.0, B: float64(c.B) / 255.0}
+		hex, _, err := aseprite.FindClosestPaletteColor(target, pal)
+		if err != nil {
+			return c
+		}
+
+		var snapped aseprite.Color
+		if err := snapped.FromHex(hex); err != nil {
+			return c
+		}
+		snapped.A = c.A
+		return snapped
+	}, nil
+}
+
+// applyPaletteName snaps colors in place to the bundled palette named by
+// paletteName and returns the use_palette flag to pass to the Lua generator.
+//
+// Colors snapped to a bundled palette are not snapped again to the sprite
+// palette, so the flag is false whenever paletteName is set.
+func applyPaletteName(paletteName string, usePalette bool, colors ...*aseprite.Color) (bool, error) {
+	snap, err := namedPaletteSnapper(paletteName)
+	if err != nil {
+		return false, err
+	}
+	if snap == nil {
+		return usePalette, nil
+	}
+
+	for _, c := range colors {
+		*c = snap(*c)
+	}
+	return false, nil
+}
@@ -0,0 +1,65 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
+	"github.com/willibrandon/pixel-mcp/pkg/palette"
+)
+
+func TestNamedPaletteColors(t *testing.T) {
+	colors, err := namedPaletteColors("Game Boy DMG")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"#9BBC0F", "#8BAC0F", "#306230", "#0F380F"}, colors)
+
+	_, err = namedPaletteColors("unknown")
+	assert.Error(t, err)
+}
+
+func TestNamedPaletteSnapper_EmptyName(t *testing.T) {
+	snap, err := namedPaletteSnapper("")
+	require.NoError(t, err)
+	assert.Nil(t, snap)
+}
+
+func TestNamedPaletteSnapper_SnapsAndKeepsAlpha(t *testing.T) {
+	snap, err := namedPaletteSnapper("pico-8")
+	require.NoError(t, err)
+	require.NotNil(t, snap)
+
+	got := snap(aseprite.Color{R: 250, G: 10, B: 70, A: 128})
+	assert.Equal(t, aseprite.Color{R: 0xFF, G: 0x00, B: 0x4D, A: 128}, got)
+}
+
+func TestApplyPaletteName(t *testing.T) {
+	c1 := aseprite.Color{R: 10, G: 10, B: 10, A: 255}
+	c2 := aseprite.Color{R: 250, G: 250, B: 250, A: 255}
+
+	usePalette, err := applyPaletteName("1bit", true, &c1, &c2)
+	require.NoError(t, err)
+	assert.False(t, usePalette, "bundled palette snapping replaces sprite palette snapping")
+	assert.Equal(t, aseprite.Color{R: 0, G: 0, B: 0, A: 255}, c1)
+	assert.Equal(t, aseprite.Color{R: 255, G: 255, B: 255, A: 255}, c2)
+
+	// Without a palette name colors and the flag pass through unchanged
+	c3 := aseprite.Color{R: 1, G: 2, B: 3, A: 255}
+	usePalette, err = applyPaletteName("", true, &c3)
+	require.NoError(t, err)
+	assert.True(t, usePalette)
+	assert.Equal(t, aseprite.Color{R: 1, G: 2, B: 3, A: 255}, c3)
+
+	_, err = applyPaletteName("unknown", false, &c3)
+	assert.Error(t, err)
+}
+
+func TestLibraryPaletteMatches(t *testing.T) {
+	lp, err := palette.Named("gameboy-dmg")
+	require.NoError(t, err)
+
+	assert.True(t, libraryPaletteMatches(lp, "game boy"))
+	assert.True(t, libraryPaletteMatches(lp, "dmg"))
+	assert.True(t, libraryPaletteMatches(lp, "green"))
+	assert.False(t, libraryPaletteMatches(lp, "pico"))
+}
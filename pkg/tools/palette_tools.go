@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/lucasb-eyer/go-colorful"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/willibrandon/mtlog/core"
 	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
@@ -14,8 +16,9 @@ import (
 
 // SetPaletteInput defines the input parameters for the set_palette tool.
 type SetPaletteInput struct {
-	SpritePath string   `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
-	Colors     []string `json:"colors" jsonschema:"Array of hex colors to set as palette (#RRGGBB format)"`
+	SpritePath  string   `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	Colors      []string `json:"colors,omitempty" jsonschema:"Array of hex colors to set as palette (#RRGGBB format)"`
+	PaletteName string   `json:"palette_name,omitempty" jsonschema:"Bundled palette to set instead of colors, e.g. pico-8 or endesga-32 (see list_palettes)"`
 }
 
 // ApplyShadingInput defines the input parameters for the apply_shading tool.
@@ -118,6 +121,49 @@ type SortPaletteInput struct {
 	Ascending  bool   `json:"ascending" jsonschema:"Sort in ascending order (default: true)"`
 }
 
+// GenerateColorRampInput defines the input parameters for the generate_color_ramp tool.
+type GenerateColorRampInput struct {
+	BaseColor        string   `json:"base_color" jsonschema:"Base color (#RRGGBB); kept unchanged in the ramp"`
+	Steps            int      `json:"steps,omitempty" jsonschema:"Number of colors in the ramp 2-32 (default: 5)"`
+	HueShift         *float64 `json:"hue_shift,omitempty" jsonschema:"Degrees of hue rotation per step away from the base, shadows toward blue and highlights toward yellow 0-60 (default: 8)"`
+	SaturationCurve  string   `json:"saturation_curve,omitempty" jsonschema:"Saturation across the ramp: flat, arc (ends desaturated), shadows (saturated shadows), or highlights (saturated highlights) (default: arc)"`
+	SaturationAmount *float64 `json:"saturation_amount,omitempty" jsonschema:"Strength of the saturation curve at the ramp ends 0.0-1.0 (default: 0.3)"`
+	LightnessMin     *float64 `json:"lightness_min,omitempty" jsonschema:"Lightness of the darkest step 0.0-1.0 (default: 0.25)"`
+	LightnessMax     *float64 `json:"lightness_max,omitempty" jsonschema:"Lightness of the lightest step 0.0-1.0 (default: 0.95)"`
+	ColorSpace       string   `json:"color_space,omitempty" jsonschema:"Interpolation color space: oklab, oklch, or hsl (default: oklch)"`
+	SpritePath       string   `json:"sprite_path,omitempty" jsonschema:"Sprite whose palette receives the ramp (required with append_to_palette)"`
+	AppendToPalette  bool     `json:"append_to_palette,omitempty" jsonschema:"Append the ramp to the end of the sprite palette (default: false)"`
+}
+
+// GenerateColorRampOutput defines the output for the generate_color_ramp tool.
+type GenerateColorRampOutput struct {
+	Colors            []string `json:"colors" jsonschema:"Ramp colors from darkest to lightest"`
+	BaseIndex         int      `json:"base_index" jsonschema:"Index of the base color within the ramp"`
+	PaletteStartIndex *int     `json:"palette_start_index,omitempty" jsonschema:"Palette index of the first ramp color when appended"`
+	PaletteSize       int      `json:"palette_size,omitempty" jsonschema:"Palette size after appending"`
+}
+
+// OptimizePaletteInput defines the input parameters for the optimize_palette tool.
+type OptimizePaletteInput struct {
+	SpritePath     string   `json:"sprite_path" jsonschema:"Path to the indexed Aseprite sprite file"`
+	RemoveUnused   *bool    `json:"remove_unused,omitempty" jsonschema:"Drop palette entries no pixel uses in any frame or layer (default: true)"`
+	MergeThreshold *float64 `json:"merge_threshold,omitempty" jsonschema:"Merge entries within this CIEDE2000 ΔE of a more used entry; 0 merges exact duplicates only, negative disables merging (default: 3.0)"`
+	SortBy         string   `json:"sort_by,omitempty" jsonschema:"Order of the optimized palette: none (keep order), usage (most used first), or ramp (hue ramps dark to light, grays last) (default: none)"`
+	DryRun         bool     `json:"dry_run,omitempty" jsonschema:"Report the mapping without changing the sprite (default: false)"`
+}
+
+// OptimizePaletteOutput defines the output for the optimize_palette tool.
+type OptimizePaletteOutput struct {
+	OriginalSize  int                           `json:"original_size" jsonschema:"Palette size before optimization"`
+	NewSize       int                           `json:"new_size" jsonschema:"Palette size after optimization"`
+	Removed       int                           `json:"removed" jsonschema:"Number of unused entries dropped"`
+	Merged        int                           `json:"merged" jsonschema:"Number of entries merged into similar colors"`
+	Colors        []string                      `json:"colors" jsonschema:"Optimized palette"`
+	Mapping       []aseprite.PaletteEntryChange `json:"mapping" jsonschema:"Before/after table with one row per original palette index"`
+	PixelsChanged int                           `json:"pixels_changed" jsonschema:"Number of pixels whose index changed (0 for dry runs)"`
+	Applied       bool                          `json:"applied" jsonschema:"True if the sprite was modified"`
+}
+
 // RegisterPaletteTools registers all palette management tools with the MCP server.
 func RegisterPaletteTools(server *mcp.Server, client *aseprite.Client, gen *aseprite.LuaGenerator, cfg *config.Config, logger core.Logger) {
 	// Register get_palette tool
@@ -288,13 +334,26 @@ func RegisterPaletteTools(server *mcp.Server, client *aseprite.Client, gen *asep
 		server,
 		&mcp.Tool{
 			Name:        "set_palette",
-			Description: "Set the sprite's color palette to the specified colors. Useful for applying extracted palettes from analyze_reference or creating custom limited palettes for pixel art. Colors should be in #RRGGBB hex format.",
+			Description: "Set the sprite's color palette to the specified colors. Useful for applying extracted palettes from analyze_reference or creating custom limited palettes for pixel art. Colors should be in #RRGGBB hex format. Pass palette_name instead of colors to use a bundled palette such as pico-8, endesga-32, db32, nes, or gameboy-dmg.",
 		},
 		maybeWrapWithTiming("set_palette", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input SetPaletteInput) (*mcp.CallToolResult, *struct{ Success bool }, error) {
 			opLogger := logger.WithContext(ctx)
 			opLogger.Debug("set_palette tool called",
 				"sprite", input.SpritePath,
-				"color_count", len(input.Colors))
+				"color_count", len(input.Colors),
+				"palette_name", input.PaletteName)
+
+			// Resolve bundled palette
+			if input.PaletteName != "" {
+				if len(input.Colors) > 0 {
+					return nil, nil, fmt.Errorf("colors and palette_name are mutually exclusive")
+				}
+				colors, err := namedPaletteColors(input.PaletteName)
+				if err != nil {
+					return nil, nil, err
+				}
+				input.Colors = colors
+			}
 
 			// Validate inputs
 			if len(input.Colors) == 0 {
@@ -467,6 +526,224 @@ func RegisterPaletteTools(server *mcp.Server, client *aseprite.Client, gen *asep
 			return nil, output, nil
 		}),
 	)
+	// Register generate_color_ramp tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "generate_color_ramp",
+			Description: "Generate a dark-to-light color ramp from a base color with pixel-art style hue shifting (shadows rotate toward blue, highlights toward yellow). Configure the number of steps, hue shift per step, saturation curve, lightness range, and interpolation space (OKLab, OKLCH, or HSL). The base color is kept unchanged at the step matching its lightness. Optionally appends the ramp to the sprite palette.",
+		},
+		maybeWrapWithTiming("generate_color_ramp", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input GenerateColorRampInput) (*mcp.CallToolResult, *GenerateColorRampOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("generate_color_ramp tool called",
+				"base_color", input.BaseColor,
+				"steps", input.Steps,
+				"color_space", input.ColorSpace,
+				"append_to_palette", input.AppendToPalette)
+
+			// Set defaults
+			if input.Steps == 0 {
+				input.Steps = 5
+			}
+			if input.HueShift == nil {
+				defaultShift := 8.0
+				input.HueShift = &defaultShift
+			}
+			if input.SaturationCurve == "" {
+				input.SaturationCurve = "arc"
+			}
+			if input.SaturationAmount == nil {
+				defaultAmount := 0.3
+				input.SaturationAmount = &defaultAmount
+			}
+			if input.LightnessMin == nil {
+				defaultMin := 0.25
+				input.LightnessMin = &defaultMin
+			}
+			if input.LightnessMax == nil {
+				defaultMax := 0.95
+				input.LightnessMax = &defaultMax
+			}
+			if input.ColorSpace == "" {
+				input.ColorSpace = "oklch"
+			}
+
+			// Validate inputs
+			base, err := colorful.Hex(input.BaseColor)
+			if err != nil || len(input.BaseColor) != 7 {
+				return nil, nil, fmt.Errorf("invalid base_color: %s (expected #RRGGBB format)", input.BaseColor)
+			}
+			if input.AppendToPalette && input.SpritePath == "" {
+				return nil, nil, fmt.Errorf("sprite_path is required when append_to_palette is true")
+			}
+
+			ramp, baseIndex, err := aseprite.GenerateRamp(base, aseprite.RampOptions{
+				Steps:            input.Steps,
+				HueShift:         *input.HueShift,
+				SaturationCurve:  input.SaturationCurve,
+				SaturationAmount: *input.SaturationAmount,
+				LightnessMin:     *input.LightnessMin,
+				LightnessMax:     *input.LightnessMax,
+				ColorSpace:       input.ColorSpace,
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+
+			result := &GenerateColorRampOutput{
+				Colors:    make([]string, len(ramp)),
+				BaseIndex: baseIndex,
+			}
+			for i, c := range ramp {
+				result.Colors[i] = strings.ToUpper(c.Hex())
+			}
+
+			if input.AppendToPalette {
+				// Check sprite file exists
+				if _, err := os.Stat(input.SpritePath); os.IsNotExist(err) {
+					return nil, nil, fmt.Errorf("sprite file not found: %s", input.SpritePath)
+				}
+
+				output, err := client.ExecuteLua(ctx, gen.AppendPaletteColors(result.Colors), input.SpritePath)
+				if err != nil {
+					opLogger.Error("Failed to append ramp to palette", "error", err)
+					return nil, nil, fmt.Errorf("failed to append ramp to palette: %w", err)
+				}
+
+				var appended struct {
+					StartIndex int `json:"start_index"`
+					Size       int `json:"size"`
+				}
+				if err := parseJSON(output, &appended); err != nil {
+					return nil, nil, fmt.Errorf("failed to parse palette output: %w", err)
+				}
+				result.PaletteStartIndex = &appended.StartIndex
+				result.PaletteSize = appended.Size
+			}
+
+			opLogger.Information("Color ramp generated successfully",
+				"base_color", input.BaseColor,
+				"steps", len(result.Colors),
+				"appended", input.AppendToPalette)
+
+			return nil, result, nil
+		}),
+	)
+
+	// Register optimize_palette tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "optimize_palette",
+			Description: "Clean up the palette of an indexed sprite. Finds entries unused across all frames and layers and drops them, merges entries within a CIEDE2000 ΔE threshold into the more used color, remaps every pixel to the new indices, and optionally reorders the palette by usage or by hue ramp. The transparent index is always kept. Returns a before/after mapping table; use dry_run to preview. Sprites with per-frame palettes (such as palette cycles) are refused.",
+		},
+		maybeWrapWithTiming("optimize_palette", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input OptimizePaletteInput) (*mcp.CallToolResult, *OptimizePaletteOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("optimize_palette tool called",
+				"sprite", input.SpritePath,
+				"sort_by", input.SortBy,
+				"dry_run", input.DryRun)
+
+			// Set defaults
+			if input.RemoveUnused == nil {
+				defaultRemoveUnused := true
+				input.RemoveUnused = &defaultRemoveUnused
+			}
+			if input.MergeThreshold == nil {
+				defaultMergeThreshold := 3.0
+				input.MergeThreshold = &defaultMergeThreshold
+			}
+			if input.SortBy == "" {
+				input.SortBy = "none"
+			}
+
+			// Validate inputs
+			if input.SortBy != "none" && input.SortBy != "usage" && input.SortBy != "ramp" {
+				return nil, nil, fmt.Errorf("invalid sort_by: %s (must be none, usage, or ramp)", input.SortBy)
+			}
+			if *input.MergeThreshold > 100 {
+				return nil, nil, fmt.Errorf("merge_threshold must be at most 100, got %f", *input.MergeThreshold)
+			}
+
+			if _, err := os.Stat(input.SpritePath); err != nil {
+				return nil, nil, fmt.Errorf("sprite file not found: %w", err)
+			}
+
+			output, err := client.ExecuteLua(ctx, gen.GetPaletteUsage(), input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to read palette usage", "error", err)
+				return nil, nil, fmt.Errorf("failed to read palette usage: %w", err)
+			}
+			var usage struct {
+				Colors           []string `json:"colors"`
+				Counts           []int    `json:"counts"`
+				TransparentIndex int      `json:"transparent_index"`
+			}
+			if err := parseJSON(output, &usage); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse palette usage: %w", err)
+			}
+
+			plan, err := aseprite.OptimizePalette(usage.Colors, usage.Counts, usage.TransparentIndex, aseprite.PaletteOptimizeOptions{
+				RemoveUnused:   *input.RemoveUnused,
+				MergeThreshold: *input.MergeThreshold,
+				SortBy:         input.SortBy,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to optimize palette: %w", err)
+			}
+
+			result := &OptimizePaletteOutput{
+				OriginalSize: len(usage.Colors),
+				NewSize:      len(plan.Colors),
+				Colors:       plan.Colors,
+				Mapping:      plan.Changes,
+			}
+			changed := len(plan.Colors) != len(usage.Colors)
+			for i, c := range plan.Changes {
+				switch c.Action {
+				case aseprite.PaletteEntryRemoved:
+					result.Removed++
+				case aseprite.PaletteEntryMerged:
+					result.Merged++
+				}
+				if plan.Mapping[i] != i {
+					changed = true
+				}
+			}
+
+			if !input.DryRun && changed {
+				transparentIndex := -1
+				if usage.TransparentIndex >= 0 && usage.TransparentIndex < len(plan.Mapping) {
+					transparentIndex = plan.Mapping[usage.TransparentIndex]
+				}
+
+				script := gen.RemapPaletteIndices(plan.Mapping, plan.Colors, transparentIndex)
+				output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+				if err != nil {
+					opLogger.Error("Failed to apply optimized palette", "error", err)
+					return nil, nil, fmt.Errorf("failed to apply optimized palette: %w", err)
+				}
+				var applied struct {
+					PixelsChanged int `json:"pixels_changed"`
+				}
+				if err := parseJSON(output, &applied); err != nil {
+					return nil, nil, fmt.Errorf("failed to parse palette output: %w", err)
+				}
+				result.PixelsChanged = applied.PixelsChanged
+				result.Applied = true
+			}
+
+			opLogger.Information("Palette optimized successfully",
+				"sprite", input.SpritePath,
+				"original_size", result.OriginalSize,
+				"new_size", result.NewSize,
+				"removed", result.Removed,
+				"merged", result.Merged,
+				"applied", result.Applied)
+
+			return nil, result, nil
+		}),
+	)
 }
 
 // analyzePaletteHarmonies performs color harmony analysis on a palette.
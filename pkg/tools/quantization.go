@@ -24,6 +24,7 @@ type QuantizePaletteInput struct {
 	Dither               bool   `json:"dither" jsonschema:"Apply Floyd-Steinberg dithering during quantization (default: false)"`
 	PreserveTransparency *bool  `json:"preserve_transparency,omitempty" jsonschema:"Keep transparent pixels transparent (default: true)"`
 	ConvertToIndexed     *bool  `json:"convert_to_indexed,omitempty" jsonschema:"Convert sprite to indexed color mode (default: true)"`
+	PaletteName          string `json:"palette_name,omitempty" jsonschema:"Map the sprite onto this bundled palette (e.g. pico-8, see list_palettes) instead of generating one; target_colors and algorithm are ignored"`
 }
 
 // QuantizePaletteOutput defines the output for the quantize_palette tool.
@@ -42,14 +43,15 @@ func RegisterQuantizationTools(server *mcp.Server, client *aseprite.Client, gen
 		server,
 		&mcp.Tool{
 			Name:        "quantize_palette",
-			Description: "Automatically reduce sprite colors using industry-standard quantization algorithms. Supports three algorithms: median_cut (fast, balanced quality), kmeans (highest quality, slower), octree (very fast, good for photos). Can apply Floyd-Steinberg dithering for smoother gradients. Optionally converts to indexed color mode for true palette constraint or keeps RGB mode for flexible multi-pass workflows.",
+			Description: "Automatically reduce sprite colors using industry-standard quantization algorithms. Supports three algorithms: median_cut (fast, balanced quality), kmeans (highest quality, slower), octree (very fast, good for photos). Can apply Floyd-Steinberg dithering for smoother gradients. Optionally converts to indexed color mode for true palette constraint or keeps RGB mode for flexible multi-pass workflows. Pass palette_name to map the sprite onto a bundled palette (e.g. pico-8, nes, gameboy-dmg) instead of generating one.",
 		},
 		maybeWrapWithTiming("quantize_palette", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input QuantizePaletteInput) (*mcp.CallToolResult, *QuantizePaletteOutput, error) {
 			opLogger := logger.WithContext(ctx)
 			opLogger.Debug("quantize_palette tool called",
 				"sprite", input.SpritePath,
 				"target_colors", input.TargetColors,
-				"algorithm", input.Algorithm)
+				"algorithm", input.Algorithm,
+				"palette_name", input.PaletteName)
 
 			// Set defaults
 			if input.Algorithm == "" {
@@ -64,6 +66,17 @@ func RegisterQuantizationTools(server *mcp.Server, client *aseprite.Client, gen
 				input.ConvertToIndexed = &defaultTrue
 			}
 
+			// Resolve bundled palette
+			var namedPalette []string
+			if input.PaletteName != "" {
+				colors, err := namedPaletteColors(input.PaletteName)
+				if err != nil {
+					return nil, nil, err
+				}
+				namedPalette = colors
+				input.TargetColors = len(colors)
+			}
+
 			// Validate inputs
 			if input.TargetColors < 2 || input.TargetColors > 256 {
 				return nil, nil, fmt.Errorf("target_colors must be between 2 and 256, got %d", input.TargetColors)
@@ -117,15 +130,25 @@ func RegisterQuantizationTools(server *mcp.Server, client *aseprite.Client, gen
 				}
 			}
 
-			// Perform quantization
-			palette, originalColors, err := aseprite.QuantizePalette(
-				img,
-				input.TargetColors,
-				input.Algorithm,
-				*input.PreserveTransparency,
+			// Perform quantization, or use the bundled palette as-is
+			var (
+				palette        []string
+				originalColors int
 			)
-			if err != nil {
-				return nil, nil, fmt.Errorf("quantization failed: %w", err)
+			if namedPalette != nil {
+				palette = namedPalette
+				originalColors = aseprite.CountUniqueColors(img, *input.PreserveTransparency)
+				input.Algorithm = "palette:" + input.PaletteName
+			} else {
+				palette, originalColors, err = aseprite.QuantizePalette(
+					img,
+					input.TargetColors,
+					input.Algorithm,
+					*input.PreserveTransparency,
+				)
+				if err != nil {
+					return nil, nil, fmt.Errorf("quantization failed: %w", err)
+				}
 			}
 
 			opLogger.Information("Quantization completed",
@@ -0,0 +1,386 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/willibrandon/mtlog/core"
+	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
+	"github.com/willibrandon/pixel-mcp/pkg/config"
+	"github.com/willibrandon/pixel-mcp/pkg/palette"
+)
+
+// ColorMapping describes one from→to entry of a color remap.
+type ColorMapping struct {
+	From      string `json:"from,omitempty" jsonschema:"Color to replace (#RRGGBB); alternative to from_index"`
+	FromIndex *int   `json:"from_index,omitempty" jsonschema:"Palette index to replace; alternative to from"`
+	To        string `json:"to,omitempty" jsonschema:"Replacement color (#RRGGBB keeps pixel alpha, #RRGGBBAA sets it); alternative to to_index"`
+	ToIndex   *int   `json:"to_index,omitempty" jsonschema:"Replacement palette index; alternative to to"`
+}
+
+// RemapColorsInput defines the input parameters for the remap_colors tool.
+type RemapColorsInput struct {
+	SpritePath string         `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	Mappings   []ColorMapping `json:"mappings" jsonschema:"Color or palette index mappings, applied simultaneously (so swaps work)"`
+	Layers     []string       `json:"layers,omitempty" jsonschema:"Layer names to remap (default: all layers)"`
+	Frames     []int          `json:"frames,omitempty" jsonschema:"Frame numbers to remap, 1-based (default: all frames)"`
+}
+
+// RemapColorsOutput defines the output for the remap_colors tool.
+type RemapColorsOutput struct {
+	PixelsChanged int    `json:"pixels_changed" jsonschema:"Number of pixels whose value changed"`
+	CelsProcessed int    `json:"cels_processed" jsonschema:"Number of cels examined (linked cels count once)"`
+	ColorMode     string `json:"color_mode" jsonschema:"Sprite color mode: rgb or indexed"`
+	PaletteSize   int    `json:"palette_size" jsonschema:"Palette size after remapping (indexed mode may append colors)"`
+}
+
+// GeneratePaletteVariantsInput defines the input parameters for the generate_palette_variants tool.
+type GeneratePaletteVariantsInput struct {
+	SpritePath     string     `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	HueRotations   []float64  `json:"hue_rotations,omitempty" jsonschema:"Hue rotations in degrees, one variant each (grays are kept)"`
+	TargetPalettes [][]string `json:"target_palettes,omitempty" jsonschema:"Target palettes as hex color lists, one variant each"`
+	PaletteNames   []string   `json:"palette_names,omitempty" jsonschema:"Bundled palette names (see list_palettes), one variant each"`
+	Layers         []string   `json:"layers,omitempty" jsonschema:"Layers to recolor (default: all layers)"`
+	Output         string     `json:"output,omitempty" jsonschema:"Where variants go: files (copies of the sprite) or layers (duplicated layers in the sprite) (default: files)"`
+	OutputDir      string     `json:"output_dir,omitempty" jsonschema:"Directory for variant files (default: the sprite's directory)"`
+}
+
+// PaletteVariant describes one generated variant.
+type PaletteVariant struct {
+	Name          string            `json:"name" jsonschema:"Variant label used in file and layer names"`
+	FilePath      string            `json:"file_path,omitempty" jsonschema:"Path of the variant sprite (files output)"`
+	Layers        []string          `json:"layers,omitempty" jsonschema:"Names of the variant layers (layers output)"`
+	Mapping       map[string]string `json:"mapping" jsonschema:"Source color to variant color"`
+	PixelsChanged int               `json:"pixels_changed" jsonschema:"Number of pixels recolored"`
+}
+
+// GeneratePaletteVariantsOutput defines the output for the generate_palette_variants tool.
+type GeneratePaletteVariantsOutput struct {
+	SourceColors []string         `json:"source_colors" jsonschema:"Distinct colors found in the recolored layers"`
+	Variants     []PaletteVariant `json:"variants" jsonschema:"Generated variants in request order"`
+}
+
+// paletteVariantSpec is one requested variant before its mapping is computed.
+type paletteVariantSpec struct {
+	name       string
+	hueShift   float64
+	target     []string
+	isRotation bool
+}
+
+// RegisterRecolorTools registers the color remapping and palette variant tools with the MCP server.
+func RegisterRecolorTools(server *mcp.Server, client *aseprite.Client, gen *aseprite.LuaGenerator, cfg *config.Config, logger core.Logger) {
+	// Register remap_colors tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "remap_colors",
+			Description: "Replace colors across all cels of the selected layers and frames using from→to mappings given as hex colors or palette indices. All mappings apply at once, so colors can be swapped. Works in RGB mode (pixels matched by color, alpha kept) and indexed mode (pixels remapped by index, missing target colors appended to the palette; transparent pixels are left alone except on the background layer). Ideal for enemy variants, team colors, and palette swaps.",
+		},
+		maybeWrapWithTiming("remap_colors", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input RemapColorsInput) (*mcp.CallToolResult, *RemapColorsOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("remap_colors tool called",
+				"sprite", input.SpritePath,
+				"mappings", len(input.Mappings),
+				"layers", input.Layers,
+				"frames", input.Frames)
+
+			// Validate inputs
+			remaps, err := colorRemaps(input.Mappings)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, f := range input.Frames {
+				if f < 1 {
+					return nil, nil, fmt.Errorf("frame numbers must be at least 1, got %d", f)
+				}
+			}
+
+			if _, err := os.Stat(input.SpritePath); err != nil {
+				return nil, nil, fmt.Errorf("sprite file not found: %w", err)
+			}
+
+			result, err := remapSpriteColors(ctx, client, gen, input.SpritePath, remaps, input.Layers, input.Frames)
+			if err != nil {
+				opLogger.Error("Failed to remap colors", "error", err)
+				return nil, nil, err
+			}
+
+			opLogger.Information("Colors remapped successfully",
+				"sprite", input.SpritePath,
+				"pixels_changed", result.PixelsChanged,
+				"cels", result.CelsProcessed)
+
+			return nil, result, nil
+		}),
+	)
+
+	// Register generate_palette_variants tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "generate_palette_variants",
+			Description: "Generate recolored copies of a sprite, one per hue rotation, target palette, or bundled palette name. Hue rotations turn colors around the OKLCH hue wheel keeping lightness and leaving grays alone. Target palettes are matched by ramp position: colors are grouped into hue ramps sorted dark to light, and each position maps to the same relative position in a target ramp, so shading survives the swap. Variants are saved as new sprite files or as new layers in the sprite.",
+		},
+		maybeWrapWithTiming("generate_palette_variants", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input GeneratePaletteVariantsInput) (*mcp.CallToolResult, *GeneratePaletteVariantsOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("generate_palette_variants tool called",
+				"sprite", input.SpritePath,
+				"hue_rotations", len(input.HueRotations),
+				"target_palettes", len(input.TargetPalettes),
+				"palette_names", len(input.PaletteNames),
+				"output", input.Output)
+
+			// Set defaults
+			if input.Output == "" {
+				input.Output = "files"
+			}
+
+			// Validate inputs
+			if input.Output != "files" && input.Output != "layers" {
+				return nil, nil, fmt.Errorf("invalid output: %s (must be files or layers)", input.Output)
+			}
+			specs, err := paletteVariantSpecs(input.HueRotations, input.TargetPalettes, input.PaletteNames)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if _, err := os.Stat(input.SpritePath); err != nil {
+				return nil, nil, fmt.Errorf("sprite file not found: %w", err)
+			}
+
+			// Collect the colors to recolor
+			script := gen.GetUsedColors(input.Layers, nil)
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to read sprite colors: %w", err)
+			}
+			var used struct {
+				Colors []string `json:"colors"`
+			}
+			if err := parseJSON(output, &used); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse sprite colors: %w", err)
+			}
+			if len(used.Colors) == 0 {
+				return nil, nil, fmt.Errorf("no opaque pixels to recolor")
+			}
+
+			// Layers output duplicates every recolored layer
+			sourceLayers := input.Layers
+			if input.Output == "layers" && len(sourceLayers) == 0 {
+				info, err := getSpriteInfoHelper(ctx, client, gen, input.SpritePath)
+				if err != nil {
+					return nil, nil, err
+				}
+				sourceLayers = info.Layers
+			}
+
+			outputDir := input.OutputDir
+			if outputDir == "" {
+				outputDir = filepath.Dir(input.SpritePath)
+			}
+			ext := filepath.Ext(input.SpritePath)
+			stem := strings.TrimSuffix(filepath.Base(input.SpritePath), ext)
+
+			result := &GeneratePaletteVariantsOutput{
+				SourceColors: used.Colors,
+				Variants:     make([]PaletteVariant, 0, len(specs)),
+			}
+
+			for _, spec := range specs {
+				var mapping map[string]string
+				if spec.isRotation {
+					mapping, err = aseprite.RotateHues(used.Colors, spec.hueShift)
+				} else {
+					mapping, err = aseprite.MatchRamps(used.Colors, spec.target)
+				}
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to compute variant %s: %w", spec.name, err)
+				}
+				remaps := mappingRemaps(mapping)
+
+				variant := PaletteVariant{Name: spec.name, Mapping: mapping}
+
+				switch input.Output {
+				case "files":
+					variant.FilePath = filepath.Join(outputDir, fmt.Sprintf("%s_%s%s", stem, spec.name, ext))
+					if err := copyFile(input.SpritePath, variant.FilePath); err != nil {
+						return nil, nil, fmt.Errorf("failed to create variant %s: %w", spec.name, err)
+					}
+					if len(remaps) > 0 {
+						remapped, err := remapSpriteColors(ctx, client, gen, variant.FilePath, remaps, input.Layers, nil)
+						if err != nil {
+							return nil, nil, fmt.Errorf("failed to recolor variant %s: %w", spec.name, err)
+						}
+						variant.PixelsChanged = remapped.PixelsChanged
+					}
+
+				case "layers":
+					for _, layer := range sourceLayers {
+						newName := fmt.Sprintf("%s (%s)", layer, spec.name)
+						if _, err := client.ExecuteLua(ctx, gen.DuplicateLayer(layer, newName), input.SpritePath); err != nil {
+							return nil, nil, fmt.Errorf("failed to duplicate layer %s: %w", layer, err)
+						}
+						variant.Layers = append(variant.Layers, newName)
+					}
+					if len(remaps) > 0 {
+						remapped, err := remapSpriteColors(ctx, client, gen, input.SpritePath, remaps, variant.Layers, nil)
+						if err != nil {
+							return nil, nil, fmt.Errorf("failed to recolor variant %s: %w", spec.name, err)
+						}
+						variant.PixelsChanged = remapped.PixelsChanged
+					}
+				}
+
+				result.Variants = append(result.Variants, variant)
+			}
+
+			opLogger.Information("Palette variants generated successfully",
+				"sprite", input.SpritePath,
+				"variants", len(result.Variants),
+				"source_colors", len(result.SourceColors))
+
+			return nil, result, nil
+		}),
+	)
+}
+
+// remapSpriteColors runs the RemapColors script on a sprite and parses its result.
+func remapSpriteColors(ctx context.Context, client *aseprite.Client, gen *aseprite.LuaGenerator, spritePath string, remaps []aseprite.ColorRemap, layers []string, frames []int) (*RemapColorsOutput, error) {
+	script := gen.RemapColors(remaps, layers, frames)
+
+	output, err := client.ExecuteLua(ctx, script, spritePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remap colors: %w", err)
+	}
+
+	var result RemapColorsOutput
+	if err := parseJSON(output, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse remap result: %w", err)
+	}
+	return &result, nil
+}
+
+// colorRemaps validates tool mappings and converts them for the Lua generator.
+func colorRemaps(mappings []ColorMapping) ([]aseprite.ColorRemap, error) {
+	if len(mappings) == 0 {
+		return nil, fmt.Errorf("mappings cannot be empty")
+	}
+
+	remaps := make([]aseprite.ColorRemap, len(mappings))
+	for i, m := range mappings {
+		switch {
+		case m.From != "" && m.FromIndex != nil:
+			return nil, fmt.Errorf("mapping %d: from and from_index are mutually exclusive", i)
+		case m.From != "":
+			if !isValidHexColor(m.From) {
+				return nil, fmt.Errorf("mapping %d: invalid from color: %s (expected #RRGGBB format)", i, m.From)
+			}
+			remaps[i].From = m.From
+		case m.FromIndex != nil:
+			if *m.FromIndex < 0 || *m.FromIndex > 255 {
+				return nil, fmt.Errorf("mapping %d: from_index must be between 0 and 255, got %d", i, *m.FromIndex)
+			}
+			remaps[i].FromIndex = *m.FromIndex
+		default:
+			return nil, fmt.Errorf("mapping %d: from or from_index is required", i)
+		}
+
+		switch {
+		case m.To != "" && m.ToIndex != nil:
+			return nil, fmt.Errorf("mapping %d: to and to_index are mutually exclusive", i)
+		case m.To != "":
+			if !isValidHexColor(m.To) {
+				return nil, fmt.Errorf("mapping %d: invalid to color: %s (expected #RRGGBB or #RRGGBBAA format)", i, m.To)
+			}
+			remaps[i].To = m.To
+		case m.ToIndex != nil:
+			if *m.ToIndex < 0 || *m.ToIndex > 255 {
+				return nil, fmt.Errorf("mapping %d: to_index must be between 0 and 255, got %d", i, *m.ToIndex)
+			}
+			remaps[i].ToIndex = *m.ToIndex
+		default:
+			return nil, fmt.Errorf("mapping %d: to or to_index is required", i)
+		}
+	}
+	return remaps, nil
+}
+
+// mappingRemaps converts a color mapping to remaps in a stable order,
+// dropping colors that map to themselves.
+func mappingRemaps(mapping map[string]string) []aseprite.ColorRemap {
+	remaps := make([]aseprite.ColorRemap, 0, len(mapping))
+	for from, to := range mapping {
+		if from != to {
+			remaps = append(remaps, aseprite.ColorRemap{From: from, To: to})
+		}
+	}
+	sort.Slice(remaps, func(i, j int) bool { return remaps[i].From < remaps[j].From })
+	return remaps
+}
+
+// paletteVariantSpecs validates the requested variants and names them.
+func paletteVariantSpecs(hueRotations []float64, targetPalettes [][]string, paletteNames []string) ([]paletteVariantSpec, error) {
+	total := len(hueRotations) + len(targetPalettes) + len(paletteNames)
+	if total == 0 {
+		return nil, fmt.Errorf("at least one of hue_rotations, target_palettes, or palette_names is required")
+	}
+	if total > 32 {
+		return nil, fmt.Errorf("at most 32 variants can be generated at once, got %d", total)
+	}
+
+	specs := make([]paletteVariantSpec, 0, total)
+	for _, deg := range hueRotations {
+		if deg <= -360 || deg >= 360 {
+			return nil, fmt.Errorf("hue rotation must be between -360 and 360 degrees, got %g", deg)
+		}
+		specs = append(specs, paletteVariantSpec{
+			name:       "hue" + strconv.FormatFloat(deg, 'f', -1, 64),
+			hueShift:   deg,
+			isRotation: true,
+		})
+	}
+
+	for i, colors := range targetPalettes {
+		if len(colors) == 0 {
+			return nil, fmt.Errorf("target palette %d is empty", i)
+		}
+		for _, c := range colors {
+			if !isValidHexColor(c) {
+				return nil, fmt.Errorf("target palette %d: invalid color: %s (expected #RRGGBB format)", i, c)
+			}
+		}
+		specs = append(specs, paletteVariantSpec{
+			name:   fmt.Sprintf("palette%d", i+1),
+			target: colors,
+		})
+	}
+
+	for _, name := range paletteNames {
+		lp, err := palette.Named(name)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, paletteVariantSpec{
+			name:   lp.ID,
+			target: lp.Hex(),
+		})
+	}
+
+	return specs, nil
+}
+
+// copyFile copies src to dst, replacing dst if it exists.
+func copyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0644)
+}
@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
+)
+
+func TestColorRemaps(t *testing.T) {
+	idx := func(i int) *int { return &i }
+
+	remaps, err := colorRemaps([]ColorMapping{
+		{From: "#FF0000", To: "#0000FF"},
+		{FromIndex: idx(2), ToIndex: idx(5)},
+		{From: "#00FF00", ToIndex: idx(0)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []aseprite.ColorRemap{
+		{From: "#FF0000", To: "#0000FF"},
+		{FromIndex: 2, ToIndex: 5},
+		{From: "#00FF00", ToIndex: 0},
+	}, remaps)
+}
+
+func TestColorRemaps_Errors(t *testing.T) {
+	idx := func(i int) *int { return &i }
+
+	tests := []struct {
+		name     string
+		mappings []ColorMapping
+		errMsg   string
+	}{
+		{"empty", nil, "cannot be empty"},
+		{"missing from", []ColorMapping{{To: "#000000"}}, "from or from_index is required"},
+		{"missing to", []ColorMapping{{From: "#000000"}}, "to or to_index is required"},
+		{"both from", []ColorMapping{{From: "#000000", FromIndex: idx(1), To: "#FFFFFF"}}, "mutually exclusive"},
+		{"bad color", []ColorMapping{{From: "red", To: "#FFFFFF"}}, "invalid from color"},
+		{"index range", []ColorMapping{{From: "#000000", ToIndex: idx(300)}}, "to_index must be between"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := colorRemaps(tt.mappings)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestMappingRemaps_SkipsIdentityAndSorts(t *testing.T) {
+	remaps := mappingRemaps(map[string]string{
+		"#FF0000": "#00FF00",
+		"#808080": "#808080",
+		"#0000FF": "#FFFF00",
+	})
+	assert.Equal(t, []aseprite.ColorRemap{
+		{From: "#0000FF", To: "#FFFF00"},
+		{From: "#FF0000", To: "#00FF00"},
+	}, remaps)
+}
+
+func TestPaletteVariantSpecs(t *testing.T) {
+	specs, err := paletteVariantSpecs([]float64{120, -45.5}, [][]string{{"#000000", "#FFFFFF"}}, []string{"Game Boy"})
+	require.NoError(t, err)
+	require.Len(t, specs, 4)
+
+	assert.Equal(t, "hue120", specs[0].name)
+	assert.True(t, specs[0].isRotation)
+	assert.Equal(t, "hue-45.5", specs[1].name)
+	assert.Equal(t, "palette1", specs[2].name)
+	assert.Equal(t, []string{"#000000", "#FFFFFF"}, specs[2].target)
+	assert.Equal(t, "gameboy-dmg", specs[3].name)
+	assert.Len(t, specs[3].target, 4)
+}
+
+func TestPaletteVariantSpecs_Errors(t *testing.T) {
+	_, err := paletteVariantSpecs(nil, nil, nil)
+	assert.Error(t, err)
+
+	_, err = paletteVariantSpecs([]float64{400}, nil, nil)
+	assert.Error(t, err)
+
+	_, err = paletteVariantSpecs(nil, [][]string{{}}, nil)
+	assert.Error(t, err)
+
+	_, err = paletteVariantSpecs(nil, [][]string{{"nope"}}, nil)
+	assert.Error(t, err)
+
+	_, err = paletteVariantSpecs(nil, nil, []string{"unknown palette"})
+	assert.Error(t, err)
+}
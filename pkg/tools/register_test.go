@@ -250,3 +250,171 @@ func TestRegisterTransformTools_WithTiming(t *testing.T) {
 
 	assert.NotNil(t, server)
 }
+
+func TestRegisterLightingTools(t *testing.T) {
+	server, client, gen := createTestServer(t)
+	cfg := testutil.LoadTestConfig(t)
+	logger := mtlog.New(mtlog.WithMinimumLevel(core.ErrorLevel))
+
+	RegisterLightingTools(server, client, gen, cfg, logger)
+
+	assert.NotNil(t, server)
+}
+
+func TestRegisterLightingTools_WithTiming(t *testing.T) {
+	server, client, gen := createTestServer(t)
+	cfg := testutil.LoadTestConfig(t)
+	cfg.EnableTiming = true
+	logger := mtlog.New(mtlog.WithMinimumLevel(core.ErrorLevel))
+
+	RegisterLightingTools(server, client, gen, cfg, logger)
+
+	assert.NotNil(t, server)
+}
+
+func TestRegisterEffectTools(t *testing.T) {
+	server, client, gen := createTestServer(t)
+	cfg := testutil.LoadTestConfig(t)
+	logger := mtlog.New(mtlog.WithMinimumLevel(core.ErrorLevel))
+
+	RegisterEffectTools(server, client, gen, cfg, logger)
+
+	assert.NotNil(t, server)
+}
+
+func TestRegisterEffectTools_WithTiming(t *testing.T) {
+	server, client, gen := createTestServer(t)
+	cfg := testutil.LoadTestConfig(t)
+	cfg.EnableTiming = true
+	logger := mtlog.New(mtlog.WithMinimumLevel(core.ErrorLevel))
+
+	RegisterEffectTools(server, client, gen, cfg, logger)
+
+	assert.NotNil(t, server)
+}
+
+func TestRegisterLintTools(t *testing.T) {
+	server, client, gen := createTestServer(t)
+	cfg := testutil.LoadTestConfig(t)
+	logger := mtlog.New(mtlog.WithMinimumLevel(core.ErrorLevel))
+
+	RegisterLintTools(server, client, gen, cfg, logger)
+
+	assert.NotNil(t, server)
+}
+
+func TestRegisterLintTools_WithTiming(t *testing.T) {
+	server, client, gen := createTestServer(t)
+	cfg := testutil.LoadTestConfig(t)
+	cfg.EnableTiming = true
+	logger := mtlog.New(mtlog.WithMinimumLevel(core.ErrorLevel))
+
+	RegisterLintTools(server, client, gen, cfg, logger)
+
+	assert.NotNil(t, server)
+}
+
+func TestRegisterPaletteFileTools(t *testing.T) {
+	server, client, gen := createTestServer(t)
+	cfg := testutil.LoadTestConfig(t)
+	logger := mtlog.New(mtlog.WithMinimumLevel(core.ErrorLevel))
+
+	RegisterPaletteFileTools(server, client, gen, cfg, logger)
+
+	assert.NotNil(t, server)
+}
+
+func TestRegisterPaletteFileTools_WithTiming(t *testing.T) {
+	server, client, gen := createTestServer(t)
+	cfg := testutil.LoadTestConfig(t)
+	cfg.EnableTiming = true
+	logger := mtlog.New(mtlog.WithMinimumLevel(core.ErrorLevel))
+
+	RegisterPaletteFileTools(server, client, gen, cfg, logger)
+
+	assert.NotNil(t, server)
+}
+
+func TestRegisterPaletteLibraryTools(t *testing.T) {
+	server, client, gen := createTestServer(t)
+	cfg := testutil.LoadTestConfig(t)
+	logger := mtlog.New(mtlog.WithMinimumLevel(core.ErrorLevel))
+
+	RegisterPaletteLibraryTools(server, client, gen, cfg, logger)
+
+	assert.NotNil(t, server)
+}
+
+func TestRegisterPaletteLibraryTools_WithTiming(t *testing.T) {
+	server, client, gen := createTestServer(t)
+	cfg := testutil.LoadTestConfig(t)
+	cfg.EnableTiming = true
+	logger := mtlog.New(mtlog.WithMinimumLevel(core.ErrorLevel))
+
+	RegisterPaletteLibraryTools(server, client, gen, cfg, logger)
+
+	assert.NotNil(t, server)
+}
+
+func TestRegisterRecolorTools(t *testing.T) {
+	server, client, gen := createTestServer(t)
+	cfg := testutil.LoadTestConfig(t)
+	logger := mtlog.New(mtlog.WithMinimumLevel(core.ErrorLevel))
+
+	RegisterRecolorTools(server, client, gen, cfg, logger)
+
+	assert.NotNil(t, server)
+}
+
+func TestRegisterRecolorTools_WithTiming(t *testing.T) {
+	server, client, gen := createTestServer(t)
+	cfg := testutil.LoadTestConfig(t)
+	cfg.EnableTiming = true
+	logger := mtlog.New(mtlog.WithMinimumLevel(core.ErrorLevel))
+
+	RegisterRecolorTools(server, client, gen, cfg, logger)
+
+	assert.NotNil(t, server)
+}
+
+func TestRegisterTextTools(t *testing.T) {
+	server, client, gen := createTestServer(t)
+	cfg := testutil.LoadTestConfig(t)
+	logger := mtlog.New(mtlog.WithMinimumLevel(core.ErrorLevel))
+
+	RegisterTextTools(server, client, gen, cfg, logger)
+
+	assert.NotNil(t, server)
+}
+
+func TestRegisterTextTools_WithTiming(t *testing.T) {
+	server, client, gen := createTestServer(t)
+	cfg := testutil.LoadTestConfig(t)
+	cfg.EnableTiming = true
+	logger := mtlog.New(mtlog.WithMinimumLevel(core.ErrorLevel))
+
+	RegisterTextTools(server, client, gen, cfg, logger)
+
+	assert.NotNil(t, server)
+}
+
+func TestRegisterAccessibilityTools(t *testing.T) {
+	server, client, gen := createTestServer(t)
+	cfg := testutil.LoadTestConfig(t)
+	logger := mtlog.New(mtlog.WithMinimumLevel(core.ErrorLevel))
+
+	RegisterAccessibilityTools(server, client, gen, cfg, logger)
+
+	assert.NotNil(t, server)
+}
+
+func TestRegisterAccessibilityTools_WithTiming(t *testing.T) {
+	server, client, gen := createTestServer(t)
+	cfg := testutil.LoadTestConfig(t)
+	cfg.EnableTiming = true
+	logger := mtlog.New(mtlog.WithMinimumLevel(core.ErrorLevel))
+
+	RegisterAccessibilityTools(server, client, gen, cfg, logger)
+
+	assert.NotNil(t, server)
+}
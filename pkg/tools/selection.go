@@ -3,6 +3,8 @@ package tools
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -41,6 +43,50 @@ type SelectEllipseOutput struct {
 	Success bool `json:"success" jsonschema:"Whether the selection was created successfully"`
 }
 
+// SelectByColorInput defines the input parameters for the select_by_color tool.
+type SelectByColorInput struct {
+	SpritePath   string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName    string `json:"layer_name" jsonschema:"Name of the layer to sample colors from"`
+	FrameNumber  int    `json:"frame_number" jsonschema:"Frame number to sample colors from (1-based index)"`
+	X            int    `json:"x" jsonschema:"X coordinate of the seed pixel whose color is selected"`
+	Y            int    `json:"y" jsonschema:"Y coordinate of the seed pixel whose color is selected"`
+	Tolerance    int    `json:"tolerance,omitempty" jsonschema:"Color matching tolerance per RGBA channel (0-255, default 0)"`
+	Contiguous   *bool  `json:"contiguous,omitempty" jsonschema:"Select only matching pixels connected to the seed (default: true); false selects every matching pixel"`
+	Connectivity int    `json:"connectivity,omitempty" jsonschema:"Neighbors considered connected for contiguous selection: 4 or 8 (default: 4)"`
+	Mode         string `json:"mode" jsonschema:"Selection mode: replace, add, subtract, or intersect (default: replace)"`
+}
+
+// SelectByColorOutput defines the output for the select_by_color tool.
+type SelectByColorOutput struct {
+	Success bool `json:"success" jsonschema:"Whether the selection was created successfully"`
+}
+
+// SelectLayerAlphaInput defines the input parameters for the select_layer_alpha tool.
+type SelectLayerAlphaInput struct {
+	SpritePath  string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName   string `json:"layer_name" jsonschema:"Name of the layer whose non-transparent pixels are selected"`
+	FrameNumber int    `json:"frame_number" jsonschema:"Frame number to read (1-based index)"`
+	Mode        string `json:"mode" jsonschema:"Selection mode: replace, add, subtract, or intersect (default: replace)"`
+}
+
+// SelectLayerAlphaOutput defines the output for the select_layer_alpha tool.
+type SelectLayerAlphaOutput struct {
+	Success bool `json:"success" jsonschema:"Whether the selection was created successfully"`
+}
+
+// SelectPolygonInput defines the input parameters for the select_polygon tool.
+type SelectPolygonInput struct {
+	SpritePath string       `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	Points     []PointInput `json:"points" jsonschema:"Polygon vertices in order (minimum 3 points, closed automatically)"`
+	FillRule   string       `json:"fill_rule,omitempty" jsonschema:"How overlapping or self-intersecting areas are selected: non_zero or even_odd (default: non_zero)"`
+	Mode       string       `json:"mode" jsonschema:"Selection mode: replace, add, subtract, or intersect (default: replace)"`
+}
+
+// SelectPolygonOutput defines the output for the select_polygon tool.
+type SelectPolygonOutput struct {
+	Success bool `json:"success" jsonschema:"Whether the selection was created successfully"`
+}
+
 // SelectAllInput defines the input parameters for the select_all tool.
 type SelectAllInput struct {
 	SpritePath string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
@@ -73,44 +119,159 @@ type MoveSelectionOutput struct {
 	Success bool `json:"success" jsonschema:"Whether the selection was moved successfully"`
 }
 
+// ModifySelectionInput defines the input parameters for the modify_selection tool.
+type ModifySelectionInput struct {
+	SpritePath    string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	Operation     string `json:"operation" jsonschema:"Modification: expand, contract, border, invert, or remove_islands"`
+	Amount        int    `json:"amount,omitempty" jsonschema:"Distance in pixels for expand, contract, and border (minimum 1)"`
+	Shape         string `json:"shape,omitempty" jsonschema:"Distance shape for expand, contract, and border: square or diamond (default: square)"`
+	MinIslandSize int    `json:"min_island_size,omitempty" jsonschema:"For remove_islands: connected regions smaller than this many pixels are removed (minimum 1)"`
+}
+
+// ModifySelectionOutput defines the output for the modify_selection tool.
+type ModifySelectionOutput struct {
+	Success    bool `json:"success" jsonschema:"Whether the selection was modified successfully"`
+	X          int  `json:"x" jsonschema:"Left edge of the resulting selection bounds"`
+	Y          int  `json:"y" jsonschema:"Top edge of the resulting selection bounds"`
+	Width      int  `json:"width" jsonschema:"Width of the resulting selection bounds (0 if empty)"`
+	Height     int  `json:"height" jsonschema:"Height of the resulting selection bounds (0 if empty)"`
+	PixelCount int  `json:"pixel_count" jsonschema:"Number of selected pixels"`
+}
+
 // CutSelectionInput defines the input parameters for the cut_selection tool.
 type CutSelectionInput struct {
 	SpritePath  string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
 	LayerName   string `json:"layer_name" jsonschema:"Name of the layer to cut from"`
 	FrameNumber int    `json:"frame_number" jsonschema:"Frame number to cut from (1-based index)"`
+	Slot        string `json:"slot,omitempty" jsonschema:"Clipboard slot to store the pixels in (default: 'default')"`
 }
 
 // CutSelectionOutput defines the output for the cut_selection tool.
 type CutSelectionOutput struct {
-	Success bool `json:"success" jsonschema:"Whether the cut was successful"`
+	Success    bool   `json:"success" jsonschema:"Whether the cut was successful"`
+	Slot       string `json:"slot" jsonschema:"Clipboard slot holding the cut pixels"`
+	X          int    `json:"x" jsonschema:"Left edge of the cut area"`
+	Y          int    `json:"y" jsonschema:"Top edge of the cut area"`
+	Width      int    `json:"width" jsonschema:"Width of the cut area"`
+	Height     int    `json:"height" jsonschema:"Height of the cut area"`
+	PixelCount int    `json:"pixel_count" jsonschema:"Number of selected pixels that were cut"`
 }
 
 // CopySelectionInput defines the input parameters for the copy_selection tool.
 type CopySelectionInput struct {
-	SpritePath string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	SpritePath  string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName   string `json:"layer_name,omitempty" jsonschema:"Name of the layer to copy from (default: bottom layer)"`
+	FrameNumber int    `json:"frame_number,omitempty" jsonschema:"Frame number to copy from (1-based index, default: 1)"`
+	Slot        string `json:"slot,omitempty" jsonschema:"Clipboard slot to store the pixels in (default: 'default')"`
 }
 
 // CopySelectionOutput defines the output for the copy_selection tool.
 type CopySelectionOutput struct {
-	Success bool `json:"success" jsonschema:"Whether the copy was successful"`
+	Success    bool   `json:"success" jsonschema:"Whether the copy was successful"`
+	Slot       string `json:"slot" jsonschema:"Clipboard slot holding the copied pixels"`
+	X          int    `json:"x" jsonschema:"Left edge of the copied area"`
+	Y          int    `json:"y" jsonschema:"Top edge of the copied area"`
+	Width      int    `json:"width" jsonschema:"Width of the copied area"`
+	Height     int    `json:"height" jsonschema:"Height of the copied area"`
+	PixelCount int    `json:"pixel_count" jsonschema:"Number of selected pixels that were copied"`
 }
 
 // PasteClipboardInput defines the input parameters for the paste_clipboard tool.
 type PasteClipboardInput struct {
-	SpritePath  string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	SpritePath  string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file to paste into (may differ from the sprite the pixels were copied from)"`
 	LayerName   string `json:"layer_name" jsonschema:"Name of the layer to paste onto"`
 	FrameNumber int    `json:"frame_number" jsonschema:"Frame number to paste onto (1-based index)"`
-	X           *int   `json:"x,omitempty" jsonschema:"X coordinate for paste position (optional)"`
-	Y           *int   `json:"y,omitempty" jsonschema:"Y coordinate for paste position (optional)"`
+	Slot        string `json:"slot,omitempty" jsonschema:"Clipboard slot to paste from (default: 'default')"`
+	X           *int   `json:"x,omitempty" jsonschema:"X coordinate for paste position (optional, default: where the pixels were copied from)"`
+	Y           *int   `json:"y,omitempty" jsonschema:"Y coordinate for paste position (optional, default: where the pixels were copied from)"`
 }
 
 // PasteClipboardOutput defines the output for the paste_clipboard tool.
 type PasteClipboardOutput struct {
-	Success bool `json:"success" jsonschema:"Whether the paste was successful"`
+	Success        bool   `json:"success" jsonschema:"Whether the paste was successful"`
+	Slot           string `json:"slot" jsonschema:"Clipboard slot that was pasted"`
+	X              int    `json:"x" jsonschema:"Left edge of the pasted area"`
+	Y              int    `json:"y" jsonschema:"Top edge of the pasted area"`
+	Width          int    `json:"width" jsonschema:"Width of the pasted area"`
+	Height         int    `json:"height" jsonschema:"Height of the pasted area"`
+	ColorsRemapped int    `json:"colors_remapped" jsonschema:"Number of distinct colors snapped to the palette of an indexed target sprite"`
+}
+
+// FillSelectionInput defines the input parameters for the fill_selection tool.
+type FillSelectionInput struct {
+	SpritePath  string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName   string `json:"layer_name" jsonschema:"Name of the layer to fill"`
+	FrameNumber int    `json:"frame_number" jsonschema:"Frame number to fill (1-based index)"`
+	Color       string `json:"color" jsonschema:"Fill color in hex format (#RRGGBB or #RRGGBBAA)"`
+	UsePalette  bool   `json:"use_palette,omitempty" jsonschema:"Snap colors to nearest palette color (default: false)"`
+	PaletteName string `json:"palette_name,omitempty" jsonschema:"Snap colors to this bundled palette instead of the sprite palette, e.g. pico-8 (see list_palettes)"`
+}
+
+// FillSelectionOutput defines the output for the fill_selection tool.
+type FillSelectionOutput struct {
+	Success       bool `json:"success" jsonschema:"Whether the fill was successful"`
+	PixelsPainted int  `json:"pixels_painted" jsonschema:"Number of pixels painted"`
+}
+
+// StrokeSelectionInput defines the input parameters for the stroke_selection tool.
+type StrokeSelectionInput struct {
+	SpritePath  string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName   string `json:"layer_name" jsonschema:"Name of the layer to draw on"`
+	FrameNumber int    `json:"frame_number" jsonschema:"Frame number to draw on (1-based index)"`
+	Color       string `json:"color" jsonschema:"Stroke color in hex format (#RRGGBB or #RRGGBBAA)"`
+	Width       int    `json:"width,omitempty" jsonschema:"Stroke width in pixels (default: 1)"`
+	Location    string `json:"location,omitempty" jsonschema:"Stroke position relative to the selection edge: inside, outside, or center (default: inside)"`
+	UsePalette  bool   `json:"use_palette,omitempty" jsonschema:"Snap colors to nearest palette color (default: false)"`
+	PaletteName string `json:"palette_name,omitempty" jsonschema:"Snap colors to this bundled palette instead of the sprite palette, e.g. pico-8 (see list_palettes)"`
+}
+
+// StrokeSelectionOutput defines the output for the stroke_selection tool.
+type StrokeSelectionOutput struct {
+	Success       bool `json:"success" jsonschema:"Whether the stroke was successful"`
+	PixelsPainted int  `json:"pixels_painted" jsonschema:"Number of pixels painted"`
+}
+
+// ClearSelectionInput defines the input parameters for the clear_selection tool.
+type ClearSelectionInput struct {
+	SpritePath  string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName   string `json:"layer_name" jsonschema:"Name of the layer to clear"`
+	FrameNumber int    `json:"frame_number" jsonschema:"Frame number to clear (1-based index)"`
+}
+
+// ClearSelectionOutput defines the output for the clear_selection tool.
+type ClearSelectionOutput struct {
+	Success       bool `json:"success" jsonschema:"Whether the clear was successful"`
+	PixelsCleared int  `json:"pixels_cleared" jsonschema:"Number of pixels made transparent"`
+}
+
+// TransformSelectionInput defines the input parameters for the transform_selection tool.
+type TransformSelectionInput struct {
+	SpritePath  string  `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName   string  `json:"layer_name" jsonschema:"Name of the layer to transform"`
+	FrameNumber int     `json:"frame_number" jsonschema:"Frame number to transform (1-based index)"`
+	Operation   string  `json:"operation" jsonschema:"Transformation: flip_horizontal, flip_vertical, rotate_90, rotate_180, rotate_270 (clockwise), or scale"`
+	ScaleX      float64 `json:"scale_x,omitempty" jsonschema:"Horizontal scale factor for scale (default: 1)"`
+	ScaleY      float64 `json:"scale_y,omitempty" jsonschema:"Vertical scale factor for scale (default: 1)"`
+}
+
+// TransformSelectionOutput defines the output for the transform_selection tool.
+type TransformSelectionOutput struct {
+	Success    bool `json:"success" jsonschema:"Whether the transformation was successful"`
+	X          int  `json:"x" jsonschema:"Left edge of the transformed selection bounds"`
+	Y          int  `json:"y" jsonschema:"Top edge of the transformed selection bounds"`
+	Width      int  `json:"width" jsonschema:"Width of the transformed selection bounds"`
+	Height     int  `json:"height" jsonschema:"Height of the transformed selection bounds"`
+	PixelCount int  `json:"pixel_count" jsonschema:"Number of selected pixels after the transformation"`
 }
 
 // RegisterSelectionTools registers all selection-related MCP tools with the server.
+//
+// Cut, copy, and paste share one in-memory clipboard store per registration,
+// so clipboard slots live as long as the server and can move pixels between
+// sprites.
 func RegisterSelectionTools(server *mcp.Server, client *aseprite.Client, gen *aseprite.LuaGenerator, cfg *config.Config, logger core.Logger) {
+	clipboard := newClipboardStore()
+
 	// Register select_rectangle tool
 	mcp.AddTool(
 		server,
@@ -213,6 +374,202 @@ func RegisterSelectionTools(server *mcp.Server, client *aseprite.Client, gen *as
 		}),
 	)
 
+	// Register select_by_color tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "select_by_color",
+			Description: "Select pixels matching the color at a seed point (magic wand) with specified mode (replace/add/subtract/intersect). Contiguous selection follows 4- or 8-connected neighbors; global selection picks every matching pixel on the layer. Tolerance is the allowed difference per RGBA channel.",
+		},
+		maybeWrapWithTiming("select_by_color", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input SelectByColorInput) (*mcp.CallToolResult, *SelectByColorOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("select_by_color tool called", "sprite_path", input.SpritePath, "layer_name", input.LayerName, "frame_number", input.FrameNumber, "x", input.X, "y", input.Y, "tolerance", input.Tolerance, "mode", input.Mode)
+
+			// Set defaults
+			contiguous := true
+			if input.Contiguous != nil {
+				contiguous = *input.Contiguous
+			}
+			if input.Connectivity == 0 {
+				input.Connectivity = 4
+			}
+
+			// Validate inputs
+			if input.LayerName == "" {
+				return nil, nil, fmt.Errorf("layer_name cannot be empty")
+			}
+
+			if input.FrameNumber < 1 {
+				return nil, nil, fmt.Errorf("frame_number must be at least 1, got %d", input.FrameNumber)
+			}
+
+			if input.X < 0 || input.Y < 0 {
+				return nil, nil, fmt.Errorf("seed point must be non-negative, got (%d, %d)", input.X, input.Y)
+			}
+
+			if input.Tolerance < 0 || input.Tolerance > 255 {
+				return nil, nil, fmt.Errorf("tolerance must be between 0 and 255, got %d", input.Tolerance)
+			}
+
+			if input.Connectivity != 4 && input.Connectivity != 8 {
+				return nil, nil, fmt.Errorf("connectivity must be 4 or 8, got %d", input.Connectivity)
+			}
+
+			// Validate mode
+			mode := input.Mode
+			if mode == "" {
+				mode = "replace"
+			}
+			validModes := map[string]bool{"replace": true, "add": true, "subtract": true, "intersect": true}
+			if !validModes[mode] {
+				return nil, nil, fmt.Errorf("invalid mode %q, must be one of: replace, add, subtract, intersect", mode)
+			}
+
+			// Generate Lua script
+			script := gen.SelectByColor(input.LayerName, input.FrameNumber, input.X, input.Y, input.Tolerance, contiguous, input.Connectivity, mode)
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to create color selection", "error", err)
+				return nil, nil, fmt.Errorf("failed to create color selection: %w", err)
+			}
+
+			// Check for success message
+			if !strings.Contains(output, "Color selection created successfully") {
+				opLogger.Warning("Unexpected output from select_by_color", "output", output)
+			}
+
+			opLogger.Information("Color selection created successfully", "sprite", input.SpritePath, "layer", input.LayerName, "frame", input.FrameNumber, "contiguous", contiguous, "mode", mode)
+
+			return nil, &SelectByColorOutput{Success: true}, nil
+		}),
+	)
+
+	// Register select_layer_alpha tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "select_layer_alpha",
+			Description: "Select the non-transparent pixels of a layer in a frame with specified mode (replace/add/subtract/intersect), like Ctrl+clicking a layer in Aseprite.",
+		},
+		maybeWrapWithTiming("select_layer_alpha", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input SelectLayerAlphaInput) (*mcp.CallToolResult, *SelectLayerAlphaOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("select_layer_alpha tool called", "sprite_path", input.SpritePath, "layer_name", input.LayerName, "frame_number", input.FrameNumber, "mode", input.Mode)
+
+			// Validate inputs
+			if input.LayerName == "" {
+				return nil, nil, fmt.Errorf("layer_name cannot be empty")
+			}
+
+			if input.FrameNumber < 1 {
+				return nil, nil, fmt.Errorf("frame_number must be at least 1, got %d", input.FrameNumber)
+			}
+
+			// Validate mode
+			mode := input.Mode
+			if mode == "" {
+				mode = "replace"
+			}
+			validModes := map[string]bool{"replace": true, "add": true, "subtract": true, "intersect": true}
+			if !validModes[mode] {
+				return nil, nil, fmt.Errorf("invalid mode %q, must be one of: replace, add, subtract, intersect", mode)
+			}
+
+			// Generate Lua script
+			script := gen.SelectLayerAlpha(input.LayerName, input.FrameNumber, mode)
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to create layer alpha selection", "error", err)
+				return nil, nil, fmt.Errorf("failed to create layer alpha selection: %w", err)
+			}
+
+			// Check for success message
+			if !strings.Contains(output, "Layer alpha selection created successfully") {
+				opLogger.Warning("Unexpected output from select_layer_alpha", "output", output)
+			}
+
+			opLogger.Information("Layer alpha selection created successfully", "sprite", input.SpritePath, "layer", input.LayerName, "frame", input.FrameNumber, "mode", mode)
+
+			return nil, &SelectLayerAlphaOutput{Success: true}, nil
+		}),
+	)
+
+	// Register select_polygon tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "select_polygon",
+			Description: "Create a polygonal (lasso) selection from a list of points with specified mode (replace/add/subtract/intersect). The polygon is closed automatically and covers the same pixels as draw_polygon.",
+		},
+		maybeWrapWithTiming("select_polygon", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input SelectPolygonInput) (*mcp.CallToolResult, *SelectPolygonOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("select_polygon tool called", "sprite_path", input.SpritePath, "points", len(input.Points), "fill_rule", input.FillRule, "mode", input.Mode)
+
+			// Set defaults
+			if input.FillRule == "" {
+				input.FillRule = aseprite.FillRuleNonZero
+			}
+
+			// Validate inputs
+			if len(input.Points) < 3 {
+				return nil, nil, fmt.Errorf("at least 3 points are required, got %d", len(input.Points))
+			}
+
+			if input.FillRule != aseprite.FillRuleNonZero && input.FillRule != aseprite.FillRuleEvenOdd {
+				return nil, nil, fmt.Errorf("invalid fill_rule: %s (must be non_zero or even_odd)", input.FillRule)
+			}
+
+			if err := validateShapePoints(input.Points); err != nil {
+				return nil, nil, err
+			}
+
+			// Validate mode
+			mode := input.Mode
+			if mode == "" {
+				mode = "replace"
+			}
+			validModes := map[string]bool{"replace": true, "add": true, "subtract": true, "intersect": true}
+			if !validModes[mode] {
+				return nil, nil, fmt.Errorf("invalid mode %q, must be one of: replace, add, subtract, intersect", mode)
+			}
+
+			// Convert PointInput to aseprite.Point
+			points := make([]aseprite.Point, len(input.Points))
+			for i, p := range input.Points {
+				points[i] = aseprite.Point{X: p.X, Y: p.Y}
+			}
+
+			// Clip the polygon to the canvas
+			clip, err := spriteBounds(ctx, client, gen, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to get sprite info", "error", err)
+				return nil, nil, fmt.Errorf("failed to get sprite info: %w", err)
+			}
+
+			// Generate Lua script
+			script := gen.SelectPolygon(points, input.FillRule, mode, clip)
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to create polygon selection", "error", err)
+				return nil, nil, fmt.Errorf("failed to create polygon selection: %w", err)
+			}
+
+			// Check for success message
+			if !strings.Contains(output, "Polygon selection created successfully") {
+				opLogger.Warning("Unexpected output from select_polygon", "output", output)
+			}
+
+			opLogger.Information("Polygon selection created successfully", "sprite", input.SpritePath, "points", len(points), "fill_rule", input.FillRule, "mode", mode)
+
+			return nil, &SelectPolygonOutput{Success: true}, nil
+		}),
+	)
+
 	// Register select_all tool
 	mcp.AddTool(
 		server,
@@ -282,7 +639,7 @@ func RegisterSelectionTools(server *mcp.Server, client *aseprite.Client, gen *as
 		server,
 		&mcp.Tool{
 			Name:        "move_selection",
-			Description: "Move the current selection by a specified offset. Does not move the pixel content, only the selection bounds. Requires an active selection.",
+			Description: "Move the current selection by a specified offset. Does not move the pixel content, only the selection mask. Requires an active selection.",
 		},
 		maybeWrapWithTiming("move_selection", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input MoveSelectionInput) (*mcp.CallToolResult, *MoveSelectionOutput, error) {
 			opLogger := logger.WithContext(ctx)
@@ -309,16 +666,77 @@ func RegisterSelectionTools(server *mcp.Server, client *aseprite.Client, gen *as
 		}),
 	)
 
+	// Register modify_selection tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "modify_selection",
+			Description: "Refine the current selection: expand or contract by N pixels (square or diamond shape), keep a border ring of width N, invert, or remove islands smaller than K pixels. Returns the resulting selection bounds and pixel count.",
+		},
+		maybeWrapWithTiming("modify_selection", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input ModifySelectionInput) (*mcp.CallToolResult, *ModifySelectionOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("modify_selection tool called", "sprite_path", input.SpritePath, "operation", input.Operation, "amount", input.Amount, "shape", input.Shape, "min_island_size", input.MinIslandSize)
+
+			// Set defaults
+			if input.Shape == "" {
+				input.Shape = "square"
+			}
+
+			// Validate inputs
+			switch input.Operation {
+			case "expand", "contract", "border":
+				if input.Amount < 1 {
+					return nil, nil, fmt.Errorf("amount must be at least 1 for %s, got %d", input.Operation, input.Amount)
+				}
+			case "remove_islands":
+				if input.MinIslandSize < 1 {
+					return nil, nil, fmt.Errorf("min_island_size must be at least 1, got %d", input.MinIslandSize)
+				}
+			case "invert":
+			default:
+				return nil, nil, fmt.Errorf("invalid operation %q, must be one of: expand, contract, border, invert, remove_islands", input.Operation)
+			}
+
+			if input.Shape != "square" && input.Shape != "diamond" {
+				return nil, nil, fmt.Errorf("invalid shape %q, must be square or diamond", input.Shape)
+			}
+
+			// Generate Lua script
+			script := gen.ModifySelection(input.Operation, input.Amount, input.Shape, input.MinIslandSize)
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to modify selection", "error", err)
+				return nil, nil, fmt.Errorf("failed to modify selection: %w", err)
+			}
+
+			result := ModifySelectionOutput{Success: true}
+			if err := parseJSON(output, &result); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse selection result: %w", err)
+			}
+
+			opLogger.Information("Selection modified successfully", "sprite", input.SpritePath, "operation", input.Operation, "pixel_count", result.PixelCount)
+
+			return nil, &result, nil
+		}),
+	)
+
 	// Register cut_selection tool
 	mcp.AddTool(
 		server,
 		&mcp.Tool{
 			Name:        "cut_selection",
-			Description: "Cut the selected pixels to clipboard. Removes pixels from the specified layer and frame, placing them on the clipboard. Requires an active selection.",
+			Description: "Cut the selected pixels to a named clipboard slot (default: 'default'). Removes pixels from the specified layer and frame; the slot keeps the pixels with the source palette and position in server memory, so they can be pasted into any sprite. Returns the cut bounds and pixel count. Requires an active selection.",
 		},
 		maybeWrapWithTiming("cut_selection", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input CutSelectionInput) (*mcp.CallToolResult, *CutSelectionOutput, error) {
 			opLogger := logger.WithContext(ctx)
-			opLogger.Debug("cut_selection tool called", "sprite_path", input.SpritePath, "layer_name", input.LayerName, "frame_number", input.FrameNumber)
+			opLogger.Debug("cut_selection tool called", "sprite_path", input.SpritePath, "layer_name", input.LayerName, "frame_number", input.FrameNumber, "slot", input.Slot)
+
+			// Set defaults
+			if input.Slot == "" {
+				input.Slot = defaultClipboardSlot
+			}
 
 			// Validate inputs
 			if input.LayerName == "" {
@@ -329,8 +747,15 @@ func RegisterSelectionTools(server *mcp.Server, client *aseprite.Client, gen *as
 				return nil, nil, fmt.Errorf("frame_number must be at least 1, got %d", input.FrameNumber)
 			}
 
+			tempDir, err := os.MkdirTemp("", "pixel-mcp-clipboard-*")
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create temp directory: %w", err)
+			}
+			defer os.RemoveAll(tempDir)
+
 			// Generate Lua script
-			script := gen.CutSelection(input.LayerName, input.FrameNumber)
+			pngPath := filepath.Join(tempDir, "clipboard.png")
+			script := gen.CutSelection(input.LayerName, input.FrameNumber, pngPath)
 
 			// Execute Lua script with the sprite
 			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
@@ -344,9 +769,31 @@ func RegisterSelectionTools(server *mcp.Server, client *aseprite.Client, gen *as
 				opLogger.Warning("Unexpected output from cut_selection", "output", output)
 			}
 
-			opLogger.Information("Cut selection completed successfully", "sprite", input.SpritePath, "layer", input.LayerName, "frame", input.FrameNumber)
+			var export clipboardExport
+			if err := parseJSON(output, &export); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse cut result: %w", err)
+			}
 
-			return nil, &CutSelectionOutput{Success: true}, nil
+			// Store the pixels in the clipboard slot
+			entry, err := loadClipboardEntry(pngPath, export)
+			if err != nil {
+				return nil, nil, err
+			}
+			clipboard.set(input.Slot, entry)
+
+			result := &CutSelectionOutput{
+				Success:    true,
+				Slot:       input.Slot,
+				X:          export.X,
+				Y:          export.Y,
+				Width:      export.Width,
+				Height:     export.Height,
+				PixelCount: export.PixelCount,
+			}
+
+			opLogger.Information("Cut selection completed successfully", "sprite", input.SpritePath, "layer", input.LayerName, "frame", input.FrameNumber, "slot", input.Slot, "pixel_count", result.PixelCount)
+
+			return nil, result, nil
 		}),
 	)
 
@@ -355,14 +802,34 @@ func RegisterSelectionTools(server *mcp.Server, client *aseprite.Client, gen *as
 		server,
 		&mcp.Tool{
 			Name:        "copy_selection",
-			Description: "Copy the selected pixels to clipboard without removing them. Requires an active selection.",
+			Description: "Copy the selected pixels of a layer and frame (default: bottom layer, frame 1) to a named clipboard slot (default: 'default') without removing them. The slot keeps the pixels with the source palette and position in server memory, so they can be pasted into any sprite. Returns the copied bounds and pixel count. Requires an active selection.",
 		},
 		maybeWrapWithTiming("copy_selection", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input CopySelectionInput) (*mcp.CallToolResult, *CopySelectionOutput, error) {
 			opLogger := logger.WithContext(ctx)
-			opLogger.Debug("copy_selection tool called", "sprite_path", input.SpritePath)
+			opLogger.Debug("copy_selection tool called", "sprite_path", input.SpritePath, "layer_name", input.LayerName, "frame_number", input.FrameNumber, "slot", input.Slot)
+
+			// Set defaults
+			if input.FrameNumber == 0 {
+				input.FrameNumber = 1
+			}
+			if input.Slot == "" {
+				input.Slot = defaultClipboardSlot
+			}
+
+			// Validate inputs
+			if input.FrameNumber < 1 {
+				return nil, nil, fmt.Errorf("frame_number must be at least 1, got %d", input.FrameNumber)
+			}
+
+			tempDir, err := os.MkdirTemp("", "pixel-mcp-clipboard-*")
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create temp directory: %w", err)
+			}
+			defer os.RemoveAll(tempDir)
 
 			// Generate Lua script
-			script := gen.CopySelection()
+			pngPath := filepath.Join(tempDir, "clipboard.png")
+			script := gen.CopySelection(input.LayerName, input.FrameNumber, pngPath)
 
 			// Execute Lua script with the sprite
 			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
@@ -376,9 +843,31 @@ func RegisterSelectionTools(server *mcp.Server, client *aseprite.Client, gen *as
 				opLogger.Warning("Unexpected output from copy_selection", "output", output)
 			}
 
-			opLogger.Information("Copy selection completed successfully", "sprite", input.SpritePath)
+			var export clipboardExport
+			if err := parseJSON(output, &export); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse copy result: %w", err)
+			}
+
+			// Store the pixels in the clipboard slot
+			entry, err := loadClipboardEntry(pngPath, export)
+			if err != nil {
+				return nil, nil, err
+			}
+			clipboard.set(input.Slot, entry)
+
+			result := &CopySelectionOutput{
+				Success:    true,
+				Slot:       input.Slot,
+				X:          export.X,
+				Y:          export.Y,
+				Width:      export.Width,
+				Height:     export.Height,
+				PixelCount: export.PixelCount,
+			}
+
+			opLogger.Information("Copy selection completed successfully", "sprite", input.SpritePath, "layer", input.LayerName, "frame", input.FrameNumber, "slot", input.Slot, "pixel_count", result.PixelCount)
 
-			return nil, &CopySelectionOutput{Success: true}, nil
+			return nil, result, nil
 		}),
 	)
 
@@ -387,11 +876,16 @@ func RegisterSelectionTools(server *mcp.Server, client *aseprite.Client, gen *as
 		server,
 		&mcp.Tool{
 			Name:        "paste_clipboard",
-			Description: "Paste clipboard content onto the specified layer and frame. Optionally specify paste position (x, y). Requires clipboard to contain image data.",
+			Description: "Paste a named clipboard slot (default: 'default') onto a layer and frame of any sprite, including a different sprite than the one copied from. Optionally specify paste position (x, y); defaults to where the pixels were copied from. Colors are snapped to the target palette when the target sprite is indexed. Returns the pasted area and the number of remapped colors.",
 		},
 		maybeWrapWithTiming("paste_clipboard", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input PasteClipboardInput) (*mcp.CallToolResult, *PasteClipboardOutput, error) {
 			opLogger := logger.WithContext(ctx)
-			opLogger.Debug("paste_clipboard tool called", "sprite_path", input.SpritePath, "layer_name", input.LayerName, "frame_number", input.FrameNumber, "x", input.X, "y", input.Y)
+			opLogger.Debug("paste_clipboard tool called", "sprite_path", input.SpritePath, "layer_name", input.LayerName, "frame_number", input.FrameNumber, "slot", input.Slot, "x", input.X, "y", input.Y)
+
+			// Set defaults
+			if input.Slot == "" {
+				input.Slot = defaultClipboardSlot
+			}
 
 			// Validate inputs
 			if input.LayerName == "" {
@@ -402,8 +896,51 @@ func RegisterSelectionTools(server *mcp.Server, client *aseprite.Client, gen *as
 				return nil, nil, fmt.Errorf("frame_number must be at least 1, got %d", input.FrameNumber)
 			}
 
+			entry, ok := clipboard.get(input.Slot)
+			if !ok {
+				return nil, nil, clipboard.emptySlotError(input.Slot)
+			}
+
+			x, y := entry.origin.X, entry.origin.Y
+			if input.X != nil {
+				x = *input.X
+			}
+			if input.Y != nil {
+				y = *input.Y
+			}
+
+			// Remap colors for indexed targets
+			info, err := getSpriteInfoHelper(ctx, client, gen, input.SpritePath)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get sprite info: %w", err)
+			}
+
+			var palette []aseprite.PaletteColor
+			if info.ColorMode == "indexed" {
+				palette, err = loadSpritePalette(ctx, client, gen, input.SpritePath)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+
+			img, remapped, err := entry.imageFor(info.ColorMode, palette)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			tempDir, err := os.MkdirTemp("", "pixel-mcp-clipboard-*")
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create temp directory: %w", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			pngPath := filepath.Join(tempDir, "clipboard.png")
+			if err := savePNG(pngPath, img); err != nil {
+				return nil, nil, err
+			}
+
 			// Generate Lua script
-			script := gen.PasteClipboard(input.LayerName, input.FrameNumber, input.X, input.Y)
+			script := gen.PasteClipboard(input.LayerName, input.FrameNumber, pngPath, x, y)
 
 			// Execute Lua script with the sprite
 			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
@@ -417,9 +954,235 @@ func RegisterSelectionTools(server *mcp.Server, client *aseprite.Client, gen *as
 				opLogger.Warning("Unexpected output from paste_clipboard", "output", output)
 			}
 
-			opLogger.Information("Paste completed successfully", "sprite", input.SpritePath, "layer", input.LayerName, "frame", input.FrameNumber)
+			result := PasteClipboardOutput{Success: true, Slot: input.Slot, ColorsRemapped: remapped}
+			if err := parseJSON(output, &result); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse paste result: %w", err)
+			}
+
+			opLogger.Information("Paste completed successfully", "sprite", input.SpritePath, "layer", input.LayerName, "frame", input.FrameNumber, "slot", input.Slot, "x", result.X, "y", result.Y, "colors_remapped", remapped)
+
+			return nil, &result, nil
+		}),
+	)
+
+	// Register fill_selection tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "fill_selection",
+			Description: "Fill the selected pixels of a layer and frame with a color. Follows the exact selection shape and replaces pixels without blending. Requires an active selection.",
+		},
+		maybeWrapWithTiming("fill_selection", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input FillSelectionInput) (*mcp.CallToolResult, *FillSelectionOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("fill_selection tool called", "sprite_path", input.SpritePath, "layer_name", input.LayerName, "frame_number", input.FrameNumber, "color", input.Color)
+
+			// Validate inputs
+			if input.LayerName == "" {
+				return nil, nil, fmt.Errorf("layer_name cannot be empty")
+			}
+
+			if input.FrameNumber < 1 {
+				return nil, nil, fmt.Errorf("frame_number must be at least 1, got %d", input.FrameNumber)
+			}
+
+			// Parse color
+			var color aseprite.Color
+			if err := color.FromHex(input.Color); err != nil {
+				return nil, nil, fmt.Errorf("invalid color format: %w", err)
+			}
+
+			// Snap to bundled palette
+			usePalette, err := applyPaletteName(input.PaletteName, input.UsePalette, &color)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// Generate Lua script
+			script := gen.FillSelection(input.LayerName, input.FrameNumber, color, usePalette)
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to fill selection", "error", err)
+				return nil, nil, fmt.Errorf("failed to fill selection: %w", err)
+			}
+
+			result := FillSelectionOutput{Success: true}
+			if err := parseJSON(output, &result); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse fill result: %w", err)
+			}
+
+			opLogger.Information("Selection filled successfully", "sprite", input.SpritePath, "layer", input.LayerName, "frame", input.FrameNumber, "pixels_painted", result.PixelsPainted)
+
+			return nil, &result, nil
+		}),
+	)
+
+	// Register stroke_selection tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "stroke_selection",
+			Description: "Draw a stroke of a given width along the edge of the selection on a layer and frame, inside, outside, or centered on the edge. Follows the exact selection shape. Requires an active selection.",
+		},
+		maybeWrapWithTiming("stroke_selection", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input StrokeSelectionInput) (*mcp.CallToolResult, *StrokeSelectionOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("stroke_selection tool called", "sprite_path", input.SpritePath, "layer_name", input.LayerName, "frame_number", input.FrameNumber, "color", input.Color, "width", input.Width, "location", input.Location)
+
+			// Set defaults
+			if input.Width == 0 {
+				input.Width = 1
+			}
+			if input.Location == "" {
+				input.Location = aseprite.StrokeInside
+			}
+
+			// Validate inputs
+			if input.LayerName == "" {
+				return nil, nil, fmt.Errorf("layer_name cannot be empty")
+			}
+
+			if input.FrameNumber < 1 {
+				return nil, nil, fmt.Errorf("frame_number must be at least 1, got %d", input.FrameNumber)
+			}
+
+			if input.Width < 1 {
+				return nil, nil, fmt.Errorf("width must be at least 1, got %d", input.Width)
+			}
+
+			if input.Location != aseprite.StrokeInside && input.Location != aseprite.StrokeOutside && input.Location != aseprite.StrokeCenter {
+				return nil, nil, fmt.Errorf("invalid location %q, must be one of: inside, outside, center", input.Location)
+			}
+
+			// Parse color
+			var color aseprite.Color
+			if err := color.FromHex(input.Color); err != nil {
+				return nil, nil, fmt.Errorf("invalid color format: %w", err)
+			}
+
+			// Snap to bundled palette
+			usePalette, err := applyPaletteName(input.PaletteName, input.UsePalette, &color)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// Generate Lua script
+			script := gen.StrokeSelection(input.LayerName, input.FrameNumber, color, input.Width, input.Location, usePalette)
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to stroke selection", "error", err)
+				return nil, nil, fmt.Errorf("failed to stroke selection: %w", err)
+			}
+
+			result := StrokeSelectionOutput{Success: true}
+			if err := parseJSON(output, &result); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse stroke result: %w", err)
+			}
+
+			opLogger.Information("Selection stroked successfully", "sprite", input.SpritePath, "layer", input.LayerName, "frame", input.FrameNumber, "pixels_painted", result.PixelsPainted)
+
+			return nil, &result, nil
+		}),
+	)
+
+	// Register clear_selection tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "clear_selection",
+			Description: "Make the selected pixels of a layer and frame transparent without copying them to the clipboard. The selection is kept. Requires an active selection.",
+		},
+		maybeWrapWithTiming("clear_selection", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input ClearSelectionInput) (*mcp.CallToolResult, *ClearSelectionOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("clear_selection tool called", "sprite_path", input.SpritePath, "layer_name", input.LayerName, "frame_number", input.FrameNumber)
+
+			// Validate inputs
+			if input.LayerName == "" {
+				return nil, nil, fmt.Errorf("layer_name cannot be empty")
+			}
+
+			if input.FrameNumber < 1 {
+				return nil, nil, fmt.Errorf("frame_number must be at least 1, got %d", input.FrameNumber)
+			}
+
+			// Generate Lua script
+			script := gen.ClearSelection(input.LayerName, input.FrameNumber)
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to clear selection", "error", err)
+				return nil, nil, fmt.Errorf("failed to clear selection: %w", err)
+			}
+
+			result := ClearSelectionOutput{Success: true}
+			if err := parseJSON(output, &result); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse clear result: %w", err)
+			}
+
+			opLogger.Information("Selection cleared successfully", "sprite", input.SpritePath, "layer", input.LayerName, "frame", input.FrameNumber, "pixels_cleared", result.PixelsCleared)
+
+			return nil, &result, nil
+		}),
+	)
+
+	// Register transform_selection tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "transform_selection",
+			Description: "Flip, rotate (90/180/270 clockwise), or scale (nearest neighbor) only the selected pixels of a layer and frame, centered on the selection bounds. The selection is transformed with the pixels. Returns the new selection bounds and pixel count. Requires an active selection.",
+		},
+		maybeWrapWithTiming("transform_selection", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input TransformSelectionInput) (*mcp.CallToolResult, *TransformSelectionOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("transform_selection tool called", "sprite_path", input.SpritePath, "layer_name", input.LayerName, "frame_number", input.FrameNumber, "operation", input.Operation, "scale_x", input.ScaleX, "scale_y", input.ScaleY)
+
+			// Set defaults
+			if input.ScaleX == 0 {
+				input.ScaleX = 1
+			}
+			if input.ScaleY == 0 {
+				input.ScaleY = 1
+			}
+
+			// Validate inputs
+			if input.LayerName == "" {
+				return nil, nil, fmt.Errorf("layer_name cannot be empty")
+			}
+
+			if input.FrameNumber < 1 {
+				return nil, nil, fmt.Errorf("frame_number must be at least 1, got %d", input.FrameNumber)
+			}
+
+			validOperations := map[string]bool{"flip_horizontal": true, "flip_vertical": true, "rotate_90": true, "rotate_180": true, "rotate_270": true, "scale": true}
+			if !validOperations[input.Operation] {
+				return nil, nil, fmt.Errorf("invalid operation %q, must be one of: flip_horizontal, flip_vertical, rotate_90, rotate_180, rotate_270, scale", input.Operation)
+			}
+
+			if input.ScaleX <= 0 || input.ScaleY <= 0 || input.ScaleX > 16 || input.ScaleY > 16 {
+				return nil, nil, fmt.Errorf("scale_x and scale_y must be greater than 0 and at most 16, got %g and %g", input.ScaleX, input.ScaleY)
+			}
+
+			// Generate Lua script
+			script := gen.TransformSelection(input.LayerName, input.FrameNumber, input.Operation, input.ScaleX, input.ScaleY)
+
+			// Execute Lua script with the sprite
+			output, err := client.ExecuteLua(ctx, script, input.SpritePath)
+			if err != nil {
+				opLogger.Error("Failed to transform selection", "error", err)
+				return nil, nil, fmt.Errorf("failed to transform selection: %w", err)
+			}
+
+			result := TransformSelectionOutput{Success: true}
+			if err := parseJSON(output, &result); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse transform result: %w", err)
+			}
+
+			opLogger.Information("Selection transformed successfully", "sprite", input.SpritePath, "layer", input.LayerName, "frame", input.FrameNumber, "operation", input.Operation, "pixel_count", result.PixelCount)
 
-			return nil, &PasteClipboardOutput{Success: true}, nil
+			return nil, &result, nil
 		}),
 	)
 }
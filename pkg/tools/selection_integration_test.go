@@ -5,7 +5,9 @@ package tools
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -369,3 +371,739 @@ func TestIntegration_SelectEllipse(t *testing.T) {
 
 	t.Logf("✓ Created elliptical selection (40x30 at 20, 20)")
 }
+
+// selectionProbeScript returns a Lua script that restores the persisted
+// selection and prints whether each point is selected as "x,y=true|false".
+func selectionProbeScript(points ...aseprite.Point) string {
+	var sb strings.Builder
+	sb.WriteString(aseprite.GenerateSelectionHelper())
+	sb.WriteString(`
+local spr = app.activeSprite
+restoreSelection(spr)
+`)
+	for _, p := range points {
+		sb.WriteString(fmt.Sprintf("print(\"%d,%d=\" .. tostring(spr.selection:contains(%d, %d)))\n", p.X, p.Y, p.X, p.Y))
+	}
+	return sb.String()
+}
+
+// assertSelected runs selectionProbeScript and checks each point's state.
+func assertSelected(t *testing.T, ctx context.Context, client *aseprite.Client, spritePath string, want map[aseprite.Point]bool) {
+	t.Helper()
+
+	points := make([]aseprite.Point, 0, len(want))
+	for p := range want {
+		points = append(points, p)
+	}
+	output, err := client.ExecuteLua(ctx, selectionProbeScript(points...), spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(selection probe) error = %v", err)
+	}
+	for p, selected := range want {
+		line := fmt.Sprintf("%d,%d=%t", p.X, p.Y, selected)
+		if !strings.Contains(output, line) {
+			t.Errorf("expected %s, got: %s", line, output)
+		}
+	}
+}
+
+func TestIntegration_SelectionMask_EllipseSurvivesCalls(t *testing.T) {
+	cfg := testutil.LoadTestConfig(t)
+	client := aseprite.NewClient(cfg.AsepritePath, cfg.TempDir, 30*time.Second)
+	gen := aseprite.NewLuaGenerator()
+	ctx := context.Background()
+
+	spritePath := testutil.TempSpritePath(t, "test-select-mask-ellipse.aseprite")
+	_, err := client.ExecuteLua(ctx, gen.CreateCanvas(64, 64, aseprite.ColorModeRGB, spritePath), "")
+	if err != nil {
+		t.Fatalf("Failed to create canvas: %v", err)
+	}
+	defer os.Remove(spritePath)
+
+	if _, err := client.ExecuteLua(ctx, gen.SelectEllipse(10, 10, 20, 20, "replace"), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(SelectEllipse) error = %v", err)
+	}
+
+	// The center is selected; the bounding box corners are not
+	assertSelected(t, ctx, client, spritePath, map[aseprite.Point]bool{
+		{X: 20, Y: 20}: true,
+		{X: 10, Y: 10}: false,
+		{X: 29, Y: 29}: false,
+	})
+
+	t.Logf("✓ Ellipse selection survived as an ellipse")
+}
+
+func TestIntegration_SelectionMask_AddsNoLayer(t *testing.T) {
+	cfg := testutil.LoadTestConfig(t)
+	client := aseprite.NewClient(cfg.AsepritePath, cfg.TempDir, 30*time.Second)
+	gen := aseprite.NewLuaGenerator()
+	ctx := context.Background()
+
+	spritePath := testutil.TempSpritePath(t, "test-select-mask-layers.aseprite")
+	_, err := client.ExecuteLua(ctx, gen.CreateCanvas(64, 64, aseprite.ColorModeRGB, spritePath), "")
+	if err != nil {
+		t.Fatalf("Failed to create canvas: %v", err)
+	}
+	defer os.Remove(spritePath)
+
+	if _, err := client.ExecuteLua(ctx, gen.SelectEllipse(10, 10, 20, 20, "replace"), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(SelectEllipse) error = %v", err)
+	}
+	if _, err := os.Stat(spritePath + ".selection"); err != nil {
+		t.Errorf("selection mask file missing: %v", err)
+	}
+
+	output, err := client.ExecuteLua(ctx, gen.GetSpriteInfo(), spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(GetSpriteInfo) error = %v", err)
+	}
+	if !strings.Contains(output, `"layer_count": 1`) || strings.Contains(output, "__mcp_selection__") {
+		t.Errorf("selection should not add a layer, got: %s", output)
+	}
+
+	// Deselecting removes the mask file
+	if _, err := client.ExecuteLua(ctx, gen.Deselect(), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(Deselect) error = %v", err)
+	}
+	if _, err := os.Stat(spritePath + ".selection"); !os.IsNotExist(err) {
+		t.Errorf("selection mask file should be removed, stat error = %v", err)
+	}
+
+	t.Logf("✓ Selection mask kept out of the sprite's layers")
+}
+
+func TestIntegration_SelectionMask_SubtractIntersect(t *testing.T) {
+	cfg := testutil.LoadTestConfig(t)
+	client := aseprite.NewClient(cfg.AsepritePath, cfg.TempDir, 30*time.Second)
+	gen := aseprite.NewLuaGenerator()
+	ctx := context.Background()
+
+	spritePath := testutil.TempSpritePath(t, "test-select-mask-modes.aseprite")
+	_, err := client.ExecuteLua(ctx, gen.CreateCanvas(64, 64, aseprite.ColorModeRGB, spritePath), "")
+	if err != nil {
+		t.Fatalf("Failed to create canvas: %v", err)
+	}
+	defer os.Remove(spritePath)
+
+	// A 30x30 square with a 10x10 hole punched in its middle
+	steps := []string{
+		gen.SelectRectangle(10, 10, 30, 30, "replace"),
+		gen.SelectRectangle(20, 20, 10, 10, "subtract"),
+	}
+	for _, script := range steps {
+		if _, err := client.ExecuteLua(ctx, script, spritePath); err != nil {
+			t.Fatalf("ExecuteLua(SelectRectangle) error = %v", err)
+		}
+	}
+
+	assertSelected(t, ctx, client, spritePath, map[aseprite.Point]bool{
+		{X: 12, Y: 12}: true,
+		{X: 25, Y: 25}: false,
+		{X: 38, Y: 38}: true,
+	})
+
+	// Intersecting with the left half keeps the hole
+	if _, err := client.ExecuteLua(ctx, gen.SelectRectangle(0, 0, 25, 64, "intersect"), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(SelectRectangle intersect) error = %v", err)
+	}
+
+	assertSelected(t, ctx, client, spritePath, map[aseprite.Point]bool{
+		{X: 12, Y: 12}: true,
+		{X: 22, Y: 22}: false,
+		{X: 38, Y: 38}: false,
+	})
+
+	t.Logf("✓ Subtract and intersect masks survived across calls")
+}
+
+func TestIntegration_SelectionMask_MoveKeepsShape(t *testing.T) {
+	cfg := testutil.LoadTestConfig(t)
+	client := aseprite.NewClient(cfg.AsepritePath, cfg.TempDir, 30*time.Second)
+	gen := aseprite.NewLuaGenerator()
+	ctx := context.Background()
+
+	spritePath := testutil.TempSpritePath(t, "test-select-mask-move.aseprite")
+	_, err := client.ExecuteLua(ctx, gen.CreateCanvas(64, 64, aseprite.ColorModeRGB, spritePath), "")
+	if err != nil {
+		t.Fatalf("Failed to create canvas: %v", err)
+	}
+	defer os.Remove(spritePath)
+
+	if _, err := client.ExecuteLua(ctx, gen.SelectEllipse(0, 0, 20, 20, "replace"), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(SelectEllipse) error = %v", err)
+	}
+	if _, err := client.ExecuteLua(ctx, gen.MoveSelection(10, 5), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(MoveSelection) error = %v", err)
+	}
+
+	assertSelected(t, ctx, client, spritePath, map[aseprite.Point]bool{
+		{X: 20, Y: 15}: true,  // moved center
+		{X: 10, Y: 5}:  false, // moved bounding box corner
+		{X: 10, Y: 10}: false, // old center, now outside
+	})
+
+	t.Logf("✓ Moved selection kept its elliptical shape")
+}
+
+func TestIntegration_SelectionMask_CutCopyOnlySelectedPixels(t *testing.T) {
+	cfg := testutil.LoadTestConfig(t)
+	client := aseprite.NewClient(cfg.AsepritePath, cfg.TempDir, 30*time.Second)
+	gen := aseprite.NewLuaGenerator()
+	ctx := context.Background()
+
+	spritePath := testutil.TempSpritePath(t, "test-select-mask-cut.aseprite")
+	_, err := client.ExecuteLua(ctx, gen.CreateCanvas(32, 32, aseprite.ColorModeRGB, spritePath), "")
+	if err != nil {
+		t.Fatalf("Failed to create canvas: %v", err)
+	}
+	defer os.Remove(spritePath)
+
+	// Keep user data set by the caller intact
+	if _, err := client.ExecuteLua(ctx, `local spr = app.activeSprite
+spr.data = "user data"
+spr:saveAs(spr.filename)`, spritePath); err != nil {
+		t.Fatalf("Failed to set sprite data: %v", err)
+	}
+
+	red := aseprite.Color{R: 255, G: 0, B: 0, A: 255}
+	if _, err := client.ExecuteLua(ctx, gen.DrawRectangle("Layer 1", 1, 0, 0, 32, 32, red, true, false), spritePath); err != nil {
+		t.Fatalf("Failed to fill canvas: %v", err)
+	}
+
+	if _, err := client.ExecuteLua(ctx, gen.SelectEllipse(0, 0, 16, 16, "replace"), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(SelectEllipse) error = %v", err)
+	}
+	clipDir := t.TempDir()
+	if _, err := client.ExecuteLua(ctx, gen.CopySelection("Layer 1", 1, filepath.Join(clipDir, "copy.png")), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(CopySelection) error = %v", err)
+	}
+	if _, err := client.ExecuteLua(ctx, gen.CutSelection("Layer 1", 1, filepath.Join(clipDir, "cut.png")), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(CutSelection) error = %v", err)
+	}
+
+	// The center was cut; the corner outside the ellipse was not
+	output, err := client.ExecuteLua(ctx, `local spr = app.activeSprite
+local img = Image(spr.width, spr.height, spr.colorMode)
+for i, lyr in ipairs(spr.layers) do
+	if lyr.name == "Layer 1" then
+		local cel = lyr:cel(1)
+		img:drawImage(cel.image, cel.position)
+	end
+end
+print("center=" .. app.pixelColor.rgbaA(img:getPixel(8, 8)))
+print("corner=" .. app.pixelColor.rgbaA(img:getPixel(0, 0)))
+print("data=" .. spr.data)`, spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(probe) error = %v", err)
+	}
+
+	for _, want := range []string{"center=0", "corner=255", "data=user data"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q, got: %s", want, output)
+		}
+	}
+
+	t.Logf("✓ Cut removed only the elliptical selection")
+}
+
+func TestIntegration_SelectByColor(t *testing.T) {
+	cfg := testutil.LoadTestConfig(t)
+	client := aseprite.NewClient(cfg.AsepritePath, cfg.TempDir, 30*time.Second)
+	gen := aseprite.NewLuaGenerator()
+	ctx := context.Background()
+
+	spritePath := testutil.TempSpritePath(t, "test-select-by-color.aseprite")
+	_, err := client.ExecuteLua(ctx, gen.CreateCanvas(32, 32, aseprite.ColorModeRGB, spritePath), "")
+	if err != nil {
+		t.Fatalf("Failed to create canvas: %v", err)
+	}
+	defer os.Remove(spritePath)
+
+	// Two red squares touching only at a corner
+	red := aseprite.Color{R: 255, G: 0, B: 0, A: 255}
+	for _, script := range []string{
+		gen.DrawRectangle("Layer 1", 1, 0, 0, 4, 4, red, true, false),
+		gen.DrawRectangle("Layer 1", 1, 4, 4, 4, 4, red, true, false),
+	} {
+		if _, err := client.ExecuteLua(ctx, script, spritePath); err != nil {
+			t.Fatalf("Failed to draw: %v", err)
+		}
+	}
+
+	// 4-connected: only the first square
+	if _, err := client.ExecuteLua(ctx, gen.SelectByColor("Layer 1", 1, 1, 1, 0, true, 4, "replace"), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(SelectByColor) error = %v", err)
+	}
+	assertSelected(t, ctx, client, spritePath, map[aseprite.Point]bool{
+		{X: 1, Y: 1}:   true,
+		{X: 5, Y: 5}:   false,
+		{X: 20, Y: 20}: false,
+	})
+
+	// 8-connected: both squares
+	if _, err := client.ExecuteLua(ctx, gen.SelectByColor("Layer 1", 1, 1, 1, 0, true, 8, "replace"), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(SelectByColor) error = %v", err)
+	}
+	assertSelected(t, ctx, client, spritePath, map[aseprite.Point]bool{
+		{X: 1, Y: 1}:   true,
+		{X: 5, Y: 5}:   true,
+		{X: 20, Y: 20}: false,
+	})
+
+	// Global transparent selection: everything but the squares
+	if _, err := client.ExecuteLua(ctx, gen.SelectByColor("Layer 1", 1, 20, 20, 0, false, 4, "replace"), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(SelectByColor) error = %v", err)
+	}
+	assertSelected(t, ctx, client, spritePath, map[aseprite.Point]bool{
+		{X: 1, Y: 1}:   false,
+		{X: 6, Y: 1}:   true,
+		{X: 31, Y: 31}: true,
+	})
+
+	t.Logf("✓ Magic wand selection respects connectivity and global mode")
+}
+
+func TestIntegration_SelectLayerAlpha_SelectPolygon(t *testing.T) {
+	cfg := testutil.LoadTestConfig(t)
+	client := aseprite.NewClient(cfg.AsepritePath, cfg.TempDir, 30*time.Second)
+	gen := aseprite.NewLuaGenerator()
+	ctx := context.Background()
+
+	spritePath := testutil.TempSpritePath(t, "test-select-alpha-polygon.aseprite")
+	_, err := client.ExecuteLua(ctx, gen.CreateCanvas(32, 32, aseprite.ColorModeRGB, spritePath), "")
+	if err != nil {
+		t.Fatalf("Failed to create canvas: %v", err)
+	}
+	defer os.Remove(spritePath)
+
+	canvas := aseprite.Rectangle{X: 0, Y: 0, Width: 32, Height: 32}
+	blue := aseprite.Color{R: 0, G: 0, B: 255, A: 255}
+	if _, err := client.ExecuteLua(ctx, gen.DrawEllipse("Layer 1", 1, 16, 16, 8, 8, blue, true, false, canvas), spritePath); err != nil {
+		t.Fatalf("Failed to draw: %v", err)
+	}
+
+	if _, err := client.ExecuteLua(ctx, gen.SelectLayerAlpha("Layer 1", 1, "replace"), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(SelectLayerAlpha) error = %v", err)
+	}
+	assertSelected(t, ctx, client, spritePath, map[aseprite.Point]bool{
+		{X: 16, Y: 16}: true,
+		{X: 9, Y: 9}:   false,
+	})
+
+	// Intersect with the top-left triangle
+	triangle := []aseprite.Point{{X: 0, Y: 0}, {X: 31, Y: 0}, {X: 0, Y: 31}}
+	if _, err := client.ExecuteLua(ctx, gen.SelectPolygon(triangle, aseprite.FillRuleNonZero, "intersect", canvas), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(SelectPolygon) error = %v", err)
+	}
+	assertSelected(t, ctx, client, spritePath, map[aseprite.Point]bool{
+		{X: 12, Y: 12}: true,
+		{X: 20, Y: 20}: false,
+		{X: 2, Y: 2}:   false,
+	})
+
+	t.Logf("✓ Layer alpha and polygon selections combine")
+}
+
+func TestIntegration_ModifySelection(t *testing.T) {
+	cfg := testutil.LoadTestConfig(t)
+	client := aseprite.NewClient(cfg.AsepritePath, cfg.TempDir, 30*time.Second)
+	gen := aseprite.NewLuaGenerator()
+	ctx := context.Background()
+
+	spritePath := testutil.TempSpritePath(t, "test-modify-selection.aseprite")
+	_, err := client.ExecuteLua(ctx, gen.CreateCanvas(32, 32, aseprite.ColorModeRGB, spritePath), "")
+	if err != nil {
+		t.Fatalf("Failed to create canvas: %v", err)
+	}
+	defer os.Remove(spritePath)
+
+	// A 5x5 square plus a single stray pixel
+	for _, script := range []string{
+		gen.SelectRectangle(10, 10, 5, 5, "replace"),
+		gen.SelectRectangle(25, 25, 1, 1, "add"),
+	} {
+		if _, err := client.ExecuteLua(ctx, script, spritePath); err != nil {
+			t.Fatalf("ExecuteLua(SelectRectangle) error = %v", err)
+		}
+	}
+
+	output, err := client.ExecuteLua(ctx, gen.ModifySelection("remove_islands", 0, "square", 2), spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(ModifySelection remove_islands) error = %v", err)
+	}
+	if !strings.Contains(output, `"pixel_count":25`) {
+		t.Errorf("Expected the stray pixel to be removed, got: %s", output)
+	}
+
+	// A diamond expansion rounds the corners
+	output, err = client.ExecuteLua(ctx, gen.ModifySelection("expand", 1, "diamond", 0), spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(ModifySelection expand) error = %v", err)
+	}
+	if !strings.Contains(output, `"pixel_count":45`) {
+		t.Errorf("Expected 25 + 4*5 pixels after a diamond expansion, got: %s", output)
+	}
+	assertSelected(t, ctx, client, spritePath, map[aseprite.Point]bool{
+		{X: 9, Y: 12}: true,
+		{X: 9, Y: 9}:  false,
+	})
+
+	// Contracting by 1 restores the square
+	output, err = client.ExecuteLua(ctx, gen.ModifySelection("contract", 1, "diamond", 0), spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(ModifySelection contract) error = %v", err)
+	}
+	if !strings.Contains(output, `{"x":10,"y":10,"width":5,"height":5,"pixel_count":25}`) {
+		t.Errorf("Expected the original square, got: %s", output)
+	}
+
+	t.Logf("✓ Selection modifiers report bounds and pixel counts")
+}
+
+// alphaProbeScript returns a Lua script that prints the alpha of each point
+// on a layer's frame 1 cel as "x,y=alpha".
+func alphaProbeScript(layerName string, points ...aseprite.Point) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`local spr = app.activeSprite
+local img = Image(spr.width, spr.height, spr.colorMode)
+for i, lyr in ipairs(spr.layers) do
+	if lyr.name == "%s" then
+		local cel = lyr:cel(1)
+		if cel then
+			img:drawImage(cel.image, cel.position)
+		end
+	end
+end
+`, aseprite.EscapeString(layerName)))
+	for _, p := range points {
+		sb.WriteString(fmt.Sprintf("print(\"%d,%d=\" .. app.pixelColor.rgbaA(img:getPixel(%d, %d)))\n", p.X, p.Y, p.X, p.Y))
+	}
+	return sb.String()
+}
+
+// assertAlpha runs alphaProbeScript and checks each point's alpha.
+func assertAlpha(t *testing.T, ctx context.Context, client *aseprite.Client, spritePath, layerName string, want map[aseprite.Point]int) {
+	t.Helper()
+
+	points := make([]aseprite.Point, 0, len(want))
+	for p := range want {
+		points = append(points, p)
+	}
+
+	output, err := client.ExecuteLua(ctx, alphaProbeScript(layerName, points...), spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(alpha probe) error = %v", err)
+	}
+
+	for p, alpha := range want {
+		line := fmt.Sprintf("%d,%d=%d", p.X, p.Y, alpha)
+		if !strings.Contains(output, line) {
+			t.Errorf("expected %s, got: %s", line, output)
+		}
+	}
+}
+
+func TestIntegration_FillStrokeClearSelection(t *testing.T) {
+	cfg := testutil.LoadTestConfig(t)
+	client := aseprite.NewClient(cfg.AsepritePath, cfg.TempDir, 30*time.Second)
+	gen := aseprite.NewLuaGenerator()
+	ctx := context.Background()
+
+	spritePath := testutil.TempSpritePath(t, "test-fill-selection.aseprite")
+	_, err := client.ExecuteLua(ctx, gen.CreateCanvas(32, 32, aseprite.ColorModeRGB, spritePath), "")
+	if err != nil {
+		t.Fatalf("Failed to create canvas: %v", err)
+	}
+	defer os.Remove(spritePath)
+
+	if _, err := client.ExecuteLua(ctx, gen.SelectEllipse(0, 0, 16, 16, "replace"), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(SelectEllipse) error = %v", err)
+	}
+
+	red := aseprite.Color{R: 255, G: 0, B: 0, A: 255}
+	output, err := client.ExecuteLua(ctx, gen.FillSelection("Layer 1", 1, red, false), spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(FillSelection) error = %v", err)
+	}
+	if !strings.Contains(output, "Selection filled successfully") {
+		t.Errorf("Expected success message, got: %s", output)
+	}
+
+	// Only the ellipse is painted, not its bounding box corners
+	assertAlpha(t, ctx, client, spritePath, "Layer 1", map[aseprite.Point]int{
+		{X: 8, Y: 8}:   255,
+		{X: 0, Y: 0}:   0,
+		{X: 20, Y: 20}: 0,
+	})
+
+	// An outside stroke paints just beyond the edge
+	black := aseprite.Color{R: 0, G: 0, B: 0, A: 255}
+	if _, err := client.ExecuteLua(ctx, gen.SelectRectangle(20, 20, 4, 4, "replace"), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(SelectRectangle) error = %v", err)
+	}
+	output, err = client.ExecuteLua(ctx, gen.StrokeSelection("Layer 1", 1, black, 1, aseprite.StrokeOutside, false), spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(StrokeSelection) error = %v", err)
+	}
+	if !strings.Contains(output, `{"pixels_painted":20}`) {
+		t.Errorf("Expected a 6x6 ring of 20 pixels, got: %s", output)
+	}
+	assertAlpha(t, ctx, client, spritePath, "Layer 1", map[aseprite.Point]int{
+		{X: 19, Y: 19}: 255,
+		{X: 20, Y: 20}: 0,
+	})
+
+	// Clearing the ellipse again leaves the stroke alone
+	if _, err := client.ExecuteLua(ctx, gen.SelectEllipse(0, 0, 16, 16, "replace"), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(SelectEllipse) error = %v", err)
+	}
+	if _, err := client.ExecuteLua(ctx, gen.ClearSelection("Layer 1", 1), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(ClearSelection) error = %v", err)
+	}
+	assertAlpha(t, ctx, client, spritePath, "Layer 1", map[aseprite.Point]int{
+		{X: 8, Y: 8}:   0,
+		{X: 19, Y: 19}: 255,
+	})
+
+	t.Logf("✓ Fill, stroke, and clear only touch the selection")
+}
+
+func TestIntegration_TransformSelection(t *testing.T) {
+	cfg := testutil.LoadTestConfig(t)
+	client := aseprite.NewClient(cfg.AsepritePath, cfg.TempDir, 30*time.Second)
+	gen := aseprite.NewLuaGenerator()
+	ctx := context.Background()
+
+	spritePath := testutil.TempSpritePath(t, "test-transform-selection.aseprite")
+	_, err := client.ExecuteLua(ctx, gen.CreateCanvas(32, 32, aseprite.ColorModeRGB, spritePath), "")
+	if err != nil {
+		t.Fatalf("Failed to create canvas: %v", err)
+	}
+	defer os.Remove(spritePath)
+
+	// A 4x6 block with one marked pixel in its top-left corner
+	red := aseprite.Color{R: 255, G: 0, B: 0, A: 255}
+	if _, err := client.ExecuteLua(ctx, gen.DrawRectangle("Layer 1", 1, 4, 4, 4, 6, red, true, false), spritePath); err != nil {
+		t.Fatalf("Failed to draw block: %v", err)
+	}
+	if _, err := client.ExecuteLua(ctx, gen.SelectRectangle(4, 4, 4, 6, "replace"), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(SelectRectangle) error = %v", err)
+	}
+
+	output, err := client.ExecuteLua(ctx, gen.TransformSelection("Layer 1", 1, "rotate_90", 1, 1), spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(TransformSelection) error = %v", err)
+	}
+	if !strings.Contains(output, `{"x":3,"y":5,"width":6,"height":4,"pixel_count":24}`) {
+		t.Errorf("Expected a 6x4 block centered on the original, got: %s", output)
+	}
+
+	// The selection follows the pixels
+	assertSelected(t, ctx, client, spritePath, map[aseprite.Point]bool{
+		{X: 3, Y: 5}: true,
+		{X: 4, Y: 4}: false,
+	})
+	assertAlpha(t, ctx, client, spritePath, "Layer 1", map[aseprite.Point]int{
+		{X: 3, Y: 5}: 255,
+		{X: 8, Y: 8}: 255,
+		{X: 4, Y: 4}: 0,
+	})
+
+	t.Logf("✓ Rotated pixels and selection together")
+}
+
+func TestIntegration_DrawClipToSelection(t *testing.T) {
+	cfg := testutil.LoadTestConfig(t)
+	client := aseprite.NewClient(cfg.AsepritePath, cfg.TempDir, 30*time.Second)
+	gen := aseprite.NewLuaGenerator()
+	ctx := context.Background()
+
+	spritePath := testutil.TempSpritePath(t, "test-draw-clip.aseprite")
+	_, err := client.ExecuteLua(ctx, gen.CreateCanvas(32, 32, aseprite.ColorModeRGB, spritePath), "")
+	if err != nil {
+		t.Fatalf("Failed to create canvas: %v", err)
+	}
+	defer os.Remove(spritePath)
+
+	if _, err := client.ExecuteLua(ctx, gen.SelectRectangle(0, 0, 16, 32, "replace"), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(SelectRectangle) error = %v", err)
+	}
+
+	red := aseprite.Color{R: 255, G: 0, B: 0, A: 255}
+	script := gen.ClipToSelection("Layer 1", 1, gen.DrawRectangle("Layer 1", 1, 0, 0, 32, 32, red, true, false))
+	output, err := client.ExecuteLua(ctx, script, spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(clipped DrawRectangle) error = %v", err)
+	}
+	if !strings.Contains(output, "Rectangle drawn successfully") {
+		t.Errorf("Expected the drawing script's message, got: %s", output)
+	}
+
+	assertAlpha(t, ctx, client, spritePath, "Layer 1", map[aseprite.Point]int{
+		{X: 15, Y: 10}: 255,
+		{X: 16, Y: 10}: 0,
+	})
+
+	t.Logf("✓ Drawing was clipped to the selection")
+}
+
+func TestIntegration_ClipboardAcrossSprites(t *testing.T) {
+	cfg := testutil.LoadTestConfig(t)
+	client := aseprite.NewClient(cfg.AsepritePath, cfg.TempDir, 30*time.Second)
+	gen := aseprite.NewLuaGenerator()
+	ctx := context.Background()
+
+	// Source: an RGB sprite with a red square on a named layer
+	sourcePath := testutil.TempSpritePath(t, "test-clipboard-source.aseprite")
+	if _, err := client.ExecuteLua(ctx, gen.CreateCanvas(32, 32, aseprite.ColorModeRGB, sourcePath), ""); err != nil {
+		t.Fatalf("Failed to create source canvas: %v", err)
+	}
+	defer os.Remove(sourcePath)
+
+	if _, err := client.ExecuteLua(ctx, gen.AddLayer("Hat"), sourcePath); err != nil {
+		t.Fatalf("Failed to add layer: %v", err)
+	}
+	red := aseprite.Color{R: 250, G: 10, B: 10, A: 255}
+	if _, err := client.ExecuteLua(ctx, gen.DrawRectangle("Hat", 1, 4, 4, 4, 4, red, true, false), sourcePath); err != nil {
+		t.Fatalf("Failed to draw on Hat: %v", err)
+	}
+	if _, err := client.ExecuteLua(ctx, gen.SelectRectangle(4, 4, 8, 8, "replace"), sourcePath); err != nil {
+		t.Fatalf("ExecuteLua(SelectRectangle) error = %v", err)
+	}
+
+	clipDir := t.TempDir()
+	copyPath := filepath.Join(clipDir, "copy.png")
+	output, err := client.ExecuteLua(ctx, gen.CopySelection("Hat", 1, copyPath), sourcePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(CopySelection) error = %v", err)
+	}
+
+	var export clipboardExport
+	if err := parseJSON(output, &export); err != nil {
+		t.Fatalf("Failed to parse copy result: %v", err)
+	}
+	if export.X != 4 || export.Y != 4 || export.PixelCount != 64 || export.ColorMode != "rgb" {
+		t.Errorf("Unexpected copy result: %+v", export)
+	}
+
+	store := newClipboardStore()
+	entry, err := loadClipboardEntry(copyPath, export)
+	if err != nil {
+		t.Fatalf("loadClipboardEntry() error = %v", err)
+	}
+	store.set("hat", entry)
+
+	// Target: an indexed sprite whose palette has no exact red
+	targetPath := testutil.TempSpritePath(t, "test-clipboard-target.aseprite")
+	if _, err := client.ExecuteLua(ctx, gen.CreateCanvas(32, 32, aseprite.ColorModeIndexed, targetPath), ""); err != nil {
+		t.Fatalf("Failed to create target canvas: %v", err)
+	}
+	defer os.Remove(targetPath)
+
+	if _, err := client.ExecuteLua(ctx, gen.SetPalette([]string{"#000000", "#FFFFFF", "#FF0000", "#0000FF"}), targetPath); err != nil {
+		t.Fatalf("Failed to set palette: %v", err)
+	}
+	palette, err := loadSpritePalette(ctx, client, gen, targetPath)
+	if err != nil {
+		t.Fatalf("loadSpritePalette() error = %v", err)
+	}
+
+	hat, _ := store.get("hat")
+	img, remapped, err := hat.imageFor("indexed", palette)
+	if err != nil {
+		t.Fatalf("imageFor() error = %v", err)
+	}
+	if remapped != 1 {
+		t.Errorf("colors remapped = %d, want 1", remapped)
+	}
+
+	pastePath := filepath.Join(clipDir, "paste.png")
+	if err := savePNG(pastePath, img); err != nil {
+		t.Fatalf("savePNG() error = %v", err)
+	}
+	if _, err := client.ExecuteLua(ctx, gen.PasteClipboard("Layer 1", 1, pastePath, hat.origin.X, hat.origin.Y), targetPath); err != nil {
+		t.Fatalf("ExecuteLua(PasteClipboard) error = %v", err)
+	}
+
+	// The red pixels landed on palette index 2; the transparent corner did not
+	output, err = client.ExecuteLua(ctx, `local spr = app.activeSprite
+local cel = spr.layers[1]:cel(1)
+local img = Image(spr.width, spr.height, spr.colorMode)
+img:clear(spr.transparentColor)
+img:drawImage(cel.image, cel.position)
+print("inside=" .. img:getPixel(5, 5))
+print("outside=" .. img:getPixel(10, 10))
+local hasClipboardLayer = false
+for i, lyr in ipairs(spr.layers) do
+	if lyr.name == "__mcp_clipboard__" then
+		hasClipboardLayer = true
+	end
+end
+print("clipboard_layer=" .. tostring(hasClipboardLayer))`, targetPath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(probe) error = %v", err)
+	}
+
+	for _, want := range []string{"inside=2", "outside=0", "clipboard_layer=false"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q, got: %s", want, output)
+		}
+	}
+
+	t.Logf("✓ Pasted a slot from an RGB sprite into an indexed sprite")
+}
+
+func TestIntegration_CopySelection_IndexedKeepsTransparency(t *testing.T) {
+	cfg := testutil.LoadTestConfig(t)
+	client := aseprite.NewClient(cfg.AsepritePath, cfg.TempDir, 30*time.Second)
+	gen := aseprite.NewLuaGenerator()
+	ctx := context.Background()
+
+	// An indexed sprite with a red square in the corner of the selection
+	spritePath := testutil.TempSpritePath(t, "test-copy-indexed.aseprite")
+	if _, err := client.ExecuteLua(ctx, gen.CreateCanvas(32, 32, aseprite.ColorModeIndexed, spritePath), ""); err != nil {
+		t.Fatalf("Failed to create canvas: %v", err)
+	}
+	defer os.Remove(spritePath)
+
+	if _, err := client.ExecuteLua(ctx, gen.SetPalette([]string{"#000000", "#FFFFFF", "#FF0000", "#0000FF"}), spritePath); err != nil {
+		t.Fatalf("Failed to set palette: %v", err)
+	}
+	red := aseprite.Color{R: 255, G: 0, B: 0, A: 255}
+	if _, err := client.ExecuteLua(ctx, gen.DrawRectangle("Layer 1", 1, 4, 4, 4, 4, red, true, false), spritePath); err != nil {
+		t.Fatalf("Failed to draw rectangle: %v", err)
+	}
+	if _, err := client.ExecuteLua(ctx, gen.SelectRectangle(4, 4, 8, 8, "replace"), spritePath); err != nil {
+		t.Fatalf("ExecuteLua(SelectRectangle) error = %v", err)
+	}
+
+	copyPath := filepath.Join(t.TempDir(), "copy.png")
+	output, err := client.ExecuteLua(ctx, gen.CopySelection("Layer 1", 1, copyPath), spritePath)
+	if err != nil {
+		t.Fatalf("ExecuteLua(CopySelection) error = %v", err)
+	}
+
+	var export clipboardExport
+	if err := parseJSON(output, &export); err != nil {
+		t.Fatalf("Failed to parse copy result: %v", err)
+	}
+	if export.ColorMode != "indexed" {
+		t.Errorf("color_mode = %q, want indexed", export.ColorMode)
+	}
+
+	entry, err := loadClipboardEntry(copyPath, export)
+	if err != nil {
+		t.Fatalf("loadClipboardEntry() error = %v", err)
+	}
+
+	// The red square stays opaque; the empty part of the selection stays clear
+	if got := entry.image.NRGBAAt(1, 1); got.R != 255 || got.G != 0 || got.B != 0 || got.A != 255 {
+		t.Errorf("pixel inside square = %v, want opaque red", got)
+	}
+	if got := entry.image.NRGBAAt(6, 6); got.A != 0 {
+		t.Errorf("pixel outside square alpha = %d, want 0", got.A)
+	}
+
+	t.Logf("✓ Copying from an indexed sprite kept the transparent index clear")
+}
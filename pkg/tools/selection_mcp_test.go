@@ -136,6 +136,162 @@ func TestSelectEllipse_ViaMCP(t *testing.T) {
 	assert.True(t, output.Success, "Select ellipse should succeed")
 }
 
+func TestSelectByColor_ViaMCP(t *testing.T) {
+	_, session, _ := createSelectionTestSession(t)
+	defer session.Close()
+
+	createResult, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "create_canvas",
+		Arguments: map[string]any{
+			"width":      32,
+			"height":     32,
+			"color_mode": "rgb",
+		},
+	})
+	require.NoError(t, err)
+
+	var createOutput struct {
+		FilePath string `json:"file_path"`
+	}
+	json.Unmarshal([]byte(createResult.Content[0].(*mcp.TextContent).Text), &createOutput)
+	defer os.Remove(createOutput.FilePath)
+
+	// Draw a shape to select
+	_, err = session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "draw_rectangle",
+		Arguments: map[string]any{
+			"sprite_path":  createOutput.FilePath,
+			"layer_name":   "Layer 1",
+			"frame_number": 1,
+			"x":            4,
+			"y":            4,
+			"width":        10,
+			"height":       10,
+			"color":        "#FF0000",
+			"filled":       true,
+		},
+	})
+	require.NoError(t, err)
+
+	// Select the rectangle by color
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "select_by_color",
+		Arguments: map[string]any{
+			"sprite_path":  createOutput.FilePath,
+			"layer_name":   "Layer 1",
+			"frame_number": 1,
+			"x":            8,
+			"y":            8,
+			"tolerance":    10,
+			"connectivity": 8,
+			"mode":         "replace",
+		},
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var output struct {
+		Success bool `json:"success"`
+	}
+	json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &output)
+	assert.True(t, output.Success, "Select by color should succeed")
+
+	// Subtract the layer alpha, leaving nothing selected
+	result, err = session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "select_layer_alpha",
+		Arguments: map[string]any{
+			"sprite_path":  createOutput.FilePath,
+			"layer_name":   "Layer 1",
+			"frame_number": 1,
+			"mode":         "subtract",
+		},
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+}
+
+func TestSelectPolygon_ViaMCP(t *testing.T) {
+	_, session, _ := createSelectionTestSession(t)
+	defer session.Close()
+
+	createResult, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "create_canvas",
+		Arguments: map[string]any{
+			"width":      32,
+			"height":     32,
+			"color_mode": "rgb",
+		},
+	})
+	require.NoError(t, err)
+
+	var createOutput struct {
+		FilePath string `json:"file_path"`
+	}
+	json.Unmarshal([]byte(createResult.Content[0].(*mcp.TextContent).Text), &createOutput)
+	defer os.Remove(createOutput.FilePath)
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "select_polygon",
+		Arguments: map[string]any{
+			"sprite_path": createOutput.FilePath,
+			"points": []map[string]int{
+				{"x": 16, "y": 2},
+				{"x": 30, "y": 28},
+				{"x": 2, "y": 28},
+			},
+			"mode": "replace",
+		},
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var output struct {
+		Success bool `json:"success"`
+	}
+	json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &output)
+	assert.True(t, output.Success, "Select polygon should succeed")
+}
+
+func TestSelectByColor_InvalidInput(t *testing.T) {
+	_, session, _ := createSelectionTestSession(t)
+	defer session.Close()
+
+	tests := []struct {
+		name string
+		args map[string]any
+	}{
+		{"tolerance out of range", map[string]any{"tolerance": 300}},
+		{"bad connectivity", map[string]any{"connectivity": 6}},
+		{"bad mode", map[string]any{"mode": "xor"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := map[string]any{
+				"sprite_path":  "unused.aseprite",
+				"layer_name":   "Layer 1",
+				"frame_number": 1,
+				"x":            0,
+				"y":            0,
+				"mode":         "replace",
+			}
+			for k, v := range tt.args {
+				args[k] = v
+			}
+
+			result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+				Name:      "select_by_color",
+				Arguments: args,
+			})
+			require.NoError(t, err)
+			assert.True(t, result.IsError, "invalid input should be rejected")
+		})
+	}
+}
+
 func TestSelectAll_ViaMCP(t *testing.T) {
 	_, session, _ := createSelectionTestSession(t)
 	defer session.Close()
@@ -232,7 +388,7 @@ func TestMoveSelection_ViaMCP(t *testing.T) {
 	json.Unmarshal([]byte(createResult.Content[0].(*mcp.TextContent).Text), &createOutput)
 	defer os.Remove(createOutput.FilePath)
 
-	// Create selection - persists to sprite.data
+	// Create selection - persists to the selection mask file
 	selectResult, err := session.CallTool(context.Background(), &mcp.CallToolParams{
 		Name: "select_rectangle",
 		Arguments: map[string]any{
@@ -247,7 +403,7 @@ func TestMoveSelection_ViaMCP(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, selectResult.IsError)
 
-	// Move selection - restored from sprite.data, works across processes!
+	// Move selection - restored from the selection mask file, works across processes!
 	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
 		Name: "move_selection",
 		Arguments: map[string]any{
@@ -274,6 +430,119 @@ func TestMoveSelection_ViaMCP(t *testing.T) {
 	assert.True(t, output.Success, "Move selection should succeed with persisted state")
 }
 
+func TestModifySelection_ViaMCP(t *testing.T) {
+	_, session, _ := createSelectionTestSession(t)
+	defer session.Close()
+
+	createResult, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "create_canvas",
+		Arguments: map[string]any{
+			"width":      32,
+			"height":     32,
+			"color_mode": "rgb",
+		},
+	})
+	require.NoError(t, err)
+
+	var createOutput struct {
+		FilePath string `json:"file_path"`
+	}
+	json.Unmarshal([]byte(createResult.Content[0].(*mcp.TextContent).Text), &createOutput)
+	defer os.Remove(createOutput.FilePath)
+
+	// Select a 10x10 square
+	selectResult, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "select_rectangle",
+		Arguments: map[string]any{
+			"sprite_path": createOutput.FilePath,
+			"x":           10,
+			"y":           10,
+			"width":       10,
+			"height":      10,
+			"mode":        "replace",
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, selectResult.IsError)
+
+	// Expand by 2 with a square shape: 14x14
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "modify_selection",
+		Arguments: map[string]any{
+			"sprite_path": createOutput.FilePath,
+			"operation":   "expand",
+			"amount":      2,
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var output ModifySelectionOutput
+	json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &output)
+	assert.True(t, output.Success)
+	assert.Equal(t, 8, output.X)
+	assert.Equal(t, 8, output.Y)
+	assert.Equal(t, 14, output.Width)
+	assert.Equal(t, 14, output.Height)
+	assert.Equal(t, 196, output.PixelCount)
+
+	// Border of width 1 leaves the outer ring
+	result, err = session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "modify_selection",
+		Arguments: map[string]any{
+			"sprite_path": createOutput.FilePath,
+			"operation":   "border",
+			"amount":      1,
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &output)
+	assert.Equal(t, 14*4-4, output.PixelCount)
+
+	// Invert selects everything else on the canvas
+	result, err = session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "modify_selection",
+		Arguments: map[string]any{
+			"sprite_path": createOutput.FilePath,
+			"operation":   "invert",
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &output)
+	assert.Equal(t, 32*32-(14*4-4), output.PixelCount)
+}
+
+func TestModifySelection_InvalidInput(t *testing.T) {
+	_, session, _ := createSelectionTestSession(t)
+	defer session.Close()
+
+	tests := []struct {
+		name string
+		args map[string]any
+	}{
+		{"unknown operation", map[string]any{"operation": "feather"}},
+		{"missing amount", map[string]any{"operation": "expand"}},
+		{"bad shape", map[string]any{"operation": "contract", "amount": 1, "shape": "circle"}},
+		{"missing island size", map[string]any{"operation": "remove_islands"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args["sprite_path"] = "unused.aseprite"
+			result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+				Name:      "modify_selection",
+				Arguments: tt.args,
+			})
+			require.NoError(t, err)
+			assert.True(t, result.IsError, "invalid input should be rejected")
+		})
+	}
+}
+
 func TestCopyPasteWorkflow_ViaMCP(t *testing.T) {
 	_, session, _ := createSelectionTestSession(t)
 	defer session.Close()
@@ -478,3 +747,161 @@ func TestCutPasteWorkflow_ViaMCP(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, pasteResult.IsError)
 }
+
+func TestSelectionScopedEditing_ViaMCP(t *testing.T) {
+	_, session, _ := createSelectionTestSession(t)
+	defer session.Close()
+
+	createResult, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "create_canvas",
+		Arguments: map[string]any{
+			"width":      32,
+			"height":     32,
+			"color_mode": "rgb",
+		},
+	})
+	require.NoError(t, err)
+
+	var createOutput struct {
+		FilePath string `json:"file_path"`
+	}
+	json.Unmarshal([]byte(createResult.Content[0].(*mcp.TextContent).Text), &createOutput)
+	defer os.Remove(createOutput.FilePath)
+
+	// Select a 4x6 rectangle
+	selectResult, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "select_rectangle",
+		Arguments: map[string]any{
+			"sprite_path": createOutput.FilePath,
+			"x":           4,
+			"y":           4,
+			"width":       4,
+			"height":      6,
+			"mode":        "replace",
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, selectResult.IsError)
+
+	// Fill it
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "fill_selection",
+		Arguments: map[string]any{
+			"sprite_path":  createOutput.FilePath,
+			"layer_name":   "Layer 1",
+			"frame_number": 1,
+			"color":        "#FF0000",
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var fillOutput FillSelectionOutput
+	json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &fillOutput)
+	assert.Equal(t, 24, fillOutput.PixelsPainted)
+
+	// Stroke a 1 pixel ring outside it
+	result, err = session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "stroke_selection",
+		Arguments: map[string]any{
+			"sprite_path":  createOutput.FilePath,
+			"layer_name":   "Layer 1",
+			"frame_number": 1,
+			"color":        "#000000",
+			"location":     "outside",
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var strokeOutput StrokeSelectionOutput
+	json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &strokeOutput)
+	assert.Equal(t, 6*8-24, strokeOutput.PixelsPainted)
+
+	// Rotate the selected pixels: 4x6 becomes 6x4 around the same center
+	result, err = session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "transform_selection",
+		Arguments: map[string]any{
+			"sprite_path":  createOutput.FilePath,
+			"layer_name":   "Layer 1",
+			"frame_number": 1,
+			"operation":    "rotate_90",
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var transformOutput TransformSelectionOutput
+	json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &transformOutput)
+	assert.Equal(t, 3, transformOutput.X)
+	assert.Equal(t, 5, transformOutput.Y)
+	assert.Equal(t, 6, transformOutput.Width)
+	assert.Equal(t, 4, transformOutput.Height)
+	assert.Equal(t, 24, transformOutput.PixelCount)
+
+	// Clear the rotated pixels
+	result, err = session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "clear_selection",
+		Arguments: map[string]any{
+			"sprite_path":  createOutput.FilePath,
+			"layer_name":   "Layer 1",
+			"frame_number": 1,
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var clearOutput ClearSelectionOutput
+	json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &clearOutput)
+	assert.Equal(t, 24, clearOutput.PixelsCleared)
+}
+
+func TestSelectionScopedEditing_InvalidInput(t *testing.T) {
+	_, session, _ := createSelectionTestSession(t)
+	defer session.Close()
+
+	tests := []struct {
+		name string
+		tool string
+		args map[string]any
+	}{
+		{"fill missing layer", "fill_selection", map[string]any{"layer_name": "", "frame_number": 1, "color": "#FF0000"}},
+		{"fill bad color", "fill_selection", map[string]any{"layer_name": "Layer 1", "frame_number": 1, "color": "red"}},
+		{"stroke bad location", "stroke_selection", map[string]any{"layer_name": "Layer 1", "frame_number": 1, "color": "#FF0000", "location": "middle"}},
+		{"stroke negative width", "stroke_selection", map[string]any{"layer_name": "Layer 1", "frame_number": 1, "color": "#FF0000", "width": -1}},
+		{"clear bad frame", "clear_selection", map[string]any{"layer_name": "Layer 1", "frame_number": 0}},
+		{"transform bad operation", "transform_selection", map[string]any{"layer_name": "Layer 1", "frame_number": 1, "operation": "skew"}},
+		{"transform bad scale", "transform_selection", map[string]any{"layer_name": "Layer 1", "frame_number": 1, "operation": "scale", "scale_x": -2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args["sprite_path"] = "unused.aseprite"
+			result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+				Name:      tt.tool,
+				Arguments: tt.args,
+			})
+			require.NoError(t, err)
+			assert.True(t, result.IsError, "invalid input should be rejected")
+		})
+	}
+}
+
+func TestPasteClipboard_EmptySlot(t *testing.T) {
+	_, session, _ := createSelectionTestSession(t)
+	defer session.Close()
+
+	// Nothing has been copied into the slot yet
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name: "paste_clipboard",
+		Arguments: map[string]any{
+			"sprite_path":  "unused.aseprite",
+			"layer_name":   "Layer 1",
+			"frame_number": 1,
+			"slot":         "hat",
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, result.IsError, "pasting an empty slot should fail")
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, `clipboard slot "hat" is empty`)
+}
@@ -0,0 +1,218 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/willibrandon/mtlog/core"
+	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
+	"github.com/willibrandon/pixel-mcp/pkg/config"
+	"github.com/willibrandon/pixel-mcp/pkg/font"
+)
+
+// DrawTextInput defines the input parameters for the draw_text tool.
+type DrawTextInput struct {
+	SpritePath    string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
+	LayerName     string `json:"layer_name" jsonschema:"Name of the layer to draw on"`
+	FrameNumber   int    `json:"frame_number" jsonschema:"Frame number to draw on (1-based)"`
+	Text          string `json:"text" jsonschema:"Text to draw; use \n for line breaks"`
+	X             int    `json:"x" jsonschema:"Anchor X: left edge (align left), center (align center), or right edge (align right) of the text"`
+	Y             int    `json:"y" jsonschema:"Top edge of the first line"`
+	Font          string `json:"font,omitempty" jsonschema:"Bundled font: 3x5 (tiny capitals), 5x7 (proportional with lowercase), or 8x8 (bold monospace) (default: 5x7)"`
+	FontPath      string `json:"font_path,omitempty" jsonschema:"BMFont descriptor (.fnt, text or XML, with its PNG pages) or BDF font file to use instead of a bundled font"`
+	Color         string `json:"color" jsonschema:"Text color (hex #RRGGBB or #RRGGBBAA)"`
+	OutlineColor  string `json:"outline_color,omitempty" jsonschema:"Outline color (hex #RRGGBB or #RRGGBBAA); enables the outline"`
+	OutlineWidth  int    `json:"outline_width,omitempty" jsonschema:"Outline thickness in pixels 1-8 (default: 1 when outline_color is set)"`
+	Align         string `json:"align,omitempty" jsonschema:"Alignment of lines and of the text relative to x: left, center, or right (default: left)"`
+	LineSpacing   int    `json:"line_spacing,omitempty" jsonschema:"Extra pixels between lines, may be negative (default: 0)"`
+	LetterSpacing int    `json:"letter_spacing,omitempty" jsonschema:"Extra pixels between characters, may be negative (default: 0)"`
+	Kerning       *bool  `json:"kerning,omitempty" jsonschema:"Apply the font's kerning pairs (BMFont only) (default: true)"`
+	UsePalette    bool   `json:"use_palette,omitempty" jsonschema:"Snap colors to nearest palette color (default: false)"`
+	PaletteName   string `json:"palette_name,omitempty" jsonschema:"Snap colors to this bundled palette instead of the sprite palette, e.g. pico-8 (see list_palettes)"`
+}
+
+// DrawTextOutput defines the output for the draw_text tool.
+type DrawTextOutput struct {
+	X            int      `json:"x" jsonschema:"Left edge of the text block (without outline)"`
+	Y            int      `json:"y" jsonschema:"Top edge of the text block (without outline)"`
+	Width        int      `json:"width" jsonschema:"Text block width in pixels (without outline)"`
+	Height       int      `json:"height" jsonschema:"Text block height in pixels (without outline)"`
+	Lines        int      `json:"lines" jsonschema:"Number of lines drawn"`
+	Font         string   `json:"font" jsonschema:"Name of the font used"`
+	MissingChars []string `json:"missing_chars,omitempty" jsonschema:"Characters the font has no glyph for (drawn as the font's default character or skipped)"`
+}
+
+// RegisterTextTools registers the text rendering tools with the MCP server.
+func RegisterTextTools(server *mcp.Server, client *aseprite.Client, gen *aseprite.LuaGenerator, cfg *config.Config, logger core.Logger) {
+	// Register draw_text tool
+	mcp.AddTool(
+		server,
+		&mcp.Tool{
+			Name:        "draw_text",
+			Description: "Draw text onto a layer with a crisp bitmap pixel font, e.g. UI labels, score counters, and damage numbers. Bundled public-domain fonts: 3x5 (tiny capitals), 5x7 (proportional with lowercase), and 8x8 (bold monospace); custom BMFont (.fnt + PNG) or BDF fonts can be loaded with font_path. Supports color, outline, multi-line alignment (left, center, right), line spacing, letter spacing, and kerning pairs. Text is blended over the existing layer pixels.",
+		},
+		maybeWrapWithTiming("draw_text", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input DrawTextInput) (*mcp.CallToolResult, *DrawTextOutput, error) {
+			opLogger := logger.WithContext(ctx)
+			opLogger.Debug("draw_text tool called",
+				"sprite", input.SpritePath,
+				"layer", input.LayerName,
+				"frame", input.FrameNumber,
+				"font", input.Font,
+				"font_path", input.FontPath,
+				"length", len(input.Text))
+
+			// Set defaults
+			if input.Font == "" {
+				input.Font = "5x7"
+			}
+			if input.Align == "" {
+				input.Align = font.AlignLeft
+			}
+			if input.OutlineColor != "" && input.OutlineWidth == 0 {
+				input.OutlineWidth = 1
+			}
+			if input.Kerning == nil {
+				defaultKerning := true
+				input.Kerning = &defaultKerning
+			}
+
+			// Validate inputs
+			if input.LayerName == "" {
+				return nil, nil, fmt.Errorf("layer_name is required")
+			}
+			if input.FrameNumber < 1 {
+				return nil, nil, fmt.Errorf("frame_number must be >= 1, got %d", input.FrameNumber)
+			}
+			if input.Text == "" {
+				return nil, nil, fmt.Errorf("text is required")
+			}
+			if !isValidHexColor(input.Color) {
+				return nil, nil, fmt.Errorf("invalid color format: %s (expected #RRGGBB or #RRGGBBAA)", input.Color)
+			}
+			if input.OutlineColor != "" && !isValidHexColor(input.OutlineColor) {
+				return nil, nil, fmt.Errorf("invalid outline_color format: %s (expected #RRGGBB or #RRGGBBAA)", input.OutlineColor)
+			}
+			if input.OutlineWidth < 0 || input.OutlineWidth > 8 {
+				return nil, nil, fmt.Errorf("outline_width must be between 0 and 8, got %d", input.OutlineWidth)
+			}
+			if input.OutlineWidth > 0 && input.OutlineColor == "" {
+				return nil, nil, fmt.Errorf("outline_color is required when outline_width is set")
+			}
+			switch input.Align {
+			case font.AlignLeft, font.AlignCenter, font.AlignRight:
+			default:
+				return nil, nil, fmt.Errorf("invalid align: %s (must be left, center, or right)", input.Align)
+			}
+
+			// Load the font
+			var f *font.Font
+			if input.FontPath != "" {
+				loaded, err := font.LoadFile(input.FontPath)
+				if err != nil {
+					return nil, nil, err
+				}
+				f = loaded
+			} else {
+				named, err := font.Named(input.Font)
+				if err != nil {
+					return nil, nil, err
+				}
+				f = named.Font
+			}
+
+			// Resolve colors, snapping to a bundled palette if requested
+			var textColor, outlineColor aseprite.Color
+			if err := textColor.FromHex(input.Color); err != nil {
+				return nil, nil, fmt.Errorf("invalid color: %w", err)
+			}
+			if input.OutlineColor != "" {
+				if err := outlineColor.FromHex(input.OutlineColor); err != nil {
+					return nil, nil, fmt.Errorf("invalid outline_color: %w", err)
+				}
+			}
+			usePalette, err := applyPaletteName(input.PaletteName, input.UsePalette, &textColor, &outlineColor)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			rendered, err := f.Render(input.Text, font.TextOptions{
+				Color:         color.NRGBA{R: textColor.R, G: textColor.G, B: textColor.B, A: textColor.A},
+				OutlineColor:  color.NRGBA{R: outlineColor.R, G: outlineColor.G, B: outlineColor.B, A: outlineColor.A},
+				OutlineWidth:  input.OutlineWidth,
+				Align:         input.Align,
+				LineSpacing:   input.LineSpacing,
+				LetterSpacing: input.LetterSpacing,
+				Kerning:       *input.Kerning,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("text rendering failed: %w", err)
+			}
+
+			// Check sprite file exists
+			if _, err := os.Stat(input.SpritePath); os.IsNotExist(err) {
+				return nil, nil, fmt.Errorf("sprite file not found: %s", input.SpritePath)
+			}
+
+			if usePalette {
+				palette, err := loadSpritePalette(ctx, client, gen, input.SpritePath)
+				if err != nil {
+					return nil, nil, err
+				}
+				if err := aseprite.SnapImageToPalette(rendered.Image, palette); err != nil {
+					return nil, nil, fmt.Errorf("palette snap failed: %w", err)
+				}
+			}
+
+			tempDir, err := os.MkdirTemp("", "pixel-mcp-text-*")
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create temp directory: %w", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			textPNG := filepath.Join(tempDir, "text.png")
+			if err := savePNG(textPNG, rendered.Image); err != nil {
+				return nil, nil, err
+			}
+
+			// Position the text block relative to the anchor
+			left := input.X
+			switch input.Align {
+			case font.AlignCenter:
+				left -= rendered.Width / 2
+			case font.AlignRight:
+				left -= rendered.Width
+			}
+
+			script := gen.StampImage(textPNG, input.LayerName, input.FrameNumber, left-rendered.Origin.X, input.Y-rendered.Origin.Y)
+			if _, err := client.ExecuteLua(ctx, script, input.SpritePath); err != nil {
+				opLogger.Error("Failed to draw text", "error", err)
+				return nil, nil, fmt.Errorf("failed to draw text: %w", err)
+			}
+
+			result := &DrawTextOutput{
+				X:      left,
+				Y:      input.Y,
+				Width:  rendered.Width,
+				Height: rendered.Height,
+				Lines:  rendered.Lines,
+				Font:   f.Name,
+			}
+			for _, r := range rendered.Missing {
+				result.MissingChars = append(result.MissingChars, string(r))
+			}
+
+			opLogger.Information("Text drawn successfully",
+				"sprite", input.SpritePath,
+				"layer", input.LayerName,
+				"font", f.Name,
+				"size", fmt.Sprintf("%dx%d", result.Width, result.Height),
+				"missing_chars", len(result.MissingChars))
+
+			return nil, result, nil
+		}),
+	)
+}
@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
+)
+
+// findTag looks up a tag of the sprite by name.
+func findTag(ctx context.Context, client *aseprite.Client, gen *aseprite.LuaGenerator, spritePath, tagName string) (TagInfo, error) {
+	output, err := client.ExecuteLua(ctx, gen.ListTags(), spritePath)
+	if err != nil {
+		return TagInfo{}, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	var list ListTagsOutput
+	if err := parseJSON(output, &list); err != nil {
+		return TagInfo{}, fmt.Errorf("failed to parse tag list: %w", err)
+	}
+
+	for _, tag := range list.Tags {
+		if tag.Name == tagName {
+			return tag, nil
+		}
+	}
+	return TagInfo{}, fmt.Errorf("tag not found: %s", tagName)
+}
+
+// frameDurations computes the durations set_frame_durations applies to count
+// frames from exactly one of a duration list, a constant per-frame duration,
+// or a total time distributed along an easing curve.
+func frameDurations(input SetFrameDurationsInput, count int) ([]int, error) {
+	var durations []int
+	switch {
+	case len(input.DurationsMs) > 0:
+		if len(input.DurationsMs) != count {
+			return nil, fmt.Errorf("durations_ms has %d values but the range has %d frames", len(input.DurationsMs), count)
+		}
+		durations = input.DurationsMs
+
+	case input.DurationMs > 0:
+		durations = make([]int, count)
+		for i := range durations {
+			durations[i] = input.DurationMs
+		}
+
+	default:
+		if input.TotalMs < count {
+			return nil, fmt.Errorf("total_ms must be at least 1 ms per frame (%d), got %d", count, input.TotalMs)
+		}
+
+		easingName := input.Easing
+		if easingName == "" {
+			easingName = "linear"
+		}
+		easing, err := parseEasing(easingName, input.Bezier)
+		if err != nil {
+			return nil, err
+		}
+		if easing.Y1 < 0 || easing.Y1 > 1 || easing.Y2 < 0 || easing.Y2 > 1 {
+			return nil, fmt.Errorf("bezier y control points must be between 0 and 1 when distributing durations, got y1=%g y2=%g", easing.Y1, easing.Y2)
+		}
+		durations = aseprite.DistributeDuration(input.TotalMs, count, easing)
+	}
+
+	for i, ms := range durations {
+		if ms < 1 || ms > 65535 {
+			return nil, fmt.Errorf("duration of frame %d must be between 1 and 65535 ms, got %d", i+1, ms)
+		}
+	}
+	return durations, nil
+}
+
+// framesPerSecond returns the frame rate of frames shown over totalMs,
+// rounded to two decimals.
+func framesPerSecond(frames, totalMs int) float64 {
+	if totalMs <= 0 {
+		return 0
+	}
+	return math.Round(float64(frames)*100000/float64(totalMs)) / 100
+}
+
+// tagLoopMs returns the length of one playback loop of a tag. Ping-pong tags
+// play their inner frames twice per loop; the end frames are not repeated.
+func tagLoopMs(tag TagInfo, durations []int) int {
+	loop := tag.DurationMs
+	if (tag.Direction == "pingpong" || tag.Direction == "pingpong_reverse") && tag.FrameCount > 2 {
+		for f := tag.FromFrame + 1; f < tag.ToFrame; f++ {
+			loop += durations[f-1]
+		}
+	}
+	return loop
+}
+
+// animationTiming builds the get_animation_timing report from the frame
+// durations and tags of a sprite.
+func animationTiming(durations []int, tags []TagInfo) *GetAnimationTimingOutput {
+	report := &GetAnimationTimingOutput{
+		FrameCount: len(durations),
+		Frames:     make([]FrameTiming, len(durations)),
+		Tags:       make([]TagTiming, len(tags)),
+	}
+
+	for i, ms := range durations {
+		report.Frames[i] = FrameTiming{Frame: i + 1, DurationMs: ms, FPS: framesPerSecond(1, ms)}
+		report.TotalMs += ms
+	}
+	report.FPS = framesPerSecond(len(durations), report.TotalMs)
+
+	for i, tag := range tags {
+		report.Tags[i] = TagTiming{
+			Name:       tag.Name,
+			FromFrame:  tag.FromFrame,
+			ToFrame:    tag.ToFrame,
+			FrameCount: tag.FrameCount,
+			Direction:  tag.Direction,
+			Repeat:     tag.Repeat,
+			DurationMs: tag.DurationMs,
+			LoopMs:     tagLoopMs(tag, durations),
+			FPS:        framesPerSecond(tag.FrameCount, tag.DurationMs),
+		}
+	}
+
+	return report
+}
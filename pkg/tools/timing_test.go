@@ -0,0 +1,122 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameDurations(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   SetFrameDurationsInput
+		count   int
+		want    []int
+		wantErr string
+	}{
+		{
+			name:  "duration list",
+			input: SetFrameDurationsInput{DurationsMs: []int{100, 200, 300}},
+			count: 3,
+			want:  []int{100, 200, 300},
+		},
+		{
+			name:    "duration list length mismatch",
+			input:   SetFrameDurationsInput{DurationsMs: []int{100, 200}},
+			count:   3,
+			wantErr: "durations_ms has 2 values but the range has 3 frames",
+		},
+		{
+			name:    "duration list out of range",
+			input:   SetFrameDurationsInput{DurationsMs: []int{100, 0}},
+			count:   2,
+			wantErr: "duration of frame 2 must be between 1 and 65535",
+		},
+		{
+			name:  "constant duration",
+			input: SetFrameDurationsInput{DurationMs: 80},
+			count: 3,
+			want:  []int{80, 80, 80},
+		},
+		{
+			name:  "constant total",
+			input: SetFrameDurationsInput{TotalMs: 1000},
+			count: 4,
+			want:  []int{250, 250, 250, 250},
+		},
+		{
+			name:    "total too small",
+			input:   SetFrameDurationsInput{TotalMs: 2},
+			count:   3,
+			wantErr: "total_ms must be at least 1 ms per frame",
+		},
+		{
+			name:    "overshooting bezier",
+			input:   SetFrameDurationsInput{TotalMs: 1000, Easing: "cubic_bezier", Bezier: []float64{0.3, 1.5, 0.6, 1}},
+			count:   4,
+			wantErr: "bezier y control points must be between 0 and 1",
+		},
+		{
+			name:    "invalid easing",
+			input:   SetFrameDurationsInput{TotalMs: 1000, Easing: "bounce"},
+			count:   4,
+			wantErr: "invalid easing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := frameDurations(tt.input, tt.count)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFrameDurations_Easing(t *testing.T) {
+	durations, err := frameDurations(SetFrameDurationsInput{TotalMs: 1000, Easing: "ease_out"}, 5)
+	require.NoError(t, err)
+
+	total := 0
+	for _, d := range durations {
+		total += d
+	}
+	assert.Equal(t, 1000, total)
+	assert.Less(t, durations[0], durations[4], "ease_out holds the last frame longest")
+}
+
+func TestFramesPerSecond(t *testing.T) {
+	assert.Equal(t, 10.0, framesPerSecond(1, 100))
+	assert.Equal(t, 12.5, framesPerSecond(5, 400))
+	assert.Equal(t, 33.33, framesPerSecond(1, 30))
+	assert.Equal(t, 0.0, framesPerSecond(3, 0))
+}
+
+func TestAnimationTiming(t *testing.T) {
+	durations := []int{100, 100, 200, 200, 400}
+	tags := []TagInfo{
+		{Name: "walk", FromFrame: 1, ToFrame: 4, FrameCount: 4, Direction: "forward", DurationMs: 600},
+		{Name: "bounce", FromFrame: 2, ToFrame: 5, FrameCount: 4, Direction: "pingpong", Repeat: 2, DurationMs: 900},
+	}
+
+	report := animationTiming(durations, tags)
+
+	assert.Equal(t, 5, report.FrameCount)
+	assert.Equal(t, 1000, report.TotalMs)
+	assert.Equal(t, 5.0, report.FPS)
+	require.Len(t, report.Frames, 5)
+	assert.Equal(t, FrameTiming{Frame: 3, DurationMs: 200, FPS: 5}, report.Frames[2])
+
+	require.Len(t, report.Tags, 2)
+	assert.Equal(t, 600, report.Tags[0].LoopMs)
+	assert.Equal(t, 6.67, report.Tags[0].FPS)
+
+	// Ping-pong plays frames 3 and 4 again on the way back
+	assert.Equal(t, 1300, report.Tags[1].LoopMs)
+	assert.Equal(t, 2, report.Tags[1].Repeat)
+}
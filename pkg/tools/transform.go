@@ -3,6 +3,8 @@ package tools
 import (
 	"context"
 	"fmt"
+	"image/color"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -101,8 +103,16 @@ type ApplyOutlineInput struct {
 	SpritePath  string `json:"sprite_path" jsonschema:"Path to the Aseprite sprite file"`
 	LayerName   string `json:"layer_name" jsonschema:"Name of the layer to apply outline to"`
 	FrameNumber int    `json:"frame_number" jsonschema:"Frame number (1-based index)"`
-	Color       string `json:"color" jsonschema:"Outline color in hex format (#RRGGBB or #RRGGBBAA)"`
+	Color       string `json:"color,omitempty" jsonschema:"Outline color in hex format (#RRGGBB or #RRGGBBAA); required unless auto_color is set or mode is selout"`
 	Thickness   int    `json:"thickness" jsonschema:"Outline thickness in pixels (1-10)"`
+
+	// Optional extended options; when any is set the outline is rendered in Go
+	// instead of with Aseprite's built-in Outline command.
+	Mode         string   `json:"mode,omitempty" jsonschema:"Outline mode: outside (grow the silhouette), inside (recolor border pixels), or selout (inside outline colored from the adjacent fill) (default: outside)"`
+	Connectivity int      `json:"connectivity,omitempty" jsonschema:"Neighbor connectivity: 4 (no diagonal corner pixels) or 8 (default: 8)"`
+	Sides        []string `json:"sides,omitempty" jsonschema:"Sides of the shape to outline: any of top, bottom, left, right (default: all)"`
+	AutoColor    bool     `json:"auto_color,omitempty" jsonschema:"Derive outline colors from neighboring pixels, darkened and picked from the sprite palette (default: false)"`
+	Darken       *float64 `json:"darken,omitempty" jsonschema:"Lightness reduction for derived colors 0.0-1.0 (default: 0.4)"`
 }
 
 // ApplyOutlineOutput defines the output for the apply_outline tool.
@@ -110,6 +120,11 @@ type ApplyOutlineOutput struct {
 	Success bool `json:"success"`
 }
 
+// usesExtendedOutline reports whether any option beyond color and thickness is set.
+func (in ApplyOutlineInput) usesExtendedOutline() bool {
+	return in.Mode != "" || in.Connectivity != 0 || len(in.Sides) > 0 || in.AutoColor || in.Darken != nil
+}
+
 // RegisterTransformTools registers all image transformation tools with the MCP server.
 func RegisterTransformTools(server *mcp.Server, client *aseprite.Client, gen *aseprite.LuaGenerator, cfg *config.Config, logger core.Logger) {
 	// Register downsample_image tool
@@ -421,11 +436,11 @@ func RegisterTransformTools(server *mcp.Server, client *aseprite.Client, gen *as
 		server,
 		&mcp.Tool{
 			Name:        "apply_outline",
-			Description: "Apply an outline effect to a layer at a specified frame. The outline is drawn around non-transparent pixels with configurable color and thickness.",
+			Description: "Apply an outline effect to a layer at a specified frame. The outline is drawn around non-transparent pixels with configurable color and thickness. Optional mode (outside, inside, or selout where the outline is a darkened version of the adjacent fill), 4- or 8-connectivity (4 omits diagonal corners), per-side toggles, and auto_color to derive outline colors from neighboring pixels through the sprite palette.",
 		},
 		maybeWrapWithTiming("apply_outline", logger, cfg.EnableTiming, func(ctx context.Context, req *mcp.CallToolRequest, input ApplyOutlineInput) (*mcp.CallToolResult, *ApplyOutlineOutput, error) {
 			opLogger := logger.WithContext(ctx)
-			opLogger.Debug("apply_outline tool called", "sprite", input.SpritePath, "layer", input.LayerName, "frame", input.FrameNumber, "color", input.Color, "thickness", input.Thickness)
+			opLogger.Debug("apply_outline tool called", "sprite", input.SpritePath, "layer", input.LayerName, "frame", input.FrameNumber, "color", input.Color, "thickness", input.Thickness, "mode", input.Mode)
 
 			// Validate frame number
 			if input.FrameNumber < 1 {
@@ -437,6 +452,17 @@ func RegisterTransformTools(server *mcp.Server, client *aseprite.Client, gen *as
 				return nil, nil, fmt.Errorf("thickness must be between 1 and 10, got: %d", input.Thickness)
 			}
 
+			if input.usesExtendedOutline() {
+				if err := applyExtendedOutline(ctx, client, gen, input); err != nil {
+					opLogger.Error("Failed to apply outline", "error", err)
+					return nil, nil, err
+				}
+
+				opLogger.Information("Outline applied successfully", "layer", input.LayerName, "frame", input.FrameNumber, "thickness", input.Thickness, "mode", input.Mode)
+
+				return nil, &ApplyOutlineOutput{Success: true}, nil
+			}
+
 			// Parse color
 			var color aseprite.Color
 			if err := color.FromHex(input.Color); err != nil {
@@ -459,3 +485,110 @@ func RegisterTransformTools(server *mcp.Server, client *aseprite.Client, gen *as
 		}),
 	)
 }
+
+// applyExtendedOutline renders an outline with mode, connectivity, side and
+// auto-color options in Go and writes it back to the layer.
+//
+// The cel is exported onto a sprite-sized canvas so outside outlines can grow
+// past the original cel bounds. Colors are snapped to the palette for indexed
+// sprites, and auto colors are always picked from the sprite palette.
+func applyExtendedOutline(ctx context.Context, client *aseprite.Client, gen *aseprite.LuaGenerator, input ApplyOutlineInput) error {
+	// Set defaults
+	if input.Mode == "" {
+		input.Mode = "outside"
+	}
+	if input.Connectivity == 0 {
+		input.Connectivity = 8
+	}
+	darken := 0.4
+	if input.Darken != nil {
+		darken = *input.Darken
+	}
+
+	opts := aseprite.OutlineOptions{
+		Mode:         input.Mode,
+		Thickness:    input.Thickness,
+		Connectivity: input.Connectivity,
+		AutoColor:    input.AutoColor,
+		Darken:       darken,
+		Sides:        aseprite.AllOutlineSides,
+	}
+
+	if len(input.Sides) > 0 {
+		opts.Sides = aseprite.OutlineSides{}
+		for _, side := range input.Sides {
+			switch side {
+			case "top":
+				opts.Sides.Top = true
+			case "bottom":
+				opts.Sides.Bottom = true
+			case "left":
+				opts.Sides.Left = true
+			case "right":
+				opts.Sides.Right = true
+			default:
+				return fmt.Errorf("invalid side: %s (must be top, bottom, left, or right)", side)
+			}
+		}
+	}
+
+	autoColor := input.AutoColor || input.Mode == "selout"
+	if !autoColor {
+		var c aseprite.Color
+		if err := c.FromHex(input.Color); err != nil {
+			return fmt.Errorf("invalid color format: %w", err)
+		}
+		opts.Color = color.RGBA{R: c.R, G: c.G, B: c.B, A: c.A}
+	}
+
+	if _, err := os.Stat(input.SpritePath); os.IsNotExist(err) {
+		return fmt.Errorf("sprite file not found: %s", input.SpritePath)
+	}
+
+	info, err := getSpriteInfoHelper(ctx, client, gen, input.SpritePath)
+	if err != nil {
+		return fmt.Errorf("failed to get sprite info: %w", err)
+	}
+
+	var palette []aseprite.PaletteColor
+	if autoColor || info.ColorMode == "indexed" {
+		if palette, err = loadSpritePalette(ctx, client, gen, input.SpritePath); err != nil {
+			return err
+		}
+		opts.Palette = palette
+	}
+
+	tempDir, err := os.MkdirTemp("", "pixel-mcp-outline-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	base, err := loadCelCanvas(ctx, client, gen, input.SpritePath, input.LayerName, input.FrameNumber, info.Width, info.Height, tempDir)
+	if err != nil {
+		return err
+	}
+
+	outlined, err := aseprite.RenderOutline(base, opts)
+	if err != nil {
+		return fmt.Errorf("outline rendering failed: %w", err)
+	}
+
+	if info.ColorMode == "indexed" {
+		if err := aseprite.SnapImageToPalette(outlined, palette); err != nil {
+			return fmt.Errorf("palette snap failed: %w", err)
+		}
+	}
+
+	outlinedPNG := filepath.Join(tempDir, "outline.png")
+	if err := savePNG(outlinedPNG, outlined); err != nil {
+		return err
+	}
+
+	x, y := 0, 0
+	if _, err := client.ExecuteLua(ctx, gen.ImportImage(outlinedPNG, input.LayerName, input.FrameNumber, &x, &y), input.SpritePath); err != nil {
+		return fmt.Errorf("failed to apply outline: %w", err)
+	}
+
+	return nil
+}
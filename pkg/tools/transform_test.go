@@ -445,3 +445,62 @@ func TestIntegration_ApplyOutline(t *testing.T) {
 
 	t.Log("✓ Outline applied successfully")
 }
+
+func TestIntegration_ApplyOutline_SelOut(t *testing.T) {
+	cfg := testutil.LoadTestConfig(t)
+	client := aseprite.NewClient(cfg.AsepritePath, cfg.TempDir, 30*time.Second)
+	gen := aseprite.NewLuaGenerator()
+
+	// Create test sprite
+	spritePath := testutil.TempSpritePath(t, "outline-selout-test.aseprite")
+	createScript := gen.CreateCanvas(32, 32, aseprite.ColorModeRGB, spritePath)
+	ctx := context.Background()
+
+	_, err := client.ExecuteLua(ctx, createScript, "")
+	if err != nil {
+		t.Fatalf("failed to create sprite: %v", err)
+	}
+	defer os.Remove(spritePath)
+
+	// Draw a filled rectangle to outline
+	drawScript := gen.DrawRectangle("Layer 1", 1, 8, 8, 16, 16, aseprite.Color{R: 200, G: 60, B: 60, A: 255}, true, false)
+	_, err = client.ExecuteLua(ctx, drawScript, spritePath)
+	if err != nil {
+		t.Fatalf("failed to draw rectangle: %v", err)
+	}
+
+	// Apply a selective inside outline on the bottom and right only
+	input := ApplyOutlineInput{
+		SpritePath:   spritePath,
+		LayerName:    "Layer 1",
+		FrameNumber:  1,
+		Thickness:    1,
+		Mode:         "selout",
+		Connectivity: 4,
+		Sides:        []string{"bottom", "right"},
+	}
+	if err := applyExtendedOutline(ctx, client, gen, input); err != nil {
+		t.Fatalf("applyExtendedOutline() error = %v", err)
+	}
+
+	// The silhouette must not grow with an inside outline
+	pixelsScript := gen.GetPixels("Layer 1", 1, 0, 0, 32, 32)
+	output, err := client.ExecuteLua(ctx, pixelsScript, spritePath)
+	if err != nil {
+		t.Fatalf("GetPixels() error = %v", err)
+	}
+
+	var pixels []PixelData
+	if err := json.Unmarshal([]byte(output), &pixels); err != nil {
+		t.Fatalf("failed to parse pixels: %v", err)
+	}
+
+	for _, p := range pixels {
+		inside := p.X >= 8 && p.X < 24 && p.Y >= 8 && p.Y < 24
+		if !inside && !strings.HasSuffix(p.Color, "00") {
+			t.Errorf("pixel (%d,%d) outside the rectangle is %s, want transparent", p.X, p.Y, p.Color)
+		}
+	}
+
+	t.Log("✓ Selective outline applied successfully")
+}
@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"path/filepath"
+
+	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
+)
+
+// maxTweenCelSize is the largest cel width or height tween_cels transforms.
+// RotSprite works on an 8x upscale, so memory grows quickly with cel size.
+const maxTweenCelSize = 512
+
+// tweenKeyframe is one keyframe cel as reported by the TweenKeyframes script.
+type tweenKeyframe struct {
+	X          int `json:"x"`
+	Y          int `json:"y"`
+	Width      int `json:"width"`
+	Height     int `json:"height"`
+	Opacity    int `json:"opacity"`
+	DurationMs int `json:"duration_ms"`
+}
+
+// tweenKeyframes is the JSON printed by the TweenKeyframes script.
+type tweenKeyframes struct {
+	From tweenKeyframe `json:"from"`
+	To   tweenKeyframe `json:"to"`
+}
+
+// center returns the center of the keyframe cel in sprite coordinates.
+func (k tweenKeyframe) center() (float64, float64) {
+	return float64(k.X) + float64(k.Width)/2, float64(k.Y) + float64(k.Height)/2
+}
+
+// parseEasing resolves an easing name, and the control points for
+// cubic_bezier, to an easing curve.
+func parseEasing(name string, bezier []float64) (aseprite.Easing, error) {
+	if name == "cubic_bezier" {
+		if len(bezier) != 4 {
+			return aseprite.Easing{}, fmt.Errorf("cubic_bezier easing requires bezier with 4 values [x1, y1, x2, y2], got %d", len(bezier))
+		}
+		return aseprite.NewCubicBezierEasing(bezier[0], bezier[1], bezier[2], bezier[3])
+	}
+
+	easing, ok := aseprite.EasingPresets[name]
+	if !ok {
+		return aseprite.Easing{}, fmt.Errorf("invalid easing: %s (valid: linear, ease_in, ease_out, ease_in_out, cubic_bezier)", name)
+	}
+	return easing, nil
+}
+
+// splitTweenDuration divides a keyframe's duration between the keyframe and
+// count in-betweens so the animation keeps its timing. Returns the new
+// keyframe duration and the in-between durations; every frame lasts at
+// least 1 ms.
+func splitTweenDuration(totalMs, count int) (int, []int) {
+	parts := count + 1
+	durations := make([]int, parts)
+	for i := range durations {
+		durations[i] = max(totalMs*(i+1)/parts-totalMs*i/parts, 1)
+	}
+	return durations[0], durations[1:]
+}
+
+// planTweenFrames renders the in-between frames of a tween. Frame k of count
+// is placed at eased progress easing.At(k / (count + 1)) between the two
+// keyframes: the cel center and opacity move from the first keyframe toward
+// the second, and the first keyframe's image is rotated by rotation degrees
+// and scaled toward scale with RotSprite. Images are written as PNGs into
+// tempDir.
+func planTweenFrames(src image.Image, keys tweenKeyframes, count int, easing aseprite.Easing, rotation, scale float64, durations []int, tempDir string) ([]aseprite.TweenFrame, error) {
+	fromX, fromY := keys.From.center()
+	toX, toY := keys.To.center()
+
+	frames := make([]aseprite.TweenFrame, count)
+	for k := 1; k <= count; k++ {
+		e := easing.At(float64(k) / float64(count+1))
+
+		// Overshooting curves may push the scale through zero
+		s := math.Max(1+(scale-1)*e, 0.01)
+		img := aseprite.RotSprite(src, rotation*e, s, s)
+
+		cx := fromX + (toX-fromX)*e
+		cy := fromY + (toY-fromY)*e
+		opacity := float64(keys.From.Opacity) + float64(keys.To.Opacity-keys.From.Opacity)*e
+
+		path := filepath.Join(tempDir, fmt.Sprintf("tween-%d.png", k))
+		if err := savePNG(path, img); err != nil {
+			return nil, err
+		}
+
+		frames[k-1] = aseprite.TweenFrame{
+			ImagePath:  path,
+			X:          int(math.Round(cx - float64(img.Rect.Dx())/2)),
+			Y:          int(math.Round(cy - float64(img.Rect.Dy())/2)),
+			Opacity:    int(math.Round(math.Min(math.Max(opacity, 0), 255))),
+			DurationMs: durations[k-1],
+		}
+	}
+
+	return frames, nil
+}
@@ -0,0 +1,117 @@
+package tools
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/willibrandon/pixel-mcp/pkg/aseprite"
+)
+
+func TestSplitTweenDuration(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		count     int
+		wantKey   int
+		wantTween []int
+	}{
+		{name: "even split", total: 400, count: 3, wantKey: 100, wantTween: []int{100, 100, 100}},
+		{name: "uneven split keeps total", total: 100, count: 2, wantKey: 33, wantTween: []int{33, 34}},
+		{name: "at least 1ms", total: 1, count: 2, wantKey: 1, wantTween: []int{1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, tweens := splitTweenDuration(tt.total, tt.count)
+			assert.Equal(t, tt.wantKey, key)
+			assert.Equal(t, tt.wantTween, tweens)
+		})
+	}
+}
+
+func TestParseEasing(t *testing.T) {
+	easing, err := parseEasing("ease_in", nil)
+	require.NoError(t, err)
+	assert.Equal(t, aseprite.EasingPresets["ease_in"], easing)
+
+	easing, err = parseEasing("cubic_bezier", []float64{0.1, 0.2, 0.3, 0.4})
+	require.NoError(t, err)
+	assert.Equal(t, aseprite.Easing{X1: 0.1, Y1: 0.2, X2: 0.3, Y2: 0.4}, easing)
+
+	_, err = parseEasing("cubic_bezier", []float64{0.1, 0.2})
+	assert.ErrorContains(t, err, "requires bezier with 4 values")
+
+	_, err = parseEasing("cubic_bezier", []float64{1.5, 0, 0.5, 1})
+	assert.ErrorContains(t, err, "x control points must be between 0 and 1")
+
+	_, err = parseEasing("bounce", nil)
+	assert.ErrorContains(t, err, "invalid easing")
+}
+
+func TestPlanTweenFrames(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			src.SetNRGBA(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+	keys := tweenKeyframes{
+		From: tweenKeyframe{X: 0, Y: 0, Width: 4, Height: 2, Opacity: 255},
+		To:   tweenKeyframe{X: 12, Y: 6, Width: 4, Height: 2, Opacity: 0},
+	}
+
+	t.Run("linear move and fade", func(t *testing.T) {
+		frames, err := planTweenFrames(src, keys, 3, aseprite.EasingPresets["linear"], 0, 1, []int{10, 20, 30}, t.TempDir())
+		require.NoError(t, err)
+		require.Len(t, frames, 3)
+
+		wantX := []int{3, 6, 9}
+		wantY := []int{2, 3, 5}
+		wantOpacity := []int{191, 128, 64}
+		for i, f := range frames {
+			assert.Equal(t, wantX[i], f.X, "frame %d x", i+1)
+			assert.Equal(t, wantY[i], f.Y, "frame %d y", i+1)
+			assert.Equal(t, wantOpacity[i], f.Opacity, "frame %d opacity", i+1)
+			assert.Equal(t, (i+1)*10, f.DurationMs)
+
+			img, err := loadPNG(f.ImagePath)
+			require.NoError(t, err)
+			assert.Equal(t, 4, img.Bounds().Dx())
+			assert.Equal(t, 2, img.Bounds().Dy())
+		}
+	})
+
+	t.Run("rotation keeps center", func(t *testing.T) {
+		frames, err := planTweenFrames(src, keys, 1, aseprite.EasingPresets["linear"], 180, 1, []int{100}, t.TempDir())
+		require.NoError(t, err)
+
+		// Halfway: rotated 90 degrees, 2x4, centered at (8, 4)
+		img, err := loadPNG(frames[0].ImagePath)
+		require.NoError(t, err)
+		assert.Equal(t, 2, img.Bounds().Dx())
+		assert.Equal(t, 4, img.Bounds().Dy())
+		assert.Equal(t, 7, frames[0].X)
+		assert.Equal(t, 2, frames[0].Y)
+	})
+
+	t.Run("scale", func(t *testing.T) {
+		frames, err := planTweenFrames(src, keys, 1, aseprite.EasingPresets["linear"], 0, 3, []int{100}, t.TempDir())
+		require.NoError(t, err)
+
+		// Halfway: scale 2
+		img, err := loadPNG(frames[0].ImagePath)
+		require.NoError(t, err)
+		assert.Equal(t, 8, img.Bounds().Dx())
+		assert.Equal(t, 4, img.Bounds().Dy())
+	})
+
+	t.Run("ease in lags behind linear", func(t *testing.T) {
+		frames, err := planTweenFrames(src, keys, 1, aseprite.EasingPresets["ease_in"], 0, 1, []int{100}, t.TempDir())
+		require.NoError(t, err)
+		assert.Less(t, frames[0].X, 6)
+		assert.Greater(t, frames[0].Opacity, 128)
+	})
+}